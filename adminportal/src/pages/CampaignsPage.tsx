@@ -1,9 +1,22 @@
 import React, { useState, useEffect } from 'react';
 import { campaignService } from '../services/api';
-import { IphoneWinner, Campaign } from '../types';
+import { IphoneWinner, Campaign, CampaignProgress, CampaignStatus, CampaignWinner } from '../types';
 import CreateCampaignForm from '../components/CreateCampaignForm';
 import UpdateCampaignForm from '../components/UpdateCampaignForm';
 
+// Number of live winners kept on the page
+const MAX_LIVE_WINNERS = 10;
+
+// Status changes an admin can make from each status, the scheduler activates and ends campaigns on time
+const STATUS_ACTIONS: Record<CampaignStatus, { label: string; status: CampaignStatus }[]> = {
+  DRAFT: [{ label: 'Schedule', status: 'SCHEDULED' }, { label: 'Archive', status: 'ARCHIVED' }],
+  SCHEDULED: [{ label: 'Back to draft', status: 'DRAFT' }, { label: 'Start now', status: 'ACTIVE' }, { label: 'Archive', status: 'ARCHIVED' }],
+  ACTIVE: [{ label: 'Pause', status: 'PAUSED' }, { label: 'End now', status: 'ENDED' }],
+  PAUSED: [{ label: 'Resume', status: 'ACTIVE' }, { label: 'End now', status: 'ENDED' }],
+  ENDED: [{ label: 'Archive', status: 'ARCHIVED' }],
+  ARCHIVED: [],
+};
+
 const CampaignsPage: React.FC = () => {
   const [loading, setLoading] = useState(false);
   const [initialLoading, setInitialLoading] = useState(true);
@@ -11,27 +24,49 @@ const CampaignsPage: React.FC = () => {
   const [success, setSuccess] = useState<string | null>(null);
   const [winners, setWinners] = useState<IphoneWinner[]>([]);
   const [showWinners, setShowWinners] = useState(false);
-  const [existingCampaign, setExistingCampaign] = useState<Campaign | null>(null);
-  const [isUpdateMode, setIsUpdateMode] = useState(false);
+  const [campaigns, setCampaigns] = useState<Campaign[]>([]);
+  const [selectedId, setSelectedId] = useState<number | null>(null);
+  const [progress, setProgress] = useState<CampaignProgress | null>(null);
+  const [liveWinners, setLiveWinners] = useState<CampaignWinner[]>([]);
 
   useEffect(() => {
-    const checkExistingCampaign = async () => {
+    const loadCampaigns = async () => {
       try {
         setInitialLoading(true);
-        const response = await campaignService.getIphoneCampaign();
-        setExistingCampaign(response.data);
-        setIsUpdateMode(true);
+        const response = await campaignService.getCampaigns();
+        setCampaigns(response.data);
+        setSelectedId(response.data.length > 0 ? response.data[0].id : null);
       } catch (err) {
-        setIsUpdateMode(false);
-        setExistingCampaign(null);
+        setCampaigns([]);
+        setSelectedId(null);
       } finally {
         setInitialLoading(false);
       }
     };
 
-    checkExistingCampaign();
+    loadCampaigns();
   }, []);
 
+  useEffect(() => {
+    setProgress(null);
+    setLiveWinners([]);
+    if (selectedId === null) return;
+    return campaignService.subscribeCampaignProgress(selectedId, (update) => {
+      setProgress(update);
+      if (update.new_winners && update.new_winners.length > 0) {
+        setLiveWinners(current => [...update.new_winners!].reverse().concat(current).slice(0, MAX_LIVE_WINNERS));
+      }
+    });
+  }, [selectedId]);
+
+  const existingCampaign = campaigns.find(campaign => campaign.id === selectedId) || null;
+
+  const handleSelectCampaign = (e: React.ChangeEvent<HTMLSelectElement>) => {
+    setSelectedId(e.target.value === '' ? null : Number(e.target.value));
+    setWinners([]);
+    setShowWinners(false);
+  };
+
   const handleSuccess = (message: string) => {
     setSuccess(message);
     setError(null);
@@ -46,12 +81,31 @@ const CampaignsPage: React.FC = () => {
     window.location.reload();
   };
 
+  const handleChangeStatus = async (status: CampaignStatus) => {
+    if (!existingCampaign) return;
+    setLoading(true);
+    try {
+      const response = await campaignService.changeCampaignStatus(existingCampaign.id, status);
+      setCampaigns(campaigns.map(campaign => campaign.id === response.data.id ? response.data : campaign));
+      handleSuccess(`Campaign is ${response.data.status}`);
+    } catch (err) {
+      handleError('Failed to change campaign status');
+    } finally {
+      setLoading(false);
+    }
+  };
+
   const handleGetWinners = async () => {
     setLoading(true);
     setError(null);
 
+    if (!existingCampaign) {
+      setLoading(false);
+      return;
+    }
+
     try {
-      const response = await campaignService.getIphoneWinners();
+      const response = await campaignService.getCampaignWinners(existingCampaign.id);
       setWinners(response.data);
       setShowWinners(true);
     } catch (err) {
@@ -71,9 +125,48 @@ const CampaignsPage: React.FC = () => {
       {error && <div className="p-3 bg-red-100 text-red-700 rounded">{error}</div>}
       {success && <div className="p-3 bg-green-100 text-green-700 rounded">{success}</div>}
       
+      {/* Campaign selection */}
+      <div className="bg-white rounded-lg shadow p-6">
+        <label className="block text-sm text-gray-600 mb-2">Campaign</label>
+        <select
+          value={selectedId ?? ''}
+          onChange={handleSelectCampaign}
+          className="border rounded px-3 py-2 w-full"
+        >
+          {campaigns.map(campaign => (
+            <option key={campaign.id} value={campaign.id}>
+              #{campaign.id} {campaign.name} [{campaign.status}] ({new Date(campaign.start_time).toLocaleDateString()} - {new Date(campaign.end_time).toLocaleDateString()})
+            </option>
+          ))}
+          <option value="">+ New campaign</option>
+        </select>
+      </div>
+
+      {/* Campaign status */}
+      {existingCampaign && (
+        <div className="bg-white rounded-lg shadow p-6">
+          <div className="flex justify-between items-center">
+            <h2 className="text-lg font-semibold">Status: {existingCampaign.status}</h2>
+            <div className="space-x-2">
+              {(STATUS_ACTIONS[existingCampaign.status] || []).map(action => (
+                <button
+                  key={action.status}
+                  onClick={() => handleChangeStatus(action.status)}
+                  disabled={loading}
+                  className="bg-gray-700 text-white px-4 py-2 rounded disabled:opacity-50"
+                >
+                  {action.label}
+                </button>
+              ))}
+            </div>
+          </div>
+        </div>
+      )}
+
       {/* Create/Update Campaign */}
-      {isUpdateMode && existingCampaign ? (
+      {existingCampaign ? (
         <UpdateCampaignForm 
+          key={existingCampaign.id}
           campaign={existingCampaign}
           onSuccess={handleSuccess}
           onError={handleError}
@@ -86,33 +179,62 @@ const CampaignsPage: React.FC = () => {
         />
       )}
 
-      {/* iPhone Inventory */}
+      {/* Live progress */}
       {existingCampaign && (
         <div className="bg-white rounded-lg shadow p-6 mb-6">
-          <h2 className="text-lg font-semibold mb-4">iPhone Inventory</h2>
-          <div className="grid grid-cols-3 gap-4">
+          <div className="flex justify-between items-center mb-4">
+            <h2 className="text-lg font-semibold">iPhone Inventory</h2>
+            <span className="text-sm text-gray-500">
+              {progress ? `Live, updated ${new Date(progress.at).toLocaleTimeString()}` : 'Connecting...'}
+            </span>
+          </div>
+          <div className="grid grid-cols-5 gap-4">
             <div className="bg-blue-50 p-4 rounded-lg text-center">
               <p className="text-sm text-gray-600">Total iPhones</p>
-              <p className="text-2xl font-bold text-blue-600">{existingCampaign.policy.total_reward}</p>
+              <p className="text-2xl font-bold text-blue-600">{progress?.total_reward ?? existingCampaign.policy.total_reward}</p>
             </div>
             <div className="bg-orange-50 p-4 rounded-lg text-center">
               <p className="text-sm text-gray-600">Winners So Far</p>
-              <p className="text-2xl font-bold text-orange-600">{winners.length}</p>
+              <p className="text-2xl font-bold text-orange-600">{progress?.winners ?? '-'}</p>
             </div>
             <div className="bg-green-50 p-4 rounded-lg text-center">
               <p className="text-sm text-gray-600">iPhones Left</p>
               <p className="text-2xl font-bold text-green-600">
-                {existingCampaign.policy.total_reward - winners.length}
+                {progress ? Math.max(progress.total_reward - progress.winners, 0) : '-'}
               </p>
             </div>
+            <div className="bg-gray-50 p-4 rounded-lg text-center">
+              <p className="text-sm text-gray-600">Pending Orders</p>
+              <p className="text-2xl font-bold text-gray-700">{progress?.pending_orders ?? '-'}</p>
+            </div>
+            <div className="bg-gray-50 p-4 rounded-lg text-center">
+              <p className="text-sm text-gray-600">Eligible Customers</p>
+              <p className="text-2xl font-bold text-gray-700">{progress?.eligible ?? '-'}</p>
+            </div>
           </div>
+          {progress?.finished && (
+            <p className="mt-4 text-sm text-green-700">All rewards have been given out.</p>
+          )}
+          {liveWinners.length > 0 && (
+            <div className="mt-4">
+              <h3 className="text-sm font-semibold text-gray-600 mb-2">Latest Winners</h3>
+              <ul className="text-sm space-y-1">
+                {liveWinners.map(winner => (
+                  <li key={`${winner.customer_id}-${winner.position}`}>
+                    #{winner.position} {winner.customer_id} (order {winner.order_id}) at {new Date(winner.selected_at).toLocaleTimeString()}
+                  </li>
+                ))}
+              </ul>
+            </div>
+          )}
         </div>
       )}
 
       {/* Get Winners */}
+      {existingCampaign && (
       <div className="bg-white rounded-lg shadow p-6">
         <div className="flex justify-between items-center mb-4">
-          <h2 className="text-lg font-semibold">iPhone Campaign Winners</h2>
+          <h2 className="text-lg font-semibold">Campaign Winners</h2>
           <button
             onClick={handleGetWinners}
             disabled={loading}
@@ -151,6 +273,7 @@ const CampaignsPage: React.FC = () => {
           </div>
         )}
       </div>
+      )}
     </div>
   );
 };
@@ -5,6 +5,8 @@ import {
   Order,
   Payment,
   Campaign,
+  CampaignProgress,
+  CampaignStatus,
   CreateCampaignRequest,
   IphoneWinner,
   SearchOrdersParams,
@@ -61,24 +63,40 @@ export const paymentService = {
 // Campaign API
 export const campaignService = {
   createCampaign: async (campaign: CreateCampaignRequest): Promise<BaseResponse<Campaign>> => {
-    const response = await campaignApi.post('/campaigns/iphones', campaign);
+    const response = await campaignApi.post('/campaigns', campaign);
     return response.data;
   },
 
-  getIphoneCampaign: async (): Promise<BaseResponse<Campaign>> => {
-    const response = await campaignApi.get('/campaigns/iphones');
+  getCampaigns: async (): Promise<BaseResponse<Campaign[]>> => {
+    const response = await campaignApi.get('/campaigns');
     return response.data;
   },
 
   updateCampaign: async (campaign: UpdateCampaignRequest): Promise<BaseResponse<Campaign>> => {
-    const response = await campaignApi.put(`/campaigns/iphones/${campaign.id}`, campaign);
+    const response = await campaignApi.put(`/campaigns/${campaign.id}`, campaign);
     return response.data;
   },
   
-  getIphoneWinners: async (): Promise<BaseResponse<IphoneWinner[]>> => {
-    const response = await campaignApi.get('/campaigns/iphones/winners');
+  getCampaignWinners: async (campaignId: number): Promise<BaseResponse<IphoneWinner[]>> => {
+    const response = await campaignApi.get(`/campaigns/${campaignId}/winners`);
     return response.data;
   },
+
+  changeCampaignStatus: async (campaignId: number, status: CampaignStatus): Promise<BaseResponse<Campaign>> => {
+    const response = await campaignApi.put(`/campaigns/${campaignId}/status`, { status });
+    return response.data;
+  },
+
+  // Opens the Server-Sent Events stream of the campaign progress, returns a function closing it.
+  // The browser reconnects by itself and gets the current counters again on reconnection.
+  subscribeCampaignProgress: (campaignId: number, onProgress: (progress: CampaignProgress) => void): (() => void) => {
+    const source = new EventSource(`${CAMPAIGN_SERVICE}/campaigns/${campaignId}/progress/stream`);
+    source.addEventListener('progress', (event) => {
+      onProgress(JSON.parse((event as MessageEvent).data));
+    });
+    source.onerror = () => console.error('Campaign progress stream error, reconnecting');
+    return () => source.close();
+  },
 };
 
 // Error handling interceptors
@@ -21,11 +21,20 @@ export interface Order {
   customer_id: string;
   customer_name: string;
   total_amount: number;
+  items: OrderItem[];
   status: string;
   created_at: string;
   updated_at: string;
 }
 
+export interface OrderItem {
+  sku: string;
+  name: string;
+  unit_price: number;
+  quantity: number;
+  subtotal: number;
+}
+
 export interface SearchOrdersParams {
   page?: number;
   size?: number;
@@ -50,10 +59,13 @@ export interface SearchPaymentsParams {
 }
 
 // Campaign types
+export type CampaignStatus = 'DRAFT' | 'SCHEDULED' | 'ACTIVE' | 'PAUSED' | 'ENDED' | 'ARCHIVED';
+
 export interface Campaign {
   id: number;
   name: string;
   type: string;
+  status: CampaignStatus;
   description: string;
   policy: {
     total_reward: number;
@@ -87,6 +99,26 @@ export interface UpdateCampaignRequest {
   max_tracked_orders: number;
 }
 
+export interface CampaignWinner {
+  campaign_id: number;
+  customer_id: string;
+  order_id: string;
+  position: number;
+  selected_at: string;
+}
+
+// Live counters pushed by the campaign progress stream
+export interface CampaignProgress {
+  campaign_id: number;
+  pending_orders: number;
+  eligible: number;
+  winners: number;
+  total_reward: number;
+  finished: boolean;
+  new_winners?: CampaignWinner[];
+  at: string;
+}
+
 export interface IphoneWinner {
   customer_id: string;
   customer_name: string;
@@ -1,6 +1,7 @@
 package fi_frontend
 
 import (
+	"context"
 	"github.com/samber/do/v2"
 	"golang.org/x/sync/errgroup"
 	"log/slog"
@@ -8,43 +9,80 @@ import (
 	"specommerce/campaignservice/config"
 	"specommerce/campaignservice/di"
 	orderConsumer "specommerce/campaignservice/internal/adapters/primary/order/event/kafka"
+	"specommerce/campaignservice/internal/core/domain/campaign"
+	"specommerce/campaignservice/internal/core/domain/order"
+	"specommerce/campaignservice/internal/core/ports/primary"
+	auditService "specommerce/campaignservice/internal/core/services/audit"
+	campaignService "specommerce/campaignservice/internal/core/services/campaign"
+	claimService "specommerce/campaignservice/internal/core/services/claim"
+	drawService "specommerce/campaignservice/internal/core/services/draw"
+	orderService "specommerce/campaignservice/internal/core/services/order"
+	reconciliationService "specommerce/campaignservice/internal/core/services/reconciliation"
 	"specommerce/campaignservice/pkg/atomicity"
 	"specommerce/campaignservice/pkg/database"
 	"specommerce/campaignservice/pkg/environment"
 	"specommerce/campaignservice/pkg/messagequeue"
+	"specommerce/campaignservice/pkg/outbox"
 	"specommerce/campaignservice/pkg/service_config"
 	"specommerce/campaignservice/pkg/shutdown"
 	"specommerce/campaignservice/server"
 )
 
 func Run(logger *slog.Logger, tasks *shutdown.Tasks) error {
-	cfg, err := service_config.InitConfig[config.AppConfig](assets.EmbeddedFiles)
+	injector, err := newInjector(logger, tasks)
 	if err != nil {
 		return err
 	}
 
-	env := environment.Development
-	if cfg.Env == string(environment.Production) {
-		env = environment.Production
-	}
-
-	getDbFunc, atomicExecutor, err := database.New(cfg.Database, tasks, assets.EmbeddedFiles)
-	if err != nil {
-		return err
-	}
-	injector := di.NewInjector()
-	do.ProvideValue(injector, logger)
-	do.ProvideValue(injector, getDbFunc)
-	do.ProvideValue(injector, cfg)
-	do.ProvideValue(injector, env)
-	do.ProvideValue[atomicity.AtomicExecutor](injector, atomicExecutor)
-	do.ProvideValue(injector, tasks)
-
 	var eg errgroup.Group
 	eg.Go(
 		func() error {
 			return server.ServeHTTP(injector)
 		})
+	eg.Go(
+		func() error {
+			return server.ServeAdminHTTP(injector)
+		})
+
+	campaignScheduler := do.MustInvoke[*campaignService.Scheduler](injector)
+	eg.Go(func() error {
+		return campaignScheduler.Start()
+	})
+
+	drawScheduler := do.MustInvoke[*drawService.Scheduler](injector)
+	eg.Go(func() error {
+		return drawScheduler.Start()
+	})
+
+	winnerReconciler := do.MustInvoke[*reconciliationService.Reconciler](injector)
+	eg.Go(func() error {
+		return winnerReconciler.Start()
+	})
+
+	claimSweeper := do.MustInvoke[*claimService.Sweeper](injector)
+	eg.Go(func() error {
+		return claimSweeper.Start()
+	})
+
+	orderFlusher := do.MustInvoke[*orderService.Flusher](injector)
+	eg.Go(func() error {
+		return orderFlusher.Start()
+	})
+
+	pendingSweeper := do.MustInvoke[*orderService.PendingSweeper](injector)
+	eg.Go(func() error {
+		return pendingSweeper.Start()
+	})
+
+	auditRelay := do.MustInvoke[*auditService.Relay](injector)
+	eg.Go(func() error {
+		return auditRelay.Start()
+	})
+
+	outboxRelay := do.MustInvoke[*outbox.Relay](injector)
+	eg.Go(func() error {
+		return outboxRelay.Start()
+	})
 
 	orderListener := do.MustInvoke[*orderConsumer.OrderConsumer](injector)
 	successOrderListener := do.MustInvoke[*orderConsumer.SuccessOrderConsumer](injector)
@@ -58,3 +96,78 @@ func Run(logger *slog.Logger, tasks *shutdown.Tasks) error {
 
 	return eg.Wait()
 }
+
+// Rebuild rebuilds the Redis state of one campaign, or of all campaigns when
+// campaignId is 0, from the database.
+func Rebuild(logger *slog.Logger, tasks *shutdown.Tasks, campaignId int64, dryRun bool) ([]campaign.RebuildReport, error) {
+	injector, err := newInjector(logger, tasks)
+	if err != nil {
+		return nil, err
+	}
+	service := do.MustInvoke[primary.RebuildService](injector)
+	ctx := context.Background()
+	if campaignId == 0 {
+		return service.RebuildAll(ctx, dryRun)
+	}
+	report, err := service.Rebuild(ctx, campaignId, dryRun)
+	if err != nil {
+		return nil, err
+	}
+	return []campaign.RebuildReport{report}, nil
+}
+
+// Simulate replays order events through the rule of a campaign, see
+// primary.SimulationService. A non-zero campaignId starts from the definition of
+// an existing campaign, overridden by the type, policy and window of input when set.
+func Simulate(logger *slog.Logger, tasks *shutdown.Tasks, campaignId int64, input campaign.Campaign, events []order.Order) (campaign.Simulation, error) {
+	injector, err := newInjector(logger, tasks)
+	if err != nil {
+		return campaign.Simulation{}, err
+	}
+	ctx := context.Background()
+	if campaignId != 0 {
+		existing, err := do.MustInvoke[primary.CampaignService](injector).GetCampaign(ctx, campaignId)
+		if err != nil {
+			return campaign.Simulation{}, err
+		}
+		if input.Type != "" {
+			existing.Type = input.Type
+		}
+		if input.Policy != nil {
+			existing.Policy = input.Policy
+		}
+		if !input.StartTime.IsZero() {
+			existing.StartTime = input.StartTime
+		}
+		if !input.EndTime.IsZero() {
+			existing.EndTime = input.EndTime
+		}
+		input = existing
+	}
+	return do.MustInvoke[primary.SimulationService](injector).Simulate(ctx, input, events)
+}
+
+func newInjector(logger *slog.Logger, tasks *shutdown.Tasks) (do.Injector, error) {
+	cfg, err := service_config.InitConfig[config.AppConfig](assets.EmbeddedFiles)
+	if err != nil {
+		return nil, err
+	}
+
+	env := environment.Development
+	if cfg.Env == string(environment.Production) {
+		env = environment.Production
+	}
+
+	getDbFunc, atomicExecutor, err := database.New(cfg.Database, tasks, assets.EmbeddedFiles)
+	if err != nil {
+		return nil, err
+	}
+	injector := di.NewInjector()
+	do.ProvideValue(injector, logger)
+	do.ProvideValue(injector, getDbFunc)
+	do.ProvideValue(injector, cfg)
+	do.ProvideValue(injector, env)
+	do.ProvideValue[atomicity.AtomicExecutor](injector, atomicExecutor)
+	do.ProvideValue(injector, tasks)
+	return injector, nil
+}
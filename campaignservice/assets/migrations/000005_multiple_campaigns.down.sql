@@ -0,0 +1,3 @@
+drop index if exists campaigns_start_time_end_time;
+
+alter table campaigns add constraint campaigns_type_key unique (type);
@@ -0,0 +1,3 @@
+alter table campaigns drop constraint campaigns_type_key;
+
+create index campaigns_start_time_end_time on campaigns(start_time, end_time);
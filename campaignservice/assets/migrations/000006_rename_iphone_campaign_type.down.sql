@@ -0,0 +1 @@
+update campaigns set type = 'iphone' where type = 'first_n_customers';
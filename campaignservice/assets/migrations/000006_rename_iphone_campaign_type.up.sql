@@ -0,0 +1 @@
+update campaigns set type = 'first_n_customers' where type = 'iphone';
@@ -0,0 +1,3 @@
+drop table draw_winners;
+drop table draw_entries;
+drop table draws;
@@ -0,0 +1,38 @@
+create table draws (
+    campaign_id bigint primary key not null references campaigns(id),
+    seed_hash varchar(64) not null,
+    seed varchar(64) not null,
+    entry_count integer not null default 0,
+    drawn_at timestamp with time zone,
+    created_at timestamp with time zone not null default now(),
+    updated_at timestamp with time zone not null default now()
+);
+
+select create_updated_at_trigger('draws');
+
+create index draws_not_drawn on draws(campaign_id) where drawn_at is null;
+
+create table draw_entries (
+    campaign_id bigint not null references draws(campaign_id),
+    customer_id varchar(20) not null,
+    order_id varchar(20) not null,
+    entered_at timestamp with time zone not null,
+    created_at timestamp with time zone not null default now(),
+    updated_at timestamp with time zone not null default now(),
+    primary key (campaign_id, customer_id)
+);
+
+select create_updated_at_trigger('draw_entries');
+
+create table draw_winners (
+    campaign_id bigint not null references draws(campaign_id),
+    customer_id varchar(20) not null,
+    position integer not null,
+    score varchar(64) not null,
+    created_at timestamp with time zone not null default now(),
+    updated_at timestamp with time zone not null default now(),
+    primary key (campaign_id, customer_id),
+    unique (campaign_id, position)
+);
+
+select create_updated_at_trigger('draw_winners');
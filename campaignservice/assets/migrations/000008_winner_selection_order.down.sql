@@ -0,0 +1,4 @@
+alter table winners drop constraint winners_campaign_id_position_key;
+alter table winners drop constraint winners_campaign_id_customer_id_key;
+alter table winners drop column position;
+alter table winners drop column order_id;
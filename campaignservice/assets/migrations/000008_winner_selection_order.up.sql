@@ -0,0 +1,17 @@
+delete from winners w using winners d
+where w.campaign_id = d.campaign_id and w.customer_id = d.customer_id
+and (w.created_at, w.id) > (d.created_at, d.id);
+
+alter table winners add column order_id varchar(20);
+alter table winners add column position integer;
+
+update winners w set position = ranked.position
+from (
+    select id, row_number() over (partition by campaign_id order by created_at, id) as position
+    from winners
+) ranked
+where w.id = ranked.id;
+
+alter table winners alter column position set not null;
+alter table winners add constraint winners_campaign_id_customer_id_key unique (campaign_id, customer_id);
+alter table winners add constraint winners_campaign_id_position_key unique (campaign_id, position);
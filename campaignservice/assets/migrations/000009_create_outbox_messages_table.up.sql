@@ -0,0 +1,23 @@
+create type outbox_status as enum (
+    'PENDING',
+    'SENT',
+    'FAILED'
+);
+
+create table outbox_messages (
+    id bigserial primary key,
+    topic varchar(255) not null,
+    message_key bytea,
+    payload bytea not null,
+    headers jsonb not null default '[]',
+    status outbox_status not null default 'PENDING',
+    attempts int not null default 0,
+    last_error text not null default '',
+    sent_at timestamp with time zone,
+    created_at timestamp with time zone not null default now(),
+    updated_at timestamp with time zone not null default now()
+);
+
+select create_updated_at_trigger('outbox_messages');
+
+create index outbox_messages_pending on outbox_messages(id) where status = 'PENDING';
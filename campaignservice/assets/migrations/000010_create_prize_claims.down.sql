@@ -0,0 +1,11 @@
+drop index winners_claim_status_claim_deadline;
+
+alter table winners drop column reassigned;
+alter table winners drop column replaces;
+alter table winners drop column shipped_at;
+alter table winners drop column claimed_at;
+alter table winners drop column shipping_address;
+alter table winners drop column claim_deadline;
+alter table winners drop column claim_status;
+
+alter table campaigns drop column prize_inventory;
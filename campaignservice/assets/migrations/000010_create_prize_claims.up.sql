@@ -0,0 +1,12 @@
+alter table campaigns add column prize_inventory integer not null default 0;
+update campaigns set prize_inventory = coalesce((policy->>'total_reward')::integer, 0);
+
+alter table winners add column claim_status varchar(20) not null default 'NOTIFIED';
+alter table winners add column claim_deadline timestamp with time zone;
+alter table winners add column shipping_address text;
+alter table winners add column claimed_at timestamp with time zone;
+alter table winners add column shipped_at timestamp with time zone;
+alter table winners add column replaces varchar(20);
+alter table winners add column reassigned boolean not null default false;
+
+create index winners_claim_status_claim_deadline on winners(claim_status, claim_deadline);
@@ -0,0 +1,3 @@
+drop index campaigns_status_start_time;
+
+alter table campaigns drop column status;
@@ -0,0 +1,8 @@
+alter table campaigns add column status varchar(20) not null default 'DRAFT';
+update campaigns set status = case
+    when end_time < now() then 'ENDED'
+    when start_time <= now() then 'ACTIVE'
+    else 'SCHEDULED'
+end;
+
+create index campaigns_status_start_time on campaigns(status, start_time);
@@ -0,0 +1,18 @@
+create table campaign_audit (
+    campaign_id bigint not null references campaigns(id),
+    stream_id varchar(64) not null,
+    order_id varchar(20) not null,
+    customer_id varchar(20) not null,
+    score bigint,
+    decision varchar(32) not null,
+    reason text not null,
+    decided_at timestamp with time zone not null,
+    created_at timestamp with time zone not null default now(),
+    primary key (campaign_id, stream_id)
+);
+
+create index campaign_audit_customer on campaign_audit(campaign_id, customer_id, decided_at);
+
+-- The audit trail is append-only
+create rule campaign_audit_no_update as on update to campaign_audit do instead nothing;
+create rule campaign_audit_no_delete as on delete to campaign_audit do instead nothing;
@@ -0,0 +1,7 @@
+update campaigns c set policy = c.policy || jsonb_build_object('seed', d.seed)
+from draws d
+where d.campaign_id = c.id and c.type = 'lucky_draw';
+
+delete from draws where campaign_id in (select id from campaigns where type = 'lucky_draw');
+
+alter table draws alter column seed type varchar(64);
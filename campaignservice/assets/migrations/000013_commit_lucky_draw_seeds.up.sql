@@ -0,0 +1,10 @@
+-- The seed of a lucky draw campaign moves from its public policy to a committed draw
+alter table draws alter column seed type text;
+
+insert into draws (campaign_id, seed_hash, seed)
+select id, encode(sha256(convert_to(policy->>'seed', 'UTF8')), 'hex'), policy->>'seed'
+from campaigns
+where type = 'lucky_draw' and policy ? 'seed'
+on conflict (campaign_id) do nothing;
+
+update campaigns set policy = policy - 'seed' where type = 'lucky_draw';
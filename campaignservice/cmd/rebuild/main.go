@@ -0,0 +1,49 @@
+// Command rebuild rebuilds the Redis state of the campaigns from the database,
+// for example after a Redis flush or failover.
+//
+// It prints the differences between Redis and the database and only writes
+// them with -apply:
+//
+//	go run ./cmd/rebuild -campaign 1
+//	go run ./cmd/rebuild -campaign 1 -apply
+//	go run ./cmd/rebuild -apply
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log/slog"
+	"os"
+	app "specommerce/campaignservice"
+	"specommerce/campaignservice/pkg/shutdown"
+	"syscall"
+)
+
+func main() {
+	campaignId := flag.Int64("campaign", 0, "campaign to rebuild, all campaigns when 0")
+	apply := flag.Bool("apply", false, "write the differences to Redis")
+	flag.Parse()
+
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, nil))
+	tasks, _ := shutdown.NewShutdownTasks(logger)
+	defer func() {
+		panicSource := recover()
+		if panicSource == nil {
+			// Nothing keeps running once the reports are printed
+			tasks.GetSigChan() <- syscall.SIGTERM
+		}
+		tasks.Wait(panicSource)
+	}()
+
+	reports, err := app.Rebuild(logger, tasks, *campaignId, !*apply)
+	if err != nil {
+		logger.Error("cannot rebuild campaign state", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(reports); err != nil {
+		logger.Error("cannot print rebuild reports", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,94 @@
+// Command simulate shows who would have won a campaign, by replaying orders
+// through the rule and Lua scripts of its type in an isolated Redis keyspace.
+// The live campaign state is not touched.
+//
+// It replays the orders of the campaign window from the orders table, or the
+// order events of a JSON Lines file with -orders:
+//
+//	go run ./cmd/simulate -campaign 1
+//	go run ./cmd/simulate -campaign 1 -policy '{"total_reward":5,"min_order_amount":100,"max_tracked_orders":20}'
+//	go run ./cmd/simulate -type draw -policy '{"total_reward":3,"min_order_amount":50}' \
+//		-start 2025-08-01T00:00:00Z -end 2025-08-31T23:59:59Z -orders events.jsonl
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log/slog"
+	"os"
+	app "specommerce/campaignservice"
+	"specommerce/campaignservice/internal/core/domain/campaign"
+	"specommerce/campaignservice/internal/core/domain/order"
+	"specommerce/campaignservice/pkg/shutdown"
+	"syscall"
+	"time"
+)
+
+func main() {
+	campaignId := flag.Int64("campaign", 0, "existing campaign to simulate, the other flags override its definition")
+	campaignType := flag.String("type", "", "campaign type, first_n_customers by default")
+	policy := flag.String("policy", "", "campaign policy as JSON")
+	start := flag.String("start", "", "start of the campaign window, RFC 3339")
+	end := flag.String("end", "", "end of the campaign window, RFC 3339")
+	ordersFile := flag.String("orders", "", "JSON Lines file of order events to replay instead of the orders table")
+	flag.Parse()
+
+	logger := slog.New(slog.NewJSONHandler(os.Stderr, nil))
+	input, events, err := parseFlags(*campaignType, *policy, *start, *end, *ordersFile)
+	if err != nil {
+		logger.Error("invalid simulation flags", slog.String("error", err.Error()))
+		os.Exit(2)
+	}
+
+	tasks, _ := shutdown.NewShutdownTasks(logger)
+	defer func() {
+		panicSource := recover()
+		if panicSource == nil {
+			// Nothing keeps running once the result is printed
+			tasks.GetSigChan() <- syscall.SIGTERM
+		}
+		tasks.Wait(panicSource)
+	}()
+
+	result, err := app.Simulate(logger, tasks, *campaignId, input, events)
+	if err != nil {
+		logger.Error("cannot simulate campaign", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		logger.Error("cannot print simulation", slog.String("error", err.Error()))
+		os.Exit(1)
+	}
+}
+
+func parseFlags(campaignType string, policy string, start string, end string, ordersFile string) (campaign.Campaign, []order.Order, error) {
+	input := campaign.Campaign{Name: "simulation", Type: campaignType}
+	var err error
+	if policy != "" {
+		if err = json.Unmarshal([]byte(policy), &input.Policy); err != nil {
+			return input, nil, err
+		}
+	}
+	if start != "" {
+		if input.StartTime, err = time.Parse(time.RFC3339, start); err != nil {
+			return input, nil, err
+		}
+	}
+	if end != "" {
+		if input.EndTime, err = time.Parse(time.RFC3339, end); err != nil {
+			return input, nil, err
+		}
+	}
+	if ordersFile == "" {
+		return input, nil, nil
+	}
+	file, err := os.Open(ordersFile)
+	if err != nil {
+		return input, nil, err
+	}
+	defer file.Close()
+	events, err := order.DecodeEvents(file)
+	return input, events, err
+}
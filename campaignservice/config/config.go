@@ -3,12 +3,23 @@ package config
 import "specommerce/campaignservice/pkg/service_config"
 
 type AppConfig struct {
-	Server         service_config.RestServiceConfig `koanf:"server"`
-	Env            string                           `koanf:"env"`
-	Database       service_config.DbConfig          `koanf:"db"`
-	Kafka          service_config.KafkaConfig       `koanf:"messagequeue"`
-	OrderConsumer  service_config.KafkaConfig       `koanf:"orderConsumer"`
-	OrderSuccess   service_config.KafkaConfig       `koanf:"orderSuccess"`
-	IphoneCampaign string                           `koanf:"iphoneCampaign"`
-	Redis          service_config.RedisConfig       `koanf:"redis"`
+	Server         service_config.RestServiceConfig    `koanf:"server"`
+	Admin          service_config.AdminServerConfig    `koanf:"adminServer"`
+	Env            string                              `koanf:"env"`
+	Database       service_config.DbConfig             `koanf:"db"`
+	Kafka          service_config.KafkaConfig          `koanf:"messagequeue"`
+	OrderConsumer  service_config.KafkaConfig          `koanf:"orderConsumer"`
+	OrderSuccess   service_config.KafkaConfig          `koanf:"orderSuccess"`
+	WinnerEvents   service_config.KafkaConfig          `koanf:"winnerEvents"`
+	Outbox         service_config.OutboxConfig         `koanf:"outbox"`
+	Redis          service_config.RedisConfig          `koanf:"redis"`
+	Draw           service_config.DrawConfig           `koanf:"draw"`
+	Reconciliation service_config.ReconciliationConfig `koanf:"reconciliation"`
+	Claim          service_config.ClaimConfig          `koanf:"claim"`
+	Schedule       service_config.ScheduleConfig       `koanf:"schedule"`
+	Progress       service_config.ProgressConfig       `koanf:"progress"`
+	Audit          service_config.AuditConfig          `koanf:"audit"`
+	OrderEvents    service_config.OrderEventsConfig    `koanf:"orderEvents"`
+	PendingSweep   service_config.PendingSweepConfig   `koanf:"pendingSweep"`
+	Rebuild        service_config.RebuildConfig        `koanf:"rebuild"`
 }
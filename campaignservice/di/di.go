@@ -4,19 +4,39 @@ import (
 	"github.com/samber/do/v2"
 	"log/slog"
 	"specommerce/campaignservice/config"
+	auditHandler "specommerce/campaignservice/internal/adapters/primary/audit/handler"
 	campaignHandler "specommerce/campaignservice/internal/adapters/primary/campaign/handler"
+	claimHandler "specommerce/campaignservice/internal/adapters/primary/claim/handler"
+	deadLetterHandler "specommerce/campaignservice/internal/adapters/primary/deadletter/handler"
+	drawHandler "specommerce/campaignservice/internal/adapters/primary/draw/handler"
 	orderConsumer "specommerce/campaignservice/internal/adapters/primary/order/event/kafka"
+	outboxHandler "specommerce/campaignservice/internal/adapters/primary/outbox/handler"
+	progressHandler "specommerce/campaignservice/internal/adapters/primary/progress/handler"
+	rebuildHandler "specommerce/campaignservice/internal/adapters/primary/rebuild/handler"
+	reconciliationHandler "specommerce/campaignservice/internal/adapters/primary/reconciliation/handler"
+	simulationHandler "specommerce/campaignservice/internal/adapters/primary/simulation/handler"
+	auditPostgres "specommerce/campaignservice/internal/adapters/secondary/audit/persistence/postgres"
 	campaignPostgres "specommerce/campaignservice/internal/adapters/secondary/campaign/persistence/postgres"
+	drawPostgres "specommerce/campaignservice/internal/adapters/secondary/draw/persistence/postgres"
 	orderPostgres "specommerce/campaignservice/internal/adapters/secondary/order/persistence/postgres"
+	winnerKafka "specommerce/campaignservice/internal/adapters/secondary/winner/event/kafka"
 	"specommerce/campaignservice/internal/core/ports/primary"
 	"specommerce/campaignservice/internal/core/ports/secondary"
+	auditService "specommerce/campaignservice/internal/core/services/audit"
 	campaignService "specommerce/campaignservice/internal/core/services/campaign"
+	claimService "specommerce/campaignservice/internal/core/services/claim"
+	drawService "specommerce/campaignservice/internal/core/services/draw"
 	orderService "specommerce/campaignservice/internal/core/services/order"
+	progressService "specommerce/campaignservice/internal/core/services/progress"
+	rebuildService "specommerce/campaignservice/internal/core/services/rebuild"
+	reconciliationService "specommerce/campaignservice/internal/core/services/reconciliation"
+	simulationService "specommerce/campaignservice/internal/core/services/simulation"
 
 	"specommerce/campaignservice/pkg/atomicity"
 	"specommerce/campaignservice/pkg/cache"
 	"specommerce/campaignservice/pkg/database"
 	"specommerce/campaignservice/pkg/messagequeue"
+	"specommerce/campaignservice/pkg/outbox"
 	"specommerce/campaignservice/pkg/shutdown"
 )
 
@@ -25,18 +45,56 @@ func NewInjector() do.Injector {
 
 	do.Provide(injector, NewCampaignRepository)
 	do.Provide(injector, NewCampaignService)
+	do.Provide(injector, NewCampaignScheduler)
 	do.Provide(injector, NewCampaignHandler)
 
+	do.Provide(injector, NewDrawRepository)
+	do.Provide(injector, NewDrawService)
+	do.Provide(injector, NewDrawScheduler)
+	do.Provide(injector, NewDrawHandler)
+
+	do.Provide(injector, NewRebuildService)
+	do.Provide(injector, NewRebuildHandler)
+
+	do.Provide(injector, NewSimulationService)
+	do.Provide(injector, NewSimulationHandler)
+
+	do.Provide(injector, NewReconciliationService)
+	do.Provide(injector, NewReconciler)
+	do.Provide(injector, NewReconciliationHandler)
+
+	do.Provide(injector, NewClaimService)
+	do.Provide(injector, NewClaimSweeper)
+	do.Provide(injector, NewClaimHandler)
+
+	do.Provide(injector, NewAuditRepository)
+	do.Provide(injector, NewAuditService)
+	do.Provide(injector, NewAuditRelay)
+	do.Provide(injector, NewAuditHandler)
+
+	do.Provide(injector, NewProgressService)
+	do.Provide(injector, NewProgressHandler)
+
 	do.Provide(injector, NewOrderRepository)
 	do.Provide(injector, NewOrderService)
+	do.Provide(injector, NewOrderFlusher)
+	do.Provide(injector, NewPendingSweeper)
 
 	do.Provide(injector, NewPublisher)
 	do.Provide(injector, NewOrderConsumer)
 	do.Provide(injector, NewSuccessOrderConsumer)
 
 	do.Provide(injector, NewBaseEventListener)
+	do.Provide(injector, NewDeadLetterQueue)
+	do.Provide(injector, NewDeadLetterHandler)
 	do.Provide(injector, NewRedisClient)
 
+	do.Provide(injector, NewWinnerPublisher)
+	do.Provide(injector, NewOutboxWriter)
+	do.Provide(injector, NewOutboxRelay)
+	do.Provide(injector, NewFailedOutboxMessages)
+	do.Provide(injector, NewOutboxHandler)
+
 	return injector
 }
 
@@ -47,17 +105,181 @@ func NewCampaignRepository(injector do.Injector) (secondary.CampaignRepository,
 
 func NewCampaignService(injector do.Injector) (primary.CampaignService, error) {
 	campaignRepository := do.MustInvoke[secondary.CampaignRepository](injector)
+	drawRepository := do.MustInvoke[secondary.DrawRepository](injector)
+	orderService := do.MustInvoke[primary.OrderService](injector)
 	atomicExecutor := do.MustInvoke[atomicity.AtomicExecutor](injector)
 	cfg := do.MustInvoke[config.AppConfig](injector)
 	cacheClient := do.MustInvoke[cache.Cache](injector)
 	return campaignService.NewCampaignService(
 		campaignRepository,
+		drawRepository,
+		orderService,
 		atomicExecutor,
 		cfg,
 		cacheClient,
 	), nil
 }
 
+func NewCampaignScheduler(injector do.Injector) (*campaignService.Scheduler, error) {
+	service := do.MustInvoke[primary.CampaignService](injector)
+	cfg := do.MustInvoke[config.AppConfig](injector)
+	tasks := do.MustInvoke[*shutdown.Tasks](injector)
+	logger := do.MustInvoke[*slog.Logger](injector)
+	return campaignService.NewScheduler(service, cfg.Schedule, tasks, logger), nil
+}
+
+func NewDrawRepository(injector do.Injector) (secondary.DrawRepository, error) {
+	getDbFunc := do.MustInvoke[database.GetDbFunc](injector)
+	return drawPostgres.NewDrawPersistenceRepository(getDbFunc), nil
+}
+
+func NewDrawService(injector do.Injector) (primary.DrawService, error) {
+	drawRepository := do.MustInvoke[secondary.DrawRepository](injector)
+	campaignRepository := do.MustInvoke[secondary.CampaignRepository](injector)
+	atomicExecutor := do.MustInvoke[atomicity.AtomicExecutor](injector)
+	cfg := do.MustInvoke[config.AppConfig](injector)
+	logger := do.MustInvoke[*slog.Logger](injector)
+	winnerEvents := do.MustInvoke[secondary.WinnerEventRepository](injector)
+	return drawService.NewDrawService(drawRepository, campaignRepository, winnerEvents, atomicExecutor, cfg.Draw, cfg.Claim, logger), nil
+}
+
+func NewDrawScheduler(injector do.Injector) (*drawService.Scheduler, error) {
+	service := do.MustInvoke[primary.DrawService](injector)
+	cfg := do.MustInvoke[config.AppConfig](injector)
+	tasks := do.MustInvoke[*shutdown.Tasks](injector)
+	logger := do.MustInvoke[*slog.Logger](injector)
+	return drawService.NewScheduler(service, cfg.Draw, tasks, logger), nil
+}
+
+func NewDrawHandler(injector do.Injector) (drawHandler.DrawHandler, error) {
+	service := do.MustInvoke[primary.DrawService](injector)
+	return drawHandler.NewDrawHandler(service), nil
+}
+
+func NewRebuildService(injector do.Injector) (primary.RebuildService, error) {
+	campaignRepository := do.MustInvoke[secondary.CampaignRepository](injector)
+	orderRepository := do.MustInvoke[secondary.OrderRepository](injector)
+	drawRepository := do.MustInvoke[secondary.DrawRepository](injector)
+	orderService := do.MustInvoke[primary.OrderService](injector)
+	cacheClient := do.MustInvoke[cache.Cache](injector)
+	cfg := do.MustInvoke[config.AppConfig](injector)
+	logger := do.MustInvoke[*slog.Logger](injector)
+	return rebuildService.NewRebuildService(campaignRepository, orderRepository, drawRepository, orderService, cacheClient, cfg, logger), nil
+}
+
+func NewRebuildHandler(injector do.Injector) (rebuildHandler.RebuildHandler, error) {
+	service := do.MustInvoke[primary.RebuildService](injector)
+	return rebuildHandler.NewRebuildHandler(service), nil
+}
+
+func NewSimulationService(injector do.Injector) (primary.SimulationService, error) {
+	orderRepository := do.MustInvoke[secondary.OrderRepository](injector)
+	cacheClient := do.MustInvoke[cache.Cache](injector)
+	cfg := do.MustInvoke[config.AppConfig](injector)
+	logger := do.MustInvoke[*slog.Logger](injector)
+	return simulationService.NewSimulationService(orderRepository, cacheClient, cfg.OrderEvents, logger), nil
+}
+
+func NewSimulationHandler(injector do.Injector) (simulationHandler.SimulationHandler, error) {
+	service := do.MustInvoke[primary.SimulationService](injector)
+	return simulationHandler.NewSimulationHandler(service), nil
+}
+
+func NewReconciliationService(injector do.Injector) (primary.ReconciliationService, error) {
+	campaignRepository := do.MustInvoke[secondary.CampaignRepository](injector)
+	orderRepository := do.MustInvoke[secondary.OrderRepository](injector)
+	cacheClient := do.MustInvoke[cache.Cache](injector)
+	logger := do.MustInvoke[*slog.Logger](injector)
+	return reconciliationService.NewReconciliationService(campaignRepository, orderRepository, cacheClient, logger), nil
+}
+
+func NewReconciler(injector do.Injector) (*reconciliationService.Reconciler, error) {
+	service := do.MustInvoke[primary.ReconciliationService](injector)
+	cfg := do.MustInvoke[config.AppConfig](injector)
+	tasks := do.MustInvoke[*shutdown.Tasks](injector)
+	logger := do.MustInvoke[*slog.Logger](injector)
+	return reconciliationService.NewReconciler(service, cfg.Reconciliation, tasks, logger), nil
+}
+
+func NewReconciliationHandler(injector do.Injector) (reconciliationHandler.ReconciliationHandler, error) {
+	service := do.MustInvoke[primary.ReconciliationService](injector)
+	return reconciliationHandler.NewReconciliationHandler(service), nil
+}
+
+func NewClaimService(injector do.Injector) (primary.ClaimService, error) {
+	campaignRepository := do.MustInvoke[secondary.CampaignRepository](injector)
+	orderRepository := do.MustInvoke[secondary.OrderRepository](injector)
+	drawRepository := do.MustInvoke[secondary.DrawRepository](injector)
+	winnerEvents := do.MustInvoke[secondary.WinnerEventRepository](injector)
+	atomicExecutor := do.MustInvoke[atomicity.AtomicExecutor](injector)
+	cacheClient := do.MustInvoke[cache.Cache](injector)
+	cfg := do.MustInvoke[config.AppConfig](injector)
+	logger := do.MustInvoke[*slog.Logger](injector)
+	return claimService.NewClaimService(
+		campaignRepository,
+		orderRepository,
+		drawRepository,
+		winnerEvents,
+		atomicExecutor,
+		cacheClient,
+		cfg.Claim,
+		logger,
+	), nil
+}
+
+func NewClaimSweeper(injector do.Injector) (*claimService.Sweeper, error) {
+	service := do.MustInvoke[primary.ClaimService](injector)
+	cfg := do.MustInvoke[config.AppConfig](injector)
+	tasks := do.MustInvoke[*shutdown.Tasks](injector)
+	logger := do.MustInvoke[*slog.Logger](injector)
+	return claimService.NewSweeper(service, cfg.Claim, tasks, logger), nil
+}
+
+func NewClaimHandler(injector do.Injector) (claimHandler.ClaimHandler, error) {
+	service := do.MustInvoke[primary.ClaimService](injector)
+	return claimHandler.NewClaimHandler(service), nil
+}
+
+func NewAuditRepository(injector do.Injector) (secondary.AuditRepository, error) {
+	getDbFunc := do.MustInvoke[database.GetDbFunc](injector)
+	return auditPostgres.NewAuditPersistenceRepository(getDbFunc), nil
+}
+
+func NewAuditService(injector do.Injector) (primary.AuditService, error) {
+	campaignRepository := do.MustInvoke[secondary.CampaignRepository](injector)
+	auditRepository := do.MustInvoke[secondary.AuditRepository](injector)
+	cacheClient := do.MustInvoke[cache.Cache](injector)
+	cfg := do.MustInvoke[config.AppConfig](injector)
+	logger := do.MustInvoke[*slog.Logger](injector)
+	return auditService.NewAuditService(campaignRepository, auditRepository, cacheClient, cfg.Audit, logger), nil
+}
+
+func NewAuditRelay(injector do.Injector) (*auditService.Relay, error) {
+	service := do.MustInvoke[primary.AuditService](injector)
+	cfg := do.MustInvoke[config.AppConfig](injector)
+	tasks := do.MustInvoke[*shutdown.Tasks](injector)
+	logger := do.MustInvoke[*slog.Logger](injector)
+	return auditService.NewRelay(service, cfg.Audit, tasks, logger), nil
+}
+
+func NewAuditHandler(injector do.Injector) (auditHandler.AuditHandler, error) {
+	service := do.MustInvoke[primary.AuditService](injector)
+	return auditHandler.NewAuditHandler(service), nil
+}
+
+func NewProgressService(injector do.Injector) (primary.ProgressService, error) {
+	campaignRepository := do.MustInvoke[secondary.CampaignRepository](injector)
+	cacheClient := do.MustInvoke[cache.Cache](injector)
+	logger := do.MustInvoke[*slog.Logger](injector)
+	return progressService.NewProgressService(campaignRepository, cacheClient, logger), nil
+}
+
+func NewProgressHandler(injector do.Injector) (progressHandler.ProgressHandler, error) {
+	service := do.MustInvoke[primary.ProgressService](injector)
+	cfg := do.MustInvoke[config.AppConfig](injector)
+	return progressHandler.NewProgressHandler(service, cfg.Progress), nil
+}
+
 func NewOrderRepository(injector do.Injector) (secondary.OrderRepository, error) {
 	getDbFunc := do.MustInvoke[database.GetDbFunc](injector)
 	return orderPostgres.NewOrderPersistenceRepository(
@@ -68,22 +290,41 @@ func NewOrderRepository(injector do.Injector) (secondary.OrderRepository, error)
 func NewOrderService(injector do.Injector) (primary.OrderService, error) {
 	orderRepository := do.MustInvoke[secondary.OrderRepository](injector)
 	campaignRepository := do.MustInvoke[secondary.CampaignRepository](injector)
+	winnerEvents := do.MustInvoke[secondary.WinnerEventRepository](injector)
+	progress := do.MustInvoke[primary.ProgressService](injector)
 	atomicExecutor := do.MustInvoke[atomicity.AtomicExecutor](injector)
 	cacheClient := do.MustInvoke[cache.Cache](injector)
 	cfg := do.MustInvoke[config.AppConfig](injector)
 	return orderService.NewOrderService(
 		orderRepository,
 		campaignRepository,
+		winnerEvents,
+		progress,
 		atomicExecutor,
 		cacheClient,
 		cfg,
 	), nil
 }
 
+func NewOrderFlusher(injector do.Injector) (*orderService.Flusher, error) {
+	service := do.MustInvoke[primary.OrderService](injector)
+	cfg := do.MustInvoke[config.AppConfig](injector)
+	tasks := do.MustInvoke[*shutdown.Tasks](injector)
+	logger := do.MustInvoke[*slog.Logger](injector)
+	return orderService.NewFlusher(service, cfg.OrderEvents, tasks, logger), nil
+}
+
+func NewPendingSweeper(injector do.Injector) (*orderService.PendingSweeper, error) {
+	service := do.MustInvoke[primary.OrderService](injector)
+	cfg := do.MustInvoke[config.AppConfig](injector)
+	tasks := do.MustInvoke[*shutdown.Tasks](injector)
+	logger := do.MustInvoke[*slog.Logger](injector)
+	return orderService.NewPendingSweeper(service, cfg.PendingSweep, tasks, logger), nil
+}
+
 func NewCampaignHandler(injector do.Injector) (campaignHandler.CampaignHandler, error) {
 	service := do.MustInvoke[primary.CampaignService](injector)
-	cfg := do.MustInvoke[config.AppConfig](injector)
-	return campaignHandler.NewCampaignHandler(service, cfg), nil
+	return campaignHandler.NewCampaignHandler(service), nil
 }
 
 func NewPublisher(injector do.Injector) (messagequeue.Publisher, error) {
@@ -93,9 +334,21 @@ func NewPublisher(injector do.Injector) (messagequeue.Publisher, error) {
 }
 
 func NewBaseEventListener(injector do.Injector) (*messagequeue.BaseEventListener, error) {
+	publisher := do.MustInvoke[messagequeue.Publisher](injector)
 	tasks := do.MustInvoke[*shutdown.Tasks](injector)
 	logger := do.MustInvoke[*slog.Logger](injector)
-	return messagequeue.NewBaseEventListener(tasks, logger), nil
+	return messagequeue.NewBaseEventListener(publisher, tasks, logger), nil
+}
+
+func NewDeadLetterQueue(injector do.Injector) (messagequeue.DeadLetterQueue, error) {
+	cfg := do.MustInvoke[config.AppConfig](injector)
+	publisher := do.MustInvoke[messagequeue.Publisher](injector)
+	return messagequeue.NewDeadLetterQueue(cfg.Kafka.Host, publisher, cfg.OrderConsumer, cfg.OrderSuccess), nil
+}
+
+func NewDeadLetterHandler(injector do.Injector) (deadLetterHandler.DeadLetterHandler, error) {
+	deadLetterQueue := do.MustInvoke[messagequeue.DeadLetterQueue](injector)
+	return deadLetterHandler.NewDeadLetterHandler(deadLetterQueue), nil
 }
 
 func NewOrderConsumer(injector do.Injector) (*orderConsumer.OrderConsumer, error) {
@@ -117,3 +370,34 @@ func NewRedisClient(injector do.Injector) (cache.Cache, error) {
 	cfg := do.MustInvoke[config.AppConfig](injector)
 	return cache.NewRedisClient(cfg.Redis, tasks), nil
 }
+
+func NewWinnerPublisher(injector do.Injector) (secondary.WinnerEventRepository, error) {
+	cfg := do.MustInvoke[config.AppConfig](injector)
+	outboxWriter := do.MustInvoke[outbox.Writer](injector)
+	return winnerKafka.NewWinnerPublisher(cfg, outboxWriter), nil
+}
+
+func NewOutboxWriter(injector do.Injector) (outbox.Writer, error) {
+	getDbFunc := do.MustInvoke[database.GetDbFunc](injector)
+	return outbox.NewWriter(getDbFunc), nil
+}
+
+func NewOutboxRelay(injector do.Injector) (*outbox.Relay, error) {
+	getDbFunc := do.MustInvoke[database.GetDbFunc](injector)
+	atomicExecutor := do.MustInvoke[atomicity.AtomicExecutor](injector)
+	publisher := do.MustInvoke[messagequeue.Publisher](injector)
+	cfg := do.MustInvoke[config.AppConfig](injector)
+	tasks := do.MustInvoke[*shutdown.Tasks](injector)
+	logger := do.MustInvoke[*slog.Logger](injector)
+	return outbox.NewRelay(getDbFunc, atomicExecutor, publisher, cfg.Outbox, tasks, logger), nil
+}
+
+func NewFailedOutboxMessages(injector do.Injector) (outbox.FailedMessages, error) {
+	getDbFunc := do.MustInvoke[database.GetDbFunc](injector)
+	return outbox.NewFailedMessages(getDbFunc), nil
+}
+
+func NewOutboxHandler(injector do.Injector) (outboxHandler.OutboxHandler, error) {
+	failedMessages := do.MustInvoke[outbox.FailedMessages](injector)
+	return outboxHandler.NewOutboxHandler(failedMessages), nil
+}
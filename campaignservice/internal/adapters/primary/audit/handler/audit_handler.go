@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	domain "specommerce/campaignservice/internal/core/domain/campaign"
+	"specommerce/campaignservice/internal/core/ports/primary"
+	"specommerce/campaignservice/pkg/database"
+	"specommerce/campaignservice/pkg/sharedto/handler"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AuditHandler interface {
+	GetCustomerTrail(ctx *gin.Context)
+}
+
+type auditHandler struct {
+	auditService primary.AuditService
+}
+
+func NewAuditHandler(auditService primary.AuditService) AuditHandler {
+	return &auditHandler{
+		auditService: auditService,
+	}
+}
+
+// GetCustomerTrail godoc
+// @Summary Get the audit trail of a customer
+// @Description Get every decision of the campaign scripts on the orders of a customer, in decision order, with the order score and the reason: ADMITTED, SKIPPED_FAILED, SKIPPED_WINNER, ELIGIBLE_FULL, ELIGIBLE, WINNER, REWARDS_EXHAUSTED, BELOW_MIN_AMOUNT, NOT_DRAWN, NO_TIER, REWARD_UPGRADED, ENTERED, ALREADY_ENTERED, REORDERED, DROPPED_LATE or EXPIRED
+// @Tags campaigns
+// @Accept json
+// @Produce json
+// @Param id path int true "Campaign ID"
+// @Param customer_id path string true "Customer ID"
+// @Success 200 {array} campaign.AuditEntry "Audit trail retrieved successfully"
+// @Failure 400 {object} handler.ErrorResponse "Bad request"
+// @Failure 404 {object} handler.ErrorResponse "Campaign not found"
+// @Failure 500 {object} handler.ErrorResponse "Internal server error"
+// @Router /admin/v1/campaigns/{id}/audit/{customer_id} [get]
+func (h *auditHandler) GetCustomerTrail(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Campaign ID is invalid"})
+		return
+	}
+
+	result, err := h.auditService.GetTrail(ctx, id, ctx.Param("customer_id"))
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, handler.BaseResponse[[]domain.AuditEntry]{
+		Data: result,
+	})
+}
+
+func errorStatus(err error) int {
+	if errors.Is(err, database.ErrRecordNotFound) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
@@ -1,10 +1,12 @@
 package handler
 
 import (
+	"errors"
 	"net/http"
-	"specommerce/campaignservice/config"
 	domain "specommerce/campaignservice/internal/core/domain/campaign"
 	"specommerce/campaignservice/internal/core/ports/primary"
+	"specommerce/campaignservice/internal/core/rules"
+	"specommerce/campaignservice/pkg/database"
 	"specommerce/campaignservice/pkg/sharedto/handler"
 	"strconv"
 
@@ -12,27 +14,28 @@ import (
 )
 
 type CampaignHandler interface {
-	CreateIphoneCampaign(ctx *gin.Context)
-	GetIphoneCampaign(ctx *gin.Context)
-	UpdateIphoneCampaign(ctx *gin.Context)
-	GetIphoneWinner(ctx *gin.Context)
+	CreateCampaign(ctx *gin.Context)
+	GetCampaigns(ctx *gin.Context)
+	GetCampaign(ctx *gin.Context)
+	UpdateCampaign(ctx *gin.Context)
+	GetCampaignWinners(ctx *gin.Context)
+	ChangeStatus(ctx *gin.Context)
 }
 
 type campaignHandler struct {
 	campaignService primary.CampaignService
-	config          config.AppConfig
 }
 
-func NewCampaignHandler(campaignService primary.CampaignService, config config.AppConfig) CampaignHandler {
+func NewCampaignHandler(campaignService primary.CampaignService) CampaignHandler {
 	return &campaignHandler{
 		campaignService: campaignService,
-		config:          config,
 	}
 }
 
 // CreateCampaign godoc
 // @Summary Create a new campaign
-// @Description Create a new marketing campaign with the provided details
+// @Description Create a new marketing campaign with the provided details. Several campaigns can run at the same time.
+// @Description The policy is validated against the schema of the campaign type: first_n_customers (default), cashback, voucher, lucky_draw, tiered or draw
 // @Tags campaigns
 // @Accept json
 // @Produce json
@@ -40,17 +43,21 @@ func NewCampaignHandler(campaignService primary.CampaignService, config config.A
 // @Success 200 {object} campaign.Campaign "Campaign created successfully"
 // @Failure 400 {object} handler.ErrorResponse "Bad request"
 // @Failure 500 {object} handler.ErrorResponse "Internal server error"
-// @Router /v1/campaigns/iphones [post]
-func (h *campaignHandler) CreateIphoneCampaign(ctx *gin.Context) {
-	var req CreateIphoneCampaignRequest
+// @Router /admin/v1/campaigns [post]
+func (h *campaignHandler) CreateCampaign(ctx *gin.Context) {
+	var req CreateCampaignRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if !req.EndTime.After(req.StartTime) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "end_time must be after start_time"})
+		return
+	}
 
-	createdCampaign, err := h.campaignService.CreateCampaign(ctx, req.ToDomain(h.config.IphoneCampaign))
+	createdCampaign, err := h.campaignService.CreateCampaign(ctx, req.ToDomain())
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -59,20 +66,48 @@ func (h *campaignHandler) CreateIphoneCampaign(ctx *gin.Context) {
 	})
 }
 
-// GetIphoneCampaign godoc
-// @Summary Get iPhone campaign
-// @Description Get the current iPhone campaign details
+// GetCampaigns godoc
+// @Summary List campaigns
+// @Description Get all campaigns, the most recent first
+// @Tags campaigns
+// @Accept json
+// @Produce json
+// @Success 200 {array} campaign.Campaign "Campaigns retrieved successfully"
+// @Failure 500 {object} handler.ErrorResponse "Internal server error"
+// @Router /admin/v1/campaigns [get]
+func (h *campaignHandler) GetCampaigns(ctx *gin.Context) {
+	campaigns, err := h.campaignService.GetCampaigns(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, handler.BaseResponse[[]domain.Campaign]{
+		Data: campaigns,
+	})
+}
+
+// GetCampaign godoc
+// @Summary Get campaign
+// @Description Get the details of a campaign
 // @Tags campaigns
 // @Accept json
 // @Produce json
+// @Param id path int true "Campaign ID"
 // @Success 200 {object} campaign.Campaign "Campaign retrieved successfully"
+// @Failure 400 {object} handler.ErrorResponse "Bad request"
 // @Failure 404 {object} handler.ErrorResponse "Campaign not found"
 // @Failure 500 {object} handler.ErrorResponse "Internal server error"
-// @Router /admin/v1/campaigns/iphones [get]
-func (h *campaignHandler) GetIphoneCampaign(ctx *gin.Context) {
-	campaign, err := h.campaignService.GetIphoneCampaign(ctx)
+// @Router /admin/v1/campaigns/{id} [get]
+func (h *campaignHandler) GetCampaign(ctx *gin.Context) {
+	id, ok := campaignId(ctx)
+	if !ok {
+		return
+	}
+
+	campaign, err := h.campaignService.GetCampaign(ctx, id)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -81,31 +116,38 @@ func (h *campaignHandler) GetIphoneCampaign(ctx *gin.Context) {
 	})
 }
 
-// UpdateIphoneCampaign godoc
-// @Summary Update iPhone campaign
-// @Description Update an existing iPhone campaign with the provided details
+// UpdateCampaign godoc
+// @Summary Update campaign
+// @Description Update an existing campaign with the provided details. The policy and the time window cannot change once the campaign is ACTIVE
 // @Tags campaigns
 // @Accept json
 // @Produce json
-// @Param campaign body UpdateIphoneCampaignRequest true "Campaign information"
+// @Param id path int true "Campaign ID"
+// @Param campaign body UpdateCampaignRequest true "Campaign information"
 // @Success 200 {object} campaign.Campaign "Campaign updated successfully"
 // @Failure 400 {object} handler.ErrorResponse "Bad request"
+// @Failure 404 {object} handler.ErrorResponse "Campaign not found"
+// @Failure 409 {object} handler.ErrorResponse "Policy or time window locked"
 // @Failure 500 {object} handler.ErrorResponse "Internal server error"
-// @Router /admin/v1/campaigns/iphones/{id} [put]
-func (h *campaignHandler) UpdateIphoneCampaign(ctx *gin.Context) {
-	var req UpdateIphoneCampaignRequest
-	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
-	if err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Campaign ID is required"})
+// @Router /admin/v1/campaigns/{id} [put]
+func (h *campaignHandler) UpdateCampaign(ctx *gin.Context) {
+	id, ok := campaignId(ctx)
+	if !ok {
+		return
 	}
+	var req UpdateCampaignRequest
 	if err := ctx.ShouldBindJSON(&req); err != nil {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	if !req.EndTime.After(req.StartTime) {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "end_time must be after start_time"})
+		return
+	}
 
-	updatedCampaign, err := h.campaignService.UpdateIphoneCampaign(ctx, req.ToDomain(id))
+	updatedCampaign, err := h.campaignService.UpdateCampaign(ctx, req.ToDomain(id))
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -114,21 +156,27 @@ func (h *campaignHandler) UpdateIphoneCampaign(ctx *gin.Context) {
 	})
 }
 
-// GetIphoneWinner godoc
-// @Summary Get iPhone campaign winners
-// @Description Get the list of winners for an iPhone campaign
+// GetCampaignWinners godoc
+// @Summary Get campaign winners
+// @Description Get the list of winners of a campaign
 // @Tags campaigns
 // @Accept json
 // @Produce json
-// @Param campaignType path string true "Campaign type"
+// @Param id path int true "Campaign ID"
 // @Success 200 {array} campaign.IphoneWinner "Winners retrieved successfully"
 // @Failure 400 {object} handler.ErrorResponse "Bad request"
+// @Failure 404 {object} handler.ErrorResponse "Campaign not found"
 // @Failure 500 {object} handler.ErrorResponse "Internal server error"
-// @Router /admin/v1/campaigns/iphones/winners [get]
-func (h *campaignHandler) GetIphoneWinner(ctx *gin.Context) {
-	winners, err := h.campaignService.GetIphoneWinner(ctx)
+// @Router /admin/v1/campaigns/{id}/winners [get]
+func (h *campaignHandler) GetCampaignWinners(ctx *gin.Context) {
+	id, ok := campaignId(ctx)
+	if !ok {
+		return
+	}
+
+	winners, err := h.campaignService.GetCampaignWinners(ctx, id)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -136,3 +184,62 @@ func (h *campaignHandler) GetIphoneWinner(ctx *gin.Context) {
 		Data: winners,
 	})
 }
+
+// ChangeStatus godoc
+// @Summary Change campaign status
+// @Description Move a campaign to another status: DRAFT, SCHEDULED, ACTIVE, PAUSED, ENDED or ARCHIVED.
+// @Description The orders of a PAUSED campaign are queued and evaluated when it is resumed or ended
+// @Tags campaigns
+// @Accept json
+// @Produce json
+// @Param id path int true "Campaign ID"
+// @Param status body ChangeStatusRequest true "New status"
+// @Success 200 {object} campaign.Campaign "Campaign status changed successfully"
+// @Failure 400 {object} handler.ErrorResponse "Bad request"
+// @Failure 404 {object} handler.ErrorResponse "Campaign not found"
+// @Failure 409 {object} handler.ErrorResponse "Invalid status transition"
+// @Failure 500 {object} handler.ErrorResponse "Internal server error"
+// @Router /admin/v1/campaigns/{id}/status [put]
+func (h *campaignHandler) ChangeStatus(ctx *gin.Context) {
+	id, ok := campaignId(ctx)
+	if !ok {
+		return
+	}
+	var req ChangeStatusRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updatedCampaign, err := h.campaignService.ChangeStatus(ctx, id, domain.Status(req.Status))
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, handler.BaseResponse[domain.Campaign]{
+		Data: updatedCampaign,
+	})
+}
+
+func campaignId(ctx *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Campaign ID is invalid"})
+		return 0, false
+	}
+	return id, true
+}
+
+func errorStatus(err error) int {
+	if errors.Is(err, database.ErrRecordNotFound) {
+		return http.StatusNotFound
+	}
+	if errors.Is(err, rules.ErrUnknownCampaignType) || errors.Is(err, rules.ErrInvalidPolicy) || errors.Is(err, domain.ErrTypeChanged) || errors.Is(err, domain.ErrInvalidStatus) {
+		return http.StatusBadRequest
+	}
+	if errors.Is(err, domain.ErrInvalidTransition) || errors.Is(err, domain.ErrPolicyLocked) {
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
@@ -6,58 +6,82 @@ import (
 )
 
 // CreateCampaignRequest represents the request for creating a campaign
-type CreateIphoneCampaignRequest struct {
-	Name             string    `json:"name" binding:"required"`
-	Description      string    `json:"description" binding:"required"`
-	StartTime        time.Time `json:"start_time" binding:"required"`
-	EndTime          time.Time `json:"end_time" binding:"required"`
-	TotalReward      int64     `json:"total_reward" binding:"required"`
-	MinOrderAmount   int64     `json:"min_order_amount" binding:"required"`
-	MaxTrackedOrders int64     `json:"max_tracked_orders" binding:"required"`
+type CreateCampaignRequest struct {
+	Name        string    `json:"name" binding:"required"`
+	Type        string    `json:"type"`
+	Description string    `json:"description" binding:"required"`
+	StartTime   time.Time `json:"start_time" binding:"required"`
+	EndTime     time.Time `json:"end_time" binding:"required"`
+	// Policy follows the policy schema of the campaign type.
+	Policy map[string]any `json:"policy"`
+	// Deprecated: policy fields of a first_n_customers campaign, used when Policy is not set.
+	TotalReward      int64 `json:"total_reward"`
+	MinOrderAmount   int64 `json:"min_order_amount"`
+	MaxTrackedOrders int64 `json:"max_tracked_orders"`
+	// PrizeInventory is the number of prizes in stock, the total reward of the policy when not set.
+	PrizeInventory int `json:"prize_inventory" binding:"gte=0"`
+	// Status is DRAFT or SCHEDULED (default). A DRAFT campaign evaluates no order until it is scheduled.
+	Status string `json:"status" binding:"omitempty,oneof=DRAFT SCHEDULED"`
 }
 
 // UpdateCampaignRequest represents the request for updating a campaign
-type UpdateIphoneCampaignRequest struct {
-	Id               int64     `uri:"id" binding:"required"`
-	Name             string    `json:"name" binding:"required"`
-	Description      string    `json:"description" binding:"required"`
-	StartTime        time.Time `json:"start_time" binding:"required"`
-	EndTime          time.Time `json:"end_time" binding:"required"`
-	TotalReward      int64     `json:"total_reward" binding:"required"`
-	MinOrderAmount   int64     `json:"min_order_amount" binding:"required"`
-	MaxTrackedOrders int64     `json:"max_tracked_orders" binding:"required"`
+type UpdateCampaignRequest struct {
+	Name        string    `json:"name" binding:"required"`
+	Type        string    `json:"type"`
+	Description string    `json:"description" binding:"required"`
+	StartTime   time.Time `json:"start_time" binding:"required"`
+	EndTime     time.Time `json:"end_time" binding:"required"`
+	// Policy follows the policy schema of the campaign type.
+	Policy map[string]any `json:"policy"`
+	// Deprecated: policy fields of a first_n_customers campaign, used when Policy is not set.
+	TotalReward      int64 `json:"total_reward"`
+	MinOrderAmount   int64 `json:"min_order_amount"`
+	MaxTrackedOrders int64 `json:"max_tracked_orders"`
+	// PrizeInventory is the number of prizes in stock, the total reward of the policy when not set.
+	PrizeInventory int `json:"prize_inventory" binding:"gte=0"`
 }
 
-func (r CreateIphoneCampaignRequest) ToDomain(campaignType string) domain.Campaign {
+// ChangeStatusRequest represents the request for moving a campaign to another status
+type ChangeStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=DRAFT SCHEDULED ACTIVE PAUSED ENDED ARCHIVED"`
+}
+
+func (r CreateCampaignRequest) ToDomain() domain.Campaign {
 	return domain.Campaign{
-		Name:        r.Name,
-		Type:        campaignType,
-		Description: r.Description,
-		StartTime:   r.StartTime,
-		EndTime:     r.EndTime,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
-		Policy: map[string]any{
-			"total_reward":       r.TotalReward,
-			"min_order_amount":   r.MinOrderAmount,
-			"max_tracked_orders": r.MaxTrackedOrders,
-		},
+		Name:           r.Name,
+		Type:           r.Type,
+		Status:         domain.Status(r.Status),
+		Description:    r.Description,
+		StartTime:      r.StartTime,
+		EndTime:        r.EndTime,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+		Policy:         policy(r.Policy, r.TotalReward, r.MinOrderAmount, r.MaxTrackedOrders),
+		PrizeInventory: r.PrizeInventory,
 	}
 }
 
-func (r UpdateIphoneCampaignRequest) ToDomain(id int64) domain.Campaign {
+func (r UpdateCampaignRequest) ToDomain(id int64) domain.Campaign {
 	return domain.Campaign{
-		Id:          id,
-		Name:        r.Name,
-		Type:        "iphone",
-		Description: r.Description,
-		StartTime:   r.StartTime,
-		EndTime:     r.EndTime,
-		UpdatedAt:   time.Now(),
-		Policy: map[string]any{
-			"total_reward":       r.TotalReward,
-			"min_order_amount":   r.MinOrderAmount,
-			"max_tracked_orders": r.MaxTrackedOrders,
-		},
+		Id:             id,
+		Name:           r.Name,
+		Type:           r.Type,
+		Description:    r.Description,
+		StartTime:      r.StartTime,
+		EndTime:        r.EndTime,
+		UpdatedAt:      time.Now(),
+		Policy:         policy(r.Policy, r.TotalReward, r.MinOrderAmount, r.MaxTrackedOrders),
+		PrizeInventory: r.PrizeInventory,
+	}
+}
+
+func policy(policy map[string]any, totalReward, minOrderAmount, maxTrackedOrders int64) map[string]any {
+	if policy != nil {
+		return policy
+	}
+	return map[string]any{
+		"total_reward":       totalReward,
+		"min_order_amount":   minOrderAmount,
+		"max_tracked_orders": maxTrackedOrders,
 	}
 }
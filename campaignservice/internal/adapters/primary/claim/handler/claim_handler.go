@@ -0,0 +1,239 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	domain "specommerce/campaignservice/internal/core/domain/campaign"
+	"specommerce/campaignservice/internal/core/ports/primary"
+	"specommerce/campaignservice/pkg/database"
+	"specommerce/campaignservice/pkg/sharedto/handler"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ClaimHandler interface {
+	GetPrize(ctx *gin.Context)
+	ClaimPrize(ctx *gin.Context)
+	ForfeitPrize(ctx *gin.Context)
+	ShipPrize(ctx *gin.Context)
+	GetClaims(ctx *gin.Context)
+	GetPrizeInventory(ctx *gin.Context)
+}
+
+type claimHandler struct {
+	claimService primary.ClaimService
+}
+
+func NewClaimHandler(claimService primary.ClaimService) ClaimHandler {
+	return &claimHandler{
+		claimService: claimService,
+	}
+}
+
+// GetPrize godoc
+// @Summary Get the prize of a customer
+// @Description Get the prize won by a customer in a campaign, with its claim status and deadline
+// @Tags prizes
+// @Accept json
+// @Produce json
+// @Param id path int true "Campaign ID"
+// @Param customer_id path string true "Customer ID"
+// @Success 200 {object} campaign.Winner "Prize retrieved successfully"
+// @Failure 400 {object} handler.ErrorResponse "Bad request"
+// @Failure 404 {object} handler.ErrorResponse "Customer is not a winner of the campaign"
+// @Failure 500 {object} handler.ErrorResponse "Internal server error"
+// @Router /v1/campaigns/{id}/prizes/{customer_id} [get]
+func (h *claimHandler) GetPrize(ctx *gin.Context) {
+	id, ok := campaignId(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := h.claimService.GetPrize(ctx, id, ctx.Param("customer_id"))
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, handler.BaseResponse[domain.Winner]{
+		Data: result,
+	})
+}
+
+// ClaimPrize godoc
+// @Summary Claim a prize
+// @Description Claim the prize of a notified winner before the claim deadline. The prize is reserved from the campaign inventory and shipped to the given address
+// @Tags prizes
+// @Accept json
+// @Produce json
+// @Param id path int true "Campaign ID"
+// @Param customer_id path string true "Customer ID"
+// @Param request body ClaimPrizeRequest true "Claim request"
+// @Success 200 {object} campaign.Winner "Prize claimed"
+// @Failure 400 {object} handler.ErrorResponse "Bad request"
+// @Failure 404 {object} handler.ErrorResponse "Customer is not a winner of the campaign"
+// @Failure 409 {object} handler.ErrorResponse "Prize cannot be claimed, expired or out of stock"
+// @Failure 500 {object} handler.ErrorResponse "Internal server error"
+// @Router /v1/campaigns/{id}/prizes/{customer_id}/claim [post]
+func (h *claimHandler) ClaimPrize(ctx *gin.Context) {
+	id, ok := campaignId(ctx)
+	if !ok {
+		return
+	}
+	var req ClaimPrizeRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.claimService.ClaimPrize(ctx, id, ctx.Param("customer_id"), req.ShippingAddress)
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, handler.BaseResponse[domain.Winner]{
+		Data: result,
+	})
+}
+
+// ForfeitPrize godoc
+// @Summary Forfeit a prize
+// @Description Give up a prize that is not shipped yet. The prize goes to the next eligible customer once the campaign has ended
+// @Tags prizes
+// @Accept json
+// @Produce json
+// @Param id path int true "Campaign ID"
+// @Param customer_id path string true "Customer ID"
+// @Success 200 {object} campaign.Winner "Prize forfeited"
+// @Failure 400 {object} handler.ErrorResponse "Bad request"
+// @Failure 404 {object} handler.ErrorResponse "Customer is not a winner of the campaign"
+// @Failure 409 {object} handler.ErrorResponse "Prize cannot be forfeited"
+// @Failure 500 {object} handler.ErrorResponse "Internal server error"
+// @Router /v1/campaigns/{id}/prizes/{customer_id}/forfeit [post]
+func (h *claimHandler) ForfeitPrize(ctx *gin.Context) {
+	id, ok := campaignId(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := h.claimService.ForfeitPrize(ctx, id, ctx.Param("customer_id"))
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, handler.BaseResponse[domain.Winner]{
+		Data: result,
+	})
+}
+
+// ShipPrize godoc
+// @Summary Ship a prize
+// @Description Mark a claimed prize as shipped
+// @Tags prizes
+// @Accept json
+// @Produce json
+// @Param id path int true "Campaign ID"
+// @Param customer_id path string true "Customer ID"
+// @Success 200 {object} campaign.Winner "Prize shipped"
+// @Failure 400 {object} handler.ErrorResponse "Bad request"
+// @Failure 404 {object} handler.ErrorResponse "Customer is not a winner of the campaign"
+// @Failure 409 {object} handler.ErrorResponse "Prize is not claimed"
+// @Failure 500 {object} handler.ErrorResponse "Internal server error"
+// @Router /admin/v1/campaigns/{id}/prizes/{customer_id}/ship [post]
+func (h *claimHandler) ShipPrize(ctx *gin.Context) {
+	id, ok := campaignId(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := h.claimService.ShipPrize(ctx, id, ctx.Param("customer_id"))
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, handler.BaseResponse[domain.Winner]{
+		Data: result,
+	})
+}
+
+// GetClaims godoc
+// @Summary Get campaign prize claims
+// @Description Get the winners of a campaign with the claim status of their prizes, in selection order
+// @Tags prizes
+// @Accept json
+// @Produce json
+// @Param id path int true "Campaign ID"
+// @Success 200 {array} campaign.Winner "Claims retrieved successfully"
+// @Failure 400 {object} handler.ErrorResponse "Bad request"
+// @Failure 404 {object} handler.ErrorResponse "Campaign not found"
+// @Failure 500 {object} handler.ErrorResponse "Internal server error"
+// @Router /admin/v1/campaigns/{id}/claims [get]
+func (h *claimHandler) GetClaims(ctx *gin.Context) {
+	id, ok := campaignId(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := h.claimService.GetClaims(ctx, id)
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, handler.BaseResponse[[]domain.Winner]{
+		Data: result,
+	})
+}
+
+// GetPrizeInventory godoc
+// @Summary Get campaign prize inventory
+// @Description Count the prizes of a campaign by claim status, and the prizes still available for replacement winners
+// @Tags prizes
+// @Accept json
+// @Produce json
+// @Param id path int true "Campaign ID"
+// @Success 200 {object} campaign.PrizeInventory "Inventory retrieved successfully"
+// @Failure 400 {object} handler.ErrorResponse "Bad request"
+// @Failure 404 {object} handler.ErrorResponse "Campaign not found"
+// @Failure 500 {object} handler.ErrorResponse "Internal server error"
+// @Router /admin/v1/campaigns/{id}/prizes [get]
+func (h *claimHandler) GetPrizeInventory(ctx *gin.Context) {
+	id, ok := campaignId(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := h.claimService.GetPrizeInventory(ctx, id)
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, handler.BaseResponse[domain.PrizeInventory]{
+		Data: result,
+	})
+}
+
+func campaignId(ctx *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Campaign ID is invalid"})
+		return 0, false
+	}
+	return id, true
+}
+
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, database.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrClaimExpired), errors.Is(err, domain.ErrInvalidClaimTransition),
+		errors.Is(err, domain.ErrOutOfStock):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,6 @@
+package handler
+
+// ClaimPrizeRequest represents the request of a winner claiming a prize
+type ClaimPrizeRequest struct {
+	ShippingAddress string `json:"shipping_address" binding:"required"`
+}
@@ -0,0 +1,140 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	domain "specommerce/campaignservice/internal/core/domain/draw"
+	"specommerce/campaignservice/internal/core/ports/primary"
+	"specommerce/campaignservice/internal/core/rules"
+	"specommerce/campaignservice/pkg/database"
+	"specommerce/campaignservice/pkg/sharedto/handler"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type DrawHandler interface {
+	GetDraw(ctx *gin.Context)
+	RunDraw(ctx *gin.Context)
+	VerifyDraw(ctx *gin.Context)
+}
+
+type drawHandler struct {
+	drawService primary.DrawService
+}
+
+func NewDrawHandler(drawService primary.DrawService) DrawHandler {
+	return &drawHandler{
+		drawService: drawService,
+	}
+}
+
+// GetDraw godoc
+// @Summary Get campaign draw
+// @Description Get the seed commitment of a draw or lucky draw campaign, and the revealed seed once drawn
+// @Tags draws
+// @Accept json
+// @Produce json
+// @Param id path int true "Campaign ID"
+// @Success 200 {object} draw.Draw "Draw retrieved successfully"
+// @Failure 400 {object} handler.ErrorResponse "Bad request"
+// @Failure 404 {object} handler.ErrorResponse "Draw not found"
+// @Failure 500 {object} handler.ErrorResponse "Internal server error"
+// @Router /admin/v1/campaigns/{id}/draw [get]
+func (h *drawHandler) GetDraw(ctx *gin.Context) {
+	id, ok := campaignId(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := h.drawService.GetDraw(ctx, id)
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, handler.BaseResponse[domain.Draw]{
+		Data: result,
+	})
+}
+
+// RunDraw godoc
+// @Summary Run campaign draw
+// @Description Draw the winners of an ended draw campaign, or reveal the seed of an ended lucky draw campaign, now instead of waiting for the scheduler. Returns the existing result if already drawn
+// @Tags draws
+// @Accept json
+// @Produce json
+// @Param id path int true "Campaign ID"
+// @Success 200 {object} draw.Draw "Campaign drawn"
+// @Failure 400 {object} handler.ErrorResponse "Bad request"
+// @Failure 404 {object} handler.ErrorResponse "Draw not found"
+// @Failure 409 {object} handler.ErrorResponse "Campaign has not ended yet"
+// @Failure 500 {object} handler.ErrorResponse "Internal server error"
+// @Router /admin/v1/campaigns/{id}/draw [post]
+func (h *drawHandler) RunDraw(ctx *gin.Context) {
+	id, ok := campaignId(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := h.drawService.RunDraw(ctx, id)
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, handler.BaseResponse[domain.Draw]{
+		Data: result,
+	})
+}
+
+// VerifyDraw godoc
+// @Summary Verify campaign draw
+// @Description Re-run a draw from its revealed seed and persisted entries, or roll the winning orders of a lucky draw again, and check the seed against the commitment and the winners against the stored result
+// @Tags draws
+// @Accept json
+// @Produce json
+// @Param id path int true "Campaign ID"
+// @Success 200 {object} draw.Verification "Draw verified"
+// @Failure 400 {object} handler.ErrorResponse "Bad request"
+// @Failure 404 {object} handler.ErrorResponse "Draw not found"
+// @Failure 409 {object} handler.ErrorResponse "Draw has not been run yet"
+// @Failure 500 {object} handler.ErrorResponse "Internal server error"
+// @Router /admin/v1/campaigns/{id}/draw/verify [get]
+func (h *drawHandler) VerifyDraw(ctx *gin.Context) {
+	id, ok := campaignId(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := h.drawService.VerifyDraw(ctx, id)
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, handler.BaseResponse[domain.Verification]{
+		Data: result,
+	})
+}
+
+func campaignId(ctx *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Campaign ID is invalid"})
+		return 0, false
+	}
+	return id, true
+}
+
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, database.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrDrawNotDue), errors.Is(err, domain.ErrNotDrawn):
+		return http.StatusConflict
+	case errors.Is(err, rules.ErrInvalidPolicy):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
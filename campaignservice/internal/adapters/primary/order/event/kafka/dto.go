@@ -7,7 +7,7 @@ import (
 	"specommerce/campaignservice/model"
 )
 
-func ToDomain(event model.Order) (domain.Order, error) {
+func ToDomain(event *model.Order) (domain.Order, error) {
 	errTemplate := "OrderConsumer.ToDomain: %w"
 	orderId, err := xid.FromString(event.Id)
 	if err != nil {
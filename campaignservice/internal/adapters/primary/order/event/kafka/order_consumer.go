@@ -48,7 +48,7 @@ func (c *OrderConsumer) HandleEvent(message kafka.Message) error {
 		return fmt.Errorf(errorTemplate, err)
 	}
 
-	order, err := ToDomain(orderEvent)
+	order, err := ToDomain(&orderEvent)
 	if err != nil {
 		return fmt.Errorf(errorTemplate, err)
 	}
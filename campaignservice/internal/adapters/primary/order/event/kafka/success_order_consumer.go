@@ -47,7 +47,7 @@ func (c *SuccessOrderConsumer) HandleEvent(message kafka.Message) error {
 		return fmt.Errorf(errorTemplate, err)
 	}
 
-	order, err := ToDomain(orderEvent)
+	order, err := ToDomain(&orderEvent)
 	if err != nil {
 		return fmt.Errorf(errorTemplate, err)
 	}
@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"specommerce/campaignservice/pkg/outbox"
+	"time"
+)
+
+// OutboxMessageResponse represents an outbox message the relay gave up on
+type OutboxMessageResponse struct {
+	ID        int64             `json:"id" example:"42"`
+	Topic     string            `json:"topic" example:"winner_events"`
+	Key       string            `json:"key" example:"d2k8s1c6n88s73b5ktqg"`
+	Payload   []byte            `json:"payload"`
+	Headers   map[string]string `json:"headers"`
+	Status    string            `json:"status" example:"FAILED"`
+	Attempts  int               `json:"attempts" example:"10"`
+	LastError string            `json:"last_error" example:"kafka write errors (1/1)"`
+	CreatedAt time.Time         `json:"created_at" example:"2023-01-01T00:00:00Z"`
+	UpdatedAt time.Time         `json:"updated_at" example:"2023-01-01T00:00:00Z"`
+}
+
+func ToOutboxMessageResponse(message outbox.Message) OutboxMessageResponse {
+	headers := make(map[string]string, len(message.Headers))
+	for _, header := range message.Headers {
+		headers[header.Key] = string(header.Value)
+	}
+	return OutboxMessageResponse{
+		ID:        message.Id,
+		Topic:     message.Topic,
+		Key:       string(message.Key),
+		Payload:   message.Payload,
+		Headers:   headers,
+		Status:    string(message.Status),
+		Attempts:  message.Attempts,
+		LastError: message.LastError,
+		CreatedAt: message.CreatedAt,
+		UpdatedAt: message.UpdatedAt,
+	}
+}
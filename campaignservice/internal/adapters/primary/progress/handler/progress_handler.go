@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	domain "specommerce/campaignservice/internal/core/domain/campaign"
+	"specommerce/campaignservice/internal/core/ports/primary"
+	"specommerce/campaignservice/pkg/database"
+	"specommerce/campaignservice/pkg/service_config"
+	"specommerce/campaignservice/pkg/sharedto/handler"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProgressHandler interface {
+	GetProgress(ctx *gin.Context)
+	StreamProgress(ctx *gin.Context)
+}
+
+type progressHandler struct {
+	progressService primary.ProgressService
+	config          service_config.ProgressConfig
+}
+
+func NewProgressHandler(progressService primary.ProgressService, config service_config.ProgressConfig) ProgressHandler {
+	return &progressHandler{
+		progressService: progressService,
+		config:          config,
+	}
+}
+
+// GetProgress godoc
+// @Summary Get campaign progress
+// @Description Get the live counters of a campaign: pending orders, eligible customers and winners against the total reward
+// @Tags campaigns
+// @Accept json
+// @Produce json
+// @Param id path int true "Campaign ID"
+// @Success 200 {object} campaign.Progress "Progress retrieved successfully"
+// @Failure 400 {object} handler.ErrorResponse "Bad request"
+// @Failure 404 {object} handler.ErrorResponse "Campaign not found"
+// @Failure 500 {object} handler.ErrorResponse "Internal server error"
+// @Router /admin/v1/campaigns/{id}/progress [get]
+func (h *progressHandler) GetProgress(ctx *gin.Context) {
+	id, ok := campaignId(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := h.progressService.GetProgress(ctx, id)
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, handler.BaseResponse[domain.Progress]{
+		Data: result,
+	})
+}
+
+// StreamProgress godoc
+// @Summary Stream campaign progress
+// @Description Push the live counters of a campaign as Server-Sent Events. A progress event is sent on connection, then after every evaluated order result, followed by a winner event for each newly selected winner
+// @Tags campaigns
+// @Produce text/event-stream
+// @Param id path int true "Campaign ID"
+// @Success 200 {object} campaign.Progress "Progress events"
+// @Failure 400 {object} handler.ErrorResponse "Bad request"
+// @Failure 404 {object} handler.ErrorResponse "Campaign not found"
+// @Failure 500 {object} handler.ErrorResponse "Internal server error"
+// @Router /admin/v1/campaigns/{id}/progress/stream [get]
+func (h *progressHandler) StreamProgress(ctx *gin.Context) {
+	id, ok := campaignId(ctx)
+	if !ok {
+		return
+	}
+
+	// Subscribe before reading the counters so no update is missed in between
+	updates, err := h.progressService.SubscribeProgress(ctx.Request.Context(), id)
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	current, err := h.progressService.GetProgress(ctx, id)
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	// The stream outlives the write timeout of the server, dead clients are
+	// detected by the heartbeat instead
+	if err := http.NewResponseController(ctx.Writer).SetWriteDeadline(time.Time{}); err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("Connection", "keep-alive")
+	// Disable the response buffering of nginx
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.SSEvent("progress", current)
+	ctx.Writer.Flush()
+
+	heartbeat := time.NewTicker(h.config.Heartbeat)
+	defer heartbeat.Stop()
+	ctx.Stream(func(w io.Writer) bool {
+		select {
+		case <-ctx.Request.Context().Done():
+			return false
+		case <-heartbeat.C:
+			_, err := io.WriteString(w, ": heartbeat\n\n")
+			return err == nil
+		case progress, ok := <-updates:
+			if !ok {
+				return false
+			}
+			ctx.SSEvent("progress", progress)
+			for _, winner := range progress.NewWinners {
+				ctx.SSEvent("winner", winner)
+			}
+			return true
+		}
+	})
+}
+
+func campaignId(ctx *gin.Context) (int64, bool) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Campaign ID is invalid"})
+		return 0, false
+	}
+	return id, true
+}
+
+func errorStatus(err error) int {
+	if errors.Is(err, database.ErrRecordNotFound) {
+		return http.StatusNotFound
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	domain "specommerce/campaignservice/internal/core/domain/campaign"
+	"specommerce/campaignservice/internal/core/ports/primary"
+	"specommerce/campaignservice/internal/core/rules"
+	"specommerce/campaignservice/pkg/database"
+	"specommerce/campaignservice/pkg/sharedto/handler"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RebuildHandler interface {
+	RebuildCampaign(ctx *gin.Context)
+	RebuildCampaigns(ctx *gin.Context)
+}
+
+type rebuildHandler struct {
+	rebuildService primary.RebuildService
+}
+
+func NewRebuildHandler(rebuildService primary.RebuildService) RebuildHandler {
+	return &rebuildHandler{
+		rebuildService: rebuildService,
+	}
+}
+
+// RebuildCampaign godoc
+// @Summary Rebuild campaign Redis state
+// @Description Rebuild the Redis state of a campaign from the campaigns, orders and winners tables. Runs as a dry run listing the differences unless dry_run=false, which also rewrites the keys that differ
+// @Tags campaigns
+// @Accept json
+// @Produce json
+// @Param id path int true "Campaign ID"
+// @Param dry_run query bool false "Only report the differences" default(true)
+// @Success 200 {object} campaign.RebuildReport "Campaign state compared or rebuilt"
+// @Failure 400 {object} handler.ErrorResponse "Bad request"
+// @Failure 404 {object} handler.ErrorResponse "Campaign not found"
+// @Failure 409 {object} handler.ErrorResponse "Campaign already being rebuilt, or its state kept changing"
+// @Failure 500 {object} handler.ErrorResponse "Internal server error"
+// @Router /admin/v1/campaigns/{id}/rebuild [post]
+func (h *rebuildHandler) RebuildCampaign(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Campaign ID is invalid"})
+		return
+	}
+	dryRun, ok := dryRun(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := h.rebuildService.Rebuild(ctx, id, dryRun)
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, handler.BaseResponse[domain.RebuildReport]{
+		Data: result,
+	})
+}
+
+// RebuildCampaigns godoc
+// @Summary Rebuild the Redis state of all campaigns
+// @Description Rebuild the Redis state of every campaign from the database. Runs as a dry run unless dry_run=false
+// @Tags campaigns
+// @Accept json
+// @Produce json
+// @Param dry_run query bool false "Only report the differences" default(true)
+// @Success 200 {array} campaign.RebuildReport "Campaign states compared or rebuilt"
+// @Failure 400 {object} handler.ErrorResponse "Bad request"
+// @Failure 409 {object} handler.ErrorResponse "Campaign already being rebuilt, or its state kept changing"
+// @Failure 500 {object} handler.ErrorResponse "Internal server error"
+// @Router /admin/v1/campaigns/rebuild [post]
+func (h *rebuildHandler) RebuildCampaigns(ctx *gin.Context) {
+	dryRun, ok := dryRun(ctx)
+	if !ok {
+		return
+	}
+
+	result, err := h.rebuildService.RebuildAll(ctx, dryRun)
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, handler.BaseResponse[[]domain.RebuildReport]{
+		Data: result,
+	})
+}
+
+func dryRun(ctx *gin.Context) (bool, bool) {
+	dryRun, err := strconv.ParseBool(ctx.DefaultQuery("dry_run", "true"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "dry_run is invalid"})
+		return false, false
+	}
+	return dryRun, true
+}
+
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, database.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, rules.ErrUnknownCampaignType), errors.Is(err, rules.ErrInvalidPolicy):
+		return http.StatusBadRequest
+	case errors.Is(err, domain.ErrRebuildInProgress), errors.Is(err, domain.ErrRebuildConflict):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
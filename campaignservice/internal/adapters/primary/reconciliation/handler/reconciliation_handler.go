@@ -0,0 +1,90 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	domain "specommerce/campaignservice/internal/core/domain/campaign"
+	"specommerce/campaignservice/internal/core/ports/primary"
+	"specommerce/campaignservice/internal/core/rules"
+	"specommerce/campaignservice/pkg/database"
+	"specommerce/campaignservice/pkg/sharedto/handler"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReconciliationHandler interface {
+	ReconcileCampaign(ctx *gin.Context)
+	ReconcileCampaigns(ctx *gin.Context)
+}
+
+type reconciliationHandler struct {
+	reconciliationService primary.ReconciliationService
+}
+
+func NewReconciliationHandler(reconciliationService primary.ReconciliationService) ReconciliationHandler {
+	return &reconciliationHandler{
+		reconciliationService: reconciliationService,
+	}
+}
+
+// ReconcileCampaign godoc
+// @Summary Reconcile campaign winners
+// @Description Compare the Redis winners of a first_n_customers campaign with the winners computed in SQL, and list the customers found on one side only with a reason
+// @Tags campaigns
+// @Accept json
+// @Produce json
+// @Param id path int true "Campaign ID"
+// @Success 200 {object} campaign.Reconciliation "Winners reconciled"
+// @Failure 400 {object} handler.ErrorResponse "Bad request"
+// @Failure 404 {object} handler.ErrorResponse "Campaign not found"
+// @Failure 500 {object} handler.ErrorResponse "Internal server error"
+// @Router /admin/v1/campaigns/{id}/reconciliation [get]
+func (h *reconciliationHandler) ReconcileCampaign(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Campaign ID is invalid"})
+		return
+	}
+
+	result, err := h.reconciliationService.Reconcile(ctx, id)
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, handler.BaseResponse[domain.Reconciliation]{
+		Data: result,
+	})
+}
+
+// ReconcileCampaigns godoc
+// @Summary Reconcile the winners of all campaigns
+// @Description Compare the Redis and SQL winners of every started first_n_customers campaign
+// @Tags campaigns
+// @Accept json
+// @Produce json
+// @Success 200 {array} campaign.Reconciliation "Winners reconciled"
+// @Failure 500 {object} handler.ErrorResponse "Internal server error"
+// @Router /admin/v1/campaigns/reconciliation [get]
+func (h *reconciliationHandler) ReconcileCampaigns(ctx *gin.Context) {
+	result, err := h.reconciliationService.ReconcileAll(ctx)
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, handler.BaseResponse[[]domain.Reconciliation]{
+		Data: result,
+	})
+}
+
+func errorStatus(err error) int {
+	switch {
+	case errors.Is(err, database.ErrRecordNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, domain.ErrReconciliationUnsupported), errors.Is(err, rules.ErrInvalidPolicy):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
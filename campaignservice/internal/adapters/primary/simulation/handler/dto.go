@@ -0,0 +1,25 @@
+package handler
+
+import (
+	domain "specommerce/campaignservice/internal/core/domain/campaign"
+	"time"
+)
+
+// SimulateCampaignRequest represents the campaign to simulate
+type SimulateCampaignRequest struct {
+	Type      string    `json:"type"`
+	StartTime time.Time `json:"start_time" binding:"required"`
+	EndTime   time.Time `json:"end_time" binding:"required"`
+	// Policy follows the policy schema of the campaign type.
+	Policy map[string]any `json:"policy" binding:"required"`
+}
+
+func (r SimulateCampaignRequest) ToDomain() domain.Campaign {
+	return domain.Campaign{
+		Name:      "simulation",
+		Type:      r.Type,
+		StartTime: r.StartTime,
+		EndTime:   r.EndTime,
+		Policy:    r.Policy,
+	}
+}
@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	domain "specommerce/campaignservice/internal/core/domain/campaign"
+	"specommerce/campaignservice/internal/core/domain/order"
+	"specommerce/campaignservice/internal/core/ports/primary"
+	"specommerce/campaignservice/internal/core/rules"
+	"specommerce/campaignservice/pkg/sharedto/handler"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+type SimulationHandler interface {
+	SimulateCampaign(ctx *gin.Context)
+}
+
+type simulationHandler struct {
+	simulationService primary.SimulationService
+}
+
+func NewSimulationHandler(simulationService primary.SimulationService) SimulationHandler {
+	return &simulationHandler{
+		simulationService: simulationService,
+	}
+}
+
+// SimulateCampaign godoc
+// @Summary Simulate a campaign
+// @Description Replay orders through the rule and Lua scripts of a campaign type in an isolated Redis keyspace, and return the would-be winners, the eligible count and the timeline. Live campaign state is not touched.
+// @Description Send the campaign as JSON to replay the orders of its window from the orders table, or as multipart/form-data with the campaign JSON in the campaign field and a JSON Lines file of order events in the orders field
+// @Tags campaigns
+// @Accept json,mpfd
+// @Produce json
+// @Param campaign body SimulateCampaignRequest true "Campaign to simulate"
+// @Param orders formData file false "Order events, one order JSON per line"
+// @Success 200 {object} campaign.Simulation "Simulation result"
+// @Failure 400 {object} handler.ErrorResponse "Bad request"
+// @Failure 500 {object} handler.ErrorResponse "Internal server error"
+// @Router /admin/v1/campaigns/simulations [post]
+func (h *simulationHandler) SimulateCampaign(ctx *gin.Context) {
+	var req SimulateCampaignRequest
+	var events []order.Order
+	if ctx.ContentType() == binding.MIMEMultipartPOSTForm {
+		if err := json.Unmarshal([]byte(ctx.PostForm("campaign")), &req); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "campaign is invalid: " + err.Error()})
+			return
+		}
+		if err := binding.Validator.ValidateStruct(&req); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		file, err := ctx.FormFile("orders")
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "orders file is required"})
+			return
+		}
+		reader, err := file.Open()
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		defer reader.Close()
+		if events, err = order.DecodeEvents(reader); err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	} else if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.simulationService.Simulate(ctx, req.ToDomain(), events)
+	if err != nil {
+		ctx.JSON(errorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, handler.BaseResponse[domain.Simulation]{
+		Data: result,
+	})
+}
+
+func errorStatus(err error) int {
+	if errors.Is(err, rules.ErrUnknownCampaignType) || errors.Is(err, rules.ErrInvalidPolicy) || errors.Is(err, domain.ErrInvalidWindow) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,46 @@
+package postgres
+
+import (
+	"github.com/uptrace/bun"
+	domain "specommerce/campaignservice/internal/core/domain/campaign"
+	"time"
+)
+
+type AuditEntry struct {
+	bun.BaseModel `bun:"campaign_audit"`
+	CampaignId    int64     `bun:"campaign_id,pk"`
+	StreamId      string    `bun:"stream_id,pk"`
+	OrderId       string    `bun:"order_id,notnull"`
+	CustomerId    string    `bun:"customer_id,notnull"`
+	Score         *int64    `bun:"score"`
+	Decision      string    `bun:"decision,notnull"`
+	Reason        string    `bun:"reason,notnull"`
+	DecidedAt     time.Time `bun:"decided_at,notnull"`
+	CreatedAt     time.Time `bun:",nullzero,notnull,default:current_timestamp,skipupdate"`
+}
+
+func (e AuditEntry) ToDomainModel() domain.AuditEntry {
+	return domain.AuditEntry{
+		CampaignId: e.CampaignId,
+		StreamId:   e.StreamId,
+		OrderId:    e.OrderId,
+		CustomerId: e.CustomerId,
+		Score:      e.Score,
+		Decision:   domain.Decision(e.Decision),
+		Reason:     e.Reason,
+		DecidedAt:  e.DecidedAt,
+	}
+}
+
+func FromDomainModel(dm domain.AuditEntry) AuditEntry {
+	return AuditEntry{
+		CampaignId: dm.CampaignId,
+		StreamId:   dm.StreamId,
+		OrderId:    dm.OrderId,
+		CustomerId: dm.CustomerId,
+		Score:      dm.Score,
+		Decision:   dm.Decision.String(),
+		Reason:     dm.Reason,
+		DecidedAt:  dm.DecidedAt,
+	}
+}
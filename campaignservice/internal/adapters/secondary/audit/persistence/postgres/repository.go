@@ -0,0 +1,54 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"github.com/uptrace/bun"
+	domain "specommerce/campaignservice/internal/core/domain/campaign"
+	"specommerce/campaignservice/internal/core/ports/secondary"
+	"specommerce/campaignservice/pkg/database"
+)
+
+type auditPersistenceRepository struct {
+	getDbFunc database.GetDbFunc
+}
+
+func NewAuditPersistenceRepository(dbFunc database.GetDbFunc) secondary.AuditRepository {
+	return &auditPersistenceRepository{
+		getDbFunc: dbFunc,
+	}
+}
+
+func (r *auditPersistenceRepository) SaveEntries(ctx context.Context, entries []domain.AuditEntry) error {
+	errTemplate := "auditPersistenceRepository SaveEntries %w"
+	if len(entries) == 0 {
+		return nil
+	}
+	records := make([]AuditEntry, 0, len(entries))
+	for _, entry := range entries {
+		records = append(records, FromDomainModel(entry))
+	}
+	if _, err := r.getDbFunc(ctx).NewInsert().Model(&records).On("conflict do nothing").Exec(ctx); err != nil {
+		return fmt.Errorf(errTemplate, err)
+	}
+	return nil
+}
+
+func (r *auditPersistenceRepository) GetByCustomer(ctx context.Context, campaignId int64, customerId string) ([]domain.AuditEntry, error) {
+	errTemplate := "auditPersistenceRepository GetByCustomer %w"
+	records, err := database.NewPostgresCrudDatabaseOperation[AuditEntry](r.getDbFunc).FindAll(ctx, func(query *bun.SelectQuery) *bun.SelectQuery {
+		return query.
+			Where("campaign_id = ?", campaignId).
+			Where("customer_id = ?", customerId).
+			// Stream ids are <milliseconds>-<sequence>, the sequence orders the entries of a millisecond
+			OrderExpr("decided_at, split_part(stream_id, '-', 2)::bigint")
+	})
+	if err != nil {
+		return nil, fmt.Errorf(errTemplate, err)
+	}
+	entries := make([]domain.AuditEntry, 0, len(records))
+	for _, record := range records {
+		entries = append(entries, record.ToDomainModel())
+	}
+	return entries, nil
+}
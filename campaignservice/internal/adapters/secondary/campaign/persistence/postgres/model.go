@@ -7,25 +7,36 @@ import (
 )
 
 type Campaign struct {
-	bun.BaseModel `bun:"campaigns"`
-	Id            int64          `bun:"id,pk,autoincrement"`
-	Name          string         `bun:"name,notnull"`
-	Type          string         `bun:"type,notnull"`
-	Description   string         `bun:"description,notnull"`
-	Policy        map[string]any `bun:"type:jsonb,default:'{}'::jsonb"`
-	StartTime     time.Time      `bun:"start_time,notnull"`
-	EndTime       time.Time      `bun:"end_time,notnull"`
-	CreatedAt     time.Time      `bun:",nullzero,notnull,default:current_timestamp,skipupdate"`
-	UpdatedAt     time.Time      `bun:",nullzero,notnull,default:current_timestamp"`
+	bun.BaseModel  `bun:"campaigns"`
+	Id             int64          `bun:"id,pk,autoincrement"`
+	Name           string         `bun:"name,notnull"`
+	Type           string         `bun:"type,notnull"`
+	Status         string         `bun:"status,notnull"`
+	Description    string         `bun:"description,notnull"`
+	Policy         map[string]any `bun:"type:jsonb,default:'{}'::jsonb"`
+	PrizeInventory int            `bun:"prize_inventory,notnull"`
+	StartTime      time.Time      `bun:"start_time,notnull"`
+	EndTime        time.Time      `bun:"end_time,notnull"`
+	CreatedAt      time.Time      `bun:",nullzero,notnull,default:current_timestamp,skipupdate"`
+	UpdatedAt      time.Time      `bun:",nullzero,notnull,default:current_timestamp"`
 }
 
 type Winner struct {
-	bun.BaseModel `bun:"winners"`
-	Id            string    `bun:"id,pk"`
-	CampaignId    int64     `bun:"campaign_id,notnull"`
-	CustomerId    string    `bun:"customer_id,notnull"`
-	CreatedAt     time.Time `bun:",nullzero,notnull,default:current_timestamp,skipupdate"`
-	UpdatedAt     time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	bun.BaseModel   `bun:"winners"`
+	Id              string     `bun:"id,pk"`
+	CampaignId      int64      `bun:"campaign_id,notnull"`
+	CustomerId      string     `bun:"customer_id,notnull"`
+	OrderId         string     `bun:"order_id,nullzero"`
+	Position        int        `bun:"position,notnull"`
+	ClaimStatus     string     `bun:"claim_status,nullzero,notnull,default:'NOTIFIED'"`
+	ClaimDeadline   *time.Time `bun:"claim_deadline"`
+	ShippingAddress string     `bun:"shipping_address,nullzero"`
+	ClaimedAt       *time.Time `bun:"claimed_at"`
+	ShippedAt       *time.Time `bun:"shipped_at"`
+	Replaces        string     `bun:"replaces,nullzero"`
+	Reassigned      bool       `bun:"reassigned,notnull"` // The prize of the expired or forfeited winner was handed to the next eligible customers
+	CreatedAt       time.Time  `bun:",nullzero,notnull,default:current_timestamp,skipupdate"`
+	UpdatedAt       time.Time  `bun:",nullzero,notnull,default:current_timestamp"`
 }
 
 type IphoneWinner struct {
@@ -46,15 +57,17 @@ func (w IphoneWinner) ToDomainModel() domain.IphoneWinner {
 
 func (c Campaign) ToDomainModel() (domain.Campaign, error) {
 	campaign := domain.Campaign{
-		Id:          c.Id,
-		CreatedAt:   c.CreatedAt,
-		UpdatedAt:   c.UpdatedAt,
-		Name:        c.Name,
-		Type:        c.Type,
-		Description: c.Description,
-		StartTime:   c.StartTime,
-		EndTime:     c.EndTime,
-		Policy:      c.Policy,
+		Id:             c.Id,
+		CreatedAt:      c.CreatedAt,
+		UpdatedAt:      c.UpdatedAt,
+		Name:           c.Name,
+		Type:           c.Type,
+		Status:         domain.Status(c.Status),
+		Description:    c.Description,
+		StartTime:      c.StartTime,
+		EndTime:        c.EndTime,
+		Policy:         c.Policy,
+		PrizeInventory: c.PrizeInventory,
 	}
 
 	return campaign, nil
@@ -62,14 +75,33 @@ func (c Campaign) ToDomainModel() (domain.Campaign, error) {
 
 func FromDomainModel(dm domain.Campaign) (Campaign, error) {
 	return Campaign{
-		Id:          dm.Id,
-		Name:        dm.Name,
-		Type:        dm.Type,
-		Description: dm.Description,
-		StartTime:   dm.StartTime,
-		EndTime:     dm.EndTime,
-		Policy:      dm.Policy,
-		CreatedAt:   dm.CreatedAt,
-		UpdatedAt:   dm.UpdatedAt,
+		Id:             dm.Id,
+		Name:           dm.Name,
+		Type:           dm.Type,
+		Status:         dm.Status.String(),
+		Description:    dm.Description,
+		StartTime:      dm.StartTime,
+		EndTime:        dm.EndTime,
+		Policy:         dm.Policy,
+		PrizeInventory: dm.PrizeInventory,
+		CreatedAt:      dm.CreatedAt,
+		UpdatedAt:      dm.UpdatedAt,
 	}, nil
 }
+
+func (w Winner) ToDomainModel() domain.Winner {
+	return domain.Winner{
+		CampaignId:      w.CampaignId,
+		CustomerId:      w.CustomerId,
+		OrderId:         w.OrderId,
+		Position:        w.Position,
+		SelectedAt:      w.CreatedAt,
+		ClaimStatus:     domain.ClaimStatus(w.ClaimStatus),
+		ClaimDeadline:   w.ClaimDeadline,
+		ShippingAddress: w.ShippingAddress,
+		ClaimedAt:       w.ClaimedAt,
+		ShippedAt:       w.ShippedAt,
+		Replaces:        w.Replaces,
+		Reassigned:      w.Reassigned,
+	}
+}
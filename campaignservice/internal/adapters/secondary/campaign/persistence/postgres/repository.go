@@ -2,12 +2,16 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"github.com/rs/xid"
 	"github.com/uptrace/bun"
 	domain "specommerce/campaignservice/internal/core/domain/campaign"
 	"specommerce/campaignservice/internal/core/ports/secondary"
+	"specommerce/campaignservice/internal/core/rules"
 	"specommerce/campaignservice/pkg/database"
+	"time"
 )
 
 type campaignPersistenceRepository struct {
@@ -56,10 +60,23 @@ func (r *campaignPersistenceRepository) Update(ctx context.Context, campaign dom
 	return entity, nil
 }
 
-func (r *campaignPersistenceRepository) GetCampaignByType(ctx context.Context, campaignType string) (domain.Campaign, error) {
-	errTemplate := "campaignPersistenceRepository GetCampaignByType %w"
-	record, err := database.NewPostgresCrudDatabaseOperation[Campaign](r.getDbFunc).Get(ctx, func(query *bun.SelectQuery) *bun.SelectQuery {
-		return query.Where("type = ?", campaignType)
+func (r *campaignPersistenceRepository) GetById(ctx context.Context, id int64) (domain.Campaign, error) {
+	errTemplate := "campaignPersistenceRepository GetById %w"
+	record, err := database.NewPostgresCrudDatabaseOperation[Campaign](r.getDbFunc).FindById(ctx, id)
+	if err != nil {
+		return domain.Campaign{}, fmt.Errorf(errTemplate, err)
+	}
+	entity, err := record.ToDomainModel()
+	if err != nil {
+		return domain.Campaign{}, fmt.Errorf(errTemplate, err)
+	}
+	return entity, nil
+}
+
+func (r *campaignPersistenceRepository) LockById(ctx context.Context, id int64) (domain.Campaign, error) {
+	errTemplate := "campaignPersistenceRepository LockById %w"
+	record, err := database.NewPostgresCrudDatabaseOperation[Campaign](r.getDbFunc).FindById(ctx, id, func(query *bun.SelectQuery) *bun.SelectQuery {
+		return query.For("UPDATE")
 	})
 	if err != nil {
 		return domain.Campaign{}, fmt.Errorf(errTemplate, err)
@@ -68,16 +85,72 @@ func (r *campaignPersistenceRepository) GetCampaignByType(ctx context.Context, c
 	if err != nil {
 		return domain.Campaign{}, fmt.Errorf(errTemplate, err)
 	}
-	return entity, err
+	return entity, nil
 }
 
-func (r *campaignPersistenceRepository) GetIphoneWinner(ctx context.Context, iphoneCampaign domain.IphoneCampaign) ([]domain.IphoneWinner, error) {
+func (r *campaignPersistenceRepository) GetAll(ctx context.Context) ([]domain.Campaign, error) {
+	errTemplate := "campaignPersistenceRepository GetAll %w"
+	records, err := database.NewPostgresCrudDatabaseOperation[Campaign](r.getDbFunc).FindAll(ctx, func(query *bun.SelectQuery) *bun.SelectQuery {
+		return query.Order("start_time desc", "id desc")
+	})
+	if err != nil {
+		return nil, fmt.Errorf(errTemplate, err)
+	}
+	return toDomainModels(records, errTemplate)
+}
+
+func (r *campaignPersistenceRepository) GetActiveCampaigns(ctx context.Context, at time.Time) ([]domain.Campaign, error) {
+	errTemplate := "campaignPersistenceRepository GetActiveCampaigns %w"
+	records, err := database.NewPostgresCrudDatabaseOperation[Campaign](r.getDbFunc).FindAll(ctx, func(query *bun.SelectQuery) *bun.SelectQuery {
+		return query.
+			Where("start_time <= ?", at).
+			Where("end_time >= ?", at).
+			Where("status in (?)", bun.In(domain.EvaluatingStatuses)).
+			Order("start_time", "id")
+	})
+	if err != nil {
+		return nil, fmt.Errorf(errTemplate, err)
+	}
+	return toDomainModels(records, errTemplate)
+}
+
+func (r *campaignPersistenceRepository) GetDueCampaigns(ctx context.Context, now time.Time) ([]domain.Campaign, error) {
+	errTemplate := "campaignPersistenceRepository GetDueCampaigns %w"
+	records, err := database.NewPostgresCrudDatabaseOperation[Campaign](r.getDbFunc).FindAll(ctx, func(query *bun.SelectQuery) *bun.SelectQuery {
+		return query.
+			WhereGroup(" or ", func(q *bun.SelectQuery) *bun.SelectQuery {
+				return q.Where("status = ?", domain.StatusScheduled).Where("start_time <= ?", now)
+			}).
+			WhereGroup(" or ", func(q *bun.SelectQuery) *bun.SelectQuery {
+				return q.Where("status in (?)", bun.In([]domain.Status{domain.StatusActive, domain.StatusPaused})).Where("end_time < ?", now)
+			}).
+			Order("start_time", "id")
+	})
+	if err != nil {
+		return nil, fmt.Errorf(errTemplate, err)
+	}
+	return toDomainModels(records, errTemplate)
+}
+
+func toDomainModels(records []Campaign, errTemplate string) ([]domain.Campaign, error) {
+	campaigns := make([]domain.Campaign, 0, len(records))
+	for _, record := range records {
+		entity, err := record.ToDomainModel()
+		if err != nil {
+			return nil, fmt.Errorf(errTemplate, err)
+		}
+		campaigns = append(campaigns, entity)
+	}
+	return campaigns, nil
+}
+
+func (r *campaignPersistenceRepository) GetIphoneWinner(ctx context.Context, campaign domain.Campaign, policy rules.FirstNCustomersPolicy) ([]domain.IphoneWinner, error) {
 	errTemplate := "campaignPersistenceRepository.GetIphoneWinner: %w"
 	query := `
 		with first_customers as (
 			select customer_id, customer_name, min(created_at) as first_order_date,
 			max(total_amount) as max_order_amount
-			from orders where created_at >= ? and created_at <= ?
+			from orders where status = 'SUCCESS' and created_at >= ? and created_at <= ?
 			group by customer_id, customer_name
 			order by min(created_at)
 			limit ?
@@ -89,11 +162,11 @@ func (r *campaignPersistenceRepository) GetIphoneWinner(ctx context.Context, iph
 
 	results := make([]domain.IphoneWinner, 0)
 	rows, err := r.getDbFunc(ctx).QueryContext(ctx, query,
-		iphoneCampaign.StartTime,
-		iphoneCampaign.EndTime,
-		iphoneCampaign.Policy.MaxTrackedOrders,
-		iphoneCampaign.Policy.MinOrderAmount,
-		iphoneCampaign.Policy.TotalReward)
+		campaign.StartTime,
+		campaign.EndTime,
+		policy.MaxTrackedOrders,
+		policy.MinOrderAmount,
+		policy.TotalReward)
 	if err != nil {
 		return nil, fmt.Errorf(errTemplate, err)
 	}
@@ -116,14 +189,188 @@ func (r *campaignPersistenceRepository) GetIphoneWinner(ctx context.Context, iph
 	return results, nil
 }
 
-func (r *campaignPersistenceRepository) SaveWinner(ctx context.Context, campaignId int64, customerId string) error {
-	errTemplate := "campaignPersistenceRepository SaveWinner %w"
-	winner := Winner{
-		Id:         xid.New().String(),
-		CampaignId: campaignId,
-		CustomerId: customerId,
+func (r *campaignPersistenceRepository) GetWinners(ctx context.Context, campaign domain.Campaign) ([]domain.IphoneWinner, error) {
+	errTemplate := "campaignPersistenceRepository.GetWinners: %w"
+	query := `
+		select w.customer_id, o.customer_name, min(o.created_at) as first_order_date,
+		max(o.total_amount) as max_order_amount
+		from winners w
+		join orders o on o.customer_id = w.customer_id and o.status = 'SUCCESS'
+		and o.created_at >= ? and o.created_at <= ?
+		where w.campaign_id = ?
+		group by w.customer_id, w.position, o.customer_name
+		order by w.position
+	`
+
+	results := make([]domain.IphoneWinner, 0)
+	rows, err := r.getDbFunc(ctx).QueryContext(ctx, query, campaign.StartTime, campaign.EndTime, campaign.Id)
+	if err != nil {
+		return nil, fmt.Errorf(errTemplate, err)
+	}
+	defer func() {
+		err = rows.Close()
+		if err != nil {
+			err = fmt.Errorf(errTemplate, err)
+		}
+	}()
+
+	for rows.Next() {
+		var record IphoneWinner
+		err = rows.Scan(&record.CustomerId, &record.CustomerName, &record.FirstOrderTime, &record.MaxTotalOrderAmount)
+		if err != nil {
+			return nil, fmt.Errorf(errTemplate, err)
+		}
+		results = append(results, record.ToDomainModel())
+	}
+
+	return results, nil
+}
+
+func (r *campaignPersistenceRepository) GetWinnerIds(ctx context.Context, campaignId int64) ([]string, error) {
+	errTemplate := "campaignPersistenceRepository GetWinnerIds %w"
+	winners, err := database.NewPostgresCrudDatabaseOperation[Winner](r.getDbFunc).FindAll(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.Where("campaign_id = ?", campaignId).Order("position")
+	})
+	if err != nil {
+		return nil, fmt.Errorf(errTemplate, err)
+	}
+	ids := make([]string, 0, len(winners))
+	for _, winner := range winners {
+		ids = append(ids, winner.CustomerId)
+	}
+	return ids, nil
+}
+
+// SaveWinners inserts the winners that are not saved yet and returns them. A
+// winner is saved once per campaign and position, so saving the same winners
+// again is a no-op.
+func (r *campaignPersistenceRepository) SaveWinners(ctx context.Context, winners []domain.Winner) ([]domain.Winner, error) {
+	errTemplate := "campaignPersistenceRepository SaveWinners %w"
+	if len(winners) == 0 {
+		return nil, nil
+	}
+	models := make([]Winner, 0, len(winners))
+	for _, winner := range winners {
+		models = append(models, Winner{
+			Id:            xid.New().String(),
+			CampaignId:    winner.CampaignId,
+			CustomerId:    winner.CustomerId,
+			OrderId:       winner.OrderId,
+			Position:      winner.Position,
+			ClaimStatus:   string(winner.ClaimStatus),
+			ClaimDeadline: winner.ClaimDeadline,
+			Replaces:      winner.Replaces,
+		})
+	}
+	inserted := make([]Winner, 0, len(models))
+	err := r.getDbFunc(ctx).NewInsert().Model(&models).On("conflict do nothing").Returning("*").Scan(ctx, &inserted)
+	if err != nil {
+		return nil, fmt.Errorf(errTemplate, err)
+	}
+	saved := make([]domain.Winner, 0, len(inserted))
+	for _, winner := range inserted {
+		saved = append(saved, winner.ToDomainModel())
+	}
+	return saved, nil
+}
+
+func (r *campaignPersistenceRepository) FindWinners(ctx context.Context, campaignId int64) ([]domain.Winner, error) {
+	errTemplate := "campaignPersistenceRepository FindWinners %w"
+	records, err := database.NewPostgresCrudDatabaseOperation[Winner](r.getDbFunc).FindAll(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.Where("campaign_id = ?", campaignId).Order("position")
+	})
+	if err != nil {
+		return nil, fmt.Errorf(errTemplate, err)
+	}
+	winners := make([]domain.Winner, 0, len(records))
+	for _, record := range records {
+		winners = append(winners, record.ToDomainModel())
+	}
+	return winners, nil
+}
+
+func (r *campaignPersistenceRepository) GetWinner(ctx context.Context, campaignId int64, customerId string) (domain.Winner, error) {
+	errTemplate := "campaignPersistenceRepository GetWinner %w"
+	record, err := database.NewPostgresCrudDatabaseOperation[Winner](r.getDbFunc).Get(ctx, func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.Where("campaign_id = ?", campaignId).Where("customer_id = ?", customerId)
+	})
+	if err != nil {
+		return domain.Winner{}, fmt.Errorf(errTemplate, err)
+	}
+	return record.ToDomainModel(), nil
+}
+
+func (r *campaignPersistenceRepository) UpdateClaim(ctx context.Context, winner domain.Winner) (domain.Winner, error) {
+	errTemplate := "campaignPersistenceRepository UpdateClaim %w"
+	record := Winner{
+		ClaimStatus:     string(winner.ClaimStatus),
+		ShippingAddress: winner.ShippingAddress,
+		ClaimedAt:       winner.ClaimedAt,
+		ShippedAt:       winner.ShippedAt,
+	}
+	err := r.getDbFunc(ctx).NewUpdate().Model(&record).
+		Column("claim_status", "shipping_address", "claimed_at", "shipped_at").
+		Where("campaign_id = ?", winner.CampaignId).
+		Where("customer_id = ?", winner.CustomerId).
+		Returning("*").
+		Scan(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Winner{}, fmt.Errorf(errTemplate, database.ErrRecordNotFound)
+	}
+	if err != nil {
+		return domain.Winner{}, fmt.Errorf(errTemplate, err)
+	}
+	return record.ToDomainModel(), nil
+}
+
+func (r *campaignPersistenceRepository) ExpireClaims(ctx context.Context, now time.Time) ([]domain.Winner, error) {
+	errTemplate := "campaignPersistenceRepository ExpireClaims %w"
+	records := make([]Winner, 0)
+	err := r.getDbFunc(ctx).NewUpdate().Model((*Winner)(nil)).
+		Set("claim_status = ?", domain.ClaimStatusExpired).
+		Where("claim_status = ?", domain.ClaimStatusNotified).
+		Where("claim_deadline < ?", now).
+		Returning("*").
+		Scan(ctx, &records)
+	if err != nil {
+		return nil, fmt.Errorf(errTemplate, err)
+	}
+	winners := make([]domain.Winner, 0, len(records))
+	for _, record := range records {
+		winners = append(winners, record.ToDomainModel())
+	}
+	return winners, nil
+}
+
+func (r *campaignPersistenceRepository) GetReassignableCampaignIds(ctx context.Context, endedBefore time.Time, limit int) ([]int64, error) {
+	errTemplate := "campaignPersistenceRepository GetReassignableCampaignIds %w"
+	var campaignIds []int64
+	err := r.getDbFunc(ctx).NewSelect().
+		TableExpr("winners as w").
+		Join("join campaigns as c on c.id = w.campaign_id").
+		ColumnExpr("distinct w.campaign_id").
+		Where("w.claim_status in (?)", bun.In([]domain.ClaimStatus{domain.ClaimStatusExpired, domain.ClaimStatusForfeited})).
+		Where("not w.reassigned").
+		Where("c.end_time < ?", endedBefore).
+		OrderExpr("w.campaign_id").
+		Limit(limit).
+		Scan(ctx, &campaignIds)
+	if err != nil {
+		return nil, fmt.Errorf(errTemplate, err)
+	}
+	return campaignIds, nil
+}
+
+func (r *campaignPersistenceRepository) MarkReassigned(ctx context.Context, campaignId int64, customerIds []string) error {
+	errTemplate := "campaignPersistenceRepository MarkReassigned %w"
+	if len(customerIds) == 0 {
+		return nil
 	}
-	_, err := database.NewPostgresCrudDatabaseOperation[Winner](r.getDbFunc).Create(ctx, winner)
+	_, err := r.getDbFunc(ctx).NewUpdate().Model((*Winner)(nil)).
+		Set("reassigned = true").
+		Where("campaign_id = ?", campaignId).
+		Where("customer_id in (?)", bun.In(customerIds)).
+		Exec(ctx)
 	if err != nil {
 		return fmt.Errorf(errTemplate, err)
 	}
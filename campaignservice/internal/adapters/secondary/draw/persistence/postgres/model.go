@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"github.com/uptrace/bun"
+	domain "specommerce/campaignservice/internal/core/domain/draw"
+	"time"
+)
+
+type Draw struct {
+	bun.BaseModel `bun:"draws"`
+	CampaignId    int64      `bun:"campaign_id,pk"`
+	SeedHash      string     `bun:"seed_hash,notnull"`
+	Seed          string     `bun:"seed,notnull"`
+	EntryCount    int        `bun:"entry_count,notnull"`
+	DrawnAt       *time.Time `bun:"drawn_at"`
+	CreatedAt     time.Time  `bun:",nullzero,notnull,default:current_timestamp,skipupdate"`
+	UpdatedAt     time.Time  `bun:",nullzero,notnull,default:current_timestamp"`
+}
+
+type Entry struct {
+	bun.BaseModel `bun:"draw_entries"`
+	CampaignId    int64     `bun:"campaign_id,pk"`
+	CustomerId    string    `bun:"customer_id,pk"`
+	OrderId       string    `bun:"order_id,notnull"`
+	EnteredAt     time.Time `bun:"entered_at,notnull"`
+	CreatedAt     time.Time `bun:",nullzero,notnull,default:current_timestamp,skipupdate"`
+	UpdatedAt     time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+}
+
+type Winner struct {
+	bun.BaseModel `bun:"draw_winners"`
+	CampaignId    int64     `bun:"campaign_id,pk"`
+	CustomerId    string    `bun:"customer_id,pk"`
+	Position      int       `bun:"position,notnull"`
+	Score         string    `bun:"score,notnull"`
+	CreatedAt     time.Time `bun:",nullzero,notnull,default:current_timestamp,skipupdate"`
+	UpdatedAt     time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+}
+
+func (d Draw) ToDomainModel() domain.Draw {
+	return domain.Draw{
+		CampaignId: d.CampaignId,
+		SeedHash:   d.SeedHash,
+		Seed:       d.Seed,
+		EntryCount: d.EntryCount,
+		DrawnAt:    d.DrawnAt,
+		CreatedAt:  d.CreatedAt,
+		UpdatedAt:  d.UpdatedAt,
+	}
+}
+
+func FromDomainModel(dm domain.Draw) Draw {
+	return Draw{
+		CampaignId: dm.CampaignId,
+		SeedHash:   dm.SeedHash,
+		Seed:       dm.Seed,
+		EntryCount: dm.EntryCount,
+		DrawnAt:    dm.DrawnAt,
+		CreatedAt:  dm.CreatedAt,
+		UpdatedAt:  dm.UpdatedAt,
+	}
+}
+
+func (e Entry) ToDomainModel() domain.Entry {
+	return domain.Entry{
+		CampaignId: e.CampaignId,
+		CustomerId: e.CustomerId,
+		OrderId:    e.OrderId,
+		EnteredAt:  e.EnteredAt,
+	}
+}
+
+func (w Winner) ToDomainModel() domain.Winner {
+	return domain.Winner{
+		CampaignId: w.CampaignId,
+		CustomerId: w.CustomerId,
+		Position:   w.Position,
+		Score:      w.Score,
+	}
+}
+
+func WinnerFromDomainModel(dm domain.Winner) Winner {
+	return Winner{
+		CampaignId: dm.CampaignId,
+		CustomerId: dm.CustomerId,
+		Position:   dm.Position,
+		Score:      dm.Score,
+	}
+}
@@ -0,0 +1,157 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/uptrace/bun"
+	"specommerce/campaignservice/internal/core/domain/campaign"
+	domain "specommerce/campaignservice/internal/core/domain/draw"
+	"specommerce/campaignservice/internal/core/ports/secondary"
+	"specommerce/campaignservice/pkg/database"
+	"time"
+)
+
+type drawPersistenceRepository struct {
+	getDbFunc database.GetDbFunc
+}
+
+func NewDrawPersistenceRepository(dbFunc database.GetDbFunc) secondary.DrawRepository {
+	return &drawPersistenceRepository{
+		getDbFunc: dbFunc,
+	}
+}
+
+func (r *drawPersistenceRepository) Create(ctx context.Context, draw domain.Draw) (domain.Draw, error) {
+	errTemplate := "drawPersistenceRepository Create %w"
+	created, err := database.NewPostgresCrudDatabaseOperation[Draw](r.getDbFunc).Create(ctx, FromDomainModel(draw))
+	if err != nil {
+		return domain.Draw{}, fmt.Errorf(errTemplate, err)
+	}
+	return created.ToDomainModel(), nil
+}
+
+func (r *drawPersistenceRepository) GetByCampaignId(ctx context.Context, campaignId int64) (domain.Draw, error) {
+	errTemplate := "drawPersistenceRepository GetByCampaignId %w"
+	record, err := database.NewPostgresCrudDatabaseOperation[Draw](r.getDbFunc).FindById(ctx, campaignId)
+	if err != nil {
+		return domain.Draw{}, fmt.Errorf(errTemplate, err)
+	}
+	return record.ToDomainModel(), nil
+}
+
+func (r *drawPersistenceRepository) LockByCampaignId(ctx context.Context, campaignId int64) (domain.Draw, error) {
+	errTemplate := "drawPersistenceRepository LockByCampaignId %w"
+	record, err := database.NewPostgresCrudDatabaseOperation[Draw](r.getDbFunc).FindById(ctx, campaignId, func(query *bun.SelectQuery) *bun.SelectQuery {
+		return query.For("UPDATE")
+	})
+	if err != nil {
+		return domain.Draw{}, fmt.Errorf(errTemplate, err)
+	}
+	return record.ToDomainModel(), nil
+}
+
+func (r *drawPersistenceRepository) GetDueCampaignIds(ctx context.Context, endedBefore time.Time, limit int) ([]int64, error) {
+	errTemplate := "drawPersistenceRepository GetDueCampaignIds %w"
+	var campaignIds []int64
+	err := r.getDbFunc(ctx).NewSelect().
+		TableExpr("draws as d").
+		Join("join campaigns as c on c.id = d.campaign_id").
+		Column("d.campaign_id").
+		Where("d.drawn_at is null").
+		Where("c.end_time < ?", endedBefore).
+		Where("c.status = ?", campaign.StatusEnded).
+		Order("c.end_time").
+		Limit(limit).
+		Scan(ctx, &campaignIds)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf(errTemplate, err)
+	}
+	return campaignIds, nil
+}
+
+func (r *drawPersistenceRepository) CreateEntries(ctx context.Context, campaignId int64, start time.Time, end time.Time, minOrderAmount float64) error {
+	errTemplate := "drawPersistenceRepository CreateEntries %w"
+	query := `
+		insert into draw_entries (campaign_id, customer_id, order_id, entered_at)
+		select distinct on (customer_id) ?, customer_id, id, created_at
+		from orders
+		where status = 'SUCCESS' and created_at >= ? and created_at <= ? and total_amount >= ?
+		order by customer_id, created_at, id
+		on conflict do nothing
+	`
+	_, err := r.getDbFunc(ctx).ExecContext(ctx, query, campaignId, start, end, minOrderAmount)
+	if err != nil {
+		return fmt.Errorf(errTemplate, err)
+	}
+	return nil
+}
+
+func (r *drawPersistenceRepository) GetEntries(ctx context.Context, campaignId int64) ([]domain.Entry, error) {
+	errTemplate := "drawPersistenceRepository GetEntries %w"
+	records, err := database.NewPostgresCrudDatabaseOperation[Entry](r.getDbFunc).FindAll(ctx, func(query *bun.SelectQuery) *bun.SelectQuery {
+		return query.Where("campaign_id = ?", campaignId).Order("entered_at", "customer_id")
+	})
+	if err != nil {
+		return nil, fmt.Errorf(errTemplate, err)
+	}
+	entries := make([]domain.Entry, 0, len(records))
+	for _, record := range records {
+		entries = append(entries, record.ToDomainModel())
+	}
+	return entries, nil
+}
+
+func (r *drawPersistenceRepository) SaveWinners(ctx context.Context, winners []domain.Winner) error {
+	errTemplate := "drawPersistenceRepository SaveWinners %w"
+	if len(winners) == 0 {
+		return nil
+	}
+	records := make([]Winner, 0, len(winners))
+	for _, winner := range winners {
+		records = append(records, WinnerFromDomainModel(winner))
+	}
+	_, err := database.NewPostgresCrudDatabaseOperation[Winner](r.getDbFunc).CreateAll(ctx, records)
+	if err != nil {
+		return fmt.Errorf(errTemplate, err)
+	}
+	return nil
+}
+
+func (r *drawPersistenceRepository) GetWinners(ctx context.Context, campaignId int64) ([]domain.Winner, error) {
+	errTemplate := "drawPersistenceRepository GetWinners %w"
+	records, err := database.NewPostgresCrudDatabaseOperation[Winner](r.getDbFunc).FindAll(ctx, func(query *bun.SelectQuery) *bun.SelectQuery {
+		return query.Where("campaign_id = ?", campaignId).Order("position")
+	})
+	if err != nil {
+		return nil, fmt.Errorf(errTemplate, err)
+	}
+	winners := make([]domain.Winner, 0, len(records))
+	for _, record := range records {
+		winners = append(winners, record.ToDomainModel())
+	}
+	return winners, nil
+}
+
+func (r *drawPersistenceRepository) MarkDrawn(ctx context.Context, campaignId int64, entryCount int, drawnAt time.Time) error {
+	errTemplate := "drawPersistenceRepository MarkDrawn %w"
+	res, err := r.getDbFunc(ctx).NewUpdate().
+		Model((*Draw)(nil)).
+		Set("entry_count = ?", entryCount).
+		Set("drawn_at = ?", drawnAt).
+		Where("campaign_id = ?", campaignId).
+		Where("drawn_at is null").
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf(errTemplate, err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf(errTemplate, err)
+	}
+	if affected == 0 {
+		return fmt.Errorf(errTemplate, database.ErrRecordNotFound)
+	}
+	return nil
+}
@@ -3,9 +3,12 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"github.com/rs/xid"
+	"github.com/uptrace/bun"
 	domain "specommerce/campaignservice/internal/core/domain/order"
 	"specommerce/campaignservice/internal/core/ports/secondary"
 	"specommerce/campaignservice/pkg/database"
+	"time"
 )
 
 type orderPersistenceRepository struct {
@@ -18,10 +21,53 @@ func NewOrderPersistenceRepository(dbFunc database.GetDbFunc) secondary.OrderRep
 	}
 }
 
-func (r *orderPersistenceRepository) Create(ctx context.Context, order domain.Order) (domain.Order, error) {
-	created, err := database.NewPostgresCrudDatabaseOperation[Order](r.getDbFunc).Create(ctx, FromDomainModel(order))
+func (r *orderPersistenceRepository) Upsert(ctx context.Context, order domain.Order) (domain.Order, error) {
+	model := FromDomainModel(order)
+	_, err := r.getDbFunc(ctx).NewInsert().
+		Model(&model).
+		On("conflict (id) do update").
+		Set("status = excluded.status").
+		Set("total_amount = excluded.total_amount").
+		Set("updated_at = excluded.updated_at").
+		Where("?TableAlias.updated_at <= excluded.updated_at").
+		Returning("*").
+		Exec(ctx)
 	if err != nil {
-		return domain.Order{}, fmt.Errorf("orderPersistenceRepository CreateOrder %w", err)
+		return domain.Order{}, fmt.Errorf("orderPersistenceRepository Upsert %w", err)
 	}
-	return created.ToDomainModel(), nil
+	return model.ToDomainModel(), nil
+}
+
+func (r *orderPersistenceRepository) GetByCreatedAt(ctx context.Context, start time.Time, end time.Time) ([]domain.Order, error) {
+	records, err := database.NewPostgresCrudDatabaseOperation[Order](r.getDbFunc).FindAll(ctx, func(query *bun.SelectQuery) *bun.SelectQuery {
+		return query.
+			Where("created_at >= ?", start).
+			Where("created_at <= ?", end).
+			Order("created_at", "id")
+	})
+	if err != nil {
+		return nil, fmt.Errorf("orderPersistenceRepository GetByCreatedAt %w", err)
+	}
+	orders := make([]domain.Order, 0, len(records))
+	for _, record := range records {
+		orders = append(orders, record.ToDomainModel())
+	}
+	return orders, nil
+}
+
+func (r *orderPersistenceRepository) GetByIds(ctx context.Context, ids []xid.ID) ([]domain.Order, error) {
+	if len(ids) == 0 {
+		return []domain.Order{}, nil
+	}
+	records, err := database.NewPostgresCrudDatabaseOperation[Order](r.getDbFunc).FindAll(ctx, func(query *bun.SelectQuery) *bun.SelectQuery {
+		return query.Where("id in (?)", bun.In(ids))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("orderPersistenceRepository GetByIds %w", err)
+	}
+	orders := make([]domain.Order, 0, len(records))
+	for _, record := range records {
+		orders = append(orders, record.ToDomainModel())
+	}
+	return orders, nil
 }
@@ -0,0 +1,49 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"github.com/golang/protobuf/proto"
+	kafkaGo "github.com/segmentio/kafka-go"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"specommerce/campaignservice/config"
+	domain "specommerce/campaignservice/internal/core/domain/campaign"
+	"specommerce/campaignservice/internal/core/ports/secondary"
+	"specommerce/campaignservice/model"
+	"specommerce/campaignservice/pkg/outbox"
+)
+
+type winnerPublisher struct {
+	config config.AppConfig
+	outbox outbox.Writer
+}
+
+func (p *winnerPublisher) SendWinnerSelected(ctx context.Context, campaign domain.Campaign, winner domain.Winner) error {
+	errTemplate := "winnerPublisher SendWinnerSelected failed: %v"
+
+	payload, err := proto.Marshal(&model.WinnerSelected{
+		CampaignId:   campaign.Id,
+		CampaignName: campaign.Name,
+		CampaignType: campaign.Type,
+		CustomerId:   winner.CustomerId,
+		Rank:         int32(winner.Position),
+		OrderId:      winner.OrderId,
+		SelectedAt:   timestamppb.New(winner.SelectedAt),
+	})
+	if err != nil {
+		return fmt.Errorf(errTemplate, err)
+	}
+
+	return p.outbox.Write(ctx, kafkaGo.Message{
+		Topic: p.config.WinnerEvents.Topic,
+		Value: payload,
+		Key:   []byte(winner.CustomerId),
+	})
+}
+
+func NewWinnerPublisher(config config.AppConfig, outboxWriter outbox.Writer) secondary.WinnerEventRepository {
+	return &winnerPublisher{
+		config: config,
+		outbox: outboxWriter,
+	}
+}
@@ -0,0 +1,70 @@
+package campaign
+
+import "time"
+
+// Decision is what a campaign script decided for an order, as recorded in the
+// campaign audit trail.
+type Decision string
+
+const (
+	// DecisionAdmitted is an order added to the pending orders of a first_n_customers campaign.
+	DecisionAdmitted Decision = "ADMITTED"
+	// DecisionReordered is an order whose result came without its PENDING
+	// event, added to the pending orders at its creation time.
+	DecisionReordered Decision = "REORDERED"
+	// DecisionDroppedLate is an order whose event came after the orders created
+	// after it were ranked, left out of the ranking.
+	DecisionDroppedLate Decision = "DROPPED_LATE"
+	// DecisionExpired is an order still PENDING after the pending timeout,
+	// settled with its status in the orders table or as FAILED so that it no
+	// longer blocks the ranking.
+	DecisionExpired Decision = "EXPIRED"
+	// DecisionSkippedFailed is a failed order, which never counts.
+	DecisionSkippedFailed Decision = "SKIPPED_FAILED"
+	// DecisionSkippedWinner is an order of a customer who already won.
+	DecisionSkippedWinner Decision = "SKIPPED_WINNER"
+	// DecisionEligibleFull is an order of a customer left out because the
+	// eligible set already holds policy_max_tracked_orders customers.
+	DecisionEligibleFull Decision = "ELIGIBLE_FULL"
+	// DecisionEligible is an order whose customer is eligible but whose largest
+	// order does not reach policy_min_order_amount yet.
+	DecisionEligible Decision = "ELIGIBLE"
+	// DecisionWinner is an order that made its customer a winner.
+	DecisionWinner Decision = "WINNER"
+	// DecisionRewardsExhausted is an order evaluated after the last reward was given.
+	DecisionRewardsExhausted Decision = "REWARDS_EXHAUSTED"
+	// DecisionBelowMinAmount is an order below policy_min_order_amount.
+	DecisionBelowMinAmount Decision = "BELOW_MIN_AMOUNT"
+	// DecisionNotDrawn is an order of a lucky_draw campaign that lost its roll.
+	DecisionNotDrawn Decision = "NOT_DRAWN"
+	// DecisionNoTier is an order of a tiered campaign whose customer reaches no tier.
+	DecisionNoTier Decision = "NO_TIER"
+	// DecisionRewardUpgraded is an order that moved a tiered winner to a higher tier.
+	DecisionRewardUpgraded Decision = "REWARD_UPGRADED"
+	// DecisionEntered is an order that entered its customer in a draw.
+	DecisionEntered Decision = "ENTERED"
+	// DecisionAlreadyEntered is an order of a customer already entered in a draw.
+	DecisionAlreadyEntered Decision = "ALREADY_ENTERED"
+)
+
+func (d Decision) String() string {
+	return string(d)
+}
+
+// AuditEntry is a decision of a campaign script on an order. Entries are
+// appended to the campaign audit stream (see AuditKey) by the scripts, in
+// decision order, then persisted and removed from the stream.
+type AuditEntry struct {
+	CampaignId int64 `json:"campaign_id"`
+	// StreamId is the id of the entry in the audit stream, unique in the campaign
+	StreamId   string `json:"stream_id"`
+	OrderId    string `json:"order_id"`
+	CustomerId string `json:"customer_id"`
+	// Score is the order creation time relative to the campaign start in
+	// milliseconds, which ranks the orders of a first_n_customers campaign.
+	// It is nil when the order was never admitted to the pending orders.
+	Score     *int64    `json:"score"`
+	Decision  Decision  `json:"decision"`
+	Reason    string    `json:"reason"`
+	DecidedAt time.Time `json:"decided_at"`
+}
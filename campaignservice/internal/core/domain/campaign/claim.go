@@ -0,0 +1,106 @@
+package campaign
+
+import (
+	"errors"
+	"time"
+)
+
+// ClaimStatus is the state of the prize of a winner.
+//
+// A winner is NOTIFIED when selected and has until the claim deadline to claim
+// the prize. A CLAIMED prize is reserved from the campaign inventory until it is
+// SHIPPED. An unclaimed prize becomes EXPIRED after the deadline, and a winner
+// can give up a prize that is not shipped yet, which makes it FORFEITED. The
+// prizes of EXPIRED and FORFEITED winners go to the next eligible customers once
+// the campaign has ended.
+type ClaimStatus string
+
+const (
+	ClaimStatusNotified  ClaimStatus = "NOTIFIED"
+	ClaimStatusClaimed   ClaimStatus = "CLAIMED"
+	ClaimStatusShipped   ClaimStatus = "SHIPPED"
+	ClaimStatusExpired   ClaimStatus = "EXPIRED"
+	ClaimStatusForfeited ClaimStatus = "FORFEITED"
+)
+
+func (s ClaimStatus) String() string {
+	return string(s)
+}
+
+// IsActive reports whether the winner still holds the prize.
+func (s ClaimStatus) IsActive() bool {
+	return s == ClaimStatusNotified || s == ClaimStatusClaimed || s == ClaimStatusShipped
+}
+
+var (
+	ErrClaimExpired           = errors.New("claim deadline has passed")
+	ErrInvalidClaimTransition = errors.New("invalid claim status transition")
+	ErrOutOfStock             = errors.New("no prize left in the campaign inventory")
+)
+
+var claimTransitions = map[ClaimStatus][]ClaimStatus{
+	ClaimStatusNotified: {ClaimStatusClaimed, ClaimStatusExpired, ClaimStatusForfeited},
+	ClaimStatusClaimed:  {ClaimStatusShipped, ClaimStatusForfeited},
+}
+
+// CanTransition reports whether a prize in status from can move to status to.
+func CanTransition(from ClaimStatus, to ClaimStatus) bool {
+	for _, next := range claimTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// PrizeInventory counts the prizes of a campaign by the claim status of their winners.
+// Available is the stock not held by any winner, and can go to replacement winners.
+type PrizeInventory struct {
+	CampaignId int64 `json:"campaign_id"`
+	Total      int   `json:"total"`
+	Notified   int   `json:"notified"`
+	Claimed    int   `json:"claimed"`
+	Shipped    int   `json:"shipped"`
+	Expired    int   `json:"expired"`
+	Forfeited  int   `json:"forfeited"`
+	Available  int   `json:"available"`
+}
+
+// NewPrizeInventory counts the prizes of the winners of a campaign with total prizes in stock.
+func NewPrizeInventory(campaignId int64, total int, winners []Winner) PrizeInventory {
+	inventory := PrizeInventory{CampaignId: campaignId, Total: total}
+	for _, winner := range winners {
+		switch winner.ClaimStatus {
+		case ClaimStatusNotified:
+			inventory.Notified++
+		case ClaimStatusClaimed:
+			inventory.Claimed++
+		case ClaimStatusShipped:
+			inventory.Shipped++
+		case ClaimStatusExpired:
+			inventory.Expired++
+		case ClaimStatusForfeited:
+			inventory.Forfeited++
+		}
+	}
+	inventory.Available = max(0, total-inventory.Notified-inventory.Claimed-inventory.Shipped)
+	return inventory
+}
+
+// Reserved is the number of prizes set aside for claimed and shipped winners.
+func (i PrizeInventory) Reserved() int {
+	return i.Claimed + i.Shipped
+}
+
+// StartClaim notifies a newly selected winner, who has until window after now to claim the prize.
+func (w Winner) StartClaim(now time.Time, window time.Duration) Winner {
+	deadline := now.Add(window)
+	w.ClaimStatus = ClaimStatusNotified
+	w.ClaimDeadline = &deadline
+	return w
+}
+
+// IsClaimExpired reports whether the claim deadline of a winner has passed at now.
+func (w Winner) IsClaimExpired(now time.Time) bool {
+	return w.ClaimDeadline != nil && now.After(*w.ClaimDeadline)
+}
@@ -1,62 +1,54 @@
 package campaign
 
 import (
-	"encoding/json"
+	"errors"
 	"time"
 )
 
+// ErrTypeChanged is returned when updating a campaign with another type, as
+// its Redis state and policy only make sense for the original type.
+var ErrTypeChanged = errors.New("campaign type cannot be changed")
+
 type Campaign struct {
 	Id          int64          `json:"id" validate:"required"`
 	Name        string         `json:"name" validate:"required"`
-	Type        string         `json:"type" validate:"required,oneof=iphone"` // Currently only supports "iphone"
+	Type        string         `json:"type" validate:"required"`
+	Status      Status         `json:"status" validate:"required"`
 	Description string         `json:"description" validate:"required"`
 	Policy      map[string]any `json:"policy" validate:"required"`
-	StartTime   time.Time      `json:"start_time" validate:"required"`
-	EndTime     time.Time      `json:"end_time" validate:"required"`
-	CreatedAt   time.Time      `json:"created_at" validate:"required"`
-	UpdatedAt   time.Time      `json:"updated_at" validate:"required"`
-}
-
-type IphoneCampaignPolicy struct {
-	TotalReward      int64 `json:"total_reward" validate:"required"`
-	MinOrderAmount   int64 `json:"min_order_amount" validate:"required"`
-	MaxTrackedOrders int64 `json:"max_tracked_orders" validate:"required"`
+	// PrizeInventory is the number of prizes in stock for the winners
+	PrizeInventory int       `json:"prize_inventory"`
+	StartTime      time.Time `json:"start_time" validate:"required"`
+	EndTime        time.Time `json:"end_time" validate:"required"`
+	CreatedAt      time.Time `json:"created_at" validate:"required"`
+	UpdatedAt      time.Time `json:"updated_at" validate:"required"`
 }
 
-func (c Campaign) ToIphoneCampaign() (IphoneCampaign, error) {
-	var policy IphoneCampaignPolicy
-	policyBytes, err := json.Marshal(c.Policy)
-	if err != nil {
-		return IphoneCampaign{}, err
-	}
-	err = json.Unmarshal(policyBytes, &policy)
-	if err != nil {
-		return IphoneCampaign{}, err
-	}
-
-	return IphoneCampaign{
-		Id:          c.Id,
-		Name:        c.Name,
-		Type:        c.Type,
-		Description: c.Description,
-		Policy:      policy,
-		StartTime:   c.StartTime,
-		EndTime:     c.EndTime,
-		CreatedAt:   c.CreatedAt,
-		UpdatedAt:   c.UpdatedAt,
-	}, nil
+// IsLocked reports whether the policy and the time window of the campaign can
+// no longer change. Besides the locked statuses, a SCHEDULED campaign whose
+// window opened is locked too, as it evaluates the orders of its window before
+// the scheduler activates it.
+func (c Campaign) IsLocked(now time.Time) bool {
+	return c.Status.IsLocked() || (c.Status == StatusScheduled && !c.StartTime.After(now))
 }
 
-type IphoneCampaign struct {
-	Id          int64
-	Name        string
-	Type        string
-	Description string
-	Policy      IphoneCampaignPolicy
-	StartTime   time.Time
-	EndTime     time.Time
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+// Winner is a customer selected by a campaign. Position is the selection order
+// and OrderId the order that made the customer win, or entered a draw.
+// Replaces is the customer whose expired or forfeited prize went to this winner.
+type Winner struct {
+	CampaignId      int64       `json:"campaign_id"`
+	CustomerId      string      `json:"customer_id"`
+	OrderId         string      `json:"order_id"`
+	Position        int         `json:"position"`
+	SelectedAt      time.Time   `json:"selected_at"`
+	ClaimStatus     ClaimStatus `json:"claim_status"`
+	ClaimDeadline   *time.Time  `json:"claim_deadline,omitempty"`
+	ShippingAddress string      `json:"shipping_address,omitempty"`
+	ClaimedAt       *time.Time  `json:"claimed_at,omitempty"`
+	ShippedAt       *time.Time  `json:"shipped_at,omitempty"`
+	Replaces        string      `json:"replaces,omitempty"`
+	// Reassigned is set once the prize of an expired or forfeited winner was handed to the next eligible customers
+	Reassigned bool `json:"reassigned,omitempty"`
 }
 
 type IphoneWinner struct {
@@ -0,0 +1,117 @@
+package campaign
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Every campaign owns its own Redis keyspace. The campaign id is wrapped in a
+// hash tag so that all keys of a campaign map to the same Redis Cluster slot
+// and can be used together in one Lua script.
+
+// KeyPrefix returns the prefix shared by all Redis keys of a campaign.
+func KeyPrefix(campaignId int64) string {
+	return fmt.Sprintf("campaign:{%d}:", campaignId)
+}
+
+// InfoKey is the hash holding the campaign details and policy.
+func InfoKey(campaignId int64) string {
+	return key(campaignId, "info")
+}
+
+// WinnersKey is the set of customers who won the campaign.
+func WinnersKey(campaignId int64) string {
+	return key(campaignId, "winners")
+}
+
+// UnsavedWinnersKey is the list of the winners selected by the scripts and not
+// saved in the winners table yet, as JSON objects with customer_id, order_id and position.
+func UnsavedWinnersKey(campaignId int64) string {
+	return key(campaignId, "unsaved_winners")
+}
+
+// EligibleKey is the set of the first customers tracked by the campaign.
+func EligibleKey(campaignId int64) string {
+	return key(campaignId, "eligible")
+}
+
+// PendingOrdersKey is the sorted set of orders waiting for their result,
+// scored by creation time relative to the campaign start.
+func PendingOrdersKey(campaignId int64) string {
+	return key(campaignId, "pending_orders")
+}
+
+// RewardsKey is the hash of the reward value granted to each winner, in the
+// unit of the campaign type (cashback amount, voucher percentage, ...).
+func RewardsKey(campaignId int64) string {
+	return key(campaignId, "rewards")
+}
+
+// EntriesKey is the set of customers entered in a draw campaign.
+func EntriesKey(campaignId int64) string {
+	return key(campaignId, "entries")
+}
+
+// RankedScoreKey is the score of the last order ranked by a first_n_customers
+// campaign. The late events of the orders created before it are dropped from the ranking.
+func RankedScoreKey(campaignId int64) string {
+	return key(campaignId, "ranked_score")
+}
+
+// QueuedOrdersKey is the list of the order events received while the campaign
+// was paused, as JSON objects with the script to run and the order, replayed in
+// arrival order when the campaign resumes or ends.
+func QueuedOrdersKey(campaignId int64) string {
+	return key(campaignId, "queued_orders")
+}
+
+// QueueAttemptsKey counts, by queued entry, the failed replays of the head of
+// the queued orders.
+func QueueAttemptsKey(campaignId int64) string {
+	return key(campaignId, "queue_attempts")
+}
+
+// DeadQueuedOrdersKey is the list of the queued order events moved out of the
+// queue after failing schedule.maxReplayAttempts replays, kept for inspection.
+func DeadQueuedOrdersKey(campaignId int64) string {
+	return key(campaignId, "dead_queued_orders")
+}
+
+// QueueLockKey is held by the instance replaying the queued orders of the campaign.
+func QueueLockKey(campaignId int64) string {
+	return key(campaignId, "queue_lock")
+}
+
+// RebuildLockKey is held while the state of the campaign is rebuilt. The order
+// events received in the meantime are queued with the events of a paused
+// campaign and replayed once the rebuild is done.
+func RebuildLockKey(campaignId int64) string {
+	return key(campaignId, "rebuild_lock")
+}
+
+// AuditKey is the stream of the decisions of the scripts on the orders of the
+// campaign, with the order, customer, score, decision and reason of each. The
+// entries are removed once persisted in the audit table.
+func AuditKey(campaignId int64) string {
+	return key(campaignId, "audit")
+}
+
+// ProgressChannel is the Pub/Sub channel on which the Progress of the campaign
+// is published after every evaluated order result.
+func ProgressChannel(campaignId int64) string {
+	return key(campaignId, "progress")
+}
+
+// TransactionKey is the hash holding the customer and status of an order.
+func TransactionKey(campaignId int64, orderId string) string {
+	return key(campaignId, "transactions", orderId)
+}
+
+// CustomerKey is the hash holding the campaign statistics of a customer.
+func CustomerKey(campaignId int64, customerId string) string {
+	return key(campaignId, "customers", customerId)
+}
+
+func key(campaignId int64, parts ...string) string {
+	return KeyPrefix(campaignId) + strings.Join(parts, ":")
+}
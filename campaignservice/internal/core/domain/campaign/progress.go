@@ -0,0 +1,20 @@
+package campaign
+
+import "time"
+
+// Progress is a snapshot of the live counters of a campaign, as pushed to the
+// admin portal after every evaluated order result.
+type Progress struct {
+	CampaignId int64 `json:"campaign_id"`
+	// PendingOrders is the number of orders waiting for their result in PendingOrdersKey
+	PendingOrders int64 `json:"pending_orders"`
+	// Eligible is the number of customers in EligibleKey
+	Eligible int64 `json:"eligible"`
+	// Winners is the number of customers in WinnersKey, out of TotalReward
+	Winners     int64 `json:"winners"`
+	TotalReward int64 `json:"total_reward"`
+	Finished    bool  `json:"finished"`
+	// NewWinners are the winners selected by the order result that triggered the update
+	NewWinners []Winner  `json:"new_winners,omitempty"`
+	At         time.Time `json:"at"`
+}
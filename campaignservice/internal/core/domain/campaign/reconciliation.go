@@ -0,0 +1,55 @@
+package campaign
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrReconciliationUnsupported is returned when reconciling a campaign type
+// whose winners are not also computed in SQL.
+var ErrReconciliationUnsupported = errors.New("campaign type does not support winner reconciliation")
+
+// Reasons why a customer is a winner in only one of the Redis winners set and
+// the SQL winner query.
+const (
+	// ReasonMinAmountBoundary is a customer whose largest order equals
+	// min_order_amount: Redis accepts it (>=), SQL does not (>).
+	ReasonMinAmountBoundary = "min_amount_boundary"
+	// ReasonBelowMinAmount is a Redis winner whose largest successful order is below min_order_amount.
+	ReasonBelowMinAmount = "below_min_amount"
+	// ReasonNoSuccessOrder is a Redis winner without a successful order in the campaign window.
+	ReasonNoSuccessOrder = "no_success_order"
+	// ReasonOutsideTrackedCustomers is a customer tracked on one side only. Redis
+	// tracks the first customers by their first completed order, SQL by their first successful order.
+	ReasonOutsideTrackedCustomers = "outside_tracked_customers"
+	// ReasonRewardLimit is a customer left out because the other side ran out of rewards first.
+	ReasonRewardLimit = "reward_limit"
+	// ReasonPendingInRedis is a SQL winner that Redis has not selected yet, as an
+	// earlier order is still pending.
+	ReasonPendingInRedis = "pending_in_redis"
+	// ReasonNotSelected is a SQL winner that Redis did not select for no known reason,
+	// e.g. an order result event that was never processed.
+	ReasonNotSelected = "not_selected"
+)
+
+// Discrepancy is a customer who is a winner in only one of Redis and SQL.
+type Discrepancy struct {
+	CustomerId     string     `json:"customer_id"`
+	InRedis        bool       `json:"in_redis"`
+	InSql          bool       `json:"in_sql"`
+	Reason         string     `json:"reason"`
+	MaxOrderAmount float64    `json:"max_order_amount"`
+	FirstOrderTime *time.Time `json:"first_order_time,omitempty"`
+}
+
+type Reconciliation struct {
+	CampaignId    int64         `json:"campaign_id"`
+	RedisWinners  int           `json:"redis_winners"`
+	SqlWinners    int           `json:"sql_winners"`
+	Discrepancies []Discrepancy `json:"discrepancies"`
+	ReconciledAt  time.Time     `json:"reconciled_at"`
+}
+
+func (r Reconciliation) IsConsistent() bool {
+	return len(r.Discrepancies) == 0
+}
@@ -0,0 +1,73 @@
+package campaign
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"time"
+)
+
+// ErrInvalidWindow is returned when the time window of a simulated campaign is empty.
+var ErrInvalidWindow = errors.New("end_time must be after start_time")
+
+// Sources of the orders replayed by a simulation.
+const (
+	SimulationSourceOrders = "orders" // the orders table, within the campaign window
+	SimulationSourceFile   = "file"   // an uploaded file of order events
+)
+
+// NewSimulationId returns a random negative campaign id. Campaign ids are
+// positive, so the keyspace of a simulation (see KeyPrefix) never collides with
+// the live state of a campaign or another simulation.
+func NewSimulationId() (int64, error) {
+	var buf [8]byte
+	if _, err := rand.Read(buf[:]); err != nil {
+		return 0, err
+	}
+	return -int64(binary.BigEndian.Uint64(buf[:])>>1) - 1, nil
+}
+
+// Simulation is the outcome of replaying orders through the rule and the Lua
+// scripts of a campaign, in an isolated Redis keyspace.
+type Simulation struct {
+	Campaign  Campaign `json:"campaign"`
+	Source    string   `json:"source"`
+	Events    int      `json:"events"`    // order events replayed
+	Orders    int      `json:"orders"`    // distinct orders
+	Customers int      `json:"customers"` // distinct customers
+	// Reordered and Dropped are the order events ranked at their creation time
+	// without a PENDING event, and the ones too late to be ranked.
+	Reordered int `json:"reordered"`
+	Dropped   int `json:"dropped"`
+	// Eligible is the number of customers tracked by a first_n_customers
+	// campaign, or entered in a draw campaign.
+	Eligible   int               `json:"eligible"`
+	Winners    []SimulatedWinner `json:"winners"`
+	FinishedAt *time.Time        `json:"finished_at,omitempty"` // when the last reward was given
+	// DrawSeed is the random seed of a simulated draw or lucky draw. The real
+	// campaign uses another seed, so its winners will differ.
+	DrawSeed string          `json:"draw_seed,omitempty"`
+	Timeline []TimelineEvent `json:"timeline"`
+}
+
+// SimulatedWinner is a customer who would have won, in selection order.
+type SimulatedWinner struct {
+	Position   int       `json:"position"`
+	CustomerId string    `json:"customer_id"`
+	OrderId    string    `json:"order_id,omitempty"`
+	Reward     string    `json:"reward,omitempty"` // in the unit of the campaign type
+	SelectedAt time.Time `json:"selected_at"`      // time of the event that selected the winner
+}
+
+// TimelineEvent is a replayed order event that selected winners or used up the
+// last reward of the campaign, or the ranking of the orders still held back for
+// late events at the end of the replay, which has no order.
+type TimelineEvent struct {
+	At         time.Time `json:"at"`
+	OrderId    string    `json:"order_id,omitempty"`
+	CustomerId string    `json:"customer_id,omitempty"`
+	Status     string    `json:"status,omitempty"`
+	NewWinners []string  `json:"new_winners,omitempty"`
+	Winners    int       `json:"winners"` // winners so far
+	Finished   bool      `json:"finished"`
+}
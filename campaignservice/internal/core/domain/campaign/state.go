@@ -0,0 +1,50 @@
+package campaign
+
+import "errors"
+
+var (
+	ErrRebuildInProgress = errors.New("campaign state is already being rebuilt")
+	ErrRebuildConflict   = errors.New("campaign state kept changing during the rebuild")
+)
+
+// State is the Redis state of a campaign, as rebuilt from the database.
+// Sets are kept in selection order. A nil Entries leaves the entries set as
+// it is, for the rules that do not keep one.
+type State struct {
+	Info          map[string]string            // InfoKey
+	Winners       []string                     // WinnersKey
+	Eligible      []string                     // EligibleKey
+	Entries       []string                     // EntriesKey
+	PendingOrders map[string]float64           // PendingOrdersKey, order id to score
+	RankedScore   string                       // RankedScoreKey, empty when no order was ranked
+	Transactions  map[string]map[string]string // TransactionKey by order id
+	Customers     map[string]map[string]string // CustomerKey by customer id
+}
+
+func NewState() State {
+	return State{
+		Info:          map[string]string{},
+		Winners:       []string{},
+		Eligible:      []string{},
+		PendingOrders: map[string]float64{},
+		Transactions:  map[string]map[string]string{},
+		Customers:     map[string]map[string]string{},
+	}
+}
+
+// Change is a difference between the Redis state of a campaign and its rebuilt state.
+// Member is a hash field or a set member. An empty Expected means the member
+// should not be there, an empty Actual that it is missing.
+type Change struct {
+	Key      string `json:"key"`
+	Member   string `json:"member"`
+	Expected string `json:"expected,omitempty"`
+	Actual   string `json:"actual,omitempty"`
+}
+
+type RebuildReport struct {
+	CampaignId int64    `json:"campaign_id"`
+	DryRun     bool     `json:"dry_run"`
+	Applied    bool     `json:"applied"`
+	Changes    []Change `json:"changes"`
+}
@@ -0,0 +1,69 @@
+package campaign
+
+import "errors"
+
+// Status is the lifecycle state of a campaign.
+//
+// A campaign is created as a DRAFT, which evaluates no order, or directly
+// SCHEDULED. The scheduler makes a SCHEDULED campaign ACTIVE at its start time
+// and ENDED after its end time. An ACTIVE campaign can be PAUSED: its orders are
+// queued instead of evaluated, and replayed in arrival order when it is resumed
+// or ends. An ENDED campaign can be ARCHIVED. The policy and the time window of a
+// campaign are locked once it is ACTIVE.
+type Status string
+
+const (
+	StatusDraft     Status = "DRAFT"
+	StatusScheduled Status = "SCHEDULED"
+	StatusActive    Status = "ACTIVE"
+	StatusPaused    Status = "PAUSED"
+	StatusEnded     Status = "ENDED"
+	StatusArchived  Status = "ARCHIVED"
+)
+
+func (s Status) String() string {
+	return string(s)
+}
+
+// IsValid reports whether s is a known status.
+func (s Status) IsValid() bool {
+	_, ok := statusTransitions[s]
+	return ok
+}
+
+// IsLocked reports whether the policy and the time window of the campaign can
+// no longer change, as its orders are being or were evaluated.
+func (s Status) IsLocked() bool {
+	return s == StatusActive || s == StatusPaused || s == StatusEnded || s == StatusArchived
+}
+
+// EvaluatingStatuses are the statuses of the campaigns whose orders are
+// evaluated, or queued while PAUSED. A SCHEDULED campaign evaluates the orders
+// of its window before the scheduler activates it, and an ENDED one the late
+// results of orders created before its end.
+var EvaluatingStatuses = []Status{StatusScheduled, StatusActive, StatusPaused, StatusEnded}
+
+var (
+	ErrInvalidStatus     = errors.New("invalid campaign status")
+	ErrInvalidTransition = errors.New("invalid campaign status transition")
+	ErrPolicyLocked      = errors.New("campaign policy and time window cannot change once the campaign has started")
+)
+
+var statusTransitions = map[Status][]Status{
+	StatusDraft:     {StatusScheduled, StatusArchived},
+	StatusScheduled: {StatusDraft, StatusActive, StatusEnded, StatusArchived},
+	StatusActive:    {StatusPaused, StatusEnded},
+	StatusPaused:    {StatusActive, StatusEnded},
+	StatusEnded:     {StatusArchived},
+	StatusArchived:  {},
+}
+
+// CanTransition reports whether a campaign in status s can move to status to.
+func (s Status) CanTransition(to Status) bool {
+	for _, next := range statusTransitions[s] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,134 @@
+// Package draw implements the verifiable lucky draw of draw campaigns.
+//
+// A random seed is generated when the campaign is created and only its SHA-256
+// commitment is published. When the campaign ends every qualifying customer gets
+// one entry, each entry is scored with sha256(seed:customer_id) and the entries
+// with the lowest scores win. The seed is revealed with the result, so anyone
+// can check it against the commitment and re-run the selection.
+//
+// Lucky draw campaigns commit to their seed the same way. Their orders are drawn
+// live with Roll as they complete, and the seed is revealed once the campaign
+// has ended so that the roll of every winning order can be checked.
+package draw
+
+import (
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"sort"
+	"time"
+)
+
+var (
+	ErrDrawNotDue = errors.New("campaign has not ended yet")
+	ErrNotDrawn   = errors.New("draw has not been run yet")
+)
+
+type Draw struct {
+	CampaignId int64      `json:"campaign_id"`
+	SeedHash   string     `json:"seed_hash"`
+	Seed       string     `json:"seed,omitempty"` // revealed once drawn
+	EntryCount int        `json:"entry_count"`
+	DrawnAt    *time.Time `json:"drawn_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+}
+
+func (d Draw) IsDrawn() bool {
+	return d.DrawnAt != nil
+}
+
+// Public hides the seed until the draw has been run.
+func (d Draw) Public() Draw {
+	if !d.IsDrawn() {
+		d.Seed = ""
+	}
+	return d
+}
+
+// Entry is the single entry of a qualifying customer, taken with their first qualifying order.
+type Entry struct {
+	CampaignId int64     `json:"campaign_id"`
+	CustomerId string    `json:"customer_id"`
+	OrderId    string    `json:"order_id"`
+	EnteredAt  time.Time `json:"entered_at"`
+}
+
+type Winner struct {
+	CampaignId int64  `json:"campaign_id"`
+	CustomerId string `json:"customer_id"`
+	Position   int    `json:"position"`
+	Score      string `json:"score"`
+}
+
+// Verification is the result of re-running a draw from its revealed seed and persisted entries.
+type Verification struct {
+	Draw                  Draw     `json:"draw"`
+	SeedMatchesCommitment bool     `json:"seed_matches_commitment"`
+	WinnersMatch          bool     `json:"winners_match"`
+	Entries               []Entry  `json:"entries"`
+	Winners               []Winner `json:"winners"`
+	RecomputedWinners     []Winner `json:"recomputed_winners"`
+}
+
+// NewDraw commits to a new random seed for a campaign.
+func NewDraw(campaignId int64) (Draw, error) {
+	seed := make([]byte, 32)
+	if _, err := rand.Read(seed); err != nil {
+		return Draw{}, err
+	}
+	hexSeed := hex.EncodeToString(seed)
+	return Draw{
+		CampaignId: campaignId,
+		SeedHash:   Commitment(hexSeed),
+		Seed:       hexSeed,
+	}, nil
+}
+
+// Commitment is the published hash of a seed.
+func Commitment(seed string) string {
+	sum := sha256.Sum256([]byte(seed))
+	return hex.EncodeToString(sum[:])
+}
+
+// Score is the draw score of a customer, the lowest scores win.
+func Score(seed string, customerId string) string {
+	sum := sha256.Sum256([]byte(seed + ":" + customerId))
+	return hex.EncodeToString(sum[:])
+}
+
+// Roll is the draw of an order of a lucky draw campaign: the first 32 bits of
+// sha1(seed:order_id) scaled to [0, 1). The order wins below the win probability.
+func Roll(seed string, orderId string) float64 {
+	sum := sha1.Sum([]byte(seed + ":" + orderId))
+	return float64(binary.BigEndian.Uint32(sum[:4])) / (1 << 32)
+}
+
+// SelectWinners picks the count entries with the lowest scores. The result
+// only depends on the seed and the set of entrants, not on the entries order.
+func SelectWinners(seed string, entries []Entry, count int) []Winner {
+	scored := make([]Winner, 0, len(entries))
+	for _, entry := range entries {
+		scored = append(scored, Winner{
+			CampaignId: entry.CampaignId,
+			CustomerId: entry.CustomerId,
+			Score:      Score(seed, entry.CustomerId),
+		})
+	}
+	sort.Slice(scored, func(i, j int) bool {
+		if scored[i].Score != scored[j].Score {
+			return scored[i].Score < scored[j].Score
+		}
+		return scored[i].CustomerId < scored[j].CustomerId
+	})
+	if count < len(scored) {
+		scored = scored[:count]
+	}
+	for i := range scored {
+		scored[i].Position = i + 1
+	}
+	return scored
+}
@@ -0,0 +1,61 @@
+package draw
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectWinners_SameSeedAndEntriesGiveSameWinners(t *testing.T) {
+	entries := []Entry{
+		{CampaignId: 1, CustomerId: "alice"},
+		{CampaignId: 1, CustomerId: "bob"},
+		{CampaignId: 1, CustomerId: "carol"},
+		{CampaignId: 1, CustomerId: "dave"},
+		{CampaignId: 1, CustomerId: "erin"},
+	}
+	reversed := make([]Entry, 0, len(entries))
+	for i := len(entries) - 1; i >= 0; i-- {
+		reversed = append(reversed, entries[i])
+	}
+
+	winners := SelectWinners("seed", entries, 3)
+	require.Len(t, winners, 3)
+	assert.Equal(t, winners, SelectWinners("seed", entries, 3))
+	assert.Equal(t, winners, SelectWinners("seed", reversed, 3))
+	for i, winner := range winners {
+		assert.Equal(t, i+1, winner.Position)
+		assert.Equal(t, Score("seed", winner.CustomerId), winner.Score)
+		if i > 0 {
+			assert.Less(t, winners[i-1].Score, winner.Score)
+		}
+	}
+}
+
+func TestSelectWinners_FewerEntriesThanRewards(t *testing.T) {
+	entries := []Entry{{CampaignId: 1, CustomerId: "alice"}, {CampaignId: 1, CustomerId: "bob"}}
+
+	assert.Len(t, SelectWinners("seed", entries, 5), 2)
+	assert.Empty(t, SelectWinners("seed", nil, 5))
+}
+
+func TestCommitment_MatchesRevealedSeed(t *testing.T) {
+	committed, err := NewDraw(1)
+	require.NoError(t, err)
+
+	assert.Len(t, committed.Seed, 64)
+	assert.Equal(t, Commitment(committed.Seed), committed.SeedHash)
+	assert.NotEqual(t, committed.Seed, committed.SeedHash)
+	assert.NotEqual(t, Commitment(committed.Seed+"0"), committed.SeedHash)
+	assert.Empty(t, committed.Public().Seed)
+}
+
+func TestRoll_IsReproducible(t *testing.T) {
+	roll := Roll("seed", "order")
+
+	assert.Equal(t, roll, Roll("seed", "order"))
+	assert.NotEqual(t, roll, Roll("other seed", "order"))
+	assert.GreaterOrEqual(t, roll, 0.0)
+	assert.Less(t, roll, 1.0)
+}
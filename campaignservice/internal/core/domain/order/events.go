@@ -0,0 +1,37 @@
+package order
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// DecodeEvents reads order events from JSON Lines, one order per line with its
+// status at the time of the event. A JSON array of orders is also accepted.
+func DecodeEvents(r io.Reader) ([]Order, error) {
+	decoder := json.NewDecoder(r)
+	events := []Order{}
+	for {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			if errors.Is(err, io.EOF) {
+				return events, nil
+			}
+			return nil, fmt.Errorf("order event %d: %w", len(events)+1, err)
+		}
+		if len(raw) > 0 && raw[0] == '[' {
+			var batch []Order
+			if err := json.Unmarshal(raw, &batch); err != nil {
+				return nil, fmt.Errorf("order events: %w", err)
+			}
+			events = append(events, batch...)
+			continue
+		}
+		var event Order
+		if err := json.Unmarshal(raw, &event); err != nil {
+			return nil, fmt.Errorf("order event %d: %w", len(events)+1, err)
+		}
+		events = append(events, event)
+	}
+}
@@ -0,0 +1,14 @@
+package primary
+
+import (
+	"context"
+	"specommerce/campaignservice/internal/core/domain/campaign"
+)
+
+type AuditService interface {
+	// GetTrail returns every decision of the campaign scripts on the orders of a
+	// customer, in decision order.
+	GetTrail(ctx context.Context, campaignId int64, customerId string) ([]campaign.AuditEntry, error)
+	// PersistTrails moves the entries of the campaign audit streams to the audit table.
+	PersistTrails(ctx context.Context) error
+}
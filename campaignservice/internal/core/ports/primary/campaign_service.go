@@ -7,7 +7,12 @@ import (
 
 type CampaignService interface {
 	CreateCampaign(ctx context.Context, input campaign.Campaign) (campaign.Campaign, error)
-	GetIphoneCampaign(ctx context.Context) (campaign.Campaign, error)
-	UpdateIphoneCampaign(ctx context.Context, input campaign.Campaign) (campaign.Campaign, error)
-	GetIphoneWinner(ctx context.Context) ([]campaign.IphoneWinner, error)
+	GetCampaigns(ctx context.Context) ([]campaign.Campaign, error)
+	GetCampaign(ctx context.Context, id int64) (campaign.Campaign, error)
+	UpdateCampaign(ctx context.Context, input campaign.Campaign) (campaign.Campaign, error)
+	GetCampaignWinners(ctx context.Context, id int64) ([]campaign.IphoneWinner, error)
+	// ChangeStatus moves a campaign to another status of its lifecycle.
+	ChangeStatus(ctx context.Context, id int64, status campaign.Status) (campaign.Campaign, error)
+	// RunSchedule activates and ends the campaigns whose start or end time has come.
+	RunSchedule(ctx context.Context) error
 }
@@ -0,0 +1,24 @@
+package primary
+
+import (
+	"context"
+	"specommerce/campaignservice/internal/core/domain/campaign"
+)
+
+type ClaimService interface {
+	// GetPrize returns the prize of a customer in a campaign, with its claim status.
+	GetPrize(ctx context.Context, campaignId int64, customerId string) (campaign.Winner, error)
+	// ClaimPrize claims the prize of a NOTIFIED winner before the claim deadline
+	// and reserves it from the campaign inventory.
+	ClaimPrize(ctx context.Context, campaignId int64, customerId string, shippingAddress string) (campaign.Winner, error)
+	// ForfeitPrize gives up a prize that is not shipped yet.
+	ForfeitPrize(ctx context.Context, campaignId int64, customerId string) (campaign.Winner, error)
+	// ShipPrize marks a claimed prize as shipped.
+	ShipPrize(ctx context.Context, campaignId int64, customerId string) (campaign.Winner, error)
+	// GetClaims returns the winners of a campaign with their claims, in selection order.
+	GetClaims(ctx context.Context, campaignId int64) ([]campaign.Winner, error)
+	GetPrizeInventory(ctx context.Context, campaignId int64) (campaign.PrizeInventory, error)
+	// SweepClaims expires the claims past their deadline, then hands the expired
+	// and forfeited prizes of the ended campaigns to the next eligible customers.
+	SweepClaims(ctx context.Context) error
+}
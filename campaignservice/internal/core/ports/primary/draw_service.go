@@ -0,0 +1,19 @@
+package primary
+
+import (
+	"context"
+	"specommerce/campaignservice/internal/core/domain/draw"
+)
+
+type DrawService interface {
+	// GetDraw returns the draw of a campaign, with the seed only once drawn.
+	GetDraw(ctx context.Context, campaignId int64) (draw.Draw, error)
+	// RunDraw draws the winners of an ended campaign, or only reveals the seed of
+	// a lucky draw campaign. Running it again returns the existing result.
+	RunDraw(ctx context.Context, campaignId int64) (draw.Draw, error)
+	// RunDueDraws draws all the campaigns that ended before the configured delay.
+	RunDueDraws(ctx context.Context) error
+	// VerifyDraw re-runs a draw from its revealed seed and persisted entries, or
+	// rolls the winning orders of a lucky draw campaign again.
+	VerifyDraw(ctx context.Context, campaignId int64) (draw.Verification, error)
+}
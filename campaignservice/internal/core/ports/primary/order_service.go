@@ -10,4 +10,12 @@ type OrderService interface {
 	ProcessPendingOrder(ctx context.Context, order order.Order) error
 	ProcessOrderResult(ctx context.Context, order order.Order) error
 	SaveSuccessOrder(ctx context.Context, order order.Order) error
+	// ProcessQueuedOrders replays the order events queued while a campaign was paused.
+	ProcessQueuedOrders(ctx context.Context, campaignId int64) error
+	// FlushOrders ranks the orders held back for late events once they are past
+	// the watermark, in the running campaigns whose rule ranks orders.
+	FlushOrders(ctx context.Context) error
+	// SweepPendingOrders expires the orders still PENDING after the pending
+	// timeout, which block the ranking of the orders created after them.
+	SweepPendingOrders(ctx context.Context) error
 }
@@ -0,0 +1,16 @@
+package primary
+
+import (
+	"context"
+	"specommerce/campaignservice/internal/core/domain/campaign"
+)
+
+type ProgressService interface {
+	// GetProgress returns the current live counters of a campaign.
+	GetProgress(ctx context.Context, campaignId int64) (campaign.Progress, error)
+	// PublishProgress publishes the live counters of a campaign to every
+	// subscriber, with the winners selected by the last order result.
+	PublishProgress(ctx context.Context, input campaign.Campaign, newWinners []campaign.Winner) error
+	// SubscribeProgress returns the progress published for a campaign until ctx is done.
+	SubscribeProgress(ctx context.Context, campaignId int64) (<-chan campaign.Progress, error)
+}
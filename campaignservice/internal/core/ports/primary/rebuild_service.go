@@ -0,0 +1,14 @@
+package primary
+
+import (
+	"context"
+	"specommerce/campaignservice/internal/core/domain/campaign"
+)
+
+type RebuildService interface {
+	// Rebuild compares the Redis state of a campaign with the state rebuilt from
+	// the database and, unless dryRun, writes the keys that differ.
+	Rebuild(ctx context.Context, campaignId int64, dryRun bool) (campaign.RebuildReport, error)
+	// RebuildAll rebuilds every campaign.
+	RebuildAll(ctx context.Context, dryRun bool) ([]campaign.RebuildReport, error)
+}
@@ -0,0 +1,14 @@
+package primary
+
+import (
+	"context"
+	"specommerce/campaignservice/internal/core/domain/campaign"
+)
+
+type ReconciliationService interface {
+	// Reconcile compares the Redis winners of a first_n_customers campaign with
+	// the winners computed in SQL.
+	Reconcile(ctx context.Context, campaignId int64) (campaign.Reconciliation, error)
+	// ReconcileAll reconciles every first_n_customers campaign that has started.
+	ReconcileAll(ctx context.Context) ([]campaign.Reconciliation, error)
+}
@@ -0,0 +1,14 @@
+package primary
+
+import (
+	"context"
+	"specommerce/campaignservice/internal/core/domain/campaign"
+	"specommerce/campaignservice/internal/core/domain/order"
+)
+
+type SimulationService interface {
+	// Simulate replays order events through the rule of a campaign and returns
+	// who would have won. Without events, the orders of the campaign window are
+	// replayed from the orders table.
+	Simulate(ctx context.Context, input campaign.Campaign, events []order.Order) (campaign.Simulation, error)
+}
@@ -0,0 +1,13 @@
+package secondary
+
+import (
+	"context"
+	domain "specommerce/campaignservice/internal/core/domain/campaign"
+)
+
+type AuditRepository interface {
+	// SaveEntries saves the audit entries not saved yet, ignoring the others.
+	SaveEntries(ctx context.Context, entries []domain.AuditEntry) error
+	// GetByCustomer returns the audit trail of a customer in a campaign, in decision order.
+	GetByCustomer(ctx context.Context, campaignId int64, customerId string) ([]domain.AuditEntry, error)
+}
@@ -3,12 +3,41 @@ package secondary
 import (
 	"context"
 	domain "specommerce/campaignservice/internal/core/domain/campaign"
+	"specommerce/campaignservice/internal/core/rules"
+	"time"
 )
 
 type CampaignRepository interface {
 	Create(ctx context.Context, input domain.Campaign) (domain.Campaign, error)
 	Update(ctx context.Context, input domain.Campaign) (domain.Campaign, error)
-	GetIphoneWinner(ctx context.Context, campaign domain.IphoneCampaign) ([]domain.IphoneWinner, error)
-	GetCampaignByType(ctx context.Context, campaignType string) (domain.Campaign, error)
-	SaveWinner(ctx context.Context, campaignId int64, customerId string) error
+	GetById(ctx context.Context, id int64) (domain.Campaign, error)
+	// LockById returns a campaign and locks it until the end of the transaction.
+	LockById(ctx context.Context, id int64) (domain.Campaign, error)
+	GetAll(ctx context.Context) ([]domain.Campaign, error)
+	// GetActiveCampaigns returns the campaigns whose time window contains at and
+	// whose status evaluates orders.
+	GetActiveCampaigns(ctx context.Context, at time.Time) ([]domain.Campaign, error)
+	// GetDueCampaigns returns the SCHEDULED campaigns started at now and the
+	// ACTIVE or PAUSED campaigns ended before now.
+	GetDueCampaigns(ctx context.Context, now time.Time) ([]domain.Campaign, error)
+	// GetIphoneWinner computes the winners of a first_n_customers campaign from the orders.
+	GetIphoneWinner(ctx context.Context, campaign domain.Campaign, policy rules.FirstNCustomersPolicy) ([]domain.IphoneWinner, error)
+	// GetWinners returns the saved winners of a campaign in selection order, with their orders in the campaign.
+	GetWinners(ctx context.Context, campaign domain.Campaign) ([]domain.IphoneWinner, error)
+	// GetWinnerIds returns the customer ids of the saved winners of a campaign, in selection order.
+	GetWinnerIds(ctx context.Context, campaignId int64) ([]string, error)
+	// SaveWinners saves the winners not saved yet, ignoring the others, and returns the saved ones.
+	SaveWinners(ctx context.Context, winners []domain.Winner) ([]domain.Winner, error)
+	// FindWinners returns the saved winners of a campaign with their claims, in selection order.
+	FindWinners(ctx context.Context, campaignId int64) ([]domain.Winner, error)
+	GetWinner(ctx context.Context, campaignId int64, customerId string) (domain.Winner, error)
+	// UpdateClaim saves the claim status, shipping address and claim times of a winner.
+	UpdateClaim(ctx context.Context, winner domain.Winner) (domain.Winner, error)
+	// ExpireClaims moves the NOTIFIED winners whose claim deadline is before now to EXPIRED and returns them.
+	ExpireClaims(ctx context.Context, now time.Time) ([]domain.Winner, error)
+	// GetReassignableCampaignIds returns the campaigns ended before endedBefore with
+	// expired or forfeited prizes not handed to the next eligible customers yet.
+	GetReassignableCampaignIds(ctx context.Context, endedBefore time.Time, limit int) ([]int64, error)
+	// MarkReassigned records that the prizes of expired or forfeited winners were handed on.
+	MarkReassigned(ctx context.Context, campaignId int64, customerIds []string) error
 }
@@ -0,0 +1,23 @@
+package secondary
+
+import (
+	"context"
+	"specommerce/campaignservice/internal/core/domain/draw"
+	"time"
+)
+
+type DrawRepository interface {
+	Create(ctx context.Context, input draw.Draw) (draw.Draw, error)
+	GetByCampaignId(ctx context.Context, campaignId int64) (draw.Draw, error)
+	// LockByCampaignId gets a draw and locks it until the end of the current transaction.
+	LockByCampaignId(ctx context.Context, campaignId int64) (draw.Draw, error)
+	// GetDueCampaignIds returns the ENDED campaigns not drawn yet whose end time is before endedBefore.
+	GetDueCampaignIds(ctx context.Context, endedBefore time.Time, limit int) ([]int64, error)
+	// CreateEntries gives one entry to every customer with a successful order of at least
+	// minOrderAmount between start and end, taken with their first qualifying order.
+	CreateEntries(ctx context.Context, campaignId int64, start time.Time, end time.Time, minOrderAmount float64) error
+	GetEntries(ctx context.Context, campaignId int64) ([]draw.Entry, error)
+	SaveWinners(ctx context.Context, winners []draw.Winner) error
+	GetWinners(ctx context.Context, campaignId int64) ([]draw.Winner, error)
+	MarkDrawn(ctx context.Context, campaignId int64, entryCount int, drawnAt time.Time) error
+}
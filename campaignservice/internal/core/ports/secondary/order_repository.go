@@ -3,9 +3,17 @@ package secondary
 import (
 	"context"
 	"specommerce/campaignservice/internal/core/domain/order"
+	"time"
+
+	"github.com/rs/xid"
 )
 
 // OrderRepository defines the secondary port for order persistence
 type OrderRepository interface {
-	Create(ctx context.Context, order order.Order) (order.Order, error)
+	// Upsert saves an order or updates its status, unless the stored order is more recent.
+	Upsert(ctx context.Context, order order.Order) (order.Order, error)
+	// GetByCreatedAt returns the orders created between start and end, oldest first.
+	GetByCreatedAt(ctx context.Context, start time.Time, end time.Time) ([]order.Order, error)
+	// GetByIds returns the known orders among ids.
+	GetByIds(ctx context.Context, ids []xid.ID) ([]order.Order, error)
 }
@@ -0,0 +1,11 @@
+package secondary
+
+import (
+	"context"
+	domain "specommerce/campaignservice/internal/core/domain/campaign"
+)
+
+type WinnerEventRepository interface {
+	// SendWinnerSelected stages a WinnerSelected event, in the transaction of ctx if any.
+	SendWinnerSelected(ctx context.Context, campaign domain.Campaign, winner domain.Winner) error
+}
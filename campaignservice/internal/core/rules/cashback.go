@@ -0,0 +1,87 @@
+package rules
+
+import (
+	"errors"
+	"specommerce/campaignservice/internal/core/domain/order"
+	"strconv"
+	"time"
+)
+
+const TypeCashback = "cashback"
+
+// CashbackPolicy pays CashbackAmount back to the customers of the first
+// TotalReward successful orders reaching MinOrderAmount, once per customer.
+type CashbackPolicy struct {
+	TotalReward    int64   `json:"total_reward"`
+	MinOrderAmount float64 `json:"min_order_amount"`
+	CashbackAmount float64 `json:"cashback_amount"`
+}
+
+func (p CashbackPolicy) validate() error {
+	if p.TotalReward <= 0 {
+		return errors.New("total_reward must be positive")
+	}
+	if p.MinOrderAmount <= 0 {
+		return errors.New("min_order_amount must be positive")
+	}
+	if p.CashbackAmount <= 0 {
+		return errors.New("cashback_amount must be positive")
+	}
+	if p.CashbackAmount > p.MinOrderAmount {
+		return errors.New("cashback_amount must not exceed min_order_amount")
+	}
+	return nil
+}
+
+// Cashback is the rule of the spend threshold cashback campaign.
+type Cashback struct{}
+
+func (Cashback) Type() string {
+	return TypeCashback
+}
+
+func (Cashback) ValidatePolicy(policy map[string]any) error {
+	_, err := DecodePolicy[CashbackPolicy](policy)
+	return err
+}
+
+func (Cashback) PolicyFields(policy map[string]any) (map[string]string, error) {
+	typed, err := DecodePolicy[CashbackPolicy](policy)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{
+		"total_reward":     strconv.FormatInt(typed.TotalReward, 10),
+		"min_order_amount": formatFloat(typed.MinOrderAmount),
+		"cashback_amount":  formatFloat(typed.CashbackAmount),
+	}, nil
+}
+
+func (Cashback) PendingScript(int64, order.Order) *Script {
+	return nil
+}
+
+// ResultScript grants the cashback to the customer of a qualifying order,
+// unless the customer already got one or the campaign has run out of rewards.
+func (Cashback) ResultScript(campaignId int64, input order.Order, _ time.Time) Script {
+	return Script{
+		Source: cashbackResultScript,
+		Keys:   orderScriptKeys(campaignId, input),
+		Args:   resultArgs(input),
+	}
+}
+
+const cashbackResultScript = orderScriptHeader + skipRewardedFunction + `
+	local policy_cashback_amount = redis.call('HGET', campaign_key, 'policy_cashback_amount')
+
+	if skip_rewarded() then
+		return {has_new_winner, is_campaign_finished}
+	end
+
+	add_winner(winners_key, unsaved_winners_key, audit_key, customer_id, order_id, score,
+		'successful order of ' .. order_total_amount)
+	redis.call('HSET', rewards_key, customer_id, policy_cashback_amount)
+	has_new_winner = true
+	is_campaign_finished = redis.call('SCARD', winners_key) >= policy_total_reward
+	return {has_new_winner, is_campaign_finished}
+`
@@ -0,0 +1,97 @@
+package rules
+
+import (
+	"errors"
+	"specommerce/campaignservice/internal/core/domain/campaign"
+	"specommerce/campaignservice/internal/core/domain/order"
+	"strconv"
+	"time"
+)
+
+const TypeDraw = "draw"
+
+// DrawPolicy draws TotalReward winners at the end of the campaign among the
+// customers with a successful order of at least MinOrderAmount, one entry each.
+type DrawPolicy struct {
+	TotalReward    int64   `json:"total_reward"`
+	MinOrderAmount float64 `json:"min_order_amount"`
+}
+
+func (p DrawPolicy) validate() error {
+	if p.TotalReward <= 0 {
+		return errors.New("total_reward must be positive")
+	}
+	if p.MinOrderAmount < 0 {
+		return errors.New("min_order_amount must not be negative")
+	}
+	return nil
+}
+
+// Draw is the rule of the verifiable lucky draw. Winners are not selected by
+// the scripts but by the draw job once the campaign has ended (see package
+// draw); the result script only keeps the live set of entrants.
+type Draw struct{}
+
+func (Draw) Type() string {
+	return TypeDraw
+}
+
+func (Draw) ValidatePolicy(policy map[string]any) error {
+	_, err := DecodePolicy[DrawPolicy](policy)
+	return err
+}
+
+func (Draw) PolicyFields(policy map[string]any) (map[string]string, error) {
+	typed, err := DecodePolicy[DrawPolicy](policy)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{
+		"total_reward":     strconv.FormatInt(typed.TotalReward, 10),
+		"min_order_amount": formatFloat(typed.MinOrderAmount),
+	}, nil
+}
+
+func (Draw) PendingScript(int64, order.Order) *Script {
+	return nil
+}
+
+// ResultScript adds the customer of a qualifying order to the campaign entrants.
+func (Draw) ResultScript(campaignId int64, input order.Order, _ time.Time) Script {
+	return Script{
+		Source: drawResultScript,
+		Keys:   append(orderScriptKeys(campaignId, input), campaign.EntriesKey(campaignId)),
+		Args:   resultArgs(input),
+	}
+}
+
+// RebuildState rebuilds the entrants from the successful orders of at least
+// policy_min_order_amount, in creation order.
+func (Draw) RebuildState(input campaign.Campaign, orders []order.Order, winners []string, _ time.Time) (campaign.State, error) {
+	policy, err := DecodePolicy[DrawPolicy](input.Policy)
+	if err != nil {
+		return campaign.State{}, err
+	}
+	state := campaign.NewState()
+	state.Winners = append(state.Winners, winners...)
+	state.Entries = []string{}
+	isEntered := map[string]bool{}
+	for _, o := range orders {
+		if o.Status != order.OrderStatusSuccess || o.TotalAmount < policy.MinOrderAmount || isEntered[o.CustomerId] {
+			continue
+		}
+		isEntered[o.CustomerId] = true
+		state.Entries = append(state.Entries, o.CustomerId)
+	}
+	return state, nil
+}
+
+const drawResultScript = orderScriptHeader + `
+	local entries_key = KEYS[8]
+	if redis.call('SADD', entries_key, customer_id) == 1 then
+		audit(audit_key, order_id, customer_id, score, 'ENTERED', 'customer entered the draw')
+	else
+		audit(audit_key, order_id, customer_id, score, 'ALREADY_ENTERED', 'customer already entered the draw')
+	end
+	return {has_new_winner, is_campaign_finished}
+`
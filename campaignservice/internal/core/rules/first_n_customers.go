@@ -0,0 +1,615 @@
+package rules
+
+import (
+	"errors"
+	"specommerce/campaignservice/internal/core/domain/campaign"
+	"specommerce/campaignservice/internal/core/domain/order"
+	"strconv"
+	"time"
+)
+
+const TypeFirstNCustomers = "first_n_customers"
+
+// FirstNCustomersPolicy gives away TotalReward prizes to the first
+// MaxTrackedOrders customers with a successful order, provided their largest
+// order reaches MinOrderAmount. Orders are processed in creation order for fairness.
+type FirstNCustomersPolicy struct {
+	TotalReward      int64 `json:"total_reward"`
+	MinOrderAmount   int64 `json:"min_order_amount"`
+	MaxTrackedOrders int64 `json:"max_tracked_orders"`
+}
+
+func (p FirstNCustomersPolicy) validate() error {
+	if p.TotalReward <= 0 {
+		return errors.New("total_reward must be positive")
+	}
+	if p.MinOrderAmount < 0 {
+		return errors.New("min_order_amount must not be negative")
+	}
+	if p.MaxTrackedOrders < p.TotalReward {
+		return errors.New("max_tracked_orders must be at least total_reward")
+	}
+	return nil
+}
+
+// FirstNCustomers is the rule of the first come, first served giveaway.
+//
+// Two-Phase Processing Flow:
+// Phase 1: PendingScript - Validates and adds new orders to sorted set in chronological order
+// Phase 2: ResultScript - Processes order completion and selects winners atomically
+type FirstNCustomers struct{}
+
+func (FirstNCustomers) Type() string {
+	return TypeFirstNCustomers
+}
+
+func (FirstNCustomers) ValidatePolicy(policy map[string]any) error {
+	_, err := DecodePolicy[FirstNCustomersPolicy](policy)
+	return err
+}
+
+func (FirstNCustomers) PolicyFields(policy map[string]any) (map[string]string, error) {
+	typed, err := DecodePolicy[FirstNCustomersPolicy](policy)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{
+		"total_reward":       strconv.FormatInt(typed.TotalReward, 10),
+		"min_order_amount":   strconv.FormatInt(typed.MinOrderAmount, 10),
+		"max_tracked_orders": strconv.FormatInt(typed.MaxTrackedOrders, 10),
+	}, nil
+}
+
+// PendingScript adds a new order to the campaign pending orders sorted set.
+//
+// The script:
+// 1. Validates order creation time is within campaign time window (start_time_millisecond to end_time_millisecond)
+// 2. Early exits if campaign has reached maximum winners (policy_total_reward from campaign config)
+// 3. Early exits if the result of the order was received first, which ranked it already
+// 4. Stores transaction data with PENDING status in Redis (transactions:{order_id})
+// 5. Adds order to pending_orders sorted set with score = (created_at - start_time_millisecond)
+//   - Score ensures chronological processing (earliest orders processed first)
+//   - Relative scoring from campaign start time for consistent ordering
+//   - An order created before the last ranked order is dropped from the ranking instead
+//
+// Orders added here will be processed when their status changes from PENDING to
+// SUCCESS/FAILED, maintaining order creation sequence for fair winner selection.
+// All time values use millisecond precision for accurate chronological ordering.
+// Returns {is_campaign_finished, arrival}.
+func (FirstNCustomers) PendingScript(campaignId int64, input order.Order) *Script {
+	orderId := input.Id.String()
+	return &Script{
+		Source: firstNCustomersPendingScript,
+		Keys: []string{
+			campaign.InfoKey(campaignId),
+			campaign.WinnersKey(campaignId),
+			campaign.PendingOrdersKey(campaignId),
+			campaign.TransactionKey(campaignId, orderId),
+			campaign.AuditKey(campaignId),
+			campaign.RankedScoreKey(campaignId),
+		},
+		Args: []any{input.CustomerId, input.CreatedAt.UnixMilli(), orderId},
+	}
+}
+
+// ResultScript processes order completion for winner selection.
+//
+// The script:
+// 1. Early exits if campaign has reached maximum winners (policy_total_reward)
+// 2. Ranks an order whose PENDING event was never received at its creation
+// time, unless it was created before the last ranked order, which drops it from the ranking
+// 3. Stores current transaction data (customer_id, status) in Redis
+// 4. Updates customer's maximum transaction amount for successful orders
+// 5. Checks if current order qualifies customer as immediate winner:
+//   - Order status is SUCCESS
+//   - Customer not already a winner
+//   - Customer is in eligible set
+//   - Customer's max amount >= minimum order amount policy
+//
+// 6. Recursively processes pending orders sorted set (oldest first):
+//   - Stops immediately if encountering order still in PENDING status
+//   - Stops at the first order created after the watermark, so that late events
+//     can still be ranked before it
+//   - Skips failed orders and orders from existing winners (removes from sorted set and continues)
+//   - Adds qualifying customers to eligible set (respects max tracked limit)
+//   - Promotes eligible customers to winners if they meet amount threshold
+//   - Continues until sorted set empty or winner quota reached
+//
+// The transaction and customer keys of the popped orders are built in the script
+// from the campaign key prefix. They share the campaign hash tag, so they live in
+// the same Redis Cluster slot as the declared keys.
+func (FirstNCustomers) ResultScript(campaignId int64, input order.Order, watermark time.Time) Script {
+	return Script{
+		Source: firstNCustomersResultScript,
+		Keys: []string{
+			campaign.InfoKey(campaignId),
+			campaign.WinnersKey(campaignId),
+			campaign.EligibleKey(campaignId),
+			campaign.PendingOrdersKey(campaignId),
+			campaign.TransactionKey(campaignId, input.Id.String()),
+			campaign.CustomerKey(campaignId, input.CustomerId),
+			campaign.UnsavedWinnersKey(campaignId),
+			campaign.AuditKey(campaignId),
+			campaign.RankedScoreKey(campaignId),
+		},
+		Args: append(resultArgs(input), campaign.KeyPrefix(campaignId), watermark.UnixMilli()),
+	}
+}
+
+// FlushScript ranks the pending orders created before the watermark, as the
+// result script does after evaluating an order.
+func (FirstNCustomers) FlushScript(campaignId int64, watermark time.Time) Script {
+	return Script{
+		Source: firstNCustomersFlushScript,
+		Keys: []string{
+			campaign.InfoKey(campaignId),
+			campaign.WinnersKey(campaignId),
+			campaign.EligibleKey(campaignId),
+			campaign.PendingOrdersKey(campaignId),
+			campaign.UnsavedWinnersKey(campaignId),
+			campaign.AuditKey(campaignId),
+			campaign.RankedScoreKey(campaignId),
+		},
+		Args: []any{campaign.KeyPrefix(campaignId), watermark.UnixMilli()},
+	}
+}
+
+// StalePendingScript lists the orders of the pending orders sorted set created
+// before cutoff whose transaction is still PENDING.
+func (FirstNCustomers) StalePendingScript(campaignId int64, cutoff time.Time, count int64) Script {
+	return Script{
+		Source: firstNCustomersStalePendingScript,
+		Keys: []string{
+			campaign.InfoKey(campaignId),
+			campaign.PendingOrdersKey(campaignId),
+		},
+		Args: []any{campaign.KeyPrefix(campaignId), cutoff.UnixMilli(), count},
+	}
+}
+
+// ExpireScript settles a stale pending order, then ranks the pending orders as
+// the result script does.
+//
+// The script:
+// 1. Exits if the order is no longer pending or its result came in the meantime
+// 2. Stores the status of input in the transaction data, and updates the
+// customer's maximum transaction amount for a successful order
+// 3. Records the expiry in the audit stream with the reason
+// 4. Checks if the order qualifies the customer as immediate winner, as the result script does
+// 5. Recursively processes the pending orders sorted set, which the order no longer blocks
+//
+// A result received after the expiry only updates the transaction data and the
+// customer's maximum transaction amount, as the order is ranked already.
+func (FirstNCustomers) ExpireScript(campaignId int64, input order.Order, reason string, watermark time.Time) Script {
+	return Script{
+		Source: firstNCustomersExpireScript,
+		Keys: []string{
+			campaign.InfoKey(campaignId),
+			campaign.WinnersKey(campaignId),
+			campaign.EligibleKey(campaignId),
+			campaign.PendingOrdersKey(campaignId),
+			campaign.TransactionKey(campaignId, input.Id.String()),
+			campaign.CustomerKey(campaignId, input.CustomerId),
+			campaign.UnsavedWinnersKey(campaignId),
+			campaign.AuditKey(campaignId),
+			campaign.RankedScoreKey(campaignId),
+		},
+		Args: append(resultArgs(input), campaign.KeyPrefix(campaignId), watermark.UnixMilli(), reason),
+	}
+}
+
+// RebuildState replays the orders as the scripts process them: orders are
+// popped in creation order until the first one still PENDING or created after
+// the watermark, which stays in the pending orders sorted set with all the
+// orders created after it. Popped customers become eligible up to
+// policy_max_tracked_orders and win once their largest successful order
+// reaches policy_min_order_amount. The score of the last popped order is the
+// ranked score.
+//
+// The largest order of a customer is taken over the whole window, as the
+// result script compares it again when a later order of the customer succeeds.
+// The orders dropped as late by the scripts are ranked at their creation time,
+// as the database does not keep when their events arrived.
+func (FirstNCustomers) RebuildState(input campaign.Campaign, orders []order.Order, winners []string, watermark time.Time) (campaign.State, error) {
+	policy, err := DecodePolicy[FirstNCustomersPolicy](input.Policy)
+	if err != nil {
+		return campaign.State{}, err
+	}
+	state := campaign.NewState()
+	isWinner := map[string]bool{}
+	isEligible := map[string]bool{}
+	maxTotalAmounts := map[string]float64{}
+	for _, customerId := range winners {
+		if !isWinner[customerId] {
+			isWinner[customerId] = true
+			state.Winners = append(state.Winners, customerId)
+		}
+	}
+	for _, o := range orders {
+		if o.Status == order.OrderStatusSuccess && o.TotalAmount > maxTotalAmounts[o.CustomerId] {
+			maxTotalAmounts[o.CustomerId] = o.TotalAmount
+		}
+	}
+
+	start := input.StartTime.UnixMilli()
+	horizon := watermark.UnixMilli() - start
+	isBlocked := false
+	for _, o := range orders {
+		if int64(len(state.Winners)) >= policy.TotalReward {
+			break
+		}
+		score := o.CreatedAt.UnixMilli() - start
+		if isBlocked || o.Status == order.OrderStatusPending || score > horizon {
+			isBlocked = true
+			orderId := o.Id.String()
+			state.PendingOrders[orderId] = float64(score)
+			state.Transactions[orderId] = map[string]string{
+				"customer_id": o.CustomerId,
+				"status":      o.Status.String(),
+			}
+			continue
+		}
+		state.RankedScore = strconv.FormatInt(score, 10)
+		if o.Status == order.OrderStatusFailed || isWinner[o.CustomerId] {
+			continue
+		}
+		if !isEligible[o.CustomerId] {
+			if int64(len(state.Eligible)) >= policy.MaxTrackedOrders {
+				continue
+			}
+			isEligible[o.CustomerId] = true
+			state.Eligible = append(state.Eligible, o.CustomerId)
+		}
+		if maxTotalAmounts[o.CustomerId] >= float64(policy.MinOrderAmount) {
+			isWinner[o.CustomerId] = true
+			state.Winners = append(state.Winners, o.CustomerId)
+		}
+	}
+
+	for customerId, amount := range maxTotalAmounts {
+		state.Customers[customerId] = map[string]string{"max_total_amount": formatFloat(amount)}
+	}
+	return state, nil
+}
+
+// RunnersUp returns the customers who qualify like the winners but were left
+// out by the reward limit, in the order they qualified: customers among the
+// first policy_max_tracked_orders by first successful order whose largest
+// successful order reaches policy_min_order_amount. The order of a runner-up is
+// its first successful order reaching the minimum amount.
+func (FirstNCustomers) RunnersUp(input campaign.Campaign, orders []order.Order, exclude map[string]bool, count int) ([]campaign.Winner, error) {
+	policy, err := DecodePolicy[FirstNCustomersPolicy](input.Policy)
+	if err != nil {
+		return nil, err
+	}
+	tracked := make([]string, 0)
+	isTracked := map[string]bool{}
+	winningOrders := map[string]string{}
+	for _, o := range orders {
+		if o.Status != order.OrderStatusSuccess {
+			continue
+		}
+		if !isTracked[o.CustomerId] {
+			if int64(len(tracked)) >= policy.MaxTrackedOrders {
+				continue
+			}
+			isTracked[o.CustomerId] = true
+			tracked = append(tracked, o.CustomerId)
+		}
+		if _, ok := winningOrders[o.CustomerId]; !ok && o.TotalAmount >= float64(policy.MinOrderAmount) {
+			winningOrders[o.CustomerId] = o.Id.String()
+		}
+	}
+
+	runnersUp := make([]campaign.Winner, 0, count)
+	for _, customerId := range tracked {
+		if len(runnersUp) >= count {
+			break
+		}
+		orderId, ok := winningOrders[customerId]
+		if !ok || exclude[customerId] {
+			continue
+		}
+		runnersUp = append(runnersUp, campaign.Winner{
+			CampaignId: input.Id,
+			CustomerId: customerId,
+			OrderId:    orderId,
+		})
+	}
+	return runnersUp, nil
+}
+
+const firstNCustomersPendingScript = auditFunction + `
+		local campaign_key = KEYS[1]
+		local winners_key = KEYS[2]
+		local pending_orders_key = KEYS[3]
+		local transaction_key = KEYS[4]
+		local audit_key = KEYS[5]
+		local ranked_score_key = KEYS[6]
+		local customer_id = ARGV[1]
+		local created_at = tonumber(ARGV[2])
+		local order_id = ARGV[3]
+		local start_time_millisecond = tonumber(redis.call('HGET', campaign_key, 'start_time_millisecond'))
+		local end_time_millisecond = tonumber(redis.call('HGET', campaign_key, 'end_time_millisecond'))
+		local policy_total_reward = tonumber(redis.call('HGET',campaign_key, 'policy_total_reward')) or 0
+		local is_campaign_finished = false
+
+		if not start_time_millisecond or not end_time_millisecond then
+			return {is_campaign_finished, 0}
+		end
+
+		if created_at < start_time_millisecond or created_at > end_time_millisecond then
+			return {is_campaign_finished, 0}
+		end
+		
+		local winner_count = redis.call('SCARD', winners_key)
+		local score = created_at - start_time_millisecond
+
+		if winner_count >= policy_total_reward then
+			is_campaign_finished = true
+			audit(audit_key, order_id, customer_id, score, 'REWARDS_EXHAUSTED',
+				'all ' .. policy_total_reward .. ' rewards are given')
+			return {is_campaign_finished, 0}
+		end
+
+		-- The result of the order came first, or the event is redelivered
+		if redis.call('EXISTS', transaction_key) == 1 then
+			return {is_campaign_finished, 0}
+		end
+
+		redis.call('HMSET', transaction_key, 'customer_id', customer_id, 'status', 'PENDING')
+
+		local ranked_score = tonumber(redis.call('GET', ranked_score_key))
+		if ranked_score and score < ranked_score then
+			audit(audit_key, order_id, customer_id, score, 'DROPPED_LATE',
+				'order received after the orders created after it were ranked')
+			return {is_campaign_finished, 2}
+		end
+		
+		redis.call('ZADD', pending_orders_key, score, order_id)
+		audit(audit_key, order_id, customer_id, score, 'ADMITTED', 'order admitted to the pending orders')
+		return {is_campaign_finished, 0}
+	`
+
+// firstNCustomersRankFunction defines recursive_pop(), which ranks the pending
+// orders in creation order until the first one still PENDING or created after
+// the horizon, and records the score of the last ranked order.
+const firstNCustomersRankFunction = `
+		local function recursive_pop() 
+			local winners_count = redis.call('SCARD', winners_key)
+            if winners_count >= policy_total_reward then
+				is_campaign_finished = true
+                return
+			end
+
+			local elements = redis.call('ZRANGE', pending_orders_key, 0, 0, 'WITHSCORES')
+			
+			if #elements == 0 then
+				return
+			end
+			
+			local current_order_id = elements[1]
+			local current_score = elements[2]
+			if tonumber(current_score) > horizon then
+				return
+			end
+			local current_order_id_key = transaction_key .. ':' .. current_order_id
+			local current_customer_id = redis.call('HGET', current_order_id_key, 'customer_id')
+			local current_customer_id_key = customer_key .. ':' .. current_customer_id
+			local current_status = redis.call('HGET', current_order_id_key, 'status')
+            if current_status == 'PENDING' then
+				return
+			end
+
+			redis.call('ZREM', pending_orders_key, current_order_id)
+			redis.call('SET', ranked_score_key, current_score)
+
+			if current_status == 'FAILED' then
+				audit(audit_key, current_order_id, current_customer_id, current_score, 'SKIPPED_FAILED', 'order failed')
+				return recursive_pop()
+			end
+
+            if redis.call('SISMEMBER', winners_key, current_customer_id) == 1 then
+				audit(audit_key, current_order_id, current_customer_id, current_score, 'SKIPPED_WINNER', 'customer already won')
+				return recursive_pop()  
+			end
+
+			if redis.call('SISMEMBER', eligible_key, current_customer_id) == 0 and redis.call('SCARD', eligible_key) == policy_max_tracked_orders then
+				audit(audit_key, current_order_id, current_customer_id, current_score, 'ELIGIBLE_FULL',
+					'the first ' .. policy_max_tracked_orders .. ' customers are already tracked')
+				return recursive_pop()
+			end
+
+			redis.call('SADD', eligible_key, current_customer_id)
+
+			local current_max_total_amount = tonumber(redis.call('HGET', current_customer_id_key, 'max_total_amount')) or 0
+
+
+			if current_max_total_amount >= policy_min_order_amount then
+				add_winner(winners_key, unsaved_winners_key, audit_key, current_customer_id, current_order_id, current_score,
+					'largest order of ' .. current_max_total_amount .. ' reaches the minimum of ' .. policy_min_order_amount)
+				has_new_winner = true
+			else
+				audit(audit_key, current_order_id, current_customer_id, current_score, 'ELIGIBLE',
+					'largest order of ' .. current_max_total_amount .. ' is below the minimum of ' .. policy_min_order_amount)
+			end
+
+			return recursive_pop()
+		end
+`
+
+const firstNCustomersResultScript = addWinnerFunction + `
+		local campaign_key = KEYS[1]
+		local winners_key = KEYS[2]
+		local eligible_key = KEYS[3]
+		local pending_orders_key = KEYS[4]
+		local current_order_id_key = KEYS[5]
+		local current_customer_id_key = KEYS[6]
+		local unsaved_winners_key = KEYS[7]
+		local audit_key = KEYS[8]
+		local ranked_score_key = KEYS[9]
+		local customer_id = ARGV[1]
+		local order_id = ARGV[2]
+		local order_status = ARGV[3]
+		local order_total_amount = tonumber(ARGV[4])
+		local created_at = tonumber(ARGV[5])
+		local key_prefix = ARGV[6]
+		local transaction_key = key_prefix .. 'transactions'
+		local customer_key = key_prefix .. 'customers'
+		local start_time_millisecond = tonumber(redis.call('HGET', campaign_key, 'start_time_millisecond')) or 0
+		local horizon = tonumber(ARGV[7]) - start_time_millisecond
+		local policy_total_reward = tonumber(redis.call('HGET',campaign_key, 'policy_total_reward')) or 0
+		local policy_min_order_amount = tonumber(redis.call('HGET', campaign_key, 'policy_min_order_amount')) or 0
+		local policy_max_tracked_orders = tonumber(redis.call('HGET', campaign_key, 'policy_max_tracked_orders')) or 0
+		local has_new_winner = false
+		local is_campaign_finished = false
+		local arrival = 0
+
+		-- The score of an order not admitted to the pending orders is unknown
+		local score = redis.call('ZSCORE', pending_orders_key, order_id)
+
+		local winners_count = redis.call('SCARD', winners_key)
+		if winners_count >= policy_total_reward then
+			is_campaign_finished = true
+			audit(audit_key, order_id, customer_id, score or '', 'REWARDS_EXHAUSTED',
+				'all ' .. policy_total_reward .. ' rewards are given')
+			return {has_new_winner, is_campaign_finished, arrival}
+		end
+
+		-- The PENDING event of the order was never received: rank the order at its
+		-- creation time, unless orders created after it were ranked already
+		if not score and redis.call('EXISTS', current_order_id_key) == 0 then
+			local created_score = created_at - start_time_millisecond
+			local ranked_score = tonumber(redis.call('GET', ranked_score_key))
+			if ranked_score and created_score < ranked_score then
+				arrival = 2
+				audit(audit_key, order_id, customer_id, created_score, 'DROPPED_LATE',
+					'result received after the orders created after it were ranked, without a PENDING event')
+			else
+				arrival = 1
+				score = created_score
+				redis.call('ZADD', pending_orders_key, score, order_id)
+				audit(audit_key, order_id, customer_id, score, 'REORDERED',
+					'result received without a PENDING event, order ranked at its creation time')
+			end
+		end
+		score = score or ''
+
+        redis.call('HMSET', current_order_id_key, 'customer_id', customer_id, 'status', order_status)
+        local current_max_total_amount = tonumber(redis.call('HGET', current_customer_id_key, 'max_total_amount')) or 0
+        if order_status == 'SUCCESS' then
+              current_max_total_amount = math.max(current_max_total_amount, order_total_amount)
+			  redis.call('HSET', current_customer_id_key, 'max_total_amount', current_max_total_amount)
+  		end
+        
+		if order_status == 'SUCCESS' and redis.call('SISMEMBER', winners_key, customer_id) == 0 and redis.call('SISMEMBER', eligible_key, customer_id) == 1 and current_max_total_amount >= policy_min_order_amount then
+			add_winner(winners_key, unsaved_winners_key, audit_key, customer_id, order_id, score,
+				'eligible customer with a largest order of ' .. current_max_total_amount .. ' reaching the minimum of ' .. policy_min_order_amount)
+			has_new_winner = true
+		end
+` + firstNCustomersRankFunction + `
+		recursive_pop()  -- Start the recursion
+		return {has_new_winner, is_campaign_finished, arrival}
+	`
+
+const firstNCustomersFlushScript = addWinnerFunction + `
+		local campaign_key = KEYS[1]
+		local winners_key = KEYS[2]
+		local eligible_key = KEYS[3]
+		local pending_orders_key = KEYS[4]
+		local unsaved_winners_key = KEYS[5]
+		local audit_key = KEYS[6]
+		local ranked_score_key = KEYS[7]
+		local key_prefix = ARGV[1]
+		local transaction_key = key_prefix .. 'transactions'
+		local customer_key = key_prefix .. 'customers'
+		local start_time_millisecond = tonumber(redis.call('HGET', campaign_key, 'start_time_millisecond'))
+		local policy_total_reward = tonumber(redis.call('HGET',campaign_key, 'policy_total_reward')) or 0
+		local policy_min_order_amount = tonumber(redis.call('HGET', campaign_key, 'policy_min_order_amount')) or 0
+		local policy_max_tracked_orders = tonumber(redis.call('HGET', campaign_key, 'policy_max_tracked_orders')) or 0
+		local has_new_winner = false
+		local is_campaign_finished = false
+
+		if not start_time_millisecond then
+			return {has_new_winner, is_campaign_finished}
+		end
+		local horizon = tonumber(ARGV[2]) - start_time_millisecond
+` + firstNCustomersRankFunction + `
+		recursive_pop()  -- Start the recursion
+		return {has_new_winner, is_campaign_finished}
+	`
+
+const firstNCustomersStalePendingScript = `
+		local campaign_key = KEYS[1]
+		local pending_orders_key = KEYS[2]
+		local transaction_key = ARGV[1] .. 'transactions'
+		local start_time_millisecond = tonumber(redis.call('HGET', campaign_key, 'start_time_millisecond'))
+		local count = tonumber(ARGV[3])
+		local stale = {}
+
+		if not start_time_millisecond then
+			return stale
+		end
+		local cutoff = tonumber(ARGV[2]) - start_time_millisecond
+		local order_ids = redis.call('ZRANGEBYSCORE', pending_orders_key, '-inf', '(' .. cutoff)
+		for _, order_id in ipairs(order_ids) do
+			if #stale >= 2 * count then
+				break
+			end
+			local current_order_id_key = transaction_key .. ':' .. order_id
+			if redis.call('HGET', current_order_id_key, 'status') == 'PENDING' then
+				table.insert(stale, order_id)
+				table.insert(stale, redis.call('HGET', current_order_id_key, 'customer_id'))
+			end
+		end
+		return stale
+	`
+
+const firstNCustomersExpireScript = addWinnerFunction + `
+		local campaign_key = KEYS[1]
+		local winners_key = KEYS[2]
+		local eligible_key = KEYS[3]
+		local pending_orders_key = KEYS[4]
+		local current_order_id_key = KEYS[5]
+		local current_customer_id_key = KEYS[6]
+		local unsaved_winners_key = KEYS[7]
+		local audit_key = KEYS[8]
+		local ranked_score_key = KEYS[9]
+		local customer_id = ARGV[1]
+		local order_id = ARGV[2]
+		local order_status = ARGV[3]
+		local order_total_amount = tonumber(ARGV[4])
+		local key_prefix = ARGV[6]
+		local reason = ARGV[8]
+		local transaction_key = key_prefix .. 'transactions'
+		local customer_key = key_prefix .. 'customers'
+		local start_time_millisecond = tonumber(redis.call('HGET', campaign_key, 'start_time_millisecond')) or 0
+		local horizon = tonumber(ARGV[7]) - start_time_millisecond
+		local policy_total_reward = tonumber(redis.call('HGET',campaign_key, 'policy_total_reward')) or 0
+		local policy_min_order_amount = tonumber(redis.call('HGET', campaign_key, 'policy_min_order_amount')) or 0
+		local policy_max_tracked_orders = tonumber(redis.call('HGET', campaign_key, 'policy_max_tracked_orders')) or 0
+		local has_new_winner = false
+		local is_campaign_finished = false
+
+		local score = redis.call('ZSCORE', pending_orders_key, order_id)
+		if not score or redis.call('HGET', current_order_id_key, 'status') ~= 'PENDING' then
+			return {has_new_winner, is_campaign_finished, false}
+		end
+
+		redis.call('HSET', current_order_id_key, 'status', order_status)
+		local current_max_total_amount = tonumber(redis.call('HGET', current_customer_id_key, 'max_total_amount')) or 0
+		if order_status == 'SUCCESS' then
+			current_max_total_amount = math.max(current_max_total_amount, order_total_amount)
+			redis.call('HSET', current_customer_id_key, 'max_total_amount', current_max_total_amount)
+		end
+		audit(audit_key, order_id, customer_id, score, 'EXPIRED', reason)
+
+		if order_status == 'SUCCESS' and redis.call('SCARD', winners_key) < policy_total_reward and redis.call('SISMEMBER', winners_key, customer_id) == 0 and redis.call('SISMEMBER', eligible_key, customer_id) == 1 and current_max_total_amount >= policy_min_order_amount then
+			add_winner(winners_key, unsaved_winners_key, audit_key, customer_id, order_id, score,
+				'eligible customer with a largest order of ' .. current_max_total_amount .. ' reaching the minimum of ' .. policy_min_order_amount)
+			has_new_winner = true
+		end
+` + firstNCustomersRankFunction + `
+		recursive_pop()  -- Start the recursion
+		return {has_new_winner, is_campaign_finished, true}
+	`
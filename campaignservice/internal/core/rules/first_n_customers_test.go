@@ -0,0 +1,40 @@
+package rules
+
+import (
+	"specommerce/campaignservice/internal/core/domain/campaign"
+	"specommerce/campaignservice/internal/core/domain/order"
+	"testing"
+	"time"
+
+	"github.com/rs/xid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFirstNCustomersRebuildState_StopsAtWatermark(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	input := campaign.Campaign{
+		Id:        1,
+		Type:      TypeFirstNCustomers,
+		Policy:    map[string]any{"total_reward": 2, "min_order_amount": 100, "max_tracked_orders": 5},
+		StartTime: start,
+		EndTime:   start.Add(time.Hour),
+	}
+	newOrder := func(customerId string, status order.OrderStatus, createdAt time.Duration) order.Order {
+		return order.Order{Id: xid.New(), CustomerId: customerId, TotalAmount: 150, Status: status, CreatedAt: start.Add(createdAt)}
+	}
+	orders := []order.Order{
+		newOrder("alice", order.OrderStatusFailed, time.Second),
+		newOrder("bob", order.OrderStatusSuccess, 2*time.Second),
+		// created after the watermark, so its late events can still be ranked before it
+		newOrder("carol", order.OrderStatusSuccess, 10*time.Second),
+	}
+
+	state, err := FirstNCustomers{}.RebuildState(input, orders, nil, start.Add(5*time.Second))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"bob"}, state.Winners)
+	assert.Equal(t, []string{"bob"}, state.Eligible)
+	assert.Equal(t, "2000", state.RankedScore)
+	assert.Equal(t, map[string]float64{orders[2].Id.String(): 10000}, state.PendingOrders)
+}
@@ -0,0 +1,110 @@
+package rules
+
+import (
+	"errors"
+	"specommerce/campaignservice/internal/core/domain/order"
+	"strconv"
+	"time"
+)
+
+const TypeLuckyDraw = "lucky_draw"
+
+// SeedField is the field of the campaign info hash holding the committed draw
+// seed of a lucky draw campaign. The seed is kept out of the policy, which is
+// returned with the campaign before it runs.
+const SeedField = "seed"
+
+// CommitsSeed reports whether the campaigns of a type are decided with a random
+// seed committed in a draw when the campaign is created (see package draw).
+func CommitsSeed(campaignType string) bool {
+	return campaignType == TypeDraw || campaignType == TypeLuckyDraw
+}
+
+// LuckyDrawPolicy lets every successful order reaching MinOrderAmount win a
+// prize with WinProbability, until TotalReward prizes are won. Each customer
+// wins at most once.
+type LuckyDrawPolicy struct {
+	TotalReward    int64   `json:"total_reward"`
+	MinOrderAmount float64 `json:"min_order_amount"`
+	WinProbability float64 `json:"win_probability"`
+}
+
+func (p LuckyDrawPolicy) validate() error {
+	if p.TotalReward <= 0 {
+		return errors.New("total_reward must be positive")
+	}
+	if p.MinOrderAmount < 0 {
+		return errors.New("min_order_amount must not be negative")
+	}
+	if p.WinProbability <= 0 || p.WinProbability > 1 {
+		return errors.New("win_probability must be between 0 and 1")
+	}
+	return nil
+}
+
+// LuckyDraw is the rule of the instant win lucky draw. The draw of an order is
+// derived from the SHA-1 of the campaign seed and the order id (see draw.Roll),
+// so it is reproducible and does not depend on the processing order or on the
+// instance evaluating it. The seed is committed in the draw of the campaign when
+// it is created, and only revealed once the campaign has ended.
+type LuckyDraw struct{}
+
+func (LuckyDraw) Type() string {
+	return TypeLuckyDraw
+}
+
+func (LuckyDraw) ValidatePolicy(policy map[string]any) error {
+	_, err := DecodePolicy[LuckyDrawPolicy](policy)
+	return err
+}
+
+func (LuckyDraw) PolicyFields(policy map[string]any) (map[string]string, error) {
+	typed, err := DecodePolicy[LuckyDrawPolicy](policy)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{
+		"total_reward":     strconv.FormatInt(typed.TotalReward, 10),
+		"min_order_amount": formatFloat(typed.MinOrderAmount),
+		"win_probability":  formatFloat(typed.WinProbability),
+	}, nil
+}
+
+func (LuckyDraw) PendingScript(int64, order.Order) *Script {
+	return nil
+}
+
+// ResultScript draws a qualifying order: the first 32 bits of
+// sha1(seed:order_id) scaled to [0, 1) must be lower than the win probability.
+// The seed is read from the SeedField of the info hash.
+func (LuckyDraw) ResultScript(campaignId int64, input order.Order, _ time.Time) Script {
+	return Script{
+		Source: luckyDrawResultScript,
+		Keys:   orderScriptKeys(campaignId, input),
+		Args:   resultArgs(input),
+	}
+}
+
+const luckyDrawResultScript = orderScriptHeader + skipRewardedFunction + `
+	local policy_win_probability = tonumber(redis.call('HGET', campaign_key, 'policy_win_probability')) or 0
+	local seed = redis.call('HGET', campaign_key, 'seed') or ''
+
+	if skip_rewarded() then
+		return {has_new_winner, is_campaign_finished}
+	end
+
+	local roll = tonumber(string.sub(redis.sha1hex(seed .. ':' .. order_id), 1, 8), 16) / 4294967296
+	redis.call('HSET', transaction_key, 'roll', tostring(roll))
+	if roll >= policy_win_probability then
+		audit(audit_key, order_id, customer_id, score, 'NOT_DRAWN',
+			'roll ' .. roll .. ' is not below the win probability of ' .. policy_win_probability)
+		return {has_new_winner, is_campaign_finished}
+	end
+
+	add_winner(winners_key, unsaved_winners_key, audit_key, customer_id, order_id, score,
+		'roll ' .. roll .. ' is below the win probability of ' .. policy_win_probability)
+	redis.call('HSET', rewards_key, customer_id, 1)
+	has_new_winner = true
+	is_campaign_finished = redis.call('SCARD', winners_key) >= policy_total_reward
+	return {has_new_winner, is_campaign_finished}
+`
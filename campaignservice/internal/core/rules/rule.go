@@ -0,0 +1,397 @@
+// Package rules implements the campaign types supported by the campaign service.
+//
+// Every campaign type is a CampaignRule. A rule owns the typed schema of the
+// Campaign.Policy JSON and the atomic Lua scripts that evaluate orders against
+// it. The scripts of a campaign only touch keys of the campaign's own Redis
+// keyspace (see campaign.KeyPrefix) and read the policy from the campaign info
+// hash, where it is cached with a "policy_" prefix.
+//
+// Result scripts return {has_new_winner, is_campaign_finished}, followed by the
+// arrival of the order event for the rules that rank orders (see Arrival). Every winner
+// they select is also queued in the campaign unsaved winners list with its
+// selection position and triggering order, to be saved in the winners table.
+//
+// Every decision of a script on an order is appended to the campaign audit
+// stream with the order score and the reason (see campaign.Decision), so the
+// winner set can be explained to a customer afterwards.
+package rules
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"specommerce/campaignservice/internal/core/domain/campaign"
+	"specommerce/campaignservice/internal/core/domain/order"
+	"strconv"
+	"time"
+)
+
+var (
+	ErrUnknownCampaignType = errors.New("unknown campaign type")
+	ErrInvalidPolicy       = errors.New("invalid campaign policy")
+)
+
+// PolicyFieldPrefix prefixes the policy fields in the campaign info hash.
+const PolicyFieldPrefix = "policy_"
+
+// Arrival is how an order event fits in the ranking of the orders of a
+// campaign, as reported by the scripts of the rules that rank orders.
+type Arrival int64
+
+const (
+	// ArrivalInOrder is an event ranked as expected.
+	ArrivalInOrder Arrival = iota
+	// ArrivalReordered is a result event for an order whose PENDING event was
+	// never seen, ranked at its creation time.
+	ArrivalReordered
+	// ArrivalDropped is an event for an order created before the last ranked
+	// order, which can no longer be ranked fairly and is left out of the ranking.
+	ArrivalDropped
+)
+
+// Positions of the arrival in the results of the pending and result scripts.
+const (
+	PendingArrivalIndex = 1
+	ResultArrivalIndex  = 2
+)
+
+// ArrivalOf returns the arrival at position index of a script result, or
+// ArrivalInOrder for the rules that do not report it.
+func ArrivalOf(result interface{}, index int) Arrival {
+	values, ok := result.([]interface{})
+	if !ok || len(values) <= index {
+		return ArrivalInOrder
+	}
+	arrival, ok := values[index].(int64)
+	if !ok {
+		return ArrivalInOrder
+	}
+	return Arrival(arrival)
+}
+
+// Script is a Lua script ready to be run with its keys and arguments.
+type Script struct {
+	Source string
+	Keys   []string
+	Args   []any
+}
+
+// StateRebuilder is implemented by the rules whose Redis state depends on the
+// order history, beyond the info hash and the winners set.
+type StateRebuilder interface {
+	// RebuildState replays the orders of the campaign window, oldest first, on
+	// top of the saved winners. The orders created after the watermark are not
+	// ranked yet, as the live scripts wait for their late events.
+	RebuildState(input campaign.Campaign, orders []order.Order, winners []string, watermark time.Time) (campaign.State, error)
+}
+
+// RunnerUpSelector is implemented by the rules that can hand the prize of an
+// expired or forfeited winner to the next eligible customer.
+type RunnerUpSelector interface {
+	// RunnersUp returns up to count customers who would have won next, in order,
+	// from the orders of the campaign window sorted by creation time. Customers in
+	// exclude, such as the past and current winners, are skipped.
+	RunnersUp(input campaign.Campaign, orders []order.Order, exclude map[string]bool, count int) ([]campaign.Winner, error)
+}
+
+type CampaignRule interface {
+	// Type is the campaign type handled by the rule.
+	Type() string
+	// ValidatePolicy checks a campaign policy against the policy schema of the rule.
+	ValidatePolicy(policy map[string]any) error
+	// PolicyFields returns the policy values to cache in the campaign info hash,
+	// without the PolicyFieldPrefix.
+	PolicyFields(policy map[string]any) (map[string]string, error)
+	// PendingScript returns the script run when an order is created, or nil
+	// when the rule only looks at completed orders.
+	PendingScript(campaignId int64, input order.Order) *Script
+	// ResultScript returns the script run when an order succeeds or fails.
+	// The rules that rank orders only rank the orders created before watermark,
+	// so that late events of the orders created after it can still be ranked
+	// at their creation time.
+	ResultScript(campaignId int64, input order.Order, watermark time.Time) Script
+}
+
+// Flusher is implemented by the rules that hold back the orders created after
+// the watermark, which must be ranked once the watermark has passed them even
+// if no other order event comes.
+type Flusher interface {
+	// FlushScript returns the script ranking the orders created before watermark.
+	// It returns {has_new_winner, is_campaign_finished}.
+	FlushScript(campaignId int64, watermark time.Time) Script
+}
+
+// PendingExpirer is implemented by the rules whose ranking waits for the result
+// of the pending orders, so that an order whose result never comes can be
+// expired instead of blocking the ranking forever.
+type PendingExpirer interface {
+	// StalePendingScript returns the script listing the orders still PENDING
+	// and created before cutoff, oldest first, up to count. It returns a flat
+	// list of order id and customer id pairs.
+	StalePendingScript(campaignId int64, cutoff time.Time, count int64) Script
+	// ExpireScript returns the script settling a stale order with the status of
+	// input, unless its result came in the meantime, and then ranking the
+	// pending orders created before watermark. It returns
+	// {has_new_winner, is_campaign_finished, is_expired}.
+	ExpireScript(campaignId int64, input order.Order, reason string, watermark time.Time) Script
+}
+
+var registry = map[string]CampaignRule{}
+
+func register(rules ...CampaignRule) {
+	for _, rule := range rules {
+		registry[rule.Type()] = rule
+	}
+}
+
+func init() {
+	register(
+		FirstNCustomers{},
+		Cashback{},
+		Voucher{},
+		LuckyDraw{},
+		Tiered{},
+		Draw{},
+	)
+}
+
+// Get returns the rule of a campaign type.
+func Get(campaignType string) (CampaignRule, error) {
+	rule, ok := registry[campaignType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownCampaignType, campaignType)
+	}
+	return rule, nil
+}
+
+// Types returns the supported campaign types, sorted.
+func Types() []string {
+	types := make([]string, 0, len(registry))
+	for campaignType := range registry {
+		types = append(types, campaignType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// Validate checks that the policy of a campaign matches the schema of its type.
+func Validate(input campaign.Campaign) error {
+	rule, err := Get(input.Type)
+	if err != nil {
+		return err
+	}
+	return rule.ValidatePolicy(input.Policy)
+}
+
+// InfoFields returns the fields of the campaign info hash read by the scripts.
+func InfoFields(input campaign.Campaign) (map[string]string, error) {
+	rule, err := Get(input.Type)
+	if err != nil {
+		return nil, err
+	}
+	policyFields, err := rule.PolicyFields(input.Policy)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]string{
+		"id":                     strconv.FormatInt(input.Id, 10),
+		"name":                   input.Name,
+		"type":                   input.Type,
+		"description":            input.Description,
+		"start_time_millisecond": strconv.FormatInt(input.StartTime.UnixMilli(), 10),
+		"end_time_millisecond":   strconv.FormatInt(input.EndTime.UnixMilli(), 10),
+		"created_at":             strconv.FormatInt(input.CreatedAt.UnixMilli(), 10),
+		"updated_at":             strconv.FormatInt(input.UpdatedAt.UnixMilli(), 10),
+	}
+	for field, value := range policyFields {
+		fields[PolicyFieldPrefix+field] = value
+	}
+	return fields, nil
+}
+
+// RebuildState returns the Redis state of a campaign rebuilt from the database.
+// Rules without a StateRebuilder only get their info hash and saved winners back.
+func RebuildState(input campaign.Campaign, orders []order.Order, winners []string, watermark time.Time) (campaign.State, error) {
+	rule, err := Get(input.Type)
+	if err != nil {
+		return campaign.State{}, err
+	}
+	var state campaign.State
+	if rebuilder, ok := rule.(StateRebuilder); ok {
+		state, err = rebuilder.RebuildState(input, orders, winners, watermark)
+		if err != nil {
+			return campaign.State{}, err
+		}
+	} else {
+		state = campaign.NewState()
+		state.Winners = append(state.Winners, winners...)
+	}
+	state.Info, err = InfoFields(input)
+	if err != nil {
+		return campaign.State{}, err
+	}
+	return state, nil
+}
+
+// TotalReward returns the number of prizes given by a campaign, read from the
+// total_reward field shared by the policies of all campaign types.
+func TotalReward(input campaign.Campaign) (int64, error) {
+	var typed struct {
+		TotalReward int64 `json:"total_reward"`
+	}
+	data, err := json.Marshal(input.Policy)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrInvalidPolicy, err)
+	}
+	if err := json.Unmarshal(data, &typed); err != nil {
+		return 0, fmt.Errorf("%w: %w", ErrInvalidPolicy, err)
+	}
+	return typed.TotalReward, nil
+}
+
+type policy interface {
+	validate() error
+}
+
+// DecodePolicy decodes a campaign policy into the typed policy of a rule and
+// validates it. Unknown fields are rejected.
+func DecodePolicy[T policy](raw map[string]any) (T, error) {
+	var typed T
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return typed, fmt.Errorf("%w: %w", ErrInvalidPolicy, err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&typed); err != nil {
+		return typed, fmt.Errorf("%w: %w", ErrInvalidPolicy, err)
+	}
+	if err := typed.validate(); err != nil {
+		return typed, fmt.Errorf("%w: %w", ErrInvalidPolicy, err)
+	}
+	return typed, nil
+}
+
+// resultArgs are the arguments shared by all result scripts:
+// customer_id, order_id, status, total_amount, created_at in milliseconds.
+func resultArgs(input order.Order) []any {
+	return []any{
+		input.CustomerId,
+		input.Id.String(),
+		input.Status.String(),
+		input.TotalAmount,
+		input.CreatedAt.UnixMilli(),
+	}
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// auditFunction defines audit(audit_key, order_id, customer_id, score, decision, reason),
+// which appends a decision on an order to the campaign audit stream. The score
+// is the order creation time relative to the campaign start, in milliseconds.
+const auditFunction = `
+	local function audit(audit_key, order_id, customer_id, score, decision, reason)
+		redis.call('XADD', audit_key, '*', 'order_id', order_id, 'customer_id', customer_id,
+			'score', tostring(score), 'decision', decision, 'reason', reason)
+	end
+`
+
+// addWinnerFunction defines add_winner(winners_key, unsaved_winners_key, audit_key, customer_id, order_id, score, reason),
+// which adds a customer to the winners set, queues it in the unsaved winners
+// list with its position in the selection order and audits the WINNER decision.
+const addWinnerFunction = auditFunction + `
+	local function add_winner(winners_key, unsaved_winners_key, audit_key, customer_id, order_id, score, reason)
+		redis.call('SADD', winners_key, customer_id)
+		local position = redis.call('SCARD', winners_key)
+		redis.call('RPUSH', unsaved_winners_key, cjson.encode({customer_id = customer_id, order_id = order_id, position = position}))
+		audit(audit_key, order_id, customer_id, score, 'WINNER', reason .. ', winner ' .. position)
+	end
+`
+
+// orderScriptHeader starts the result scripts of the rules that evaluate each
+// completed order on its own. It returns early unless the order succeeded
+// inside the campaign window with at least policy_min_order_amount, and marks
+// the order as evaluated so a redelivered event is not counted twice. A failed
+// or too small order is audited, an order outside the window or redelivered is not.
+const orderScriptHeader = addWinnerFunction + `
+	local campaign_key = KEYS[1]
+	local winners_key = KEYS[2]
+	local rewards_key = KEYS[3]
+	local customer_key = KEYS[4]
+	local transaction_key = KEYS[5]
+	local unsaved_winners_key = KEYS[6]
+	local audit_key = KEYS[7]
+	local customer_id = ARGV[1]
+	local order_id = ARGV[2]
+	local order_status = ARGV[3]
+	local order_total_amount = tonumber(ARGV[4])
+	local created_at = tonumber(ARGV[5])
+	local start_time_millisecond = tonumber(redis.call('HGET', campaign_key, 'start_time_millisecond'))
+	local end_time_millisecond = tonumber(redis.call('HGET', campaign_key, 'end_time_millisecond'))
+	local policy_total_reward = tonumber(redis.call('HGET', campaign_key, 'policy_total_reward')) or 0
+	local policy_min_order_amount = tonumber(redis.call('HGET', campaign_key, 'policy_min_order_amount')) or 0
+	local has_new_winner = false
+	local is_campaign_finished = false
+
+	if not start_time_millisecond or not end_time_millisecond then
+		return {has_new_winner, is_campaign_finished}
+	end
+
+	if created_at < start_time_millisecond or created_at > end_time_millisecond then
+		return {has_new_winner, is_campaign_finished}
+	end
+	local score = created_at - start_time_millisecond
+
+	is_campaign_finished = redis.call('SCARD', winners_key) >= policy_total_reward
+	if order_status ~= 'SUCCESS' then
+		if order_status == 'FAILED' then
+			audit(audit_key, order_id, customer_id, score, 'SKIPPED_FAILED', 'order failed')
+		end
+		return {has_new_winner, is_campaign_finished}
+	end
+	if order_total_amount < policy_min_order_amount then
+		audit(audit_key, order_id, customer_id, score, 'BELOW_MIN_AMOUNT',
+			'order amount ' .. order_total_amount .. ' is below the minimum of ' .. policy_min_order_amount)
+		return {has_new_winner, is_campaign_finished}
+	end
+
+	if redis.call('HSETNX', transaction_key, 'customer_id', customer_id) == 0 then
+		return {has_new_winner, is_campaign_finished}
+	end
+	redis.call('HSET', transaction_key, 'status', order_status)
+`
+
+// skipRewardedFunction defines skip_rewarded(), which audits and reports whether
+// the order of a script starting with orderScriptHeader gets no reward because
+// the campaign ran out of rewards or the customer already won.
+const skipRewardedFunction = `
+	local function skip_rewarded()
+		if is_campaign_finished then
+			audit(audit_key, order_id, customer_id, score, 'REWARDS_EXHAUSTED',
+				'all ' .. policy_total_reward .. ' rewards are given')
+			return true
+		end
+		if redis.call('SISMEMBER', winners_key, customer_id) == 1 then
+			audit(audit_key, order_id, customer_id, score, 'SKIPPED_WINNER', 'customer already won')
+			return true
+		end
+		return false
+	end
+`
+
+// orderScriptKeys are the keys used by scripts starting with orderScriptHeader.
+func orderScriptKeys(campaignId int64, input order.Order) []string {
+	return []string{
+		campaign.InfoKey(campaignId),
+		campaign.WinnersKey(campaignId),
+		campaign.RewardsKey(campaignId),
+		campaign.CustomerKey(campaignId, input.CustomerId),
+		campaign.TransactionKey(campaignId, input.Id.String()),
+		campaign.UnsavedWinnersKey(campaignId),
+		campaign.AuditKey(campaignId),
+	}
+}
@@ -0,0 +1,77 @@
+package rules
+
+import (
+	"maps"
+	"slices"
+	"specommerce/campaignservice/internal/core/domain/campaign"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate_AcceptsValidPolicies(t *testing.T) {
+	policies := map[string]map[string]any{
+		TypeFirstNCustomers: {"total_reward": 10, "min_order_amount": 100, "max_tracked_orders": 20},
+		TypeCashback:        {"total_reward": 10, "min_order_amount": 100, "cashback_amount": 10},
+		TypeVoucher:         {"total_reward": 10, "min_order_amount": 100, "percent": 15, "max_discount": 50},
+		TypeLuckyDraw:       {"total_reward": 10, "min_order_amount": 100, "win_probability": 0.1},
+		TypeTiered: {"total_reward": 10, "tiers": []any{
+			map[string]any{"min_spend": 100, "reward": 5},
+			map[string]any{"min_spend": 500, "reward": 30},
+		}},
+		TypeDraw: {"total_reward": 10, "min_order_amount": 100},
+	}
+
+	assert.ElementsMatch(t, Types(), slices.Collect(maps.Keys(policies)))
+	for campaignType, policy := range policies {
+		assert.NoError(t, Validate(campaign.Campaign{Type: campaignType, Policy: policy}), campaignType)
+	}
+}
+
+func TestValidate_RejectsInvalidPolicies(t *testing.T) {
+	tests := []struct {
+		name         string
+		campaignType string
+		policy       map[string]any
+	}{
+		{"tiers with decreasing min_spend", TypeTiered, map[string]any{"total_reward": 10, "tiers": []any{
+			map[string]any{"min_spend": 500, "reward": 5},
+			map[string]any{"min_spend": 100, "reward": 30},
+		}}},
+		{"tiers with the same min_spend", TypeTiered, map[string]any{"total_reward": 10, "tiers": []any{
+			map[string]any{"min_spend": 100, "reward": 5},
+			map[string]any{"min_spend": 100, "reward": 30},
+		}}},
+		{"tiers with decreasing reward", TypeTiered, map[string]any{"total_reward": 10, "tiers": []any{
+			map[string]any{"min_spend": 100, "reward": 30},
+			map[string]any{"min_spend": 500, "reward": 5},
+		}}},
+		{"no tier", TypeTiered, map[string]any{"total_reward": 10, "tiers": []any{}}},
+		{"voucher percent above 100", TypeVoucher, map[string]any{"total_reward": 10, "min_order_amount": 100, "percent": 120, "max_discount": 50}},
+		{"voucher without percent", TypeVoucher, map[string]any{"total_reward": 10, "min_order_amount": 100, "max_discount": 50}},
+		{"cashback above the min order amount", TypeCashback, map[string]any{"total_reward": 10, "min_order_amount": 100, "cashback_amount": 150}},
+		{"max_tracked_orders below total_reward", TypeFirstNCustomers, map[string]any{"total_reward": 10, "min_order_amount": 100, "max_tracked_orders": 5}},
+		{"win_probability above 1", TypeLuckyDraw, map[string]any{"total_reward": 10, "min_order_amount": 100, "win_probability": 1.5}},
+		{"lucky draw seed in the policy", TypeLuckyDraw, map[string]any{"total_reward": 10, "min_order_amount": 100, "win_probability": 0.1, "seed": "public"}},
+		{"first_n_customers without total_reward", TypeFirstNCustomers, map[string]any{"min_order_amount": 100, "max_tracked_orders": 20}},
+		{"cashback without total_reward", TypeCashback, map[string]any{"min_order_amount": 100, "cashback_amount": 10}},
+		{"voucher without total_reward", TypeVoucher, map[string]any{"min_order_amount": 100, "percent": 15, "max_discount": 50}},
+		{"lucky draw without total_reward", TypeLuckyDraw, map[string]any{"min_order_amount": 100, "win_probability": 0.1}},
+		{"tiered without total_reward", TypeTiered, map[string]any{"tiers": []any{map[string]any{"min_spend": 100, "reward": 5}}}},
+		{"draw without total_reward", TypeDraw, map[string]any{"min_order_amount": 100}},
+		{"unknown field", TypeDraw, map[string]any{"total_reward": 10, "min_order_amount": 100, "bonus": 1}},
+		{"wrong field type", TypeDraw, map[string]any{"total_reward": "ten", "min_order_amount": 100}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(campaign.Campaign{Type: tt.campaignType, Policy: tt.policy})
+			assert.ErrorIs(t, err, ErrInvalidPolicy)
+		})
+	}
+}
+
+func TestValidate_RejectsUnknownType(t *testing.T) {
+	err := Validate(campaign.Campaign{Type: "mystery_box", Policy: map[string]any{"total_reward": 10}})
+	assert.ErrorIs(t, err, ErrUnknownCampaignType)
+}
@@ -0,0 +1,129 @@
+package rules
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"specommerce/campaignservice/internal/core/domain/order"
+	"strconv"
+	"time"
+)
+
+const TypeTiered = "tiered"
+
+// Tier grants Reward to a customer whose successful orders in the campaign
+// add up to at least MinSpend.
+type Tier struct {
+	MinSpend float64 `json:"min_spend"`
+	Reward   float64 `json:"reward"`
+}
+
+// TieredPolicy rewards up to TotalReward customers with the reward of the
+// highest tier their cumulative spend reaches. Tiers are sorted by MinSpend.
+type TieredPolicy struct {
+	TotalReward int64  `json:"total_reward"`
+	Tiers       []Tier `json:"tiers"`
+}
+
+func (p TieredPolicy) validate() error {
+	if p.TotalReward <= 0 {
+		return errors.New("total_reward must be positive")
+	}
+	if len(p.Tiers) == 0 {
+		return errors.New("at least one tier is required")
+	}
+	for i, tier := range p.Tiers {
+		if tier.MinSpend <= 0 || tier.Reward <= 0 {
+			return fmt.Errorf("tier %d: min_spend and reward must be positive", i)
+		}
+		if i > 0 && (tier.MinSpend <= p.Tiers[i-1].MinSpend || tier.Reward <= p.Tiers[i-1].Reward) {
+			return fmt.Errorf("tier %d: min_spend and reward must increase with each tier", i)
+		}
+	}
+	return nil
+}
+
+// Tiered is the rule of the tiered rewards campaign. A customer already
+// rewarded is moved up to a higher tier when they keep spending, even once
+// all rewards are given out.
+type Tiered struct{}
+
+func (Tiered) Type() string {
+	return TypeTiered
+}
+
+func (Tiered) ValidatePolicy(policy map[string]any) error {
+	_, err := DecodePolicy[TieredPolicy](policy)
+	return err
+}
+
+func (Tiered) PolicyFields(policy map[string]any) (map[string]string, error) {
+	typed, err := DecodePolicy[TieredPolicy](policy)
+	if err != nil {
+		return nil, err
+	}
+	tiers, err := json.Marshal(typed.Tiers)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{
+		"total_reward": strconv.FormatInt(typed.TotalReward, 10),
+		"tiers":        string(tiers),
+	}, nil
+}
+
+func (Tiered) PendingScript(int64, order.Order) *Script {
+	return nil
+}
+
+// ResultScript adds a successful order to the customer's spend and grants or
+// upgrades the reward of the highest tier reached.
+func (Tiered) ResultScript(campaignId int64, input order.Order, _ time.Time) Script {
+	return Script{
+		Source: tieredResultScript,
+		Keys:   orderScriptKeys(campaignId, input),
+		Args:   resultArgs(input),
+	}
+}
+
+const tieredResultScript = orderScriptHeader + `
+	local policy_tiers = cjson.decode(redis.call('HGET', campaign_key, 'policy_tiers') or '[]')
+	local total_spend = tonumber(redis.call('HINCRBYFLOAT', customer_key, 'total_spend', order_total_amount))
+
+	local reward = nil
+	for _, tier in ipairs(policy_tiers) do
+		if total_spend >= tier.min_spend then
+			reward = tier.reward
+		end
+	end
+	if not reward then
+		audit(audit_key, order_id, customer_id, score, 'NO_TIER',
+			'total spend ' .. total_spend .. ' reaches no tier')
+		return {has_new_winner, is_campaign_finished}
+	end
+
+	local is_winner = redis.call('SISMEMBER', winners_key, customer_id) == 1
+	if not is_winner then
+		if is_campaign_finished then
+			audit(audit_key, order_id, customer_id, score, 'REWARDS_EXHAUSTED',
+				'all ' .. policy_total_reward .. ' rewards are given')
+			return {has_new_winner, is_campaign_finished}
+		end
+		add_winner(winners_key, unsaved_winners_key, audit_key, customer_id, order_id, score,
+			'total spend ' .. total_spend .. ' reaches the tier of reward ' .. reward)
+		has_new_winner = true
+	end
+
+	local current_reward = tonumber(redis.call('HGET', rewards_key, customer_id)) or 0
+	if reward > current_reward then
+		redis.call('HSET', rewards_key, customer_id, tostring(reward))
+		if is_winner then
+			audit(audit_key, order_id, customer_id, score, 'REWARD_UPGRADED',
+				'total spend ' .. total_spend .. ' reaches the tier of reward ' .. reward)
+		end
+	elseif is_winner then
+		audit(audit_key, order_id, customer_id, score, 'SKIPPED_WINNER', 'customer already won this tier')
+	end
+	is_campaign_finished = redis.call('SCARD', winners_key) >= policy_total_reward
+	return {has_new_winner, is_campaign_finished}
+`
@@ -0,0 +1,91 @@
+package rules
+
+import (
+	"errors"
+	"specommerce/campaignservice/internal/core/domain/order"
+	"strconv"
+	"time"
+)
+
+const TypeVoucher = "voucher"
+
+// VoucherPolicy gives a Percent off voucher, capped at MaxDiscount, to the
+// customers of the first TotalReward successful orders reaching
+// MinOrderAmount, once per customer.
+type VoucherPolicy struct {
+	TotalReward    int64   `json:"total_reward"`
+	MinOrderAmount float64 `json:"min_order_amount"`
+	Percent        float64 `json:"percent"`
+	MaxDiscount    float64 `json:"max_discount"`
+}
+
+func (p VoucherPolicy) validate() error {
+	if p.TotalReward <= 0 {
+		return errors.New("total_reward must be positive")
+	}
+	if p.MinOrderAmount < 0 {
+		return errors.New("min_order_amount must not be negative")
+	}
+	if p.Percent <= 0 || p.Percent > 100 {
+		return errors.New("percent must be between 0 and 100")
+	}
+	if p.MaxDiscount <= 0 {
+		return errors.New("max_discount must be positive")
+	}
+	return nil
+}
+
+// Voucher is the rule of the percentage voucher campaign. The reward recorded
+// for a winner is the voucher percentage.
+type Voucher struct{}
+
+func (Voucher) Type() string {
+	return TypeVoucher
+}
+
+func (Voucher) ValidatePolicy(policy map[string]any) error {
+	_, err := DecodePolicy[VoucherPolicy](policy)
+	return err
+}
+
+func (Voucher) PolicyFields(policy map[string]any) (map[string]string, error) {
+	typed, err := DecodePolicy[VoucherPolicy](policy)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]string{
+		"total_reward":     strconv.FormatInt(typed.TotalReward, 10),
+		"min_order_amount": formatFloat(typed.MinOrderAmount),
+		"percent":          formatFloat(typed.Percent),
+		"max_discount":     formatFloat(typed.MaxDiscount),
+	}, nil
+}
+
+func (Voucher) PendingScript(int64, order.Order) *Script {
+	return nil
+}
+
+// ResultScript issues a voucher to the customer of a qualifying order, unless
+// the customer already got one or the campaign has run out of vouchers.
+func (Voucher) ResultScript(campaignId int64, input order.Order, _ time.Time) Script {
+	return Script{
+		Source: voucherResultScript,
+		Keys:   orderScriptKeys(campaignId, input),
+		Args:   resultArgs(input),
+	}
+}
+
+const voucherResultScript = orderScriptHeader + skipRewardedFunction + `
+	local policy_percent = redis.call('HGET', campaign_key, 'policy_percent')
+
+	if skip_rewarded() then
+		return {has_new_winner, is_campaign_finished}
+	end
+
+	add_winner(winners_key, unsaved_winners_key, audit_key, customer_id, order_id, score,
+		'successful order of ' .. order_total_amount)
+	redis.call('HSET', rewards_key, customer_id, policy_percent)
+	has_new_winner = true
+	is_campaign_finished = redis.call('SCARD', winners_key) >= policy_total_reward
+	return {has_new_winner, is_campaign_finished}
+`
@@ -0,0 +1,158 @@
+// Package audit keeps the trail of the decisions of the campaign scripts.
+//
+// The scripts append every decision on an order to the audit stream of the
+// campaign (see campaign.AuditKey) in the same atomic step as the decision. The
+// entries are then copied to the append-only audit table and removed from the
+// stream. An entry is saved at most once thanks to its stream id, so a copy
+// interrupted before the removal is simply copied again.
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"specommerce/campaignservice/internal/core/domain/campaign"
+	"specommerce/campaignservice/internal/core/ports/primary"
+	"specommerce/campaignservice/internal/core/ports/secondary"
+	"specommerce/campaignservice/pkg/cache"
+	"specommerce/campaignservice/pkg/service_config"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type auditService struct {
+	campaignRepository secondary.CampaignRepository
+	auditRepository    secondary.AuditRepository
+	cacheClient        cache.Cache
+	config             service_config.AuditConfig
+	logger             *slog.Logger
+}
+
+func NewAuditService(
+	campaignRepository secondary.CampaignRepository,
+	auditRepository secondary.AuditRepository,
+	cacheClient cache.Cache,
+	config service_config.AuditConfig,
+	logger *slog.Logger,
+) primary.AuditService {
+	return &auditService{
+		campaignRepository: campaignRepository,
+		auditRepository:    auditRepository,
+		cacheClient:        cacheClient,
+		config:             config,
+		logger:             logger,
+	}
+}
+
+// GetTrail persists the audit stream of the campaign first, so the trail
+// includes the latest decisions.
+func (s *auditService) GetTrail(ctx context.Context, campaignId int64, customerId string) ([]campaign.AuditEntry, error) {
+	errTemplate := "auditService GetTrail %w"
+	if _, err := s.campaignRepository.GetById(ctx, campaignId); err != nil {
+		return nil, fmt.Errorf(errTemplate, err)
+	}
+	if _, err := s.persist(ctx, campaignId); err != nil {
+		return nil, fmt.Errorf(errTemplate, err)
+	}
+	entries, err := s.auditRepository.GetByCustomer(ctx, campaignId, customerId)
+	if err != nil {
+		return nil, fmt.Errorf(errTemplate, err)
+	}
+	return entries, nil
+}
+
+// PersistTrails persists the audit streams of all the campaigns but the drafts,
+// which evaluate no order. Late results still reach ENDED campaigns.
+func (s *auditService) PersistTrails(ctx context.Context) error {
+	errTemplate := "auditService PersistTrails %w"
+	campaigns, err := s.campaignRepository.GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf(errTemplate, err)
+	}
+	var errs []error
+	for _, existing := range campaigns {
+		if existing.Status == campaign.StatusDraft {
+			continue
+		}
+		persisted, err := s.persist(ctx, existing.Id)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("campaign %d: %w", existing.Id, err))
+			continue
+		}
+		if persisted > 0 {
+			s.logger.Info("Persisted campaign audit entries",
+				slog.Int64("campaign_id", existing.Id),
+				slog.Int("entries", persisted))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf(errTemplate, err)
+	}
+	return nil
+}
+
+// persist moves the entries of the audit stream of a campaign to the audit
+// table by batches, oldest first, and returns how many were moved.
+func (s *auditService) persist(ctx context.Context, campaignId int64) (int, error) {
+	key := campaign.AuditKey(campaignId)
+	persisted := 0
+	for {
+		streamEntries, err := s.cacheClient.XRange(ctx, key, s.config.BatchSize)
+		if err != nil {
+			return persisted, err
+		}
+		if len(streamEntries) == 0 {
+			return persisted, nil
+		}
+		entries := make([]campaign.AuditEntry, 0, len(streamEntries))
+		ids := make([]string, 0, len(streamEntries))
+		for _, streamEntry := range streamEntries {
+			entry, err := toAuditEntry(campaignId, streamEntry)
+			if err != nil {
+				return persisted, err
+			}
+			entries = append(entries, entry)
+			ids = append(ids, streamEntry.Id)
+		}
+		if err := s.auditRepository.SaveEntries(ctx, entries); err != nil {
+			return persisted, err
+		}
+		if err := s.cacheClient.XDel(ctx, key, ids...); err != nil {
+			return persisted, err
+		}
+		persisted += len(entries)
+		if int64(len(streamEntries)) < s.config.BatchSize {
+			return persisted, nil
+		}
+	}
+}
+
+// toAuditEntry decodes an entry of an audit stream. The decision time is the
+// time part of the stream id.
+func toAuditEntry(campaignId int64, streamEntry cache.StreamEntry) (campaign.AuditEntry, error) {
+	milliseconds, _, _ := strings.Cut(streamEntry.Id, "-")
+	decidedAt, err := strconv.ParseInt(milliseconds, 10, 64)
+	if err != nil {
+		return campaign.AuditEntry{}, fmt.Errorf("invalid audit stream id %q: %w", streamEntry.Id, err)
+	}
+	entry := campaign.AuditEntry{
+		CampaignId: campaignId,
+		StreamId:   streamEntry.Id,
+		OrderId:    streamEntry.Values["order_id"],
+		CustomerId: streamEntry.Values["customer_id"],
+		Decision:   campaign.Decision(streamEntry.Values["decision"]),
+		Reason:     streamEntry.Values["reason"],
+		DecidedAt:  time.UnixMilli(decidedAt),
+	}
+	if value := streamEntry.Values["score"]; value != "" {
+		score, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return campaign.AuditEntry{}, fmt.Errorf("invalid audit score %q: %w", value, err)
+		}
+		rounded := int64(score)
+		entry.Score = &rounded
+	}
+	return entry, nil
+}
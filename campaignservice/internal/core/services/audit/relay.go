@@ -0,0 +1,62 @@
+package audit
+
+import (
+	"context"
+	"log/slog"
+	"specommerce/campaignservice/internal/core/ports/primary"
+	"specommerce/campaignservice/pkg/service_config"
+	"specommerce/campaignservice/pkg/shutdown"
+	"time"
+)
+
+// Relay periodically moves the entries of the campaign audit streams to the audit table.
+type Relay struct {
+	auditService primary.AuditService
+	config       service_config.AuditConfig
+	shutdownTask *shutdown.Tasks
+	logger       *slog.Logger
+}
+
+func NewRelay(
+	auditService primary.AuditService,
+	cfg service_config.AuditConfig,
+	shutdownTask *shutdown.Tasks,
+	logger *slog.Logger,
+) *Relay {
+	return &Relay{
+		auditService: auditService,
+		config:       cfg,
+		shutdownTask: shutdownTask,
+		logger:       logger,
+	}
+}
+
+func (r *Relay) Start() error {
+	r.logger.Info("Starting campaign audit relay",
+		slog.Duration("interval", r.config.Interval),
+		slog.Int64("batch_size", r.config.BatchSize),
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	r.shutdownTask.AddShutdownTask(
+		func(_ context.Context) error {
+			cancel()
+			<-stopped
+			return nil
+		},
+	)
+	defer close(stopped)
+
+	ticker := time.NewTicker(r.config.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := r.auditService.PersistTrails(ctx); err != nil {
+				r.logger.Error("Failed to persist the campaign audit trails", slog.String("error", err.Error()))
+			}
+		}
+	}
+}
@@ -2,18 +2,25 @@ package campaign
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
+	"maps"
 	"specommerce/campaignservice/config"
 	"specommerce/campaignservice/internal/core/domain/campaign"
+	"specommerce/campaignservice/internal/core/domain/draw"
 	"specommerce/campaignservice/internal/core/ports/primary"
 	"specommerce/campaignservice/internal/core/ports/secondary"
+	"specommerce/campaignservice/internal/core/rules"
 	"specommerce/campaignservice/pkg/atomicity"
 	"specommerce/campaignservice/pkg/cache"
-	"strconv"
+	"time"
 )
 
 type campaignService struct {
 	campaignRepository secondary.CampaignRepository
+	drawRepository     secondary.DrawRepository
+	orderService       primary.OrderService
 	atomicExecutor     atomicity.AtomicExecutor
 	config             config.AppConfig
 	cacheClient        cache.Cache
@@ -21,12 +28,16 @@ type campaignService struct {
 
 func NewCampaignService(
 	campaignRepository secondary.CampaignRepository,
+	drawRepository secondary.DrawRepository,
+	orderService primary.OrderService,
 	atomicExecutor atomicity.AtomicExecutor,
 	config config.AppConfig,
 	cacheClient cache.Cache,
 ) primary.CampaignService {
 	return &campaignService{
 		campaignRepository: campaignRepository,
+		drawRepository:     drawRepository,
+		orderService:       orderService,
 		atomicExecutor:     atomicExecutor,
 		config:             config,
 		cacheClient:        cacheClient,
@@ -35,61 +46,49 @@ func NewCampaignService(
 
 func (s *campaignService) CreateCampaign(ctx context.Context, input campaign.Campaign) (campaign.Campaign, error) {
 	errTemplate := "campaignService Create %w"
-	savedCampaign, err := s.campaignRepository.Create(ctx, input)
+	if input.Type == "" {
+		input.Type = rules.TypeFirstNCustomers
+	}
+	if input.Status == "" {
+		input.Status = campaign.StatusScheduled
+	}
+	if input.Status != campaign.StatusDraft && input.Status != campaign.StatusScheduled {
+		return campaign.Campaign{}, fmt.Errorf(errTemplate, campaign.ErrInvalidStatus)
+	}
+	if err := rules.Validate(input); err != nil {
+		return campaign.Campaign{}, fmt.Errorf(errTemplate, err)
+	}
+	if input.PrizeInventory == 0 {
+		// Stock one prize per winner unless the inventory is set
+		totalReward, err := rules.TotalReward(input)
+		if err != nil {
+			return campaign.Campaign{}, fmt.Errorf(errTemplate, err)
+		}
+		input.PrizeInventory = int(totalReward)
+	}
+	var savedCampaign campaign.Campaign
+	err := s.atomicExecutor.Execute(ctx, func(ctx context.Context) error {
+		var err error
+		savedCampaign, err = s.campaignRepository.Create(ctx, input)
+		if err != nil {
+			return err
+		}
+		if !rules.CommitsSeed(savedCampaign.Type) {
+			return nil
+		}
+		// Commit to the draw seed before the campaign starts
+		commitment, err := draw.NewDraw(savedCampaign.Id)
+		if err != nil {
+			return err
+		}
+		_, err = s.drawRepository.Create(ctx, commitment)
+		return err
+	})
 	if err != nil {
 		return campaign.Campaign{}, fmt.Errorf(errTemplate, err)
 	}
 
-	// Store full campaign information in Redis Hash using Lua script
-	luaScript := `
-		local key = KEYS[1]
-		local id = ARGV[1]
-		local name = ARGV[2] 
-		local type = ARGV[3]
-		local description = ARGV[4]
-		local total_reward = ARGV[5]
-		local min_order_amount = ARGV[6]
-		local max_tracked_orders = ARGV[7]
-		local start_time_millisecond = ARGV[8]
-		local end_time_millisecond = ARGV[9]
-		local created_at = ARGV[10]
-		local updated_at = ARGV[11]
-		
-		return redis.call('HMSET', key,
-			'id', id,
-			'name', name,
-			'type', type,
-			'description', description,
-			'policy_total_reward', total_reward,
-			'policy_min_order_amount', min_order_amount,
-			'policy_max_tracked_orders', max_tracked_orders,
-			'start_time_millisecond', start_time_millisecond,
-			'end_time_millisecond', end_time_millisecond,
-			'created_at', created_at,
-			'updated_at', updated_at
-		)
-	`
-
-	campaignKey := fmt.Sprintf("campaign:%s", s.config.IphoneCampaign)
-
-	// Extract policy fields from the map
-	totalReward := fmt.Sprintf("%.0f", savedCampaign.Policy["total_reward"])
-	minOrderAmount := fmt.Sprintf("%.0f", savedCampaign.Policy["min_order_amount"])
-	maxTrackedOrders := fmt.Sprintf("%.0f", savedCampaign.Policy["max_tracked_orders"])
-
-	_, err = s.cacheClient.Eval(ctx, luaScript, []string{campaignKey},
-		strconv.FormatInt(savedCampaign.Id, 10),
-		savedCampaign.Name,
-		savedCampaign.Type,
-		savedCampaign.Description,
-		totalReward,
-		minOrderAmount,
-		maxTrackedOrders,
-		strconv.FormatInt(savedCampaign.StartTime.UnixMilli(), 10),
-		strconv.FormatInt(savedCampaign.EndTime.UnixMilli(), 10),
-		strconv.FormatInt(savedCampaign.CreatedAt.UnixMilli(), 10),
-		strconv.FormatInt(savedCampaign.UpdatedAt.UnixMilli(), 10),
-	)
+	err = s.cacheCampaign(ctx, savedCampaign)
 	if err != nil {
 		// Log error but don't fail the campaign creation
 		fmt.Printf("Failed to store campaign in Redis: %v\n", err)
@@ -98,72 +97,66 @@ func (s *campaignService) CreateCampaign(ctx context.Context, input campaign.Cam
 	return savedCampaign, nil
 }
 
-func (s *campaignService) GetIphoneCampaign(ctx context.Context) (campaign.Campaign, error) {
-	errTemplate := "campaignService GetIphoneCampaign %w"
-	campaign, err := s.campaignRepository.GetCampaignByType(ctx, s.config.IphoneCampaign)
+func (s *campaignService) GetCampaigns(ctx context.Context) ([]campaign.Campaign, error) {
+	errTemplate := "campaignService GetCampaigns %w"
+	campaigns, err := s.campaignRepository.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(errTemplate, err)
+	}
+	return campaigns, nil
+}
+
+func (s *campaignService) GetCampaign(ctx context.Context, id int64) (campaign.Campaign, error) {
+	errTemplate := "campaignService GetCampaign %w"
+	campaign, err := s.campaignRepository.GetById(ctx, id)
 	if err != nil {
 		return campaign, fmt.Errorf(errTemplate, err)
 	}
 	return campaign, nil
 }
 
-func (s *campaignService) UpdateIphoneCampaign(ctx context.Context, input campaign.Campaign) (campaign.Campaign, error) {
-	errTemplate := "campaignService UpdateIphoneCampaign %w"
-	updatedCampaign, err := s.campaignRepository.Update(ctx, input)
+// UpdateCampaign checks and writes the update under the row lock of the
+// campaign, the same lock as changeStatus, so that a concurrent status change
+// can neither be overwritten nor slip between the lock check and the write.
+func (s *campaignService) UpdateCampaign(ctx context.Context, input campaign.Campaign) (campaign.Campaign, error) {
+	errTemplate := "campaignService UpdateCampaign %w"
+	var updatedCampaign campaign.Campaign
+	err := s.atomicExecutor.Execute(ctx, func(ctx context.Context) error {
+		existing, err := s.campaignRepository.LockById(ctx, input.Id)
+		if err != nil {
+			return err
+		}
+		if input.Type == "" {
+			input.Type = existing.Type
+		}
+		if input.Type != existing.Type {
+			return campaign.ErrTypeChanged
+		}
+		if err := rules.Validate(input); err != nil {
+			return err
+		}
+		if input.PrizeInventory == 0 {
+			input.PrizeInventory = existing.PrizeInventory
+		}
+		// The status only changes through ChangeStatus
+		input.Status = existing.Status
+		if existing.IsLocked(time.Now()) {
+			changed, err := policyChanged(existing, input)
+			if err != nil {
+				return err
+			}
+			if changed {
+				return campaign.ErrPolicyLocked
+			}
+		}
+		updatedCampaign, err = s.campaignRepository.Update(ctx, input)
+		return err
+	})
 	if err != nil {
 		return campaign.Campaign{}, fmt.Errorf(errTemplate, err)
 	}
 
-	// Update campaign information in Redis Hash using Lua script
-	luaScript := `
-		local key = KEYS[1]
-		local id = ARGV[1]
-		local name = ARGV[2] 
-		local type = ARGV[3]
-		local description = ARGV[4]
-		local total_reward = ARGV[5]
-		local min_order_amount = ARGV[6]
-		local max_tracked_orders = ARGV[7]
-		local start_time_millisecond = ARGV[8]
-		local end_time_millisecond = ARGV[9]
-		local created_at = ARGV[10]
-		local updated_at = ARGV[11]
-		
-		return redis.call('HMSET', key,
-			'id', id,
-			'name', name,
-			'type', type,
-			'description', description,
-			'policy_total_reward', total_reward,
-			'policy_min_order_amount', min_order_amount,
-			'policy_max_tracked_orders', max_tracked_orders,
-			'start_time_millisecond', start_time_millisecond,
-			'end_time_millisecond', end_time_millisecond,
-			'created_at', created_at,
-			'updated_at', updated_at
-		)
-	`
-
-	campaignKey := fmt.Sprintf("campaign:%s", s.config.IphoneCampaign)
-
-	// Extract policy fields from the map
-	totalReward := fmt.Sprintf("%.0f", updatedCampaign.Policy["total_reward"])
-	minOrderAmount := fmt.Sprintf("%.0f", updatedCampaign.Policy["min_order_amount"])
-	maxTrackedOrders := fmt.Sprintf("%.0f", updatedCampaign.Policy["max_tracked_orders"])
-
-	_, err = s.cacheClient.Eval(ctx, luaScript, []string{campaignKey},
-		strconv.FormatInt(updatedCampaign.Id, 10),
-		updatedCampaign.Name,
-		updatedCampaign.Type,
-		updatedCampaign.Description,
-		totalReward,
-		minOrderAmount,
-		maxTrackedOrders,
-		strconv.FormatInt(updatedCampaign.StartTime.UnixMilli(), 10),
-		strconv.FormatInt(updatedCampaign.EndTime.UnixMilli(), 10),
-		strconv.FormatInt(updatedCampaign.CreatedAt.UnixMilli(), 10),
-		strconv.FormatInt(updatedCampaign.UpdatedAt.UnixMilli(), 10),
-	)
+	err = s.cacheCampaign(ctx, updatedCampaign)
 	if err != nil {
 		// Log error but don't fail the campaign update
 		fmt.Printf("Failed to update campaign in Redis: %v\n", err)
@@ -172,19 +165,179 @@ func (s *campaignService) UpdateIphoneCampaign(ctx context.Context, input campai
 	return updatedCampaign, nil
 }
 
-func (s *campaignService) GetIphoneWinner(ctx context.Context) ([]campaign.IphoneWinner, error) {
-	errTemplate := "campaignService GetWinner %w"
-	campaign, err := s.campaignRepository.GetCampaignByType(ctx, s.config.IphoneCampaign)
+func (s *campaignService) GetCampaignWinners(ctx context.Context, id int64) ([]campaign.IphoneWinner, error) {
+	errTemplate := "campaignService GetCampaignWinners %w"
+	existing, err := s.campaignRepository.GetById(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf(errTemplate, err)
 	}
-	iphoneCampaign, err := campaign.ToIphoneCampaign()
+	if existing.Type != rules.TypeFirstNCustomers {
+		winners, err := s.campaignRepository.GetWinners(ctx, existing)
+		if err != nil {
+			return nil, fmt.Errorf(errTemplate, err)
+		}
+		return winners, nil
+	}
+
+	policy, err := rules.DecodePolicy[rules.FirstNCustomersPolicy](existing.Policy)
 	if err != nil {
 		return nil, fmt.Errorf(errTemplate, err)
 	}
-	winners, err := s.campaignRepository.GetIphoneWinner(ctx, iphoneCampaign)
+	winners, err := s.campaignRepository.GetIphoneWinner(ctx, existing, policy)
 	if err != nil {
 		return nil, fmt.Errorf(errTemplate, err)
 	}
 	return winners, nil
 }
+
+// ChangeStatus moves a campaign to another status. Ending a campaign before its
+// end time closes its window now, and activating it before its start time opens
+// it now. The orders queued while the campaign was paused are replayed when it
+// is resumed or ended.
+func (s *campaignService) ChangeStatus(ctx context.Context, id int64, status campaign.Status) (campaign.Campaign, error) {
+	errTemplate := "campaignService ChangeStatus %w"
+	updated, err := s.changeStatus(ctx, id, status, time.Now())
+	if err != nil {
+		return campaign.Campaign{}, fmt.Errorf(errTemplate, err)
+	}
+	return updated, nil
+}
+
+// RunSchedule activates the SCHEDULED campaigns whose start time has come and
+// ends the ACTIVE or PAUSED campaigns whose end time has passed. It also replays
+// the orders left in the queues of the active campaigns by an interrupted replay.
+func (s *campaignService) RunSchedule(ctx context.Context) error {
+	errTemplate := "campaignService RunSchedule %w"
+	now := time.Now()
+	due, err := s.campaignRepository.GetDueCampaigns(ctx, now)
+	if err != nil {
+		return fmt.Errorf(errTemplate, err)
+	}
+	var errs []error
+	for _, input := range due {
+		status := campaign.StatusEnded
+		if input.Status == campaign.StatusScheduled && !input.EndTime.Before(now) {
+			status = campaign.StatusActive
+		}
+		if _, err := s.changeStatus(ctx, input.Id, status, now); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	active, err := s.campaignRepository.GetActiveCampaigns(ctx, now)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	for _, input := range active {
+		if input.Status == campaign.StatusPaused {
+			continue
+		}
+		if err := s.orderService.ProcessQueuedOrders(ctx, input.Id); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf(errTemplate, err)
+	}
+	return nil
+}
+
+func (s *campaignService) changeStatus(ctx context.Context, id int64, status campaign.Status, now time.Time) (campaign.Campaign, error) {
+	if !status.IsValid() {
+		return campaign.Campaign{}, campaign.ErrInvalidStatus
+	}
+	var updated campaign.Campaign
+	windowChanged := false
+	err := s.atomicExecutor.Execute(ctx, func(ctx context.Context) error {
+		existing, err := s.campaignRepository.LockById(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !existing.Status.CanTransition(status) {
+			return fmt.Errorf("%w: %s to %s", campaign.ErrInvalidTransition, existing.Status, status)
+		}
+		if status == campaign.StatusActive && existing.StartTime.After(now) {
+			existing.StartTime = now
+			windowChanged = true
+		}
+		if status == campaign.StatusEnded && existing.EndTime.After(now) {
+			existing.EndTime = now
+			windowChanged = true
+		}
+		existing.Status = status
+		existing.UpdatedAt = now
+		updated, err = s.campaignRepository.Update(ctx, existing)
+		return err
+	})
+	if err != nil {
+		return campaign.Campaign{}, err
+	}
+	log.Printf("Campaign %d is %s", id, status)
+
+	if windowChanged {
+		if err := s.cacheCampaign(ctx, updated); err != nil {
+			return updated, err
+		}
+	}
+	if status == campaign.StatusActive || status == campaign.StatusEnded {
+		if err := s.orderService.ProcessQueuedOrders(ctx, id); err != nil {
+			return updated, err
+		}
+	}
+	return updated, nil
+}
+
+// policyChanged reports whether an update changes the policy or the time
+// window of a campaign. Policies are compared by their cached values, so the
+// same policy sent with another number format is not a change.
+func policyChanged(existing campaign.Campaign, input campaign.Campaign) (bool, error) {
+	// The scripts read the window in milliseconds
+	if existing.StartTime.UnixMilli() != input.StartTime.UnixMilli() || existing.EndTime.UnixMilli() != input.EndTime.UnixMilli() {
+		return true, nil
+	}
+	rule, err := rules.Get(existing.Type)
+	if err != nil {
+		return false, err
+	}
+	existingFields, err := rule.PolicyFields(existing.Policy)
+	if err != nil {
+		return false, err
+	}
+	inputFields, err := rule.PolicyFields(input.Policy)
+	if err != nil {
+		return false, err
+	}
+	return !maps.Equal(existingFields, inputFields), nil
+}
+
+// cacheCampaign stores the campaign information in the campaign's own Redis
+// hash, where the evaluation scripts of its type read the time window and the
+// policy fields. The hash is replaced so that no stale policy field is left.
+// The committed seed of a lucky draw campaign is stored next to the policy.
+func (s *campaignService) cacheCampaign(ctx context.Context, input campaign.Campaign) error {
+	fields, err := rules.InfoFields(input)
+	if err != nil {
+		return err
+	}
+	if input.Type == rules.TypeLuckyDraw {
+		commitment, err := s.drawRepository.GetByCampaignId(ctx, input.Id)
+		if err != nil {
+			return err
+		}
+		fields[rules.SeedField] = commitment.Seed
+	}
+
+	luaScript := `
+		local key = KEYS[1]
+		redis.call('DEL', key)
+		return redis.call('HSET', key, unpack(ARGV))
+	`
+
+	args := make([]any, 0, 2*len(fields))
+	for field, value := range fields {
+		args = append(args, field, value)
+	}
+
+	_, err = s.cacheClient.Eval(ctx, luaScript, []string{campaign.InfoKey(input.Id)}, args...)
+	return err
+}
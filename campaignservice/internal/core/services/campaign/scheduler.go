@@ -0,0 +1,60 @@
+package campaign
+
+import (
+	"context"
+	"log/slog"
+	"specommerce/campaignservice/internal/core/ports/primary"
+	"specommerce/campaignservice/pkg/service_config"
+	"specommerce/campaignservice/pkg/shutdown"
+	"time"
+)
+
+// Scheduler periodically activates the campaigns whose start time has come and
+// ends the campaigns whose end time has passed.
+type Scheduler struct {
+	campaignService primary.CampaignService
+	config          service_config.ScheduleConfig
+	shutdownTask    *shutdown.Tasks
+	logger          *slog.Logger
+}
+
+func NewScheduler(
+	campaignService primary.CampaignService,
+	cfg service_config.ScheduleConfig,
+	shutdownTask *shutdown.Tasks,
+	logger *slog.Logger,
+) *Scheduler {
+	return &Scheduler{
+		campaignService: campaignService,
+		config:          cfg,
+		shutdownTask:    shutdownTask,
+		logger:          logger,
+	}
+}
+
+func (s *Scheduler) Start() error {
+	s.logger.Info("Starting campaign scheduler", slog.Duration("interval", s.config.Interval))
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	s.shutdownTask.AddShutdownTask(
+		func(_ context.Context) error {
+			cancel()
+			<-stopped
+			return nil
+		},
+	)
+	defer close(stopped)
+
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := s.campaignService.RunSchedule(ctx); err != nil {
+				s.logger.Error("Failed to run the campaign schedule", slog.String("error", err.Error()))
+			}
+		}
+	}
+}
@@ -0,0 +1,351 @@
+package claim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"specommerce/campaignservice/internal/core/domain/campaign"
+	"specommerce/campaignservice/internal/core/domain/draw"
+	"specommerce/campaignservice/internal/core/ports/primary"
+	"specommerce/campaignservice/internal/core/ports/secondary"
+	"specommerce/campaignservice/internal/core/rules"
+	"specommerce/campaignservice/pkg/atomicity"
+	"specommerce/campaignservice/pkg/cache"
+	"specommerce/campaignservice/pkg/service_config"
+	"time"
+)
+
+// addReplacementsScript adds the replacement winners to the campaign winners set
+const addReplacementsScript = `
+	return redis.call('SADD', KEYS[1], unpack(ARGV))
+`
+
+type claimService struct {
+	campaignRepository secondary.CampaignRepository
+	orderRepository    secondary.OrderRepository
+	drawRepository     secondary.DrawRepository
+	winnerEvents       secondary.WinnerEventRepository
+	atomicExecutor     atomicity.AtomicExecutor
+	cacheClient        cache.Cache
+	config             service_config.ClaimConfig
+	logger             *slog.Logger
+}
+
+func NewClaimService(
+	campaignRepository secondary.CampaignRepository,
+	orderRepository secondary.OrderRepository,
+	drawRepository secondary.DrawRepository,
+	winnerEvents secondary.WinnerEventRepository,
+	atomicExecutor atomicity.AtomicExecutor,
+	cacheClient cache.Cache,
+	cfg service_config.ClaimConfig,
+	logger *slog.Logger,
+) primary.ClaimService {
+	return &claimService{
+		campaignRepository: campaignRepository,
+		orderRepository:    orderRepository,
+		drawRepository:     drawRepository,
+		winnerEvents:       winnerEvents,
+		atomicExecutor:     atomicExecutor,
+		cacheClient:        cacheClient,
+		config:             cfg,
+		logger:             logger,
+	}
+}
+
+func (s *claimService) GetPrize(ctx context.Context, campaignId int64, customerId string) (campaign.Winner, error) {
+	errTemplate := "claimService GetPrize %w"
+	winner, err := s.campaignRepository.GetWinner(ctx, campaignId, customerId)
+	if err != nil {
+		return campaign.Winner{}, fmt.Errorf(errTemplate, err)
+	}
+	return winner, nil
+}
+
+// ClaimPrize locks the campaign so concurrent claims cannot reserve more prizes than the inventory holds.
+func (s *claimService) ClaimPrize(ctx context.Context, campaignId int64, customerId string, shippingAddress string) (campaign.Winner, error) {
+	errTemplate := "claimService ClaimPrize %w"
+	var result campaign.Winner
+	err := s.atomicExecutor.Execute(ctx, func(ctx context.Context) error {
+		existing, err := s.campaignRepository.LockById(ctx, campaignId)
+		if err != nil {
+			return err
+		}
+		winner, err := s.campaignRepository.GetWinner(ctx, campaignId, customerId)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		if winner.ClaimStatus == campaign.ClaimStatusNotified && winner.IsClaimExpired(now) {
+			return campaign.ErrClaimExpired
+		}
+		if !campaign.CanTransition(winner.ClaimStatus, campaign.ClaimStatusClaimed) {
+			return fmt.Errorf("%w: %s to %s", campaign.ErrInvalidClaimTransition, winner.ClaimStatus, campaign.ClaimStatusClaimed)
+		}
+		winners, err := s.campaignRepository.FindWinners(ctx, campaignId)
+		if err != nil {
+			return err
+		}
+		inventory := campaign.NewPrizeInventory(campaignId, existing.PrizeInventory, winners)
+		if inventory.Reserved() >= inventory.Total {
+			return campaign.ErrOutOfStock
+		}
+
+		winner.ClaimStatus = campaign.ClaimStatusClaimed
+		winner.ShippingAddress = shippingAddress
+		winner.ClaimedAt = &now
+		result, err = s.campaignRepository.UpdateClaim(ctx, winner)
+		return err
+	})
+	if err != nil {
+		return campaign.Winner{}, fmt.Errorf(errTemplate, err)
+	}
+	return result, nil
+}
+
+func (s *claimService) ForfeitPrize(ctx context.Context, campaignId int64, customerId string) (campaign.Winner, error) {
+	errTemplate := "claimService ForfeitPrize %w"
+	result, err := s.transition(ctx, campaignId, customerId, campaign.ClaimStatusForfeited)
+	if err != nil {
+		return campaign.Winner{}, fmt.Errorf(errTemplate, err)
+	}
+	return result, nil
+}
+
+func (s *claimService) ShipPrize(ctx context.Context, campaignId int64, customerId string) (campaign.Winner, error) {
+	errTemplate := "claimService ShipPrize %w"
+	result, err := s.transition(ctx, campaignId, customerId, campaign.ClaimStatusShipped)
+	if err != nil {
+		return campaign.Winner{}, fmt.Errorf(errTemplate, err)
+	}
+	return result, nil
+}
+
+func (s *claimService) transition(ctx context.Context, campaignId int64, customerId string, to campaign.ClaimStatus) (campaign.Winner, error) {
+	var result campaign.Winner
+	err := s.atomicExecutor.Execute(ctx, func(ctx context.Context) error {
+		if _, err := s.campaignRepository.LockById(ctx, campaignId); err != nil {
+			return err
+		}
+		winner, err := s.campaignRepository.GetWinner(ctx, campaignId, customerId)
+		if err != nil {
+			return err
+		}
+		if !campaign.CanTransition(winner.ClaimStatus, to) {
+			return fmt.Errorf("%w: %s to %s", campaign.ErrInvalidClaimTransition, winner.ClaimStatus, to)
+		}
+		winner.ClaimStatus = to
+		if to == campaign.ClaimStatusShipped {
+			now := time.Now()
+			winner.ShippedAt = &now
+		}
+		result, err = s.campaignRepository.UpdateClaim(ctx, winner)
+		return err
+	})
+	return result, err
+}
+
+func (s *claimService) GetClaims(ctx context.Context, campaignId int64) ([]campaign.Winner, error) {
+	errTemplate := "claimService GetClaims %w"
+	if _, err := s.campaignRepository.GetById(ctx, campaignId); err != nil {
+		return nil, fmt.Errorf(errTemplate, err)
+	}
+	winners, err := s.campaignRepository.FindWinners(ctx, campaignId)
+	if err != nil {
+		return nil, fmt.Errorf(errTemplate, err)
+	}
+	return winners, nil
+}
+
+func (s *claimService) GetPrizeInventory(ctx context.Context, campaignId int64) (campaign.PrizeInventory, error) {
+	errTemplate := "claimService GetPrizeInventory %w"
+	existing, err := s.campaignRepository.GetById(ctx, campaignId)
+	if err != nil {
+		return campaign.PrizeInventory{}, fmt.Errorf(errTemplate, err)
+	}
+	winners, err := s.campaignRepository.FindWinners(ctx, campaignId)
+	if err != nil {
+		return campaign.PrizeInventory{}, fmt.Errorf(errTemplate, err)
+	}
+	return campaign.NewPrizeInventory(campaignId, existing.PrizeInventory, winners), nil
+}
+
+func (s *claimService) SweepClaims(ctx context.Context) error {
+	errTemplate := "claimService SweepClaims %w"
+	now := time.Now()
+	expired, err := s.campaignRepository.ExpireClaims(ctx, now)
+	if err != nil {
+		return fmt.Errorf(errTemplate, err)
+	}
+	for _, winner := range expired {
+		s.logger.Info("Prize claim expired",
+			slog.Int64("campaign_id", winner.CampaignId),
+			slog.String("customer_id", winner.CustomerId),
+		)
+	}
+
+	campaignIds, err := s.campaignRepository.GetReassignableCampaignIds(ctx, now, s.config.BatchSize)
+	if err != nil {
+		return fmt.Errorf(errTemplate, err)
+	}
+	var errs []error
+	for _, campaignId := range campaignIds {
+		if err := s.reassign(ctx, campaignId); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf(errTemplate, err)
+	}
+	return nil
+}
+
+// reassign hands the expired and forfeited prizes of an ended campaign to the
+// next eligible customers, as long as the inventory has prizes not held by a
+// winner. The vacated prizes are marked reassigned even when no customer is left
+// to take them, as no new customer can qualify once the campaign has ended.
+// Replacements are numbered after every winner selected by the scripts, saved or
+// not, and are added to the winners set only once the transaction committed.
+func (s *claimService) reassign(ctx context.Context, campaignId int64) error {
+	var saved []campaign.Winner
+	err := s.atomicExecutor.Execute(ctx, func(ctx context.Context) error {
+		existing, err := s.campaignRepository.LockById(ctx, campaignId)
+		if err != nil {
+			return err
+		}
+		winners, err := s.campaignRepository.FindWinners(ctx, campaignId)
+		if err != nil {
+			return err
+		}
+		vacated := make([]string, 0)
+		exclude := make(map[string]bool, len(winners))
+		nextPosition := 1
+		for _, winner := range winners {
+			exclude[winner.CustomerId] = true
+			nextPosition = max(nextPosition, winner.Position+1)
+			if !winner.ClaimStatus.IsActive() && !winner.Reassigned {
+				vacated = append(vacated, winner.CustomerId)
+			}
+		}
+		if len(vacated) == 0 {
+			return nil
+		}
+
+		inventory := campaign.NewPrizeInventory(campaignId, existing.PrizeInventory, winners)
+		runnersUp, err := s.runnersUp(ctx, existing, exclude, min(len(vacated), inventory.Available))
+		if err != nil {
+			return err
+		}
+		selected, err := s.cacheClient.SMembers(ctx, campaign.WinnersKey(campaignId))
+		if err != nil {
+			return err
+		}
+		count := len(selected)
+		isSelected := make(map[string]bool, len(selected))
+		for _, customerId := range selected {
+			isSelected[customerId] = true
+		}
+		now := time.Now()
+		for i := range runnersUp {
+			if !isSelected[runnersUp[i].CustomerId] {
+				count++
+			}
+			nextPosition = max(nextPosition, count)
+			runnersUp[i].Position = nextPosition
+			runnersUp[i].Replaces = vacated[i]
+			runnersUp[i] = runnersUp[i].StartClaim(now, s.config.Window)
+			nextPosition++
+		}
+		saved, err = s.campaignRepository.SaveWinners(ctx, runnersUp)
+		if err != nil {
+			return err
+		}
+		for _, winner := range saved {
+			if err := s.winnerEvents.SendWinnerSelected(ctx, existing, winner); err != nil {
+				return err
+			}
+			s.logger.Info("Prize handed to the next eligible customer",
+				slog.Int64("campaign_id", campaignId),
+				slog.String("customer_id", winner.CustomerId),
+				slog.String("replaces", winner.Replaces),
+				slog.Int("position", winner.Position),
+			)
+		}
+		if len(saved) < len(vacated) {
+			s.logger.Warn("No eligible customer left for the vacated prizes",
+				slog.Int64("campaign_id", campaignId),
+				slog.Int("vacated", len(vacated)),
+				slog.Int("reassigned", len(saved)),
+			)
+		}
+		return s.campaignRepository.MarkReassigned(ctx, campaignId, vacated)
+	})
+	if err != nil || len(saved) == 0 {
+		return err
+	}
+	customerIds := make([]interface{}, 0, len(saved))
+	for _, winner := range saved {
+		customerIds = append(customerIds, winner.CustomerId)
+	}
+	if _, err = s.cacheClient.Eval(ctx, addReplacementsScript, []string{campaign.WinnersKey(campaignId)}, customerIds...); err != nil {
+		// the replacements are saved, a rebuild of the campaign restores its winners set
+		return fmt.Errorf("add replacements of campaign %d to the winners set: %w", campaignId, err)
+	}
+	return nil
+}
+
+// runnersUp returns up to count customers who would have won next. Draw
+// campaigns continue the ranking of the draw; other campaign types need a rule
+// implementing rules.RunnerUpSelector, and have no runner-up otherwise.
+func (s *claimService) runnersUp(ctx context.Context, input campaign.Campaign, exclude map[string]bool, count int) ([]campaign.Winner, error) {
+	if count <= 0 {
+		return nil, nil
+	}
+	if input.Type == rules.TypeDraw {
+		existing, err := s.drawRepository.GetByCampaignId(ctx, input.Id)
+		if err != nil {
+			return nil, err
+		}
+		if !existing.IsDrawn() {
+			return nil, draw.ErrNotDrawn
+		}
+		entries, err := s.drawRepository.GetEntries(ctx, input.Id)
+		if err != nil {
+			return nil, err
+		}
+		entryOrders := make(map[string]string, len(entries))
+		for _, entry := range entries {
+			entryOrders[entry.CustomerId] = entry.OrderId
+		}
+		runnersUp := make([]campaign.Winner, 0, count)
+		for _, ranked := range draw.SelectWinners(existing.Seed, entries, len(entries)) {
+			if len(runnersUp) >= count {
+				break
+			}
+			if exclude[ranked.CustomerId] {
+				continue
+			}
+			runnersUp = append(runnersUp, campaign.Winner{
+				CampaignId: input.Id,
+				CustomerId: ranked.CustomerId,
+				OrderId:    entryOrders[ranked.CustomerId],
+			})
+		}
+		return runnersUp, nil
+	}
+
+	rule, err := rules.Get(input.Type)
+	if err != nil {
+		return nil, err
+	}
+	selector, ok := rule.(rules.RunnerUpSelector)
+	if !ok {
+		return nil, nil
+	}
+	orders, err := s.orderRepository.GetByCreatedAt(ctx, input.StartTime, input.EndTime)
+	if err != nil {
+		return nil, err
+	}
+	return selector.RunnersUp(input, orders, exclude, count)
+}
@@ -0,0 +1,63 @@
+package claim
+
+import (
+	"context"
+	"log/slog"
+	"specommerce/campaignservice/internal/core/ports/primary"
+	"specommerce/campaignservice/pkg/service_config"
+	"specommerce/campaignservice/pkg/shutdown"
+	"time"
+)
+
+// Sweeper periodically expires the prize claims past their deadline and hands
+// the vacated prizes of the ended campaigns to the next eligible customers.
+type Sweeper struct {
+	claimService primary.ClaimService
+	config       service_config.ClaimConfig
+	shutdownTask *shutdown.Tasks
+	logger       *slog.Logger
+}
+
+func NewSweeper(
+	claimService primary.ClaimService,
+	cfg service_config.ClaimConfig,
+	shutdownTask *shutdown.Tasks,
+	logger *slog.Logger,
+) *Sweeper {
+	return &Sweeper{
+		claimService: claimService,
+		config:       cfg,
+		shutdownTask: shutdownTask,
+		logger:       logger,
+	}
+}
+
+func (s *Sweeper) Start() error {
+	s.logger.Info("Starting prize claim sweeper",
+		slog.Duration("interval", s.config.Interval),
+		slog.Duration("window", s.config.Window),
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	s.shutdownTask.AddShutdownTask(
+		func(_ context.Context) error {
+			cancel()
+			<-stopped
+			return nil
+		},
+	)
+	defer close(stopped)
+
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := s.claimService.SweepClaims(ctx); err != nil {
+				s.logger.Error("Failed to sweep prize claims", slog.String("error", err.Error()))
+			}
+		}
+	}
+}
@@ -0,0 +1,265 @@
+package draw
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"specommerce/campaignservice/internal/core/domain/campaign"
+	"specommerce/campaignservice/internal/core/domain/draw"
+	"specommerce/campaignservice/internal/core/ports/primary"
+	"specommerce/campaignservice/internal/core/ports/secondary"
+	"specommerce/campaignservice/internal/core/rules"
+	"specommerce/campaignservice/pkg/atomicity"
+	"specommerce/campaignservice/pkg/service_config"
+	"strconv"
+	"time"
+)
+
+type drawService struct {
+	drawRepository     secondary.DrawRepository
+	campaignRepository secondary.CampaignRepository
+	winnerEvents       secondary.WinnerEventRepository
+	atomicExecutor     atomicity.AtomicExecutor
+	config             service_config.DrawConfig
+	claimConfig        service_config.ClaimConfig
+	logger             *slog.Logger
+}
+
+func NewDrawService(
+	drawRepository secondary.DrawRepository,
+	campaignRepository secondary.CampaignRepository,
+	winnerEvents secondary.WinnerEventRepository,
+	atomicExecutor atomicity.AtomicExecutor,
+	cfg service_config.DrawConfig,
+	claimConfig service_config.ClaimConfig,
+	logger *slog.Logger,
+) primary.DrawService {
+	return &drawService{
+		drawRepository:     drawRepository,
+		campaignRepository: campaignRepository,
+		winnerEvents:       winnerEvents,
+		atomicExecutor:     atomicExecutor,
+		config:             cfg,
+		claimConfig:        claimConfig,
+		logger:             logger,
+	}
+}
+
+func (s *drawService) GetDraw(ctx context.Context, campaignId int64) (draw.Draw, error) {
+	errTemplate := "drawService GetDraw %w"
+	existing, err := s.drawRepository.GetByCampaignId(ctx, campaignId)
+	if err != nil {
+		return draw.Draw{}, fmt.Errorf(errTemplate, err)
+	}
+	return existing.Public(), nil
+}
+
+// RunDraw enters the qualifying customers, selects the winners and reveals the
+// seed in one transaction. The draw row is locked, so concurrent runs wait and
+// then return the result of the first one. The orders of a lucky draw campaign
+// were drawn as they completed, so its draw only reveals the seed.
+func (s *drawService) RunDraw(ctx context.Context, campaignId int64) (draw.Draw, error) {
+	errTemplate := "drawService RunDraw %w"
+	var result draw.Draw
+	err := s.atomicExecutor.Execute(ctx, func(ctx context.Context) error {
+		existing, err := s.drawRepository.LockByCampaignId(ctx, campaignId)
+		if err != nil {
+			return err
+		}
+		if existing.IsDrawn() {
+			result = existing
+			return nil
+		}
+
+		drawCampaign, err := s.campaignRepository.GetById(ctx, campaignId)
+		if err != nil {
+			return err
+		}
+		if time.Now().Before(drawCampaign.EndTime) {
+			return draw.ErrDrawNotDue
+		}
+		if drawCampaign.Type == rules.TypeLuckyDraw {
+			drawnAt := time.Now()
+			if err := s.drawRepository.MarkDrawn(ctx, campaignId, 0, drawnAt); err != nil {
+				return err
+			}
+			existing.DrawnAt = &drawnAt
+			result = existing
+			return nil
+		}
+		policy, err := rules.DecodePolicy[rules.DrawPolicy](drawCampaign.Policy)
+		if err != nil {
+			return err
+		}
+
+		err = s.drawRepository.CreateEntries(ctx, campaignId, drawCampaign.StartTime, drawCampaign.EndTime, policy.MinOrderAmount)
+		if err != nil {
+			return err
+		}
+		entries, err := s.drawRepository.GetEntries(ctx, campaignId)
+		if err != nil {
+			return err
+		}
+
+		winners := draw.SelectWinners(existing.Seed, entries, int(policy.TotalReward))
+		if err := s.drawRepository.SaveWinners(ctx, winners); err != nil {
+			return err
+		}
+		entryOrders := make(map[string]string, len(entries))
+		for _, entry := range entries {
+			entryOrders[entry.CustomerId] = entry.OrderId
+		}
+		selectedAt := time.Now()
+		campaignWinners := make([]campaign.Winner, 0, len(winners))
+		for _, winner := range winners {
+			campaignWinners = append(campaignWinners, campaign.Winner{
+				CampaignId: campaignId,
+				CustomerId: winner.CustomerId,
+				OrderId:    entryOrders[winner.CustomerId],
+				Position:   winner.Position,
+			}.StartClaim(selectedAt, s.claimConfig.Window))
+		}
+		saved, err := s.campaignRepository.SaveWinners(ctx, campaignWinners)
+		if err != nil {
+			return err
+		}
+		for _, winner := range saved {
+			if err := s.winnerEvents.SendWinnerSelected(ctx, drawCampaign, winner); err != nil {
+				return err
+			}
+		}
+
+		drawnAt := time.Now()
+		if err := s.drawRepository.MarkDrawn(ctx, campaignId, len(entries), drawnAt); err != nil {
+			return err
+		}
+		existing.EntryCount = len(entries)
+		existing.DrawnAt = &drawnAt
+		result = existing
+		return nil
+	})
+	if err != nil {
+		return draw.Draw{}, fmt.Errorf(errTemplate, err)
+	}
+	return result, nil
+}
+
+func (s *drawService) RunDueDraws(ctx context.Context) error {
+	errTemplate := "drawService RunDueDraws %w"
+	campaignIds, err := s.drawRepository.GetDueCampaignIds(ctx, time.Now().Add(-s.config.Delay), s.config.BatchSize)
+	if err != nil {
+		return fmt.Errorf(errTemplate, err)
+	}
+
+	var errs []error
+	for _, campaignId := range campaignIds {
+		result, err := s.RunDraw(ctx, campaignId)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		s.logger.Info("Campaign drawn",
+			slog.Int64("campaign_id", campaignId),
+			slog.Int("entry_count", result.EntryCount),
+			slog.String("seed_hash", result.SeedHash),
+		)
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf(errTemplate, err)
+	}
+	return nil
+}
+
+func (s *drawService) VerifyDraw(ctx context.Context, campaignId int64) (draw.Verification, error) {
+	errTemplate := "drawService VerifyDraw %w"
+	existing, err := s.drawRepository.GetByCampaignId(ctx, campaignId)
+	if err != nil {
+		return draw.Verification{}, fmt.Errorf(errTemplate, err)
+	}
+	if !existing.IsDrawn() {
+		return draw.Verification{}, fmt.Errorf(errTemplate, draw.ErrNotDrawn)
+	}
+	drawCampaign, err := s.campaignRepository.GetById(ctx, campaignId)
+	if err != nil {
+		return draw.Verification{}, fmt.Errorf(errTemplate, err)
+	}
+	if drawCampaign.Type == rules.TypeLuckyDraw {
+		verification, err := s.verifyLuckyDraw(ctx, existing, drawCampaign)
+		if err != nil {
+			return draw.Verification{}, fmt.Errorf(errTemplate, err)
+		}
+		return verification, nil
+	}
+	policy, err := rules.DecodePolicy[rules.DrawPolicy](drawCampaign.Policy)
+	if err != nil {
+		return draw.Verification{}, fmt.Errorf(errTemplate, err)
+	}
+	entries, err := s.drawRepository.GetEntries(ctx, campaignId)
+	if err != nil {
+		return draw.Verification{}, fmt.Errorf(errTemplate, err)
+	}
+	winners, err := s.drawRepository.GetWinners(ctx, campaignId)
+	if err != nil {
+		return draw.Verification{}, fmt.Errorf(errTemplate, err)
+	}
+
+	recomputed := draw.SelectWinners(existing.Seed, entries, int(policy.TotalReward))
+	return draw.Verification{
+		Draw:                  existing,
+		SeedMatchesCommitment: draw.Commitment(existing.Seed) == existing.SeedHash,
+		WinnersMatch:          sameWinners(winners, recomputed),
+		Entries:               entries,
+		Winners:               winners,
+		RecomputedWinners:     recomputed,
+	}, nil
+}
+
+// verifyLuckyDraw rolls the winning order of every winner of a lucky draw
+// campaign again with the revealed seed. The winners scored below the win
+// probability are the recomputed winners.
+func (s *drawService) verifyLuckyDraw(ctx context.Context, existing draw.Draw, drawCampaign campaign.Campaign) (draw.Verification, error) {
+	policy, err := rules.DecodePolicy[rules.LuckyDrawPolicy](drawCampaign.Policy)
+	if err != nil {
+		return draw.Verification{}, err
+	}
+	saved, err := s.campaignRepository.FindWinners(ctx, drawCampaign.Id)
+	if err != nil {
+		return draw.Verification{}, err
+	}
+	winners := make([]draw.Winner, 0, len(saved))
+	recomputed := make([]draw.Winner, 0, len(saved))
+	for _, winner := range saved {
+		roll := draw.Roll(existing.Seed, winner.OrderId)
+		scored := draw.Winner{
+			CampaignId: winner.CampaignId,
+			CustomerId: winner.CustomerId,
+			Position:   winner.Position,
+			Score:      strconv.FormatFloat(roll, 'f', -1, 64),
+		}
+		winners = append(winners, scored)
+		if roll < policy.WinProbability {
+			recomputed = append(recomputed, scored)
+		}
+	}
+	return draw.Verification{
+		Draw:                  existing,
+		SeedMatchesCommitment: draw.Commitment(existing.Seed) == existing.SeedHash,
+		WinnersMatch:          sameWinners(winners, recomputed),
+		Entries:               []draw.Entry{},
+		Winners:               winners,
+		RecomputedWinners:     recomputed,
+	}, nil
+}
+
+func sameWinners(stored []draw.Winner, recomputed []draw.Winner) bool {
+	if len(stored) != len(recomputed) {
+		return false
+	}
+	for i := range stored {
+		if stored[i].CustomerId != recomputed[i].CustomerId || stored[i].Position != recomputed[i].Position {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,62 @@
+package draw
+
+import (
+	"context"
+	"log/slog"
+	"specommerce/campaignservice/internal/core/ports/primary"
+	"specommerce/campaignservice/pkg/service_config"
+	"specommerce/campaignservice/pkg/shutdown"
+	"time"
+)
+
+// Scheduler periodically runs the draws of the campaigns that ended more than draw.delay ago.
+type Scheduler struct {
+	drawService  primary.DrawService
+	config       service_config.DrawConfig
+	shutdownTask *shutdown.Tasks
+	logger       *slog.Logger
+}
+
+func NewScheduler(
+	drawService primary.DrawService,
+	cfg service_config.DrawConfig,
+	shutdownTask *shutdown.Tasks,
+	logger *slog.Logger,
+) *Scheduler {
+	return &Scheduler{
+		drawService:  drawService,
+		config:       cfg,
+		shutdownTask: shutdownTask,
+		logger:       logger,
+	}
+}
+
+func (s *Scheduler) Start() error {
+	s.logger.Info("Starting draw scheduler",
+		slog.Duration("interval", s.config.Interval),
+		slog.Duration("delay", s.config.Delay),
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	s.shutdownTask.AddShutdownTask(
+		func(_ context.Context) error {
+			cancel()
+			<-stopped
+			return nil
+		},
+	)
+	defer close(stopped)
+
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := s.drawService.RunDueDraws(ctx); err != nil {
+				s.logger.Error("Failed to run due draws", slog.String("error", err.Error()))
+			}
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package order
+
+import (
+	"context"
+	"log/slog"
+	"specommerce/campaignservice/internal/core/ports/primary"
+	"specommerce/campaignservice/pkg/service_config"
+	"specommerce/campaignservice/pkg/shutdown"
+	"time"
+)
+
+// Flusher periodically ranks the orders held back for late events once the
+// watermark has passed them, for the campaigns that get no new order event.
+type Flusher struct {
+	orderService primary.OrderService
+	config       service_config.OrderEventsConfig
+	shutdownTask *shutdown.Tasks
+	logger       *slog.Logger
+}
+
+func NewFlusher(
+	orderService primary.OrderService,
+	cfg service_config.OrderEventsConfig,
+	shutdownTask *shutdown.Tasks,
+	logger *slog.Logger,
+) *Flusher {
+	return &Flusher{
+		orderService: orderService,
+		config:       cfg,
+		shutdownTask: shutdownTask,
+		logger:       logger,
+	}
+}
+
+func (f *Flusher) Start() error {
+	f.logger.Info("Starting late order event flusher",
+		slog.Duration("interval", f.config.FlushInterval),
+		slog.Duration("allowed_lateness", f.config.AllowedLateness),
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	f.shutdownTask.AddShutdownTask(
+		func(_ context.Context) error {
+			cancel()
+			<-stopped
+			return nil
+		},
+	)
+	defer close(stopped)
+
+	ticker := time.NewTicker(f.config.FlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := f.orderService.FlushOrders(ctx); err != nil {
+				f.logger.Error("Failed to flush the held back orders", slog.String("error", err.Error()))
+			}
+		}
+	}
+}
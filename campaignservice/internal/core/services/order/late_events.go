@@ -0,0 +1,90 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+	"log"
+	"slices"
+	domain "specommerce/campaignservice/internal/core/domain/campaign"
+	"specommerce/campaignservice/internal/core/rules"
+	"strconv"
+	"time"
+)
+
+// Order events may come out of order, or never: the PENDING event of an order
+// can be lost, or come after its result. The campaigns that rank orders by
+// creation time rank a result without a PENDING event at its creation time,
+// and hold back the orders created after the watermark, which lags the current
+// time by orderEvents.allowedLateness, so that late events can still be ranked
+// before them. An event for an order created before the last ranked order is
+// too late and is dropped from the ranking.
+
+var (
+	// reorderedEvents counts the order results ranked at their creation time
+	// without a PENDING event, by campaign, exposed on /debug/vars
+	reorderedEvents = expvar.NewMap("campaign_reordered_order_events")
+	// droppedEvents counts the order events too late to be ranked, by campaign
+	droppedEvents = expvar.NewMap("campaign_dropped_order_events")
+)
+
+// rankingStatuses are the statuses of the campaigns whose orders are ranked in
+// the background. A PAUSED campaign ranks nothing until it is resumed.
+var rankingStatuses = []domain.Status{domain.StatusScheduled, domain.StatusActive, domain.StatusEnded}
+
+// watermark returns the creation time up to which the orders can be ranked.
+func (s *service) watermark() time.Time {
+	return time.Now().Add(-s.config.OrderEvents.AllowedLateness)
+}
+
+// countArrival counts the reordered and dropped order events of a campaign.
+func countArrival(campaignId int64, arrival rules.Arrival) {
+	switch arrival {
+	case rules.ArrivalReordered:
+		reorderedEvents.Add(strconv.FormatInt(campaignId, 10), 1)
+	case rules.ArrivalDropped:
+		droppedEvents.Add(strconv.FormatInt(campaignId, 10), 1)
+		log.Printf("Dropped a late order event of campaign %d", campaignId)
+	}
+}
+
+func (s *service) FlushOrders(ctx context.Context) error {
+	errTemplate := "orderService FlushOrders %w"
+	campaigns, err := s.campaignRepo.GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf(errTemplate, err)
+	}
+	watermark := s.watermark()
+	var errs []error
+	for _, existing := range campaigns {
+		if !slices.Contains(rankingStatuses, existing.Status) {
+			continue
+		}
+		rule, err := rules.Get(existing.Type)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("campaign %d: %w", existing.Id, err))
+			continue
+		}
+		flusher, ok := rule.(rules.Flusher)
+		if !ok {
+			continue
+		}
+		script := flusher.FlushScript(existing.Id, watermark)
+		result, err := s.cacheClient.Eval(ctx, script.Source, script.Keys, script.Args...)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("campaign %d: %w", existing.Id, err))
+			continue
+		}
+		if values, ok := result.([]interface{}); !ok || len(values) == 0 || values[0] != int64(1) {
+			continue
+		}
+		if err := s.commitWinners(ctx, existing); err != nil {
+			errs = append(errs, fmt.Errorf("campaign %d: %w", existing.Id, err))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf(errTemplate, err)
+	}
+	return nil
+}
@@ -1,225 +1,136 @@
-// Package order implements iPhone campaign business logic.
+// Package order evaluates orders against the running campaigns.
 //
-// Campaign Rules:
-// Gives away free M iPhones (configurable) for
-// * Belong to first N customer (configurable) has successful transaction
-// * Has a transaction greater than minimum (configurable)
-// * One gift per customer (no duplicate)
-// Orders processed in chronological order for fairness
-//
-// Two-Phase Processing Flow:
-// Phase 1: ProcessPendingOrder - Validates and adds new orders to sorted set in chronological order
-// Phase 2: ProcessOrderResult - Processes order completion and selects winners atomically
+// Any number of campaigns can run at the same time. Every order event is
+// evaluated against each campaign whose time window contains the order creation
+// time, with the atomic scripts of the campaign type (see package rules). Each
+// campaign keeps its state in its own Redis keyspace (see campaign.KeyPrefix).
 package order
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"specommerce/campaignservice/config"
+	domain "specommerce/campaignservice/internal/core/domain/campaign"
 	"specommerce/campaignservice/internal/core/domain/order"
 	"specommerce/campaignservice/internal/core/ports/primary"
 	"specommerce/campaignservice/internal/core/ports/secondary"
+	"specommerce/campaignservice/internal/core/rules"
 	"specommerce/campaignservice/pkg/atomicity"
 	"specommerce/campaignservice/pkg/cache"
+	"time"
 )
 
 // OrderService implements the order business logic
 type service struct {
 	orderRepo      secondary.OrderRepository
 	campaignRepo   secondary.CampaignRepository
+	winnerEvents   secondary.WinnerEventRepository
+	progress       primary.ProgressService
 	atomicExecutor atomicity.AtomicExecutor
 	cacheClient    cache.Cache
 	config         config.AppConfig
 }
 
-func NewOrderService(orderRepo secondary.OrderRepository, campaignRepo secondary.CampaignRepository, atomicExecutor atomicity.AtomicExecutor, cacheClient cache.Cache, config config.AppConfig) primary.OrderService {
+func NewOrderService(orderRepo secondary.OrderRepository, campaignRepo secondary.CampaignRepository, winnerEvents secondary.WinnerEventRepository, progress primary.ProgressService, atomicExecutor atomicity.AtomicExecutor, cacheClient cache.Cache, config config.AppConfig) primary.OrderService {
 	return &service{
 		orderRepo:      orderRepo,
 		campaignRepo:   campaignRepo,
+		winnerEvents:   winnerEvents,
+		progress:       progress,
 		atomicExecutor: atomicExecutor,
 		cacheClient:    cacheClient,
 		config:         config,
 	}
 }
 
-// ProcessPendingOrder adds a new order to the iPhone campaign pending orders sorted set.
-// This is Phase 1 of the campaign flow. See ProcessOrderResult for Phase 2.
-//
-// The function executes an atomic Lua script that:
-// 1. Validates order creation time is within campaign time window (start_time_millisecond to end_time_millisecond)
-// 2. Early exits if campaign has reached maximum winners (policy_total_reward from campaign config)
-// 3. Stores transaction data with PENDING status in Redis (transactions:{order_id})
-// 4. Adds order to pending_orders sorted set with score = (created_at - start_time_millisecond)
-//   - Score ensures chronological processing (earliest orders processed first)
-//   - Relative scoring from campaign start time for consistent ordering
-//
-// Orders added here will be processed when their status changes from PENDING to
-// SUCCESS/FAILED, maintaining order creation sequence for fair winner selection.
-// All time values use millisecond precision for accurate chronological ordering.
+// ProcessPendingOrder runs the pending script of every active campaign for a new order,
+// or queues it for the paused ones (see evaluate). The order is saved first so
+// the campaign Redis state can be rebuilt from the database.
 func (s *service) ProcessPendingOrder(ctx context.Context, input order.Order) error {
 	errTemplate := "orderService ProcessPendingOrder %w"
-	luaScript := `
-		local customer_id = KEYS[1]
-		local created_at = tonumber(ARGV[1])
-		local order_id = ARGV[2]
-		local campaign_key = ARGV[3]
-		local start_time_millisecond = tonumber(redis.call('HGET', campaign_key, 'start_time_millisecond'))
-		local end_time_millisecond = tonumber(redis.call('HGET', campaign_key, 'end_time_millisecond'))
-		local policy_total_reward = tonumber(redis.call('HGET',campaign_key, 'policy_total_reward')) or 0
-		local is_campaign_finished = false
-
-		if created_at < start_time_millisecond or created_at > end_time_millisecond then
-			return is_campaign_finished
-		end
-		
-		local winners_key = 'campaign_winners'
-		local winner_count = redis.call('SCARD', winners_key)
-
-		if winner_count == policy_total_reward then
-			is_campaign_finished = true
-			return is_campaign_finished
-		end
-
-		local transaction_key = 'transactions:' .. order_id
-		redis.call('HMSET', transaction_key, 'customer_id', customer_id, 'status', 'PENDING')
-		
-		local pending_orders_key = 'pending_orders'
-		local score = created_at - start_time_millisecond
-		redis.call('ZADD', pending_orders_key, score, order_id)
-		return is_campaign_finished
-	`
-
-	createdAt := input.CreatedAt.UnixMilli()
-	campaignKey := fmt.Sprintf("campaign:%s", s.config.IphoneCampaign)
-	_, err := s.cacheClient.Eval(ctx, luaScript, []string{input.CustomerId}, createdAt, input.Id.String(), campaignKey)
+	if _, err := s.orderRepo.Upsert(ctx, input); err != nil {
+		return fmt.Errorf(errTemplate, err)
+	}
+	campaigns, err := s.campaignRepo.GetActiveCampaigns(ctx, input.CreatedAt)
 	if err != nil {
 		return fmt.Errorf(errTemplate, err)
 	}
 
+	var errs []error
+	for _, activeCampaign := range campaigns {
+		if err := s.evaluate(ctx, activeCampaign, scriptPending, input); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf(errTemplate, err)
+	}
 	return nil
 }
 
-// ProcessOrderResult processes order completion for iPhone campaign winner selection.
-// This is Phase 2 of the campaign flow. Processes orders added by ProcessPendingOrder.
-//
-// The function executes an atomic Lua script that:
-// 1. Early exits if campaign has reached maximum winners (policy_total_reward)
-// 2. Stores current transaction data (customer_id, status) in Redis
-// 3. Updates customer's maximum transaction amount for successful orders
-// 4. Checks if current order qualifies customer as immediate winner:
-//   - Order status is SUCCESS
-//   - Customer not already a winner
-//   - Customer is in eligible set
-//   - Customer's max amount >= minimum order amount policy
-//
-// 5. Recursively processes pending orders sorted set (oldest first):
-//   - Stops immediately if encountering order still in PENDING status
-//   - Skips failed orders and orders from existing winners (removes from sorted set and continues)
-//   - Adds qualifying customers to eligible set (respects max tracked limit)
-//   - Promotes eligible customers to winners if they meet amount threshold
-//   - Continues until sorted set empty or winner quota reached
-//
-// Returns {has_new_winner, winners_count} indicating if any new winners were
-// added and current total winner count. All operations are atomic to prevent
-// race conditions in concurrent order processing.
-func (s *service) ProcessOrderResult(ctx context.Context, order order.Order) error {
-	errTemplate := "orderService ProcessOrderResult %w"
-	luaScript := `
-		local customer_id = KEYS[1]
-		local order_id = ARGV[1]
-		local order_status = ARGV[2]
-		local order_total_amount = tonumber(ARGV[3])
-		local campaign_key = ARGV[4]
-		local winners_key = 'campaign_winners'
-		local eligible_key = 'campaign_eligible'
-        local pending_orders_key = 'pending_orders'
-		local transaction_key = 'transactions'
-		local customer_key = 'customers'
-		local policy_total_reward = tonumber(redis.call('HGET',campaign_key, 'policy_total_reward')) or 0
-		local policy_min_order_amount = tonumber(redis.call('HGET', campaign_key, 'policy_min_order_amount')) or 0
-		local policy_max_tracked_orders = tonumber(redis.call('HGET', campaign_key, 'policy_max_tracked_orders')) or 0
-		local has_new_winner = false
-		local is_campaign_finished = false
-
-		local winners_count = redis.call('SCARD', winners_key)
-		if winners_count == policy_total_reward then
-			is_campaign_finished = true
-			return {has_new_winner, is_campaign_finished}
-		end
-
-		local current_order_id_key = transaction_key .. ':' .. order_id
-        local current_customer_id_key = customer_key .. ':' .. customer_id
-        redis.call('HMSET', current_order_id_key, 'customer_id', customer_id, 'status', order_status)
-        local current_max_total_amount = tonumber(redis.call('HGET', current_customer_id_key, 'max_total_amount')) or 0
-        if order_status == 'SUCCESS' then
-              current_max_total_amount = math.max(current_max_total_amount, order_total_amount)
-			  redis.call('HSET', current_customer_id_key, 'max_total_amount', current_max_total_amount)
-  		end
-        
-		if order_status == 'SUCCESS' and redis.call('SISMEMBER', winners_key, customer_id) == 0 and redis.call('SISMEMBER', eligible_key, customer_id) == 1 and current_max_total_amount >= policy_min_order_amount then
-            redis.call('SADD', winners_key, customer_id)
-			has_new_winner = true
-		end
-
-		local function recursive_pop() 
-			local winners_count = redis.call('SCARD', winners_key)
-            if winners_count == policy_total_reward then
-				is_campaign_finished = true
-                return {has_new_winner, is_campaign_finished}
-			end
-
-			local elements = redis.call('ZRANGE', pending_orders_key, 0, 0, 'WITHSCORES')
-			
-			if #elements == 0 then
-				return {has_new_winner, is_campaign_finished}
-			end
-			
-			local current_order_id = elements[1]
-			local current_order_id_key = transaction_key .. ':' .. current_order_id
-			local current_customer_id = redis.call('HGET', current_order_id_key, 'customer_id')
-			local current_customer_id_key = customer_key .. ':' .. current_customer_id
-			local current_status = redis.call('HGET', current_order_id_key, 'status')
-            if current_status == 'PENDING' then
-				return {has_new_winner, is_campaign_finished}
-			end
-
-			redis.call('ZREM', pending_orders_key, current_order_id)
-
-			if current_status == 'FAILED' then
-				return recursive_pop()
-			end
-
-            if redis.call('SISMEMBER', winners_key, current_customer_id) == 1 then
-				return recursive_pop()  
-			end
-
-			if redis.call('SISMEMBER', eligible_key, current_customer_id) == 0 and redis.call('SCARD', eligible_key) == policy_max_tracked_orders then
-				return recursive_pop()
-			end
+func (s *service) processPendingOrder(ctx context.Context, activeCampaign domain.Campaign, input order.Order) error {
+	errTemplate := "orderService processPendingOrder campaign %d: %w"
+	rule, err := rules.Get(activeCampaign.Type)
+	if err != nil {
+		return fmt.Errorf(errTemplate, activeCampaign.Id, err)
+	}
+	script := rule.PendingScript(activeCampaign.Id, input)
+	if script == nil {
+		return nil
+	}
 
-			redis.call('SADD', eligible_key, current_customer_id)
+	result, err := s.cacheClient.Eval(ctx, script.Source, script.Keys, script.Args...)
+	if err != nil {
+		return fmt.Errorf(errTemplate, activeCampaign.Id, err)
+	}
+	countArrival(activeCampaign.Id, rules.ArrivalOf(result, rules.PendingArrivalIndex))
 
-			local current_max_total_amount = tonumber(redis.call('HGET', current_customer_id_key, 'max_total_amount')) or 0
+	return nil
+}
 
+// ProcessOrderResult saves the new status of an order and runs the result script
+// of every active campaign for it. The winners selected by a script are saved to
+// the database, and the live progress of the campaign is published to the admin portal.
+func (s *service) ProcessOrderResult(ctx context.Context, input order.Order) error {
+	errTemplate := "orderService ProcessOrderResult %w"
+	if _, err := s.orderRepo.Upsert(ctx, input); err != nil {
+		return fmt.Errorf(errTemplate, err)
+	}
+	campaigns, err := s.campaignRepo.GetActiveCampaigns(ctx, input.CreatedAt)
+	if err != nil {
+		return fmt.Errorf(errTemplate, err)
+	}
 
-			if current_max_total_amount >= policy_min_order_amount then
-				redis.call('SADD', winners_key, current_customer_id)
-				has_new_winner = true
-			end
+	var errs []error
+	for _, activeCampaign := range campaigns {
+		if err := s.evaluate(ctx, activeCampaign, scriptResult, input); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf(errTemplate, err)
+	}
+	return nil
+}
 
-			return recursive_pop()
-		end
-		return recursive_pop()  -- Start the recursion
-	`
-	campaignKey := fmt.Sprintf("campaign:%s", s.config.IphoneCampaign)
-	result, err := s.cacheClient.Eval(ctx, luaScript, []string{order.CustomerId}, order.Id.String(), order.Status.String(), order.TotalAmount, campaignKey)
+func (s *service) processOrderResult(ctx context.Context, activeCampaign domain.Campaign, input order.Order) error {
+	errTemplate := "orderService processOrderResult campaign %d: %w"
+	campaignId := activeCampaign.Id
+	rule, err := rules.Get(activeCampaign.Type)
 	if err != nil {
-		return fmt.Errorf(errTemplate, err)
+		return fmt.Errorf(errTemplate, campaignId, err)
+	}
+	script := rule.ResultScript(campaignId, input, s.watermark())
+	result, err := s.cacheClient.Eval(ctx, script.Source, script.Keys, script.Args...)
+	if err != nil {
+		return fmt.Errorf(errTemplate, campaignId, err)
 	}
 
-	// Parse Lua script result: [has_new_winner, is_campaign_finished]
-	if resultArray, ok := result.([]interface{}); ok && len(resultArray) == 2 {
+	// Parse Lua script result: [has_new_winner, is_campaign_finished, arrival]
+	if resultArray, ok := result.([]interface{}); ok && len(resultArray) >= 2 {
 		// Redis Lua returns booleans as integers: 1 = true, 0 = false
 		hasNewWinner := false
 		isCampaignFinished := false
@@ -231,41 +142,97 @@ func (s *service) ProcessOrderResult(ctx context.Context, order order.Order) err
 			isCampaignFinished = true
 		}
 
-		log.Printf("Lua result: has_new_winner=%v, is_campaign_finished=%v", hasNewWinner, isCampaignFinished)
+		log.Printf("Lua result: campaign=%d, has_new_winner=%v, is_campaign_finished=%v", campaignId, hasNewWinner, isCampaignFinished)
+	}
+	countArrival(campaignId, rules.ArrivalOf(result, rules.ResultArrivalIndex))
 
-		if hasNewWinner && isCampaignFinished {
-			campaign, err := s.campaignRepo.GetCampaignByType(ctx, "iphone")
-			if err != nil {
-				return fmt.Errorf(errTemplate, err)
-			}
+	if err := s.commitWinners(ctx, activeCampaign); err != nil {
+		return fmt.Errorf(errTemplate, campaignId, err)
+	}
+	return nil
+}
 
-			// Get all winners from Redis campaign_winners set
-			winners, err := s.cacheClient.SMembers(ctx, "campaign_winners")
-			if err != nil {
-				return fmt.Errorf(errTemplate, err)
-			}
+// commitWinners saves the winners selected by the scripts of a campaign and
+// publishes its progress.
+func (s *service) commitWinners(ctx context.Context, activeCampaign domain.Campaign) error {
+	saved, err := s.saveWinners(ctx, activeCampaign)
+	if err != nil {
+		return err
+	}
+	// The progress is only informative, the order is not retried for it
+	if err := s.progress.PublishProgress(ctx, activeCampaign, saved); err != nil {
+		log.Printf("Failed to publish the progress of campaign %d: %v", activeCampaign.Id, err)
+	}
+	return nil
+}
 
-			log.Printf("Campaign finished! All winners: %v", winners)
+// saveWinners moves the winners queued by the result scripts to the winners
+// table. It runs after every result, so winners left in the list by a failed
+// or interrupted save are saved with the next order. A WinnerSelected event is
+// staged in the same transaction for every winner actually inserted. The saved
+// entries are only removed from the head of the list if still there, as
+// another consumer may have saved them already. Returns the winners inserted.
+func (s *service) saveWinners(ctx context.Context, activeCampaign domain.Campaign) ([]domain.Winner, error) {
+	campaignId := activeCampaign.Id
+	key := domain.UnsavedWinnersKey(campaignId)
+	entries, err := s.cacheClient.LRange(ctx, key, 0, -1)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
 
-			// Save all winners to database
-			for _, customerID := range winners {
-				err := s.campaignRepo.SaveWinner(ctx, campaign.Id, customerID)
-				if err != nil {
-					log.Printf("Failed to save winner %s: %v", customerID, err)
-					// Continue with other winners even if one fails
-				} else {
-					log.Printf("Successfully saved winner: %s", customerID)
-				}
+	selectedAt := time.Now()
+	winners := make([]domain.Winner, 0, len(entries))
+	for _, entry := range entries {
+		winner := domain.Winner{CampaignId: campaignId}
+		if err := json.Unmarshal([]byte(entry), &winner); err != nil {
+			return nil, err
+		}
+		winners = append(winners, winner.StartClaim(selectedAt, s.config.Claim.Window))
+	}
+	var saved []domain.Winner
+	err = s.atomicExecutor.Execute(ctx, func(ctx context.Context) error {
+		var err error
+		saved, err = s.campaignRepo.SaveWinners(ctx, winners)
+		if err != nil {
+			return err
+		}
+		for _, winner := range saved {
+			if err := s.winnerEvents.SendWinnerSelected(ctx, activeCampaign, winner); err != nil {
+				return err
 			}
 		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	luaScript := `
+		for i = 1, #ARGV do
+			if redis.call('LINDEX', KEYS[1], 0) ~= ARGV[i] then
+				return i - 1
+			end
+			redis.call('LPOP', KEYS[1])
+		end
+		return #ARGV
+	`
+	args := make([]any, 0, len(entries))
+	for _, entry := range entries {
+		args = append(args, entry)
+	}
+	if _, err := s.cacheClient.Eval(ctx, luaScript, []string{key}, args...); err != nil {
+		return nil, err
+	}
+	log.Printf("Saved %d winners of campaign %d", len(winners), campaignId)
+	return saved, nil
 }
 
 func (s *service) SaveSuccessOrder(ctx context.Context, input order.Order) error {
 	errTemplate := "orderService SaveSuccessOrder %w"
-	_, err := s.orderRepo.Create(ctx, input)
+	_, err := s.orderRepo.Upsert(ctx, input)
 	if err != nil {
 		return fmt.Errorf(errTemplate, err)
 	}
@@ -0,0 +1,122 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+	"log"
+	"slices"
+	domain "specommerce/campaignservice/internal/core/domain/campaign"
+	"specommerce/campaignservice/internal/core/domain/order"
+	"specommerce/campaignservice/internal/core/rules"
+	"strconv"
+	"time"
+
+	"github.com/rs/xid"
+)
+
+// The campaigns that rank orders stop at the first order still PENDING, so an
+// order whose payment never completes would block the ranking of every order
+// created after it. An order still PENDING after pendingSweep.timeout is
+// expired: it is settled with its status in the orders table when its result
+// was stored without reaching the campaign, or as FAILED otherwise, and the
+// ranking resumes. Every expiry is recorded in the campaign audit trail.
+
+// expiredOrders counts the expired pending orders, by campaign, exposed on /debug/vars
+var expiredOrders = expvar.NewMap("campaign_expired_pending_orders")
+
+func (s *service) SweepPendingOrders(ctx context.Context) error {
+	errTemplate := "orderService SweepPendingOrders %w"
+	campaigns, err := s.campaignRepo.GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf(errTemplate, err)
+	}
+	var errs []error
+	for _, existing := range campaigns {
+		if !slices.Contains(rankingStatuses, existing.Status) {
+			continue
+		}
+		rule, err := rules.Get(existing.Type)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("campaign %d: %w", existing.Id, err))
+			continue
+		}
+		expirer, ok := rule.(rules.PendingExpirer)
+		if !ok {
+			continue
+		}
+		if err := s.expirePendingOrders(ctx, existing, expirer); err != nil {
+			errs = append(errs, fmt.Errorf("campaign %d: %w", existing.Id, err))
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf(errTemplate, err)
+	}
+	return nil
+}
+
+// expirePendingOrders expires a batch of the stale pending orders of a campaign,
+// oldest first, and saves the winners they unblocked.
+func (s *service) expirePendingOrders(ctx context.Context, activeCampaign domain.Campaign, expirer rules.PendingExpirer) error {
+	timeout := s.config.PendingSweep.Timeout
+	script := expirer.StalePendingScript(activeCampaign.Id, time.Now().Add(-timeout), s.config.PendingSweep.BatchSize)
+	result, err := s.cacheClient.Eval(ctx, script.Source, script.Keys, script.Args...)
+	if err != nil {
+		return err
+	}
+	values, _ := result.([]interface{})
+	stale := make([]order.Order, 0, len(values)/2)
+	ids := make([]xid.ID, 0, len(values)/2)
+	for i := 0; i+1 < len(values); i += 2 {
+		orderId, _ := values[i].(string)
+		id, err := xid.FromString(orderId)
+		if err != nil {
+			return fmt.Errorf("invalid pending order id %q: %w", orderId, err)
+		}
+		customerId, _ := values[i+1].(string)
+		ids = append(ids, id)
+		stale = append(stale, order.Order{Id: id, CustomerId: customerId, Status: order.OrderStatusFailed})
+	}
+	if len(stale) == 0 {
+		return nil
+	}
+
+	stored, err := s.orderRepo.GetByIds(ctx, ids)
+	if err != nil {
+		return err
+	}
+	settled := make(map[xid.ID]order.Order, len(stored))
+	for _, o := range stored {
+		if o.Status != order.OrderStatusPending {
+			settled[o.Id] = o
+		}
+	}
+
+	hasNewWinner := false
+	for _, input := range stale {
+		reason := fmt.Sprintf("still PENDING after %s, treated as FAILED", timeout)
+		if o, ok := settled[input.Id]; ok {
+			input = o
+			reason = fmt.Sprintf("still PENDING after %s, settled as %s from the orders table", timeout, o.Status)
+		}
+		script := expirer.ExpireScript(activeCampaign.Id, input, reason, s.watermark())
+		result, err := s.cacheClient.Eval(ctx, script.Source, script.Keys, script.Args...)
+		if err != nil {
+			return err
+		}
+		// Redis Lua returns booleans as integers: 1 = true, false = nil
+		values, ok := result.([]interface{})
+		if !ok || len(values) != 3 || values[2] != int64(1) {
+			// The result of the order came in the meantime
+			continue
+		}
+		hasNewWinner = hasNewWinner || values[0] == int64(1)
+		expiredOrders.Add(strconv.FormatInt(activeCampaign.Id, 10), 1)
+		log.Printf("Expired pending order %s of campaign %d as %s", input.Id, activeCampaign.Id, input.Status)
+	}
+	if !hasNewWinner {
+		return nil
+	}
+	return s.commitWinners(ctx, activeCampaign)
+}
@@ -0,0 +1,63 @@
+package order
+
+import (
+	"context"
+	"log/slog"
+	"specommerce/campaignservice/internal/core/ports/primary"
+	"specommerce/campaignservice/pkg/service_config"
+	"specommerce/campaignservice/pkg/shutdown"
+	"time"
+)
+
+// PendingSweeper periodically expires the orders still PENDING after the
+// pending timeout, so that they no longer block the ranking of the campaigns.
+type PendingSweeper struct {
+	orderService primary.OrderService
+	config       service_config.PendingSweepConfig
+	shutdownTask *shutdown.Tasks
+	logger       *slog.Logger
+}
+
+func NewPendingSweeper(
+	orderService primary.OrderService,
+	cfg service_config.PendingSweepConfig,
+	shutdownTask *shutdown.Tasks,
+	logger *slog.Logger,
+) *PendingSweeper {
+	return &PendingSweeper{
+		orderService: orderService,
+		config:       cfg,
+		shutdownTask: shutdownTask,
+		logger:       logger,
+	}
+}
+
+func (p *PendingSweeper) Start() error {
+	p.logger.Info("Starting stale pending order sweeper",
+		slog.Duration("interval", p.config.Interval),
+		slog.Duration("timeout", p.config.Timeout),
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	p.shutdownTask.AddShutdownTask(
+		func(_ context.Context) error {
+			cancel()
+			<-stopped
+			return nil
+		},
+	)
+	defer close(stopped)
+
+	ticker := time.NewTicker(p.config.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := p.orderService.SweepPendingOrders(ctx); err != nil {
+				p.logger.Error("Failed to sweep the stale pending orders", slog.String("error", err.Error()))
+			}
+		}
+	}
+}
@@ -0,0 +1,266 @@
+package order
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"expvar"
+	"fmt"
+	"log"
+	domain "specommerce/campaignservice/internal/core/domain/campaign"
+	"specommerce/campaignservice/internal/core/domain/order"
+	"strconv"
+
+	"github.com/rs/xid"
+)
+
+const (
+	scriptPending = "pending"
+	scriptResult  = "result"
+)
+
+// queuedOrder is an order event waiting in the queue of a paused campaign.
+type queuedOrder struct {
+	Script string      `json:"script"`
+	Order  order.Order `json:"order"`
+}
+
+// queueScript appends an order event to the campaign queue when the campaign
+// is paused (ARGV[1] = 1) or rebuilt (KEYS[2] is held), or older events are
+// still queued, so the events of a resumed campaign are evaluated in arrival
+// order. Returns 1 when queued.
+const queueScript = `
+	if ARGV[1] == '1' or redis.call('EXISTS', KEYS[2]) == 1 or redis.call('LLEN', KEYS[1]) > 0 then
+		redis.call('RPUSH', KEYS[1], ARGV[2])
+		return 1
+	end
+	return 0
+`
+
+const existsScript = `return redis.call('EXISTS', KEYS[1])`
+
+const lockScript = `
+	if redis.call('SET', KEYS[1], ARGV[1], 'NX', 'PX', ARGV[2]) then
+		return 1
+	end
+	return 0
+`
+
+const unlockScript = `
+	if redis.call('GET', KEYS[1]) == ARGV[1] then
+		return redis.call('DEL', KEYS[1])
+	end
+	return 0
+`
+
+// popScript removes the head of the queue if it is still the replayed event,
+// with its failed attempts, and extends the replay lock while it is held.
+const popScript = `
+	if redis.call('LINDEX', KEYS[1], 0) == ARGV[1] then
+		redis.call('LPOP', KEYS[1])
+		redis.call('HDEL', KEYS[3], ARGV[1])
+	end
+	if redis.call('GET', KEYS[2]) == ARGV[2] then
+		redis.call('PEXPIRE', KEYS[2], ARGV[3])
+	end
+	return 1
+`
+
+// failScript counts a failed replay of the head of the queue if it is still the
+// replayed event. After ARGV[2] failures the event is moved to the dead queue,
+// so it no longer blocks the events queued behind it. Returns the number of
+// failures, or -1 when the event was moved.
+const failScript = `
+	if redis.call('LINDEX', KEYS[1], 0) ~= ARGV[1] then
+		return 0
+	end
+	local attempts = redis.call('HINCRBY', KEYS[2], ARGV[1], 1)
+	if attempts < tonumber(ARGV[2]) then
+		return attempts
+	end
+	redis.call('LPOP', KEYS[1])
+	redis.call('HDEL', KEYS[2], ARGV[1])
+	redis.call('RPUSH', KEYS[3], ARGV[1])
+	return -1
+`
+
+// deadQueuedOrders counts the queued order events moved to the dead queue, by
+// campaign, exposed on /debug/vars
+var deadQueuedOrders = expvar.NewMap("campaign_dead_queued_order_events")
+
+// evaluate runs the script of a campaign for an order event, unless the
+// campaign is paused or still has queued events, in which case the event is
+// queued. If the campaign was resumed or ended in the meantime, the queue is
+// replayed right away so the event is not left behind.
+func (s *service) evaluate(ctx context.Context, activeCampaign domain.Campaign, script string, input order.Order) error {
+	errTemplate := "orderService evaluate campaign %d: %w"
+	queued, err := s.queue(ctx, activeCampaign, script, input)
+	if err != nil {
+		return fmt.Errorf(errTemplate, activeCampaign.Id, err)
+	}
+	if !queued {
+		return s.run(ctx, activeCampaign, script, input)
+	}
+
+	current, err := s.campaignRepo.GetById(ctx, activeCampaign.Id)
+	if err != nil {
+		return fmt.Errorf(errTemplate, activeCampaign.Id, err)
+	}
+	if current.Status == domain.StatusPaused {
+		return nil
+	}
+	// The event is safely queued, a failed replay is retried by the campaign scheduler
+	if err := s.ProcessQueuedOrders(ctx, activeCampaign.Id); err != nil {
+		log.Printf("Failed to replay the queued orders of campaign %d: %v", activeCampaign.Id, err)
+	}
+	return nil
+}
+
+func (s *service) queue(ctx context.Context, activeCampaign domain.Campaign, script string, input order.Order) (bool, error) {
+	entry, err := json.Marshal(queuedOrder{Script: script, Order: input})
+	if err != nil {
+		return false, err
+	}
+	paused := "0"
+	if activeCampaign.Status == domain.StatusPaused {
+		paused = "1"
+	}
+	keys := []string{domain.QueuedOrdersKey(activeCampaign.Id), domain.RebuildLockKey(activeCampaign.Id)}
+	result, err := s.cacheClient.Eval(ctx, queueScript, keys, paused, string(entry))
+	if err != nil {
+		return false, err
+	}
+	return result == int64(1), nil
+}
+
+func (s *service) run(ctx context.Context, activeCampaign domain.Campaign, script string, input order.Order) error {
+	if script == scriptPending {
+		return s.processPendingOrder(ctx, activeCampaign, input)
+	}
+	return s.processOrderResult(ctx, activeCampaign, input)
+}
+
+// ProcessQueuedOrders replays the order events queued while a campaign was
+// paused or rebuilt, oldest first, until the queue is empty or the campaign is
+// paused or rebuilt again. Only one instance replays a queue at a time, and an event is removed
+// from the queue once evaluated, so an interrupted replay resumes where it stopped.
+// An event whose replay keeps failing is moved to the dead queue after
+// schedule.maxReplayAttempts failures, and the replay goes on with the next one.
+func (s *service) ProcessQueuedOrders(ctx context.Context, campaignId int64) error {
+	errTemplate := "orderService ProcessQueuedOrders %w"
+	lockKey := domain.QueueLockKey(campaignId)
+	owner := xid.New().String()
+	for {
+		pending, err := s.cacheClient.LRange(ctx, domain.QueuedOrdersKey(campaignId), 0, 0)
+		if err != nil {
+			return fmt.Errorf(errTemplate, err)
+		}
+		if len(pending) == 0 {
+			return nil
+		}
+		locked, err := s.cacheClient.Eval(ctx, lockScript, []string{lockKey}, owner, s.config.Schedule.LockTtl.Milliseconds())
+		if err != nil {
+			return fmt.Errorf(errTemplate, err)
+		}
+		if locked != int64(1) {
+			// Another instance is replaying the queue
+			return nil
+		}
+		paused, err := s.replay(ctx, campaignId, owner)
+		if _, unlockErr := s.cacheClient.Eval(ctx, unlockScript, []string{lockKey}, owner); err == nil {
+			err = unlockErr
+		}
+		if err != nil {
+			return fmt.Errorf(errTemplate, err)
+		}
+		if paused {
+			return nil
+		}
+		// Check again for the events queued after the queue was found empty and
+		// before the lock was released
+	}
+}
+
+// replay evaluates the queued events of a campaign under the replay lock. It
+// stops when the queue is empty, or reports that the campaign was paused again
+// or is being rebuilt.
+func (s *service) replay(ctx context.Context, campaignId int64, owner string) (bool, error) {
+	keys := []string{domain.QueuedOrdersKey(campaignId), domain.QueueLockKey(campaignId), domain.QueueAttemptsKey(campaignId)}
+	replayed := 0
+	defer func() {
+		if replayed > 0 {
+			log.Printf("Replayed %d queued orders of campaign %d", replayed, campaignId)
+		}
+	}()
+	for {
+		current, err := s.campaignRepo.GetById(ctx, campaignId)
+		if err != nil {
+			return false, err
+		}
+		if current.Status == domain.StatusPaused {
+			return true, nil
+		}
+		rebuilding, err := s.cacheClient.Eval(ctx, existsScript, []string{domain.RebuildLockKey(campaignId)})
+		if err != nil {
+			return false, err
+		}
+		if rebuilding == int64(1) {
+			// The rebuild replays the queue once done
+			return true, nil
+		}
+		entries, err := s.cacheClient.LRange(ctx, keys[0], 0, 0)
+		if err != nil {
+			return false, err
+		}
+		if len(entries) == 0 {
+			return false, nil
+		}
+		var event queuedOrder
+		err = json.Unmarshal([]byte(entries[0]), &event)
+		if err == nil {
+			err = s.run(ctx, current, event.Script, event.Order)
+		} else {
+			// an entry that cannot be decoded never will
+			err = fmt.Errorf("%w: %w", errUndecodableEntry, err)
+		}
+		if err != nil {
+			moved, failErr := s.failQueuedOrder(ctx, campaignId, entries[0], err)
+			if failErr != nil {
+				return false, failErr
+			}
+			if !moved {
+				return false, err
+			}
+			continue
+		}
+		if _, err := s.cacheClient.Eval(ctx, popScript, keys, entries[0], owner, s.config.Schedule.LockTtl.Milliseconds()); err != nil {
+			return false, err
+		}
+		replayed++
+	}
+}
+
+// errUndecodableEntry fails a queued entry at once, as replaying it cannot succeed
+var errUndecodableEntry = errors.New("queued order event cannot be decoded")
+
+// failQueuedOrder counts a failed replay of the head of the queue and reports
+// whether it was moved to the dead queue.
+func (s *service) failQueuedOrder(ctx context.Context, campaignId int64, entry string, cause error) (bool, error) {
+	maxAttempts := s.config.Schedule.MaxReplayAttempts
+	if errors.Is(cause, errUndecodableEntry) {
+		maxAttempts = 1
+	}
+	keys := []string{domain.QueuedOrdersKey(campaignId), domain.QueueAttemptsKey(campaignId), domain.DeadQueuedOrdersKey(campaignId)}
+	result, err := s.cacheClient.Eval(ctx, failScript, keys, entry, maxAttempts)
+	if err != nil {
+		return false, err
+	}
+	attempts, _ := result.(int64)
+	if attempts != -1 {
+		log.Printf("Failed to replay a queued order of campaign %d, attempt %d: %v", campaignId, attempts, cause)
+		return false, nil
+	}
+	deadQueuedOrders.Add(strconv.FormatInt(campaignId, 10), 1)
+	log.Printf("Moved a queued order of campaign %d to the dead queue: %v", campaignId, cause)
+	return true, nil
+}
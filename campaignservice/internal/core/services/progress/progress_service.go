@@ -0,0 +1,144 @@
+// Package progress pushes the live counters of the campaigns to the admin portal.
+//
+// The order service publishes the progress of a campaign on its Redis Pub/Sub
+// channel (see campaign.ProgressChannel) after every evaluated order result, so
+// subscribers get the updates of every instance, whichever consumed the order.
+package progress
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"specommerce/campaignservice/internal/core/domain/campaign"
+	"specommerce/campaignservice/internal/core/ports/primary"
+	"specommerce/campaignservice/internal/core/ports/secondary"
+	"specommerce/campaignservice/internal/core/rules"
+	"specommerce/campaignservice/pkg/cache"
+	"time"
+)
+
+// countersScript reads the counters of a campaign at once:
+// {pending_orders, eligible, winners, total_reward}.
+const countersScript = `
+	local total_reward = tonumber(redis.call('HGET', KEYS[1], ARGV[1]) or '0') or 0
+	return {
+		redis.call('ZCARD', KEYS[2]),
+		redis.call('SCARD', KEYS[3]),
+		redis.call('SCARD', KEYS[4]),
+		total_reward
+	}
+`
+
+type progressService struct {
+	campaignRepository secondary.CampaignRepository
+	cacheClient        cache.Cache
+	logger             *slog.Logger
+}
+
+func NewProgressService(
+	campaignRepository secondary.CampaignRepository,
+	cacheClient cache.Cache,
+	logger *slog.Logger,
+) primary.ProgressService {
+	return &progressService{
+		campaignRepository: campaignRepository,
+		cacheClient:        cacheClient,
+		logger:             logger,
+	}
+}
+
+func (s *progressService) GetProgress(ctx context.Context, campaignId int64) (campaign.Progress, error) {
+	errTemplate := "progressService GetProgress %w"
+	existing, err := s.campaignRepository.GetById(ctx, campaignId)
+	if err != nil {
+		return campaign.Progress{}, fmt.Errorf(errTemplate, err)
+	}
+	progress, err := s.counters(ctx, existing)
+	if err != nil {
+		return campaign.Progress{}, fmt.Errorf(errTemplate, err)
+	}
+	return progress, nil
+}
+
+func (s *progressService) PublishProgress(ctx context.Context, input campaign.Campaign, newWinners []campaign.Winner) error {
+	errTemplate := "progressService PublishProgress campaign %d: %w"
+	progress, err := s.counters(ctx, input)
+	if err != nil {
+		return fmt.Errorf(errTemplate, input.Id, err)
+	}
+	progress.NewWinners = newWinners
+	message, err := json.Marshal(progress)
+	if err != nil {
+		return fmt.Errorf(errTemplate, input.Id, err)
+	}
+	if err := s.cacheClient.Publish(ctx, campaign.ProgressChannel(input.Id), message); err != nil {
+		return fmt.Errorf(errTemplate, input.Id, err)
+	}
+	return nil
+}
+
+func (s *progressService) SubscribeProgress(ctx context.Context, campaignId int64) (<-chan campaign.Progress, error) {
+	errTemplate := "progressService SubscribeProgress %w"
+	messages, err := s.cacheClient.Subscribe(ctx, campaign.ProgressChannel(campaignId))
+	if err != nil {
+		return nil, fmt.Errorf(errTemplate, err)
+	}
+	updates := make(chan campaign.Progress)
+	go func() {
+		defer close(updates)
+		for message := range messages {
+			var progress campaign.Progress
+			if err := json.Unmarshal([]byte(message), &progress); err != nil {
+				s.logger.Error("Failed to decode the campaign progress",
+					slog.Int64("campaign_id", campaignId),
+					slog.String("error", err.Error()))
+				continue
+			}
+			select {
+			case updates <- progress:
+			case <-ctx.Done():
+				// Drain the messages until the subscription is closed
+			}
+		}
+	}()
+	return updates, nil
+}
+
+// counters reads the live counters of a campaign from its Redis state. The
+// eligible customers of a draw campaign are its entrants.
+func (s *progressService) counters(ctx context.Context, input campaign.Campaign) (campaign.Progress, error) {
+	eligibleKey := campaign.EligibleKey(input.Id)
+	if input.Type == rules.TypeDraw {
+		eligibleKey = campaign.EntriesKey(input.Id)
+	}
+	keys := []string{
+		campaign.InfoKey(input.Id),
+		campaign.PendingOrdersKey(input.Id),
+		eligibleKey,
+		campaign.WinnersKey(input.Id),
+	}
+	result, err := s.cacheClient.Eval(ctx, countersScript, keys, rules.PolicyFieldPrefix+"total_reward")
+	if err != nil {
+		return campaign.Progress{}, err
+	}
+	values, ok := result.([]interface{})
+	if !ok || len(values) != 4 {
+		return campaign.Progress{}, fmt.Errorf("unexpected counters %v", result)
+	}
+	counters := make([]int64, len(values))
+	for i, value := range values {
+		if counters[i], ok = value.(int64); !ok {
+			return campaign.Progress{}, fmt.Errorf("unexpected counters %v", result)
+		}
+	}
+	return campaign.Progress{
+		CampaignId:    input.Id,
+		PendingOrders: counters[0],
+		Eligible:      counters[1],
+		Winners:       counters[2],
+		TotalReward:   counters[3],
+		Finished:      counters[3] > 0 && counters[2] >= counters[3],
+		At:            time.Now(),
+	}, nil
+}
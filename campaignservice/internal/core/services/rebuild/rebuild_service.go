@@ -0,0 +1,578 @@
+package rebuild
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"specommerce/campaignservice/config"
+	"specommerce/campaignservice/internal/core/domain/campaign"
+	"specommerce/campaignservice/internal/core/ports/primary"
+	"specommerce/campaignservice/internal/core/ports/secondary"
+	"specommerce/campaignservice/internal/core/rules"
+	"specommerce/campaignservice/pkg/cache"
+	"strconv"
+	"time"
+
+	"github.com/rs/xid"
+)
+
+type rebuildService struct {
+	campaignRepository secondary.CampaignRepository
+	orderRepository    secondary.OrderRepository
+	drawRepository     secondary.DrawRepository
+	orderService       primary.OrderService
+	cacheClient        cache.Cache
+	config             config.AppConfig
+	logger             *slog.Logger
+}
+
+func NewRebuildService(
+	campaignRepository secondary.CampaignRepository,
+	orderRepository secondary.OrderRepository,
+	drawRepository secondary.DrawRepository,
+	orderService primary.OrderService,
+	cacheClient cache.Cache,
+	config config.AppConfig,
+	logger *slog.Logger,
+) primary.RebuildService {
+	return &rebuildService{
+		campaignRepository: campaignRepository,
+		orderRepository:    orderRepository,
+		drawRepository:     drawRepository,
+		orderService:       orderService,
+		cacheClient:        cacheClient,
+		config:             config,
+		logger:             logger,
+	}
+}
+
+func (s *rebuildService) Rebuild(ctx context.Context, campaignId int64, dryRun bool) (campaign.RebuildReport, error) {
+	errTemplate := "rebuildService Rebuild %w"
+	input, err := s.campaignRepository.GetById(ctx, campaignId)
+	if err != nil {
+		return campaign.RebuildReport{}, fmt.Errorf(errTemplate, err)
+	}
+	report, err := s.rebuild(ctx, input, dryRun)
+	if err != nil {
+		return campaign.RebuildReport{}, fmt.Errorf(errTemplate, err)
+	}
+	return report, nil
+}
+
+func (s *rebuildService) RebuildAll(ctx context.Context, dryRun bool) ([]campaign.RebuildReport, error) {
+	errTemplate := "rebuildService RebuildAll %w"
+	campaigns, err := s.campaignRepository.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(errTemplate, err)
+	}
+	reports := make([]campaign.RebuildReport, 0, len(campaigns))
+	for _, input := range campaigns {
+		report, err := s.rebuild(ctx, input, dryRun)
+		if err != nil {
+			return reports, fmt.Errorf(errTemplate, err)
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+const lockScript = `
+	if redis.call('SET', KEYS[1], ARGV[1], 'NX', 'PX', ARGV[2]) then
+		return 1
+	end
+	return 0
+`
+
+const unlockScript = `
+	if redis.call('GET', KEYS[1]) == ARGV[1] then
+		return redis.call('DEL', KEYS[1])
+	end
+	return 0
+`
+
+// rebuild always diffs first and only rewrites the keys with changes, so
+// running it on a healthy campaign writes nothing.
+//
+// The order events of the campaign are queued under the rebuild lock, like the
+// events of a paused campaign, and replayed once the rebuild is done. The
+// events evaluated before the lock was taken were saved in the database first,
+// so the rebuilt state accounts for them. The keys are rewritten by one script,
+// only if they still hold what the diff read, as an evaluation started before
+// the lock may still write them. Otherwise the rebuild starts over, up to
+// rebuild.maxAttempts times.
+func (s *rebuildService) rebuild(ctx context.Context, input campaign.Campaign, dryRun bool) (campaign.RebuildReport, error) {
+	if dryRun {
+		report, _, err := s.compare(ctx, input, dryRun)
+		return report, err
+	}
+
+	lockKey := campaign.RebuildLockKey(input.Id)
+	owner := xid.New().String()
+	locked, err := s.cacheClient.Eval(ctx, lockScript, []string{lockKey}, owner, s.config.Rebuild.LockTtl.Milliseconds())
+	if err != nil {
+		return campaign.RebuildReport{}, err
+	}
+	if locked != int64(1) {
+		return campaign.RebuildReport{}, campaign.ErrRebuildInProgress
+	}
+	defer s.unlock(ctx, input.Id, owner)
+
+	for attempt := 1; ; attempt++ {
+		report, writes, err := s.compare(ctx, input, dryRun)
+		if err != nil || len(writes) == 0 {
+			return report, err
+		}
+		applied, err := s.apply(ctx, lockKey, owner, writes)
+		if err != nil {
+			return report, err
+		}
+		if applied {
+			report.Applied = true
+			s.logger.Info("rebuilt campaign state",
+				slog.Int64("campaign_id", input.Id),
+				slog.Int("changes", len(report.Changes)),
+				slog.Int("keys", len(writes)))
+			return report, nil
+		}
+		if attempt >= s.config.Rebuild.MaxAttempts {
+			return report, campaign.ErrRebuildConflict
+		}
+		s.logger.Warn("campaign state changed during the rebuild, starting over",
+			slog.Int64("campaign_id", input.Id),
+			slog.Int("attempt", attempt))
+	}
+}
+
+// unlock releases the rebuild lock and replays the order events queued in the meantime.
+func (s *rebuildService) unlock(ctx context.Context, campaignId int64, owner string) {
+	if _, err := s.cacheClient.Eval(ctx, unlockScript, []string{campaign.RebuildLockKey(campaignId)}, owner); err != nil {
+		// The lock expires after rebuild.lockTtl
+		s.logger.Error("failed to release the rebuild lock",
+			slog.Int64("campaign_id", campaignId),
+			slog.String("error", err.Error()))
+		return
+	}
+	if err := s.orderService.ProcessQueuedOrders(ctx, campaignId); err != nil {
+		// The campaign scheduler replays the queue again
+		s.logger.Error("failed to replay the orders queued during the rebuild",
+			slog.Int64("campaign_id", campaignId),
+			slog.String("error", err.Error()))
+	}
+}
+
+// compare rebuilds the state of a campaign and diffs it with Redis.
+//
+// The winners set is read before the database: a winner selected by the scripts
+// is saved in the winners table after it is added to the winners set, and is
+// removed from the unsaved winners list once saved. The unsaved winners are
+// winners too, as the database does not know them yet.
+func (s *rebuildService) compare(ctx context.Context, input campaign.Campaign, dryRun bool) (campaign.RebuildReport, []write, error) {
+	winnersKey := campaign.WinnersKey(input.Id)
+	actualWinners, err := s.cacheClient.SMembers(ctx, winnersKey)
+	if err != nil {
+		return campaign.RebuildReport{}, nil, err
+	}
+	unsaved, err := s.unsavedWinners(ctx, input.Id)
+	if err != nil {
+		return campaign.RebuildReport{}, nil, err
+	}
+	winners, err := s.campaignRepository.GetWinnerIds(ctx, input.Id)
+	if err != nil {
+		return campaign.RebuildReport{}, nil, err
+	}
+	orders, err := s.orderRepository.GetByCreatedAt(ctx, input.StartTime, input.EndTime)
+	if err != nil {
+		return campaign.RebuildReport{}, nil, err
+	}
+	watermark := time.Now().Add(-s.config.OrderEvents.AllowedLateness)
+	state, err := rules.RebuildState(input, orders, appendMissing(winners, unsaved), watermark)
+	if err != nil {
+		return campaign.RebuildReport{}, nil, err
+	}
+	if input.Type == rules.TypeLuckyDraw {
+		commitment, err := s.drawRepository.GetByCampaignId(ctx, input.Id)
+		if err != nil {
+			return campaign.RebuildReport{}, nil, err
+		}
+		state.Info[rules.SeedField] = commitment.Seed
+	}
+
+	report := campaign.RebuildReport{
+		CampaignId: input.Id,
+		DryRun:     dryRun,
+		Changes:    []campaign.Change{},
+	}
+	var writes []write
+	add := func(w *write, err error) error {
+		if err != nil {
+			return err
+		}
+		if w != nil {
+			writes = append(writes, *w)
+		}
+		return nil
+	}
+
+	if err := add(s.diffHash(ctx, campaign.InfoKey(input.Id), state.Info, modeHash, &report)); err != nil {
+		return campaign.RebuildReport{}, nil, err
+	}
+	if w := diffSet(winnersKey, state.Winners, actualWinners, &report); w != nil {
+		writes = append(writes, *w)
+	}
+	if err := add(s.diffStoredSet(ctx, campaign.EligibleKey(input.Id), state.Eligible, &report)); err != nil {
+		return campaign.RebuildReport{}, nil, err
+	}
+	if state.Entries != nil {
+		if err := add(s.diffStoredSet(ctx, campaign.EntriesKey(input.Id), state.Entries, &report)); err != nil {
+			return campaign.RebuildReport{}, nil, err
+		}
+	}
+	if err := add(s.diffSortedSet(ctx, campaign.PendingOrdersKey(input.Id), state.PendingOrders, &report)); err != nil {
+		return campaign.RebuildReport{}, nil, err
+	}
+	if err := add(s.diffString(ctx, campaign.RankedScoreKey(input.Id), state.RankedScore, &report)); err != nil {
+		return campaign.RebuildReport{}, nil, err
+	}
+	for _, orderId := range sortedKeys(state.Transactions) {
+		key := campaign.TransactionKey(input.Id, orderId)
+		if err := add(s.diffHash(ctx, key, state.Transactions[orderId], modeHashFields, &report)); err != nil {
+			return campaign.RebuildReport{}, nil, err
+		}
+	}
+	for _, customerId := range sortedKeys(state.Customers) {
+		key := campaign.CustomerKey(input.Id, customerId)
+		if err := add(s.diffHash(ctx, key, state.Customers[customerId], modeHashFields, &report)); err != nil {
+			return campaign.RebuildReport{}, nil, err
+		}
+	}
+	return report, writes, nil
+}
+
+// unsavedWinners returns the customers of the unsaved winners list.
+func (s *rebuildService) unsavedWinners(ctx context.Context, campaignId int64) ([]string, error) {
+	entries, err := s.cacheClient.LRange(ctx, campaign.UnsavedWinnersKey(campaignId), 0, -1)
+	if err != nil {
+		return nil, err
+	}
+	customerIds := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		var unsaved struct {
+			CustomerId string `json:"customer_id"`
+		}
+		if err := json.Unmarshal([]byte(entry), &unsaved); err != nil {
+			return nil, err
+		}
+		customerIds = append(customerIds, unsaved.CustomerId)
+	}
+	return customerIds, nil
+}
+
+// apply rewrites the keys with writeScript and reports whether they still held
+// what the diff read.
+func (s *rebuildService) apply(ctx context.Context, lockKey string, owner string, writes []write) (bool, error) {
+	keys := []string{lockKey}
+	args := []any{owner}
+	for _, w := range writes {
+		keys = append(keys, w.key)
+		args = append(args, w.mode, len(w.guard))
+		args = append(args, w.guard...)
+		args = append(args, len(w.values))
+		args = append(args, w.values...)
+	}
+	result, err := s.cacheClient.Eval(ctx, writeScript, keys, args...)
+	if err != nil {
+		return false, err
+	}
+	return result == int64(1), nil
+}
+
+// write is a key to rewrite with writeScript. The guard is what the diff read
+// from the key, in the format of its values.
+type write struct {
+	key    string
+	mode   string
+	guard  []any
+	values []any
+}
+
+const (
+	modeHash       = "hash"
+	modeHashFields = "hash_fields"
+	modeSet        = "set"
+	modeSortedSet  = "zset"
+	modeString     = "string"
+)
+
+// writeScript rewrites KEYS[2..] while the rebuild lock KEYS[1] is held by
+// ARGV[1]. The arguments of every key follow: its mode, the guard length and
+// guard, the values length and values. Nothing is written unless every key
+// still matches its guard. Returns 1 once written.
+//
+// A key is replaced by its values, except in hash_fields mode which only sets
+// the given fields of a hash.
+const writeScript = `
+	if redis.call('GET', KEYS[1]) ~= ARGV[1] then
+		return 0
+	end
+
+	local function matches(key, mode, first, count)
+		if mode == 'string' then
+			if count == 0 then
+				return redis.call('EXISTS', key) == 0
+			end
+			return redis.call('GET', key) == ARGV[first]
+		elseif mode == 'hash' or mode == 'hash_fields' then
+			if redis.call('HLEN', key) ~= count / 2 then
+				return false
+			end
+			for i = first, first + count - 1, 2 do
+				if redis.call('HGET', key, ARGV[i]) ~= ARGV[i + 1] then
+					return false
+				end
+			end
+		elseif mode == 'set' then
+			if redis.call('SCARD', key) ~= count then
+				return false
+			end
+			for i = first, first + count - 1 do
+				if redis.call('SISMEMBER', key, ARGV[i]) == 0 then
+					return false
+				end
+			end
+		elseif mode == 'zset' then
+			if redis.call('ZCARD', key) ~= count / 2 then
+				return false
+			end
+			for i = first, first + count - 1, 2 do
+				if tonumber(redis.call('ZSCORE', key, ARGV[i])) ~= tonumber(ARGV[i + 1]) then
+					return false
+				end
+			end
+		end
+		return true
+	end
+
+	local function rewrite(key, mode, first, count)
+		if mode ~= 'hash_fields' then
+			redis.call('DEL', key)
+		end
+		if mode == 'string' then
+			if count > 0 then
+				redis.call('SET', key, ARGV[first])
+			end
+		elseif mode == 'hash' or mode == 'hash_fields' then
+			for i = first, first + count - 1, 2 do
+				redis.call('HSET', key, ARGV[i], ARGV[i + 1])
+			end
+		elseif mode == 'set' then
+			for i = first, first + count - 1 do
+				redis.call('SADD', key, ARGV[i])
+			end
+		elseif mode == 'zset' then
+			for i = first, first + count - 1, 2 do
+				redis.call('ZADD', key, ARGV[i + 1], ARGV[i])
+			end
+		end
+	end
+
+	local values = {}
+	local arg = 2
+	for k = 2, #KEYS do
+		local mode = ARGV[arg]
+		local guard_count = tonumber(ARGV[arg + 1])
+		if not matches(KEYS[k], mode, arg + 2, guard_count) then
+			return 0
+		end
+		arg = arg + 2 + guard_count
+		local value_count = tonumber(ARGV[arg])
+		values[k] = {mode, arg + 1, value_count}
+		arg = arg + 1 + value_count
+	end
+	for k = 2, #KEYS do
+		rewrite(KEYS[k], values[k][1], values[k][2], values[k][3])
+	end
+	return 1
+`
+
+// diffHash compares a hash with the expected fields. In hash mode, unexpected
+// fields are changes too.
+func (s *rebuildService) diffHash(ctx context.Context, key string, expected map[string]string, mode string, report *campaign.RebuildReport) (*write, error) {
+	actual, err := s.cacheClient.HGetAll(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	changed := false
+	for _, field := range sortedKeys(expected) {
+		if !sameValue(expected[field], actual[field]) {
+			changed = true
+			report.Changes = append(report.Changes, campaign.Change{Key: key, Member: field, Expected: expected[field], Actual: actual[field]})
+		}
+	}
+	if mode == modeHash {
+		for _, field := range sortedKeys(actual) {
+			if _, ok := expected[field]; !ok {
+				changed = true
+				report.Changes = append(report.Changes, campaign.Change{Key: key, Member: field, Actual: actual[field]})
+			}
+		}
+	}
+	if !changed {
+		return nil, nil
+	}
+	w := &write{key: key, mode: mode}
+	for _, field := range sortedKeys(actual) {
+		w.guard = append(w.guard, field, actual[field])
+	}
+	for _, field := range sortedKeys(expected) {
+		w.values = append(w.values, field, expected[field])
+	}
+	return w, nil
+}
+
+func (s *rebuildService) diffStoredSet(ctx context.Context, key string, expected []string, report *campaign.RebuildReport) (*write, error) {
+	members, err := s.cacheClient.SMembers(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	return diffSet(key, expected, members, report), nil
+}
+
+// diffSet compares the members read from a set with the expected ones.
+func diffSet(key string, expected []string, members []string, report *campaign.RebuildReport) *write {
+	actual := make(map[string]bool, len(members))
+	for _, member := range members {
+		actual[member] = true
+	}
+	isExpected := make(map[string]bool, len(expected))
+	changed := false
+	for _, member := range expected {
+		isExpected[member] = true
+		if !actual[member] {
+			changed = true
+			report.Changes = append(report.Changes, campaign.Change{Key: key, Member: member, Expected: member})
+		}
+	}
+	members = append([]string(nil), members...)
+	sort.Strings(members)
+	for _, member := range members {
+		if !isExpected[member] {
+			changed = true
+			report.Changes = append(report.Changes, campaign.Change{Key: key, Member: member, Actual: member})
+		}
+	}
+	if !changed {
+		return nil
+	}
+	w := &write{key: key, mode: modeSet}
+	for _, member := range members {
+		w.guard = append(w.guard, member)
+	}
+	for _, member := range expected {
+		w.values = append(w.values, member)
+	}
+	return w
+}
+
+func (s *rebuildService) diffSortedSet(ctx context.Context, key string, expected map[string]float64, report *campaign.RebuildReport) (*write, error) {
+	actual, err := s.cacheClient.ZRangeWithScores(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	changed := false
+	for _, member := range sortedKeys(expected) {
+		score, ok := actual[member]
+		if !ok || score != expected[member] {
+			changed = true
+			change := campaign.Change{Key: key, Member: member, Expected: formatScore(expected[member])}
+			if ok {
+				change.Actual = formatScore(score)
+			}
+			report.Changes = append(report.Changes, change)
+		}
+	}
+	for _, member := range sortedKeys(actual) {
+		if _, ok := expected[member]; !ok {
+			changed = true
+			report.Changes = append(report.Changes, campaign.Change{Key: key, Member: member, Actual: formatScore(actual[member])})
+		}
+	}
+	if !changed {
+		return nil, nil
+	}
+	w := &write{key: key, mode: modeSortedSet}
+	for _, member := range sortedKeys(actual) {
+		w.guard = append(w.guard, member, formatScore(actual[member]))
+	}
+	for _, member := range sortedKeys(expected) {
+		w.values = append(w.values, member, expected[member])
+	}
+	return w, nil
+}
+
+// getScript returns the value of a string key, or an empty string when it is missing.
+const getScript = `return redis.call('GET', KEYS[1]) or ''`
+
+// diffString compares a string key with the expected value. An empty value
+// means the key should not be there.
+func (s *rebuildService) diffString(ctx context.Context, key string, expected string, report *campaign.RebuildReport) (*write, error) {
+	result, err := s.cacheClient.Eval(ctx, getScript, []string{key})
+	if err != nil {
+		return nil, err
+	}
+	actual, _ := result.(string)
+	if sameValue(expected, actual) {
+		return nil, nil
+	}
+	report.Changes = append(report.Changes, campaign.Change{Key: key, Expected: expected, Actual: actual})
+	w := &write{key: key, mode: modeString}
+	if actual != "" {
+		w.guard = []any{actual}
+	}
+	if expected != "" {
+		w.values = []any{expected}
+	}
+	return w, nil
+}
+
+// appendMissing appends the values not in list yet, keeping their order.
+func appendMissing(list []string, values []string) []string {
+	seen := make(map[string]bool, len(list))
+	for _, value := range list {
+		seen[value] = true
+	}
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			list = append(list, value)
+		}
+	}
+	return list
+}
+
+// sameValue compares numbers by value, as the Lua scripts may store them in another format.
+func sameValue(expected string, actual string) bool {
+	if expected == actual {
+		return true
+	}
+	expectedNumber, err := strconv.ParseFloat(expected, 64)
+	if err != nil {
+		return false
+	}
+	actualNumber, err := strconv.ParseFloat(actual, 64)
+	return err == nil && expectedNumber == actualNumber
+}
+
+func formatScore(score float64) string {
+	return strconv.FormatFloat(score, 'f', -1, 64)
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
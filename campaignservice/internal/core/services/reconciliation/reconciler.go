@@ -0,0 +1,60 @@
+package reconciliation
+
+import (
+	"context"
+	"log/slog"
+	"specommerce/campaignservice/internal/core/ports/primary"
+	"specommerce/campaignservice/pkg/service_config"
+	"specommerce/campaignservice/pkg/shutdown"
+	"time"
+)
+
+// Reconciler periodically reconciles the Redis and SQL winners of the started
+// first_n_customers campaigns.
+type Reconciler struct {
+	reconciliationService primary.ReconciliationService
+	config                service_config.ReconciliationConfig
+	shutdownTask          *shutdown.Tasks
+	logger                *slog.Logger
+}
+
+func NewReconciler(
+	reconciliationService primary.ReconciliationService,
+	cfg service_config.ReconciliationConfig,
+	shutdownTask *shutdown.Tasks,
+	logger *slog.Logger,
+) *Reconciler {
+	return &Reconciler{
+		reconciliationService: reconciliationService,
+		config:                cfg,
+		shutdownTask:          shutdownTask,
+		logger:                logger,
+	}
+}
+
+func (r *Reconciler) Start() error {
+	r.logger.Info("Starting winner reconciler", slog.Duration("interval", r.config.Interval))
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	r.shutdownTask.AddShutdownTask(
+		func(_ context.Context) error {
+			cancel()
+			<-stopped
+			return nil
+		},
+	)
+	defer close(stopped)
+
+	ticker := time.NewTicker(r.config.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if _, err := r.reconciliationService.ReconcileAll(ctx); err != nil {
+				r.logger.Error("Failed to reconcile winners", slog.String("error", err.Error()))
+			}
+		}
+	}
+}
@@ -0,0 +1,281 @@
+package reconciliation
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+	"log/slog"
+	"sort"
+	"specommerce/campaignservice/internal/core/domain/campaign"
+	"specommerce/campaignservice/internal/core/domain/order"
+	"specommerce/campaignservice/internal/core/ports/primary"
+	"specommerce/campaignservice/internal/core/ports/secondary"
+	"specommerce/campaignservice/internal/core/rules"
+	"specommerce/campaignservice/pkg/cache"
+	"strconv"
+	"time"
+)
+
+var (
+	// winnerDiscrepancies holds the number of discrepancies found by the last
+	// reconciliation of each campaign, exposed on /debug/vars
+	winnerDiscrepancies = expvar.NewMap("campaign_winner_discrepancies")
+	// reconciliations counts the reconciliations run
+	reconciliations = expvar.NewInt("campaign_winner_reconciliations")
+)
+
+type reconciliationService struct {
+	campaignRepository secondary.CampaignRepository
+	orderRepository    secondary.OrderRepository
+	cacheClient        cache.Cache
+	logger             *slog.Logger
+}
+
+func NewReconciliationService(
+	campaignRepository secondary.CampaignRepository,
+	orderRepository secondary.OrderRepository,
+	cacheClient cache.Cache,
+	logger *slog.Logger,
+) primary.ReconciliationService {
+	return &reconciliationService{
+		campaignRepository: campaignRepository,
+		orderRepository:    orderRepository,
+		cacheClient:        cacheClient,
+		logger:             logger,
+	}
+}
+
+func (s *reconciliationService) Reconcile(ctx context.Context, campaignId int64) (campaign.Reconciliation, error) {
+	errTemplate := "reconciliationService Reconcile %w"
+	input, err := s.campaignRepository.GetById(ctx, campaignId)
+	if err != nil {
+		return campaign.Reconciliation{}, fmt.Errorf(errTemplate, err)
+	}
+	result, err := s.reconcile(ctx, input)
+	if err != nil {
+		return campaign.Reconciliation{}, fmt.Errorf(errTemplate, err)
+	}
+	return result, nil
+}
+
+func (s *reconciliationService) ReconcileAll(ctx context.Context) ([]campaign.Reconciliation, error) {
+	errTemplate := "reconciliationService ReconcileAll %w"
+	campaigns, err := s.campaignRepository.GetAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(errTemplate, err)
+	}
+	now := time.Now()
+	results := make([]campaign.Reconciliation, 0)
+	var errs []error
+	for _, input := range campaigns {
+		if input.Type != rules.TypeFirstNCustomers || input.StartTime.After(now) {
+			continue
+		}
+		result, err := s.reconcile(ctx, input)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		results = append(results, result)
+	}
+	if err := errors.Join(errs...); err != nil {
+		return results, fmt.Errorf(errTemplate, err)
+	}
+	return results, nil
+}
+
+func (s *reconciliationService) reconcile(ctx context.Context, input campaign.Campaign) (campaign.Reconciliation, error) {
+	if input.Type != rules.TypeFirstNCustomers {
+		return campaign.Reconciliation{}, campaign.ErrReconciliationUnsupported
+	}
+	policy, err := rules.DecodePolicy[rules.FirstNCustomersPolicy](input.Policy)
+	if err != nil {
+		return campaign.Reconciliation{}, err
+	}
+
+	redisWinners, err := s.cacheClient.SMembers(ctx, campaign.WinnersKey(input.Id))
+	if err != nil {
+		return campaign.Reconciliation{}, err
+	}
+	redisWinners, err = s.withoutReplacements(ctx, input.Id, redisWinners)
+	if err != nil {
+		return campaign.Reconciliation{}, err
+	}
+	eligible, err := s.cacheClient.SMembers(ctx, campaign.EligibleKey(input.Id))
+	if err != nil {
+		return campaign.Reconciliation{}, err
+	}
+	pendingOrders, err := s.cacheClient.ZRangeWithScores(ctx, campaign.PendingOrdersKey(input.Id))
+	if err != nil {
+		return campaign.Reconciliation{}, err
+	}
+	sqlWinners, err := s.campaignRepository.GetIphoneWinner(ctx, input, policy)
+	if err != nil {
+		return campaign.Reconciliation{}, err
+	}
+	orders, err := s.orderRepository.GetByCreatedAt(ctx, input.StartTime, input.EndTime)
+	if err != nil {
+		return campaign.Reconciliation{}, err
+	}
+
+	c := newComparison(policy, orders, eligible, len(pendingOrders) > 0)
+	inRedis := toSet(redisWinners)
+	inSql := make(map[string]bool, len(sqlWinners))
+	for _, winner := range sqlWinners {
+		inSql[winner.CustomerId] = true
+	}
+
+	result := campaign.Reconciliation{
+		CampaignId:    input.Id,
+		RedisWinners:  len(redisWinners),
+		SqlWinners:    len(sqlWinners),
+		Discrepancies: []campaign.Discrepancy{},
+		ReconciledAt:  time.Now(),
+	}
+	for _, customerId := range redisWinners {
+		if !inSql[customerId] {
+			result.Discrepancies = append(result.Discrepancies, c.discrepancy(customerId, true, c.redisOnlyReason(customerId)))
+		}
+	}
+	for _, winner := range sqlWinners {
+		if !inRedis[winner.CustomerId] {
+			reason := c.sqlOnlyReason(winner.CustomerId, len(redisWinners))
+			result.Discrepancies = append(result.Discrepancies, c.discrepancy(winner.CustomerId, false, reason))
+		}
+	}
+	sort.Slice(result.Discrepancies, func(i, j int) bool {
+		return result.Discrepancies[i].CustomerId < result.Discrepancies[j].CustomerId
+	})
+
+	reconciliations.Add(1)
+	count := new(expvar.Int)
+	count.Set(int64(len(result.Discrepancies)))
+	winnerDiscrepancies.Set(strconv.FormatInt(input.Id, 10), count)
+	if !result.IsConsistent() {
+		s.logger.Warn("Redis and SQL winners disagree",
+			slog.Int64("campaign_id", input.Id),
+			slog.Int("redis_winners", result.RedisWinners),
+			slog.Int("sql_winners", result.SqlWinners),
+			slog.Int("discrepancies", len(result.Discrepancies)))
+	}
+	return result, nil
+}
+
+// customerStats are the successful orders of a customer in the campaign window.
+type customerStats struct {
+	maxOrderAmount float64
+	firstOrderTime time.Time
+	// rank is the position of the customer by first successful order, as in the SQL query
+	rank int
+}
+
+// comparison explains why a customer is a winner on one side only.
+type comparison struct {
+	policy           rules.FirstNCustomersPolicy
+	customers        map[string]*customerStats
+	eligible         map[string]bool
+	eligibleCount    int
+	hasPendingOrders bool
+}
+
+func newComparison(policy rules.FirstNCustomersPolicy, orders []order.Order, eligible []string, hasPendingOrders bool) comparison {
+	customers := map[string]*customerStats{}
+	ranked := make([]*customerStats, 0)
+	for _, o := range orders {
+		if o.Status != order.OrderStatusSuccess {
+			continue
+		}
+		stats, ok := customers[o.CustomerId]
+		if !ok {
+			// orders are sorted by creation time, so the first one seen is the first order
+			stats = &customerStats{firstOrderTime: o.CreatedAt, rank: len(ranked)}
+			customers[o.CustomerId] = stats
+			ranked = append(ranked, stats)
+		}
+		stats.maxOrderAmount = max(stats.maxOrderAmount, o.TotalAmount)
+	}
+	return comparison{
+		policy:           policy,
+		customers:        customers,
+		eligible:         toSet(eligible),
+		eligibleCount:    len(eligible),
+		hasPendingOrders: hasPendingOrders,
+	}
+}
+
+func (c comparison) redisOnlyReason(customerId string) string {
+	stats, ok := c.customers[customerId]
+	minOrderAmount := float64(c.policy.MinOrderAmount)
+	switch {
+	case !ok:
+		return campaign.ReasonNoSuccessOrder
+	case stats.maxOrderAmount == minOrderAmount:
+		return campaign.ReasonMinAmountBoundary
+	case stats.maxOrderAmount < minOrderAmount:
+		return campaign.ReasonBelowMinAmount
+	case int64(stats.rank) >= c.policy.MaxTrackedOrders:
+		return campaign.ReasonOutsideTrackedCustomers
+	}
+	return campaign.ReasonRewardLimit
+}
+
+func (c comparison) sqlOnlyReason(customerId string, redisWinners int) string {
+	switch {
+	case !c.eligible[customerId] && int64(c.eligibleCount) >= c.policy.MaxTrackedOrders:
+		return campaign.ReasonOutsideTrackedCustomers
+	case int64(redisWinners) >= c.policy.TotalReward:
+		return campaign.ReasonRewardLimit
+	case c.hasPendingOrders:
+		return campaign.ReasonPendingInRedis
+	}
+	return campaign.ReasonNotSelected
+}
+
+func (c comparison) discrepancy(customerId string, inRedis bool, reason string) campaign.Discrepancy {
+	discrepancy := campaign.Discrepancy{
+		CustomerId: customerId,
+		InRedis:    inRedis,
+		InSql:      !inRedis,
+		Reason:     reason,
+	}
+	if stats, ok := c.customers[customerId]; ok {
+		discrepancy.MaxOrderAmount = stats.maxOrderAmount
+		discrepancy.FirstOrderTime = &stats.firstOrderTime
+	}
+	return discrepancy
+}
+
+// withoutReplacements removes the runners-up that replaced an expired or
+// forfeited claim from the Redis winners. They are added to the winners set by
+// the claim service and are not selected by the SQL winner query.
+func (s *reconciliationService) withoutReplacements(ctx context.Context, campaignId int64, redisWinners []string) ([]string, error) {
+	saved, err := s.campaignRepository.FindWinners(ctx, campaignId)
+	if err != nil {
+		return nil, err
+	}
+	replacements := make(map[string]bool)
+	for _, winner := range saved {
+		if winner.Replaces != "" {
+			replacements[winner.CustomerId] = true
+		}
+	}
+	if len(replacements) == 0 {
+		return redisWinners, nil
+	}
+	selected := make([]string, 0, len(redisWinners))
+	for _, customerId := range redisWinners {
+		if !replacements[customerId] {
+			selected = append(selected, customerId)
+		}
+	}
+	return selected, nil
+}
+
+func toSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[value] = true
+	}
+	return set
+}
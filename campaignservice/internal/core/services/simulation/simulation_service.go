@@ -0,0 +1,341 @@
+// Package simulation replays orders through the rule of a campaign to show who
+// would have won, without touching the live campaign state.
+//
+// A simulation runs the same Lua scripts as the order service, in the keyspace
+// of a random negative campaign id (see campaign.NewSimulationId), which is
+// deleted at the end. The time of each replayed event is the current time of
+// the scripts, so the orders held back for late events are ranked as they would
+// have been, and the ones still held back at the end of the replay are ranked
+// at the end time of the campaign.
+package simulation
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"specommerce/campaignservice/internal/core/domain/campaign"
+	"specommerce/campaignservice/internal/core/domain/draw"
+	"specommerce/campaignservice/internal/core/domain/order"
+	"specommerce/campaignservice/internal/core/ports/primary"
+	"specommerce/campaignservice/internal/core/ports/secondary"
+	"specommerce/campaignservice/internal/core/rules"
+	"specommerce/campaignservice/pkg/cache"
+	"specommerce/campaignservice/pkg/service_config"
+	"time"
+)
+
+const writeInfoScript = `
+	for i = 1, #ARGV, 2 do
+		redis.call('HSET', KEYS[1], ARGV[i], ARGV[i + 1])
+	end
+	return #ARGV / 2
+`
+
+type simulationService struct {
+	orderRepository secondary.OrderRepository
+	cacheClient     cache.Cache
+	config          service_config.OrderEventsConfig
+	logger          *slog.Logger
+}
+
+func NewSimulationService(
+	orderRepository secondary.OrderRepository,
+	cacheClient cache.Cache,
+	cfg service_config.OrderEventsConfig,
+	logger *slog.Logger,
+) primary.SimulationService {
+	return &simulationService{
+		orderRepository: orderRepository,
+		cacheClient:     cacheClient,
+		config:          cfg,
+		logger:          logger,
+	}
+}
+
+func (s *simulationService) Simulate(ctx context.Context, input campaign.Campaign, events []order.Order) (campaign.Simulation, error) {
+	errTemplate := "simulationService Simulate %w"
+	if input.Type == "" {
+		input.Type = rules.TypeFirstNCustomers
+	}
+	if !input.EndTime.After(input.StartTime) {
+		return campaign.Simulation{}, fmt.Errorf(errTemplate, campaign.ErrInvalidWindow)
+	}
+	if err := rules.Validate(input); err != nil {
+		return campaign.Simulation{}, fmt.Errorf(errTemplate, err)
+	}
+
+	source := campaign.SimulationSourceFile
+	if events == nil {
+		source = campaign.SimulationSourceOrders
+		orders, err := s.orderRepository.GetByCreatedAt(ctx, input.StartTime, input.EndTime)
+		if err != nil {
+			return campaign.Simulation{}, fmt.Errorf(errTemplate, err)
+		}
+		events = orderEvents(orders)
+	}
+
+	simulationId, err := campaign.NewSimulationId()
+	if err != nil {
+		return campaign.Simulation{}, fmt.Errorf(errTemplate, err)
+	}
+	defer func() {
+		// The context of the request may be done already
+		deleted, err := s.cacheClient.DeleteByPrefix(context.WithoutCancel(ctx), campaign.KeyPrefix(simulationId))
+		if err != nil {
+			s.logger.Error("Failed to delete the simulation keys",
+				slog.Int64("simulation_id", simulationId),
+				slog.String("error", err.Error()))
+			return
+		}
+		s.logger.Info("Simulation finished",
+			slog.Int64("simulation_id", simulationId),
+			slog.Int("events", len(events)),
+			slog.Int64("deleted_keys", deleted))
+	}()
+
+	result, err := s.replay(ctx, simulationId, input, events)
+	if err != nil {
+		return campaign.Simulation{}, fmt.Errorf(errTemplate, err)
+	}
+	result.Source = source
+	return result, nil
+}
+
+// orderEvents turns the orders of the orders table, which only keep their
+// latest status, into a pending event at their creation followed by a result
+// event at their last update for the others, in time order.
+func orderEvents(orders []order.Order) []order.Order {
+	events := make([]order.Order, 0, 2*len(orders))
+	for _, input := range orders {
+		pending := input
+		pending.Status = order.OrderStatusPending
+		pending.UpdatedAt = input.CreatedAt
+		events = append(events, pending)
+		if input.Status != order.OrderStatusPending {
+			events = append(events, input)
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool {
+		return events[i].UpdatedAt.Before(events[j].UpdatedAt)
+	})
+	return events
+}
+
+func (s *simulationService) replay(ctx context.Context, simulationId int64, input campaign.Campaign, events []order.Order) (campaign.Simulation, error) {
+	rule, err := rules.Get(input.Type)
+	if err != nil {
+		return campaign.Simulation{}, err
+	}
+	live := input
+	live.Id = simulationId
+	fields, err := rules.InfoFields(live)
+	if err != nil {
+		return campaign.Simulation{}, err
+	}
+	result := campaign.Simulation{
+		Campaign: input,
+		Events:   len(events),
+		Winners:  []campaign.SimulatedWinner{},
+		Timeline: []campaign.TimelineEvent{},
+	}
+	if input.Type == rules.TypeLuckyDraw {
+		// The seed of the campaign is only revealed once it has ended
+		commitment, err := draw.NewDraw(simulationId)
+		if err != nil {
+			return campaign.Simulation{}, err
+		}
+		fields[rules.SeedField] = commitment.Seed
+		result.DrawSeed = commitment.Seed
+	}
+	args := make([]any, 0, 2*len(fields))
+	for field, value := range fields {
+		args = append(args, field, value)
+	}
+	if _, err := s.cacheClient.Eval(ctx, writeInfoScript, []string{campaign.InfoKey(simulationId)}, args...); err != nil {
+		return campaign.Simulation{}, err
+	}
+	orders := map[string]bool{}
+	customers := map[string]bool{}
+	for _, event := range events {
+		orders[event.Id.String()] = true
+		customers[event.CustomerId] = true
+		// As the order consumer, only PENDING events run the pending script
+		if event.Status == order.OrderStatusPending {
+			script := rule.PendingScript(simulationId, event)
+			if script == nil {
+				continue
+			}
+			output, err := s.cacheClient.Eval(ctx, script.Source, script.Keys, script.Args...)
+			if err != nil {
+				return campaign.Simulation{}, err
+			}
+			countArrival(&result, rules.ArrivalOf(output, rules.PendingArrivalIndex))
+			continue
+		}
+
+		script := rule.ResultScript(simulationId, event, event.UpdatedAt.Add(-s.config.AllowedLateness))
+		output, err := s.cacheClient.Eval(ctx, script.Source, script.Keys, script.Args...)
+		if err != nil {
+			return campaign.Simulation{}, err
+		}
+		countArrival(&result, rules.ArrivalOf(output, rules.ResultArrivalIndex))
+		finished := false
+		if values, ok := output.([]interface{}); ok && len(values) >= 2 {
+			// Redis Lua returns booleans as integers: 1 = true, 0 = false
+			finished = values[1] == int64(1)
+		}
+		newWinners, err := s.newWinners(ctx, simulationId, len(result.Winners), event.UpdatedAt)
+		if err != nil {
+			return campaign.Simulation{}, err
+		}
+		result.Winners = append(result.Winners, newWinners...)
+		if len(newWinners) == 0 && (!finished || result.FinishedAt != nil) {
+			continue
+		}
+		timelineEvent := campaign.TimelineEvent{
+			At:         event.UpdatedAt,
+			OrderId:    event.Id.String(),
+			CustomerId: event.CustomerId,
+			Status:     event.Status.String(),
+			Winners:    len(result.Winners),
+			Finished:   finished,
+		}
+		for _, winner := range newWinners {
+			timelineEvent.NewWinners = append(timelineEvent.NewWinners, winner.CustomerId)
+		}
+		result.Timeline = append(result.Timeline, timelineEvent)
+		if finished && result.FinishedAt == nil {
+			finishedAt := event.UpdatedAt
+			result.FinishedAt = &finishedAt
+		}
+	}
+	if flusher, ok := rule.(rules.Flusher); ok {
+		if err := s.flush(ctx, simulationId, flusher, input.EndTime, &result); err != nil {
+			return campaign.Simulation{}, err
+		}
+	}
+	result.Orders = len(orders)
+	result.Customers = len(customers)
+
+	if input.Type == rules.TypeDraw {
+		if err := s.draw(ctx, simulationId, input, &result); err != nil {
+			return campaign.Simulation{}, err
+		}
+		return result, nil
+	}
+	eligible, err := s.cacheClient.SMembers(ctx, campaign.EligibleKey(simulationId))
+	if err != nil {
+		return campaign.Simulation{}, err
+	}
+	result.Eligible = len(eligible)
+	rewards, err := s.cacheClient.HGetAll(ctx, campaign.RewardsKey(simulationId))
+	if err != nil {
+		return campaign.Simulation{}, err
+	}
+	for i := range result.Winners {
+		result.Winners[i].Reward = rewards[result.Winners[i].CustomerId]
+	}
+	return result, nil
+}
+
+// flush ranks the orders still held back for late events at the end of the
+// replay, as the order flusher does once the watermark passes the end time.
+func (s *simulationService) flush(ctx context.Context, simulationId int64, flusher rules.Flusher, at time.Time, result *campaign.Simulation) error {
+	script := flusher.FlushScript(simulationId, at)
+	output, err := s.cacheClient.Eval(ctx, script.Source, script.Keys, script.Args...)
+	if err != nil {
+		return err
+	}
+	newWinners, err := s.newWinners(ctx, simulationId, len(result.Winners), at)
+	if err != nil {
+		return err
+	}
+	result.Winners = append(result.Winners, newWinners...)
+	finished := false
+	if values, ok := output.([]interface{}); ok && len(values) >= 2 {
+		finished = values[1] == int64(1)
+	}
+	if len(newWinners) == 0 && (!finished || result.FinishedAt != nil) {
+		return nil
+	}
+	timelineEvent := campaign.TimelineEvent{
+		At:       at,
+		Winners:  len(result.Winners),
+		Finished: finished,
+	}
+	for _, winner := range newWinners {
+		timelineEvent.NewWinners = append(timelineEvent.NewWinners, winner.CustomerId)
+	}
+	result.Timeline = append(result.Timeline, timelineEvent)
+	if finished && result.FinishedAt == nil {
+		result.FinishedAt = &at
+	}
+	return nil
+}
+
+// countArrival counts the reordered and dropped order events of a simulation.
+func countArrival(result *campaign.Simulation, arrival rules.Arrival) {
+	switch arrival {
+	case rules.ArrivalReordered:
+		result.Reordered++
+	case rules.ArrivalDropped:
+		result.Dropped++
+	}
+}
+
+// newWinners returns the winners queued in the unsaved winners list by the last
+// script, after the read ones, selected at the given time. The list is never
+// saved nor emptied in a simulation.
+func (s *simulationService) newWinners(ctx context.Context, simulationId int64, read int, at time.Time) ([]campaign.SimulatedWinner, error) {
+	entries, err := s.cacheClient.LRange(ctx, campaign.UnsavedWinnersKey(simulationId), int64(read), -1)
+	if err != nil {
+		return nil, err
+	}
+	winners := make([]campaign.SimulatedWinner, 0, len(entries))
+	for _, entry := range entries {
+		var winner campaign.Winner
+		if err := json.Unmarshal([]byte(entry), &winner); err != nil {
+			return nil, err
+		}
+		winners = append(winners, campaign.SimulatedWinner{
+			Position:   winner.Position,
+			CustomerId: winner.CustomerId,
+			OrderId:    winner.OrderId,
+			SelectedAt: at,
+		})
+	}
+	return winners, nil
+}
+
+// draw runs the draw of a draw campaign over the simulated entries, with a new
+// random seed.
+func (s *simulationService) draw(ctx context.Context, simulationId int64, input campaign.Campaign, result *campaign.Simulation) error {
+	policy, err := rules.DecodePolicy[rules.DrawPolicy](input.Policy)
+	if err != nil {
+		return err
+	}
+	entrants, err := s.cacheClient.SMembers(ctx, campaign.EntriesKey(simulationId))
+	if err != nil {
+		return err
+	}
+	result.Eligible = len(entrants)
+	commitment, err := draw.NewDraw(simulationId)
+	if err != nil {
+		return err
+	}
+	entries := make([]draw.Entry, 0, len(entrants))
+	for _, customerId := range entrants {
+		entries = append(entries, draw.Entry{CampaignId: simulationId, CustomerId: customerId})
+	}
+	result.DrawSeed = commitment.Seed
+	for _, winner := range draw.SelectWinners(commitment.Seed, entries, int(policy.TotalReward)) {
+		result.Winners = append(result.Winners, campaign.SimulatedWinner{
+			Position:   winner.Position,
+			CustomerId: winner.CustomerId,
+			SelectedAt: input.EndTime,
+		})
+	}
+	return nil
+}
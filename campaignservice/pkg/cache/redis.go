@@ -16,6 +16,20 @@ type Cache interface {
 	Get(ctx context.Context, key string) (string, error)
 	Eval(ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error)
 	SMembers(ctx context.Context, key string) ([]string, error)
+	HGetAll(ctx context.Context, key string) (map[string]string, error)
+	LRange(ctx context.Context, key string, start int64, stop int64) ([]string, error)
+	ZRangeWithScores(ctx context.Context, key string) (map[string]float64, error)
+	DeleteByPrefix(ctx context.Context, prefix string) (int64, error)
+	XRange(ctx context.Context, key string, count int64) ([]StreamEntry, error)
+	XDel(ctx context.Context, key string, ids ...string) error
+	Publish(ctx context.Context, channel string, message interface{}) error
+	Subscribe(ctx context.Context, channel string) (<-chan string, error)
+}
+
+// StreamEntry is an entry of a Redis stream.
+type StreamEntry struct {
+	Id     string
+	Values map[string]string
 }
 
 type RedisClient struct {
@@ -62,3 +76,111 @@ func (r *RedisClient) Eval(ctx context.Context, script string, keys []string, ar
 func (r *RedisClient) SMembers(ctx context.Context, key string) ([]string, error) {
 	return r.client.SMembers(ctx, key).Result()
 }
+
+func (r *RedisClient) HGetAll(ctx context.Context, key string) (map[string]string, error) {
+	return r.client.HGetAll(ctx, key).Result()
+}
+
+func (r *RedisClient) LRange(ctx context.Context, key string, start int64, stop int64) ([]string, error) {
+	return r.client.LRange(ctx, key, start, stop).Result()
+}
+
+// ZRangeWithScores returns all the members of a sorted set with their score.
+func (r *RedisClient) ZRangeWithScores(ctx context.Context, key string) (map[string]float64, error) {
+	members, err := r.client.ZRangeWithScores(ctx, key, 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+	scores := make(map[string]float64, len(members))
+	for _, member := range members {
+		scores[fmt.Sprint(member.Member)] = member.Score
+	}
+	return scores, nil
+}
+
+// DeleteByPrefix deletes all the keys starting with prefix and returns how many were deleted.
+func (r *RedisClient) DeleteByPrefix(ctx context.Context, prefix string) (int64, error) {
+	var deleted int64
+	iter := r.client.Scan(ctx, 0, prefix+"*", 1000).Iterator()
+	keys := make([]string, 0, 1000)
+	flush := func() error {
+		if len(keys) == 0 {
+			return nil
+		}
+		count, err := r.client.Unlink(ctx, keys...).Result()
+		deleted += count
+		keys = keys[:0]
+		return err
+	}
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+		if len(keys) == cap(keys) {
+			if err := flush(); err != nil {
+				return deleted, err
+			}
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return deleted, err
+	}
+	return deleted, flush()
+}
+
+// XRange returns the first count entries of a stream, oldest first.
+func (r *RedisClient) XRange(ctx context.Context, key string, count int64) ([]StreamEntry, error) {
+	messages, err := r.client.XRangeN(ctx, key, "-", "+", count).Result()
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]StreamEntry, 0, len(messages))
+	for _, message := range messages {
+		values := make(map[string]string, len(message.Values))
+		for field, value := range message.Values {
+			values[field] = fmt.Sprint(value)
+		}
+		entries = append(entries, StreamEntry{Id: message.ID, Values: values})
+	}
+	return entries, nil
+}
+
+func (r *RedisClient) XDel(ctx context.Context, key string, ids ...string) error {
+	return r.client.XDel(ctx, key, ids...).Err()
+}
+
+func (r *RedisClient) Publish(ctx context.Context, channel string, message interface{}) error {
+	return r.client.Publish(ctx, channel, message).Err()
+}
+
+// Subscribe returns the messages published on a channel from now on. The
+// subscription is closed, and the returned channel with it, when ctx is done.
+func (r *RedisClient) Subscribe(ctx context.Context, channel string) (<-chan string, error) {
+	pubsub := r.client.Subscribe(ctx, channel)
+	// Wait for the subscription to be confirmed so no message published after
+	// Subscribe returns is missed
+	if _, err := pubsub.Receive(ctx); err != nil {
+		_ = pubsub.Close()
+		return nil, err
+	}
+	messages := make(chan string)
+	go func() {
+		defer close(messages)
+		defer pubsub.Close()
+		received := pubsub.Channel()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case message, ok := <-received:
+				if !ok {
+					return
+				}
+				select {
+				case messages <- message.Payload:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return messages, nil
+}
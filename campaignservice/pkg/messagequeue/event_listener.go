@@ -2,8 +2,10 @@ package messagequeue
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"sync"
+	"time"
 
 	"github.com/segmentio/kafka-go"
 	"specommerce/campaignservice/pkg/service_config"
@@ -17,13 +19,20 @@ type EventListener interface {
 
 type HandlerFunc func(message kafka.Message) error
 
+const (
+	defaultRetryBackoff = 100 * time.Millisecond
+	publishRetryBackoff = time.Second
+)
+
 type BaseEventListener struct {
+	publisher    Publisher
 	logger       *slog.Logger
 	shutdownTask *shutdown.Tasks
 }
 
-func NewBaseEventListener(shutdownTask *shutdown.Tasks, logger *slog.Logger) *BaseEventListener {
+func NewBaseEventListener(publisher Publisher, shutdownTask *shutdown.Tasks, logger *slog.Logger) *BaseEventListener {
 	return &BaseEventListener{
+		publisher:    publisher,
 		shutdownTask: shutdownTask,
 		logger:       logger,
 	}
@@ -33,53 +42,208 @@ func (l *BaseEventListener) Logger() *slog.Logger {
 	return l.logger
 }
 
+// Start consumes the topic with at-least-once delivery: offsets are committed manually once the handler
+// succeeds, and only up to the highest offset of the partition below which every message is handled.
+// Messages are dispatched by key to cfg.Workers workers, so events with the same key are handled in order.
+// A failed handler is retried cfg.Retry times with exponential backoff, then the message goes through the
+// cfg.RetryTopics delay topics <topic>.retry.N, each consumed after a longer delay, and finally to <topic>.dlq.
+// On shutdown, fetching stops and in-flight handlers finish and commit before the readers close;
+// unhandled messages are redelivered on the next start.
 func (l *BaseEventListener) Start(cfg service_config.KafkaConfig, handlerFunc HandlerFunc) error {
 	l.logger.Info("Starting Kafka event listener",
 		slog.String("brokers", cfg.Host),
 		slog.String("consumer_group", cfg.ConsumerGroup),
 		slog.Any("topic", cfg.Topic),
+		slog.Int("workers", cfg.Workers),
+		slog.Int("queue_size", cfg.QueueSize),
+		slog.Int("retry_topics", cfg.RetryTopics),
 	)
 
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var waitGroup sync.WaitGroup
+	for stage := 0; stage <= cfg.RetryTopics; stage++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+			l.consume(ctx, cancel, cfg, stage, handlerFunc)
+		}()
+	}
+	waitGroup.Wait()
+	return nil
+}
+
+// consume reads the main topic (stage 0) or the retry topic of the given stage until shutdown
+func (l *BaseEventListener) consume(ctx context.Context, cancel context.CancelFunc, cfg service_config.KafkaConfig, stage int, handlerFunc HandlerFunc) {
+	topic, group := cfg.Topic, cfg.ConsumerGroup
+	if stage > 0 {
+		topic = RetryTopic(cfg.Topic, stage)
+		group = fmt.Sprintf("%s.retry.%d", cfg.ConsumerGroup, stage)
+	}
 	reader := kafka.NewReader(
 		kafka.ReaderConfig{
 			Brokers: []string{cfg.Host},
-			Topic:   cfg.Topic,
-			GroupID: cfg.ConsumerGroup,
+			Topic:   topic,
+			GroupID: group,
 		},
 	)
-	loop := true
+	tracker := newOffsetTracker()
+	var commitMu sync.Mutex
+	pool := newKeyedWorkerPool(cfg.Workers, cfg.QueueSize, func(msg kafka.Message) {
+		if ctx.Err() != nil || !l.process(ctx, cfg, stage, handlerFunc, msg) {
+			return
+		}
+		// Commits are serialized so that a lower offset can never be committed after a higher one
+		commitMu.Lock()
+		defer commitMu.Unlock()
+		offset, ok := tracker.markDone(msg.Partition, msg.Offset)
+		if !ok {
+			return
+		}
+		committed := kafka.Message{Topic: msg.Topic, Partition: msg.Partition, Offset: offset}
+		if err := reader.CommitMessages(context.Background(), committed); err != nil {
+			l.logger.Error("Failed to commit offset",
+				slog.String("error", err.Error()),
+				slog.String("topic", msg.Topic),
+				slog.Int("partition", msg.Partition),
+				slog.Int64("offset", offset),
+			)
+		}
+	})
+
+	stopped := make(chan struct{})
 	l.shutdownTask.AddShutdownTask(
-		func(ctx context.Context) error {
-			loop = false
+		func(_ context.Context) error {
+			cancel()
+			<-stopped
+			pool.close()
 			return reader.Close()
 		},
 	)
-	var waitGroup sync.WaitGroup
-	l.shutdownTask.AddShutdownTask(
-		func(ctx context.Context) error {
-			waitGroup.Wait()
-			return nil
-		},
-	)
-	for loop {
-		//NOTE: this auto committed after fetch message -> need to re-consider
-		message, err := reader.ReadMessage(context.Background())
+	defer close(stopped)
+
+	for {
+		message, err := reader.FetchMessage(ctx)
+		if ctx.Err() != nil {
+			return
+		}
 		if err != nil {
-			l.logger.Error("Failed to read message from Kafka", slog.String("error", err.Error()))
+			l.logger.Error("Failed to fetch message from Kafka",
+				slog.String("error", err.Error()),
+				slog.String("topic", topic),
+			)
 			continue
 		}
-		waitGroup.Add(1)
-		go func(msg kafka.Message) {
-			defer waitGroup.Done()
-			err := handlerFunc(msg)
-			if err != nil {
-				l.logger.Error("Can not handle event",
-					slog.String("error", err.Error()),
-					slog.String("topic", msg.Topic),
-					slog.String("key", string(msg.Key)),
-				)
-			}
-		}(message)
+		tracker.track(message.Partition, message.Offset)
+		if !pool.submit(ctx, message) {
+			return
+		}
+	}
+}
+
+// process handles one message and forwards it to the next retry topic or the dead-letter topic when it fails.
+// It returns false when the listener is shutting down before the message is settled, so its offset stays
+// uncommitted and the message is redelivered.
+func (l *BaseEventListener) process(ctx context.Context, cfg service_config.KafkaConfig, stage int, handlerFunc HandlerFunc, msg kafka.Message) bool {
+	// Retry topics and re-driven messages are shared by every consumer group of the topic
+	if group := headerValue(msg, HeaderConsumerGroup); group != "" && group != cfg.ConsumerGroup {
+		return true
+	}
+	retries := cfg.Retry
+	if stage > 0 {
+		if !sleep(ctx, time.Until(msg.Time.Add(retryTopicDelay(cfg, stage)))) {
+			return false
+		}
+		retries = 0
+	}
+
+	attempts, err := l.handle(ctx, cfg, retries, handlerFunc, msg)
+	if err == nil {
+		return true
+	}
+	if ctx.Err() != nil {
+		return false
+	}
+
+	next := DeadLetterTopic(cfg.Topic)
+	if stage < cfg.RetryTopics {
+		next = RetryTopic(cfg.Topic, stage+1)
+	}
+	forwarded := kafka.Message{
+		Topic:   next,
+		Key:     msg.Key,
+		Value:   msg.Value,
+		Headers: failureHeaders(msg, cfg.ConsumerGroup, attempts, err),
+	}
+	for {
+		publishErr := l.publisher.Publish(forwarded)
+		if publishErr == nil {
+			l.logger.Warn("Forwarded failed event",
+				slog.String("topic", msg.Topic),
+				slog.String("next_topic", next),
+				slog.String("key", string(msg.Key)),
+				slog.Int("partition", msg.Partition),
+				slog.Int64("offset", msg.Offset),
+			)
+			return true
+		}
+		l.logger.Error("Failed to forward failed event",
+			slog.String("error", publishErr.Error()),
+			slog.String("next_topic", next),
+		)
+		if !sleep(ctx, publishRetryBackoff) {
+			return false
+		}
+	}
+}
+
+// handle runs the handler and retries it with exponential backoff.
+// It returns the number of attempts made and the last error.
+func (l *BaseEventListener) handle(ctx context.Context, cfg service_config.KafkaConfig, retries int, handlerFunc HandlerFunc, msg kafka.Message) (int, error) {
+	backoff := retryBackoff(cfg)
+	for attempt := 1; ; attempt++ {
+		err := handlerFunc(msg)
+		if err == nil {
+			return attempt, nil
+		}
+		l.logger.Error("Can not handle event",
+			slog.String("error", err.Error()),
+			slog.String("topic", msg.Topic),
+			slog.String("key", string(msg.Key)),
+			slog.Int("partition", msg.Partition),
+			slog.Int64("offset", msg.Offset),
+			slog.Int("attempt", attempt),
+		)
+		if attempt > retries || !sleep(ctx, backoff) {
+			return attempt, err
+		}
+		backoff *= 2
+	}
+}
+
+func retryBackoff(cfg service_config.KafkaConfig) time.Duration {
+	if cfg.RetryBackoff <= 0 {
+		return defaultRetryBackoff
+	}
+	return cfg.RetryBackoff
+}
+
+// retryTopicDelay continues the in-process backoff sequence, so retry topic N waits twice as long as retry topic N-1
+func retryTopicDelay(cfg service_config.KafkaConfig, stage int) time.Duration {
+	return retryBackoff(cfg) << (cfg.Retry + stage - 1)
+}
+
+// sleep waits for d and returns false if ctx is cancelled first
+func sleep(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return ctx.Err() == nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
 	}
-	return nil
 }
@@ -0,0 +1,272 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/uptrace/bun"
+	"specommerce/campaignservice/pkg/atomicity"
+	"specommerce/campaignservice/pkg/database"
+	"specommerce/campaignservice/pkg/messagequeue"
+	"specommerce/campaignservice/pkg/service_config"
+	"specommerce/campaignservice/pkg/shutdown"
+)
+
+// relayLockKey is the Postgres advisory lock shared by every relay instance of the service.
+const relayLockKey = 7_001_001
+
+// failedMessages counts the outbox messages marked FAILED after MaxAttempts, exposed on /debug/vars
+var failedMessages = expvar.NewInt("outbox_messages_failed")
+
+// Relay drains the outbox table to Kafka.
+type Relay struct {
+	getDbFunc      database.GetDbFunc
+	atomicExecutor atomicity.AtomicExecutor
+	publisher      messagequeue.Publisher
+	config         service_config.OutboxConfig
+	shutdownTask   *shutdown.Tasks
+	logger         *slog.Logger
+}
+
+func NewRelay(
+	getDbFunc database.GetDbFunc,
+	atomicExecutor atomicity.AtomicExecutor,
+	publisher messagequeue.Publisher,
+	cfg service_config.OutboxConfig,
+	shutdownTask *shutdown.Tasks,
+	logger *slog.Logger,
+) *Relay {
+	return &Relay{
+		getDbFunc:      getDbFunc,
+		atomicExecutor: atomicExecutor,
+		publisher:      publisher,
+		config:         cfg,
+		shutdownTask:   shutdownTask,
+		logger:         logger,
+	}
+}
+
+// Start polls the outbox table until shutdown.
+// Only one relay publishes at a time and messages are sent in insertion order, so per-key ordering survives
+// the hop from Postgres to Kafka: a batch is claimed under a transaction-scoped advisory lock, and no batch is
+// claimed while another claim is running. The batch is published outside of any transaction, within
+// PublishTimeout, and its outcome is recorded in a second transaction.
+// A failed publish keeps the batch pending and backs off exponentially up to MaxBackoff;
+// a message that still fails after MaxAttempts is marked FAILED so it cannot block the rest of the table,
+// it is counted in outbox_messages_failed and can be re-driven with FailedMessages.
+func (r *Relay) Start() error {
+	r.logger.Info("Starting outbox relay",
+		slog.Duration("poll_interval", r.config.PollInterval),
+		slog.Int("batch_size", r.config.BatchSize),
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	r.shutdownTask.AddShutdownTask(
+		func(_ context.Context) error {
+			cancel()
+			<-stopped
+			return nil
+		},
+	)
+	defer close(stopped)
+
+	failures := 0
+	for {
+		published, err := r.relayBatch(ctx)
+		wait := r.config.PollInterval
+		switch {
+		case err != nil:
+			failures++
+			wait = r.backoff(failures)
+			if ctx.Err() == nil {
+				r.logger.Error("Failed to relay outbox messages",
+					slog.String("error", err.Error()),
+					slog.Int("failures", failures),
+					slog.Duration("retry_in", wait),
+				)
+			}
+		case published == r.config.BatchSize:
+			failures = 0
+			wait = 0
+		default:
+			failures = 0
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(wait):
+		}
+	}
+}
+
+func (r *Relay) relayBatch(ctx context.Context) (int, error) {
+	errTemplate := "outbox relayBatch %w"
+	messages, err := r.claimBatch(ctx)
+	if err != nil {
+		return 0, fmt.Errorf(errTemplate, err)
+	}
+	if len(messages) == 0 {
+		return 0, nil
+	}
+
+	kafkaMessages := make([]kafka.Message, 0, len(messages))
+	for _, message := range messages {
+		kafkaMessages = append(kafkaMessages, message.ToKafkaMessage())
+	}
+	publishCtx, cancel := context.WithTimeout(ctx, r.config.PublishTimeout)
+	publishErr := r.publisher.PublishContext(publishCtx, kafkaMessages...)
+	cancel()
+
+	sentIds, failedIds := splitPublishResult(messages, publishErr)
+	// The outcome is recorded even at shutdown, so the published messages are not sent again
+	if err = r.recordBatch(context.WithoutCancel(ctx), messages, sentIds, failedIds, publishErr); err != nil {
+		return 0, fmt.Errorf(errTemplate, err)
+	}
+	if publishErr != nil {
+		return len(sentIds), fmt.Errorf(errTemplate, publishErr)
+	}
+	return len(sentIds), nil
+}
+
+// claimBatch claims the next pending messages for twice the PublishTimeout, so the claim outlives the publish.
+// It returns no message when another relay holds the lock or a claim, the claim of a relay that crashed expires.
+func (r *Relay) claimBatch(ctx context.Context) ([]Message, error) {
+	var messages []Message
+	err := r.atomicExecutor.Execute(
+		ctx, func(tc context.Context) error {
+			db := r.getDbFunc(tc)
+			var locked bool
+			if err := db.NewSelect().ColumnExpr("pg_try_advisory_xact_lock(?)", relayLockKey).Scan(tc, &locked); err != nil {
+				return err
+			}
+			if !locked {
+				return nil
+			}
+			claimed, err := db.NewSelect().Model((*Message)(nil)).
+				Where("status = ?", StatusPending).
+				Where("claimed_until > now()").
+				Exists(tc)
+			if err != nil || claimed {
+				return err
+			}
+
+			batch := make([]Message, 0, r.config.BatchSize)
+			err = db.NewSelect().Model(&batch).
+				Where("status = ?", StatusPending).
+				OrderExpr("id ASC").
+				Limit(r.config.BatchSize).
+				Scan(tc)
+			if err != nil || len(batch) == 0 {
+				return err
+			}
+			ids := make([]int64, 0, len(batch))
+			for _, message := range batch {
+				ids = append(ids, message.Id)
+			}
+			_, err = db.NewUpdate().Model((*Message)(nil)).
+				Set("claimed_until = now() + ? * interval '1 millisecond'", (2*r.config.PublishTimeout).Milliseconds()).
+				Where("id IN (?)", bun.In(ids)).
+				Exec(tc)
+			if err != nil {
+				return err
+			}
+			messages = batch
+			return nil
+		},
+	)
+	return messages, err
+}
+
+// recordBatch releases the claim of the batch and records which messages were sent and which failed
+func (r *Relay) recordBatch(ctx context.Context, messages []Message, sentIds []int64, failedIds []int64, publishErr error) error {
+	ids := make([]int64, 0, len(messages))
+	for _, message := range messages {
+		ids = append(ids, message.Id)
+	}
+	return r.atomicExecutor.Execute(
+		ctx, func(tc context.Context) error {
+			db := r.getDbFunc(tc)
+			_, err := db.NewUpdate().Model((*Message)(nil)).
+				Set("claimed_until = NULL").
+				Where("id IN (?)", bun.In(ids)).
+				Exec(tc)
+			if err != nil {
+				return err
+			}
+			if len(sentIds) > 0 {
+				_, err = db.NewUpdate().Model((*Message)(nil)).
+					Set("status = ?", StatusSent).
+					Set("attempts = attempts + 1").
+					Set("sent_at = now()").
+					Where("id IN (?)", bun.In(sentIds)).
+					Exec(tc)
+				if err != nil {
+					return err
+				}
+			}
+			if len(failedIds) == 0 {
+				return nil
+			}
+			var updated []Message
+			_, err = db.NewUpdate().Model((*Message)(nil)).
+				Set("attempts = attempts + 1").
+				Set("last_error = ?", publishErr.Error()).
+				Set("status = CASE WHEN attempts + 1 >= ? THEN ?::outbox_status ELSE status END", r.config.MaxAttempts, StatusFailed).
+				Where("id IN (?)", bun.In(failedIds)).
+				Returning("id, status").
+				Exec(tc, &updated)
+			if err != nil {
+				return err
+			}
+			var deadIds []int64
+			for _, message := range updated {
+				if message.Status == StatusFailed {
+					deadIds = append(deadIds, message.Id)
+				}
+			}
+			if len(deadIds) > 0 {
+				failedMessages.Add(int64(len(deadIds)))
+				r.logger.Error("Outbox messages failed after the maximum attempts",
+					slog.Any("ids", deadIds),
+					slog.String("error", publishErr.Error()),
+				)
+			}
+			return nil
+		},
+	)
+}
+
+// splitPublishResult separates the ids that reached Kafka from the ones that failed.
+// kafka.WriteErrors reports the outcome per message; any other error fails the whole batch.
+// Once a message failed, the later messages with the same topic and key are in neither list: they stay
+// pending without using an attempt, even when they reached Kafka, so they are never delivered ahead of it.
+func splitPublishResult(messages []Message, publishErr error) (sentIds []int64, failedIds []int64) {
+	var writeErrors kafka.WriteErrors
+	isPartial := errors.As(publishErr, &writeErrors) && len(writeErrors) == len(messages)
+	failedKeys := make(map[string]bool)
+	for i, message := range messages {
+		key := message.Topic + "/" + string(message.Key)
+		switch {
+		case len(message.Key) > 0 && failedKeys[key]:
+		case publishErr == nil, isPartial && writeErrors[i] == nil:
+			sentIds = append(sentIds, message.Id)
+		default:
+			failedIds = append(failedIds, message.Id)
+			failedKeys[key] = true
+		}
+	}
+	return sentIds, failedIds
+}
+
+func (r *Relay) backoff(failures int) time.Duration {
+	wait := r.config.PollInterval
+	for i := 1; i < failures && wait < r.config.MaxBackoff; i++ {
+		wait *= 2
+	}
+	return min(wait, r.config.MaxBackoff)
+}
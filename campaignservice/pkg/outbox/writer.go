@@ -0,0 +1,39 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/segmentio/kafka-go"
+	"specommerce/campaignservice/pkg/database"
+)
+
+// Writer stages messages in the outbox table.
+// When ctx carries a transaction (see atomicity.ContextSetTx) the messages are committed or rolled back with it.
+type Writer interface {
+	Write(ctx context.Context, messages ...kafka.Message) error
+}
+
+type postgresWriter struct {
+	getDbFunc database.GetDbFunc
+}
+
+func NewWriter(getDbFunc database.GetDbFunc) Writer {
+	return &postgresWriter{getDbFunc: getDbFunc}
+}
+
+func (w *postgresWriter) Write(ctx context.Context, messages ...kafka.Message) error {
+	errTemplate := "outbox Write %w"
+	if len(messages) == 0 {
+		return nil
+	}
+	records := make([]Message, 0, len(messages))
+	for _, message := range messages {
+		records = append(records, FromKafkaMessage(message))
+	}
+	_, err := database.NewPostgresCrudDatabaseOperation[Message](w.getDbFunc).CreateAll(ctx, records)
+	if err != nil {
+		return fmt.Errorf(errTemplate, err)
+	}
+	return nil
+}
@@ -1,5 +1,7 @@
 package service_config
 
+import "time"
+
 type DbConfig struct {
 	User            string `koanf:"user"`
 	Password        string `koanf:"password"`
@@ -12,11 +14,15 @@ type DbConfig struct {
 }
 
 type KafkaConfig struct {
-	Host            string `koanf:"host"`
-	Retry           int    `koanf:"retry"`
-	AutoCreateTopic bool   `koanf:"autoCreateTopic"`
-	Topic           string `koanf:"topic"`
-	ConsumerGroup   string `koanf:"consumerGroup"`
+	Host            string        `koanf:"host"`
+	Retry           int           `koanf:"retry"`
+	AutoCreateTopic bool          `koanf:"autoCreateTopic"`
+	Topic           string        `koanf:"topic"`
+	ConsumerGroup   string        `koanf:"consumerGroup"`
+	Workers         int           `koanf:"workers"`      // Consumer workers, messages with the same key go to the same worker
+	QueueSize       int           `koanf:"queueSize"`    // Messages buffered per worker before fetching blocks
+	RetryBackoff    time.Duration `koanf:"retryBackoff"` // Initial handler retry backoff, doubled on every retry
+	RetryTopics     int           `koanf:"retryTopics"`  // Delay topics <topic>.retry.N a failed message goes through before <topic>.dlq
 }
 
 // GrpcServiceConfig defines the configuration for gRPC services
@@ -33,9 +39,92 @@ type RestServiceConfig struct {
 	Name string `koanf:"name" yaml:"name" required:"true"`
 }
 
+// AdminServerConfig configures the internal listener of the operational endpoints, kept off the public router
+type AdminServerConfig struct {
+	Host string `koanf:"host"` // Interface the listener binds to, loopback by default so only the host can reach it
+	Port int    `koanf:"port"`
+}
+
 type RedisConfig struct {
 	Host     string `koanf:"host"`
 	Port     int    `koanf:"port"`
 	Password string `koanf:"password"`
 	DB       int    `koanf:"db"`
 }
+
+// DrawConfig defines how often ended draw campaigns are looked up and how long
+// after the end of a campaign its draw runs, so late order events are still entered
+type DrawConfig struct {
+	Interval  time.Duration `koanf:"interval"`
+	Delay     time.Duration `koanf:"delay"`
+	BatchSize int           `koanf:"batchSize"`
+}
+
+// OutboxConfig defines how the outbox relay drains staged messages to Kafka
+type OutboxConfig struct {
+	PollInterval   time.Duration `koanf:"pollInterval"`
+	BatchSize      int           `koanf:"batchSize"`
+	MaxAttempts    int           `koanf:"maxAttempts"`
+	MaxBackoff     time.Duration `koanf:"maxBackoff"`
+	PublishTimeout time.Duration `koanf:"publishTimeout"`
+}
+
+// ClaimConfig defines how long winners have to claim their prize, and how often
+// expired claims are swept and their prizes handed to the next eligible customers
+type ClaimConfig struct {
+	Window    time.Duration `koanf:"window"`
+	Interval  time.Duration `koanf:"interval"`
+	BatchSize int           `koanf:"batchSize"`
+}
+
+// ScheduleConfig defines how often the campaign scheduler activates and closes
+// campaigns, how long a campaign queue is locked while its orders are replayed,
+// and how many failed replays move a queued order out of the queue
+type ScheduleConfig struct {
+	Interval          time.Duration `koanf:"interval"`
+	LockTtl           time.Duration `koanf:"lockTtl"`
+	MaxReplayAttempts int64         `koanf:"maxReplayAttempts"`
+}
+
+// ProgressConfig defines how often a comment is sent on the campaign progress
+// streams, so idle connections are not closed by proxies
+type ProgressConfig struct {
+	Heartbeat time.Duration `koanf:"heartbeat"`
+}
+
+// OrderEventsConfig defines how long the campaigns that rank orders wait for
+// late order events before ranking an order, and how often the orders past
+// that watermark are ranked when no event comes
+type OrderEventsConfig struct {
+	AllowedLateness time.Duration `koanf:"allowedLateness"`
+	FlushInterval   time.Duration `koanf:"flushInterval"`
+}
+
+// PendingSweepConfig defines how long an order can stay PENDING before it is
+// expired, so that it no longer blocks the ranking of the orders created after it,
+// how often the stale orders are swept and how many at once per campaign
+type PendingSweepConfig struct {
+	Timeout   time.Duration `koanf:"timeout"`
+	Interval  time.Duration `koanf:"interval"`
+	BatchSize int64         `koanf:"batchSize"`
+}
+
+// RebuildConfig defines how long the order events of a campaign are queued
+// while its Redis state is rebuilt, and how many times a rebuild is retried when
+// the state changes between the diff and the write
+type RebuildConfig struct {
+	LockTtl     time.Duration `koanf:"lockTtl"`
+	MaxAttempts int           `koanf:"maxAttempts"`
+}
+
+// AuditConfig defines how often the campaign audit streams are persisted, and
+// how many entries of a stream are persisted at once
+type AuditConfig struct {
+	Interval  time.Duration `koanf:"interval"`
+	BatchSize int64         `koanf:"batchSize"`
+}
+
+// ReconciliationConfig defines how often the Redis winners of the campaigns are compared with SQL
+type ReconciliationConfig struct {
+	Interval time.Duration `koanf:"interval"`
+}
@@ -3,15 +3,60 @@ package server
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/samber/do/v2"
+	auditHandler "specommerce/campaignservice/internal/adapters/primary/audit/handler"
 	campaignHandler "specommerce/campaignservice/internal/adapters/primary/campaign/handler"
+	claimHandler "specommerce/campaignservice/internal/adapters/primary/claim/handler"
+	deadLetterHandler "specommerce/campaignservice/internal/adapters/primary/deadletter/handler"
+	drawHandler "specommerce/campaignservice/internal/adapters/primary/draw/handler"
+	outboxHandler "specommerce/campaignservice/internal/adapters/primary/outbox/handler"
+	progressHandler "specommerce/campaignservice/internal/adapters/primary/progress/handler"
+	rebuildHandler "specommerce/campaignservice/internal/adapters/primary/rebuild/handler"
+	reconciliationHandler "specommerce/campaignservice/internal/adapters/primary/reconciliation/handler"
+	simulationHandler "specommerce/campaignservice/internal/adapters/primary/simulation/handler"
 )
 
 func adminRoutes(routerGroup *gin.RouterGroup, injector do.Injector) {
+	audit := do.MustInvoke[auditHandler.AuditHandler](injector)
 	campaign := do.MustInvoke[campaignHandler.CampaignHandler](injector)
+	claim := do.MustInvoke[claimHandler.ClaimHandler](injector)
+	deadLetter := do.MustInvoke[deadLetterHandler.DeadLetterHandler](injector)
+	draw := do.MustInvoke[drawHandler.DrawHandler](injector)
+	outbox := do.MustInvoke[outboxHandler.OutboxHandler](injector)
+	progress := do.MustInvoke[progressHandler.ProgressHandler](injector)
+	rebuild := do.MustInvoke[rebuildHandler.RebuildHandler](injector)
+	reconciliation := do.MustInvoke[reconciliationHandler.ReconciliationHandler](injector)
+	simulation := do.MustInvoke[simulationHandler.SimulationHandler](injector)
 
-	v1OrderGroup := routerGroup.Group("v1/campaigns")
-	v1OrderGroup.POST("/iphones", campaign.CreateIphoneCampaign)
-	v1OrderGroup.GET("/iphones", campaign.GetIphoneCampaign)
-	v1OrderGroup.GET("/iphones/winners", campaign.GetIphoneWinner)
-	v1OrderGroup.PUT("/iphones/:id", campaign.UpdateIphoneCampaign)
+	v1CampaignGroup := routerGroup.Group("v1/campaigns")
+	v1CampaignGroup.POST("", campaign.CreateCampaign)
+	v1CampaignGroup.GET("", campaign.GetCampaigns)
+	v1CampaignGroup.POST("/rebuild", rebuild.RebuildCampaigns)
+	v1CampaignGroup.GET("/reconciliation", reconciliation.ReconcileCampaigns)
+	v1CampaignGroup.POST("/simulations", simulation.SimulateCampaign)
+	v1CampaignGroup.GET("/:id", campaign.GetCampaign)
+	v1CampaignGroup.PUT("/:id", campaign.UpdateCampaign)
+	v1CampaignGroup.GET("/:id/winners", campaign.GetCampaignWinners)
+	v1CampaignGroup.GET("/:id/progress", progress.GetProgress)
+	v1CampaignGroup.GET("/:id/progress/stream", progress.StreamProgress)
+	v1CampaignGroup.GET("/:id/audit/:customer_id", audit.GetCustomerTrail)
+	v1CampaignGroup.GET("/:id/draw", draw.GetDraw)
+	v1CampaignGroup.POST("/:id/draw", draw.RunDraw)
+	v1CampaignGroup.GET("/:id/draw/verify", draw.VerifyDraw)
+	v1CampaignGroup.POST("/:id/rebuild", rebuild.RebuildCampaign)
+	v1CampaignGroup.GET("/:id/reconciliation", reconciliation.ReconcileCampaign)
+	v1CampaignGroup.PUT("/:id/status", campaign.ChangeStatus)
+	v1CampaignGroup.GET("/:id/claims", claim.GetClaims)
+	v1CampaignGroup.GET("/:id/prizes", claim.GetPrizeInventory)
+	v1CampaignGroup.POST("/:id/prizes/:customer_id/ship", claim.ShipPrize)
+	v1CampaignGroup.POST("/:id/prizes/:customer_id/forfeit", claim.ForfeitPrize)
+
+	v1DeadLetterGroup := routerGroup.Group("/v1/dead-letters")
+	v1DeadLetterGroup.GET("", deadLetter.GetTopics)
+	v1DeadLetterGroup.GET("/:topic", deadLetter.ListDeadLetters)
+	v1DeadLetterGroup.GET("/:topic/:partition/:offset", deadLetter.GetDeadLetter)
+	v1DeadLetterGroup.POST("/:topic/:partition/:offset/redrive", deadLetter.RedriveDeadLetter)
+
+	v1OutboxGroup := routerGroup.Group("/v1/outbox-messages")
+	v1OutboxGroup.GET("/failed", outbox.ListFailedMessages)
+	v1OutboxGroup.POST("/:id/redrive", outbox.RedriveFailedMessage)
 }
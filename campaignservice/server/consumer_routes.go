@@ -3,7 +3,14 @@ package server
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/samber/do/v2"
+	claimHandler "specommerce/campaignservice/internal/adapters/primary/claim/handler"
 )
 
 func consumerRoutes(routerGroup *gin.RouterGroup, injector do.Injector) {
+	claim := do.MustInvoke[claimHandler.ClaimHandler](injector)
+
+	v1CampaignGroup := routerGroup.Group("v1/campaigns")
+	v1CampaignGroup.GET("/:id/prizes/:customer_id", claim.GetPrize)
+	v1CampaignGroup.POST("/:id/prizes/:customer_id/claim", claim.ClaimPrize)
+	v1CampaignGroup.POST("/:id/prizes/:customer_id/forfeit", claim.ForfeitPrize)
 }
@@ -0,0 +1,59 @@
+package fi_frontend
+
+import (
+	"github.com/samber/do/v2"
+	"golang.org/x/sync/errgroup"
+	"log/slog"
+	"specommerce/notificationservice/assets"
+	"specommerce/notificationservice/config"
+	"specommerce/notificationservice/di"
+	winnerConsumer "specommerce/notificationservice/internal/adapters/primary/winner/event/kafka"
+	"specommerce/notificationservice/pkg/atomicity"
+	"specommerce/notificationservice/pkg/database"
+	"specommerce/notificationservice/pkg/environment"
+	"specommerce/notificationservice/pkg/service_config"
+	"specommerce/notificationservice/pkg/shutdown"
+	"specommerce/notificationservice/server"
+)
+
+func Run(logger *slog.Logger, tasks *shutdown.Tasks) error {
+	cfg, err := service_config.InitConfig[config.AppConfig](assets.EmbeddedFiles)
+	if err != nil {
+		return err
+	}
+
+	env := environment.Development
+	if cfg.Env == string(environment.Production) {
+		env = environment.Production
+	}
+
+	getDbFunc, atomicExecutor, err := database.New(cfg.Database, tasks, assets.EmbeddedFiles)
+	if err != nil {
+		return err
+	}
+	injector := di.NewInjector()
+	do.ProvideValue(injector, logger)
+	do.ProvideValue(injector, getDbFunc)
+	do.ProvideValue(injector, cfg)
+	do.ProvideValue(injector, env)
+	do.ProvideValue[atomicity.AtomicExecutor](injector, atomicExecutor)
+	do.ProvideValue(injector, tasks)
+
+	var eg errgroup.Group
+	eg.Go(
+		func() error {
+			return server.ServeHTTP(injector)
+		})
+	eg.Go(
+		func() error {
+			return server.ServeAdminHTTP(injector)
+		})
+
+	winnerSelectedConsumer := do.MustInvoke[*winnerConsumer.WinnerSelectedConsumer](injector)
+
+	eg.Go(func() error {
+		return winnerSelectedConsumer.Start()
+	})
+
+	return eg.Wait()
+}
@@ -0,0 +1,6 @@
+package assets
+
+import "embed"
+
+//go:embed "migrations" "templates" "config.yml"
+var EmbeddedFiles embed.FS
@@ -0,0 +1,3 @@
+DROP FUNCTION IF EXISTS create_updated_at_trigger(table_name text);
+
+DROP FUNCTION IF EXISTS auto_timestamps();
\ No newline at end of file
@@ -0,0 +1,23 @@
+create table notifications (
+    id varchar(20) primary key not null,
+    campaign_id bigint not null,
+    campaign_name varchar(255) not null,
+    customer_id varchar(20) not null,
+    rank integer not null,
+    order_id varchar(20),
+    channel varchar(10) not null,
+    recipient varchar(255) not null,
+    subject text not null default '',
+    body text not null,
+    status varchar(10) not null default 'PENDING',
+    attempts integer not null default 0,
+    last_error text not null default '',
+    sent_at timestamp with time zone,
+    created_at timestamp with time zone not null default now(),
+    updated_at timestamp with time zone not null default now(),
+    unique (campaign_id, customer_id, channel)
+);
+
+select create_updated_at_trigger('notifications');
+
+create index notifications_customer_id_created_at on notifications(customer_id, created_at);
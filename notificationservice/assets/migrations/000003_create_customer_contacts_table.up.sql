@@ -0,0 +1,10 @@
+create table customer_contacts (
+    customer_id varchar(20) not null,
+    channel varchar(10) not null,
+    address varchar(255) not null,
+    created_at timestamp with time zone not null default now(),
+    updated_at timestamp with time zone not null default now(),
+    primary key (customer_id, channel)
+);
+
+select create_updated_at_trigger('customer_contacts');
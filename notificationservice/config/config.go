@@ -0,0 +1,20 @@
+package config
+
+import "specommerce/notificationservice/pkg/service_config"
+
+type AppConfig struct {
+	Server       service_config.RestServiceConfig `koanf:"server"`
+	Admin        service_config.AdminServerConfig `koanf:"adminServer"`
+	Env          string                           `koanf:"env"`
+	Database     service_config.DbConfig          `koanf:"db"`
+	Kafka        service_config.KafkaConfig       `koanf:"messagequeue"`
+	WinnerEvents service_config.KafkaConfig       `koanf:"winnerEvents"`
+	Notification NotificationConfig               `koanf:"notification"`
+}
+
+// NotificationConfig configures how customers are notified
+type NotificationConfig struct {
+	Channels []string `koanf:"channels"` // Channels every winner is notified on: EMAIL, SMS
+	Sender   string   `koanf:"sender"`   // Sender delivering the notifications: log or file
+	FilePath string   `koanf:"filePath"` // File the file sender appends notifications to, one JSON object per line
+}
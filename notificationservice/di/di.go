@@ -0,0 +1,126 @@
+package di
+
+import (
+	"fmt"
+	"github.com/samber/do/v2"
+	"log/slog"
+	"specommerce/notificationservice/assets"
+	"specommerce/notificationservice/config"
+	deadLetterHandler "specommerce/notificationservice/internal/adapters/primary/deadletter/handler"
+	notificationHandler "specommerce/notificationservice/internal/adapters/primary/notification/handler"
+	winnerConsumer "specommerce/notificationservice/internal/adapters/primary/winner/event/kafka"
+	contactPostgres "specommerce/notificationservice/internal/adapters/secondary/contact/persistence/postgres"
+	notificationPostgres "specommerce/notificationservice/internal/adapters/secondary/notification/persistence/postgres"
+	fileSender "specommerce/notificationservice/internal/adapters/secondary/notification/sender/file"
+	logSender "specommerce/notificationservice/internal/adapters/secondary/notification/sender/logger"
+	"specommerce/notificationservice/internal/core/domain/notification"
+	"specommerce/notificationservice/internal/core/ports/primary"
+	"specommerce/notificationservice/internal/core/ports/secondary"
+	notificationService "specommerce/notificationservice/internal/core/services/notification"
+	"specommerce/notificationservice/pkg/database"
+	"specommerce/notificationservice/pkg/messagequeue"
+	"specommerce/notificationservice/pkg/shutdown"
+)
+
+func NewInjector() do.Injector {
+	injector := do.New()
+	do.Provide(injector, NewNotificationRepository)
+	do.Provide(injector, NewContactDirectory)
+	do.Provide(injector, NewNotificationService)
+	do.Provide(injector, NewNotificationHandler)
+	do.Provide(injector, NewSender)
+
+	do.Provide(injector, NewPublisher)
+	do.Provide(injector, NewWinnerSelectedConsumer)
+
+	do.Provide(injector, NewBaseEventListener)
+	do.Provide(injector, NewDeadLetterQueue)
+	do.Provide(injector, NewDeadLetterHandler)
+
+	return injector
+}
+
+func NewNotificationRepository(injector do.Injector) (secondary.NotificationRepository, error) {
+	getDbFunc := do.MustInvoke[database.GetDbFunc](injector)
+	return notificationPostgres.NewNotificationPersistenceRepository(getDbFunc), nil
+}
+
+func NewContactDirectory(injector do.Injector) (secondary.ContactDirectory, error) {
+	getDbFunc := do.MustInvoke[database.GetDbFunc](injector)
+	return contactPostgres.NewContactPersistenceRepository(getDbFunc), nil
+}
+
+func NewNotificationService(injector do.Injector) (primary.NotificationService, error) {
+	cfg := do.MustInvoke[config.AppConfig](injector)
+	notificationRepository := do.MustInvoke[secondary.NotificationRepository](injector)
+	contactDirectory := do.MustInvoke[secondary.ContactDirectory](injector)
+	sender := do.MustInvoke[secondary.Sender](injector)
+	logger := do.MustInvoke[*slog.Logger](injector)
+
+	channels := make([]notification.Channel, 0, len(cfg.Notification.Channels))
+	for _, channel := range cfg.Notification.Channels {
+		channels = append(channels, notification.Channel(channel))
+	}
+	templates, err := notificationService.NewTemplates(assets.EmbeddedFiles, channels)
+	if err != nil {
+		return nil, err
+	}
+	return notificationService.NewNotificationService(
+		notificationRepository,
+		contactDirectory,
+		sender,
+		templates,
+		channels,
+		logger,
+	), nil
+}
+
+// NewSender picks the sender of the notifications from the configuration
+func NewSender(injector do.Injector) (secondary.Sender, error) {
+	cfg := do.MustInvoke[config.AppConfig](injector)
+	logger := do.MustInvoke[*slog.Logger](injector)
+	switch cfg.Notification.Sender {
+	case "log":
+		return logSender.NewLogSender(logger), nil
+	case "file":
+		return fileSender.NewFileSender(cfg.Notification.FilePath), nil
+	default:
+		return nil, fmt.Errorf("unknown notification sender %q", cfg.Notification.Sender)
+	}
+}
+
+func NewNotificationHandler(injector do.Injector) (notificationHandler.NotificationHandler, error) {
+	service := do.MustInvoke[primary.NotificationService](injector)
+	return notificationHandler.NewNotificationHandler(service), nil
+}
+
+func NewPublisher(injector do.Injector) (messagequeue.Publisher, error) {
+	cfg := do.MustInvoke[config.AppConfig](injector)
+	tasks := do.MustInvoke[*shutdown.Tasks](injector)
+	return messagequeue.NewPublisher(cfg, tasks), nil
+}
+
+func NewBaseEventListener(injector do.Injector) (*messagequeue.BaseEventListener, error) {
+	publisher := do.MustInvoke[messagequeue.Publisher](injector)
+	tasks := do.MustInvoke[*shutdown.Tasks](injector)
+	logger := do.MustInvoke[*slog.Logger](injector)
+	return messagequeue.NewBaseEventListener(publisher, tasks, logger), nil
+}
+
+func NewDeadLetterQueue(injector do.Injector) (messagequeue.DeadLetterQueue, error) {
+	cfg := do.MustInvoke[config.AppConfig](injector)
+	publisher := do.MustInvoke[messagequeue.Publisher](injector)
+	return messagequeue.NewDeadLetterQueue(cfg.Kafka.Host, publisher, cfg.WinnerEvents), nil
+}
+
+func NewDeadLetterHandler(injector do.Injector) (deadLetterHandler.DeadLetterHandler, error) {
+	deadLetterQueue := do.MustInvoke[messagequeue.DeadLetterQueue](injector)
+	return deadLetterHandler.NewDeadLetterHandler(deadLetterQueue), nil
+}
+
+func NewWinnerSelectedConsumer(injector do.Injector) (*winnerConsumer.WinnerSelectedConsumer, error) {
+	cfg := do.MustInvoke[config.AppConfig](injector)
+	baseEventListener := do.MustInvoke[*messagequeue.BaseEventListener](injector)
+	service := do.MustInvoke[primary.NotificationService](injector)
+	return winnerConsumer.NewWinnerSelectedConsumer(baseEventListener, cfg.WinnerEvents, service), nil
+}
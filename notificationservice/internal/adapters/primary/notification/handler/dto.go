@@ -0,0 +1,77 @@
+package handler
+
+import (
+	domain "specommerce/notificationservice/internal/core/domain/notification"
+	"specommerce/notificationservice/internal/core/ports/secondary"
+	"specommerce/notificationservice/pkg/pagination"
+	"time"
+)
+
+func ToNotificationResponse(entity domain.Notification) NotificationResponse {
+	return NotificationResponse{
+		ID:           entity.Id.String(),
+		CampaignID:   entity.CampaignId,
+		CampaignName: entity.CampaignName,
+		CustomerID:   entity.CustomerId,
+		Rank:         entity.Rank,
+		OrderID:      entity.OrderId,
+		Channel:      entity.Channel.String(),
+		Recipient:    entity.Recipient,
+		Subject:      entity.Subject,
+		Body:         entity.Body,
+		Status:       entity.Status.String(),
+		Attempts:     entity.Attempts,
+		LastError:    entity.LastError,
+		SentAt:       entity.SentAt,
+		CreatedAt:    entity.CreatedAt,
+		UpdatedAt:    entity.UpdatedAt,
+	}
+}
+
+func ToNotificationPageResponse(page pagination.Page[domain.Notification]) pagination.Page[NotificationResponse] {
+	response := make([]NotificationResponse, 0, len(page.Data))
+	for _, entity := range page.Data {
+		response = append(response, ToNotificationResponse(entity))
+	}
+	return pagination.Page[NotificationResponse]{
+		Data:     response,
+		Metadata: page.Metadata,
+	}
+}
+
+// NotificationResponse represents notification response for Swagger
+type NotificationResponse struct {
+	ID           string     `json:"id" example:"abc123"`
+	CampaignID   int64      `json:"campaign_id" example:"1"`
+	CampaignName string     `json:"campaign_name" example:"First 100 customers"`
+	CustomerID   string     `json:"customer_id" example:"customer123"`
+	Rank         int        `json:"rank" example:"1"`
+	OrderID      string     `json:"order_id,omitempty" example:"order123"`
+	Channel      string     `json:"channel" example:"EMAIL"`
+	Recipient    string     `json:"recipient" example:"customer@example.com"`
+	Subject      string     `json:"subject,omitempty" example:"You won First 100 customers!"`
+	Body         string     `json:"body"`
+	Status       string     `json:"status" example:"SENT"`
+	Attempts     int        `json:"attempts" example:"1"`
+	LastError    string     `json:"last_error,omitempty"`
+	SentAt       *time.Time `json:"sent_at,omitempty" example:"2023-01-01T00:00:00Z"`
+	CreatedAt    time.Time  `json:"created_at" example:"2023-01-01T00:00:00Z"`
+	UpdatedAt    time.Time  `json:"updated_at" example:"2023-01-01T00:00:00Z"`
+}
+
+// SearchNotificationsRequest represents the request for searching notifications with pagination
+type SearchNotificationsRequest struct {
+	Paging     pagination.Paging
+	CampaignId int64
+	CustomerId string
+	Status     string
+}
+
+func (req SearchNotificationsRequest) ToFilter() secondary.SearchNotificationsFilter {
+	return secondary.SearchNotificationsFilter{
+		Paging:     req.Paging,
+		CampaignId: req.CampaignId,
+		CustomerId: req.CustomerId,
+		Status:     domain.Status(req.Status),
+	}
+}
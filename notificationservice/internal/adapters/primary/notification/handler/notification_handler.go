@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"net/http"
+	"specommerce/notificationservice/internal/core/ports/primary"
+	"specommerce/notificationservice/pkg/pagination"
+	"specommerce/notificationservice/pkg/sharedto/handler"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type NotificationHandler interface {
+	SearchNotifications(ctx *gin.Context)
+}
+type notificationHandler struct {
+	notificationService primary.NotificationService
+}
+
+func NewNotificationHandler(notificationService primary.NotificationService) NotificationHandler {
+	return &notificationHandler{
+		notificationService: notificationService,
+	}
+}
+
+// SearchNotifications godoc
+// @Summary Search notifications with their delivery status
+// @Description Search the notifications sent to customers, filtered by campaign, customer or delivery status, newest first by default
+// @Tags notifications
+// @Accept json
+// @Produce json
+// @Param campaign_id query int false "Campaign ID"
+// @Param customer_id query string false "Customer ID"
+// @Param status query string false "Delivery status" Enums(PENDING, SENT, FAILED)
+// @Param page query int false "Page number" minimum(1) default(1)
+// @Param size query int false "Page size" minimum(1) default(10)
+// @Param sort query string false "Sort by field with direction (e.g., created_at, -attempts)"
+// @Success 200 {array} NotificationResponse "Paginated notifications"
+// @Failure 400 {object} handler.ErrorResponse "Bad request"
+// @Failure 500 {object} handler.ErrorResponse "Internal server error"
+// @Router /admin/v1/notifications [get]
+func (h *notificationHandler) SearchNotifications(ctx *gin.Context) {
+	var req SearchNotificationsRequest
+	if err := handler.ParsePagination(ctx, &req.Paging); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if campaignId := ctx.Query("campaign_id"); campaignId != "" {
+		id, err := strconv.ParseInt(campaignId, 10, 64)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid campaign ID"})
+			return
+		}
+		req.CampaignId = id
+	}
+	req.CustomerId = ctx.Query("customer_id")
+	req.Status = ctx.Query("status")
+	if len(req.Paging.Sort) == 0 {
+		req.Paging.Sort.Add(pagination.Order{Direction: pagination.DirectionDesc, ColumnName: "created_at"})
+	}
+
+	result, err := h.notificationService.SearchNotifications(ctx, req.ToFilter())
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ToNotificationPageResponse(result))
+}
@@ -0,0 +1,75 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"github.com/golang/protobuf/proto"
+	"log/slog"
+	"specommerce/notificationservice/internal/core/domain/notification"
+	"specommerce/notificationservice/internal/core/ports/primary"
+	"specommerce/notificationservice/model"
+
+	"github.com/segmentio/kafka-go"
+	"specommerce/notificationservice/pkg/messagequeue"
+	"specommerce/notificationservice/pkg/service_config"
+)
+
+type WinnerSelectedConsumer struct {
+	baseListener *messagequeue.BaseEventListener
+	config       service_config.KafkaConfig
+	service      primary.NotificationService
+}
+
+func NewWinnerSelectedConsumer(
+	baseListener *messagequeue.BaseEventListener,
+	cfg service_config.KafkaConfig,
+	service primary.NotificationService,
+) *WinnerSelectedConsumer {
+	return &WinnerSelectedConsumer{
+		baseListener: baseListener,
+		config:       cfg,
+		service:      service,
+	}
+}
+
+func (c *WinnerSelectedConsumer) Start() error {
+	return c.baseListener.Start(c.config, c.handleEvent)
+}
+
+func (c *WinnerSelectedConsumer) handleEvent(message kafka.Message) error {
+	errorTemplate := "WinnerSelectedConsumer.handleEvent: %w"
+	c.baseListener.Logger().Info("Received winner selected event",
+		slog.String("topic", message.Topic),
+		slog.String("key", string(message.Key)),
+	)
+
+	var event model.WinnerSelected
+	if err := proto.Unmarshal(message.Value, &event); err != nil {
+		return fmt.Errorf(errorTemplate, err)
+	}
+	ctx := context.Background()
+	notifications, err := c.service.NotifyWinner(ctx, notification.WinnerSelected{
+		CampaignId:   event.CampaignId,
+		CampaignName: event.CampaignName,
+		CampaignType: event.CampaignType,
+		CustomerId:   event.CustomerId,
+		Rank:         int(event.Rank),
+		OrderId:      event.OrderId,
+		SelectedAt:   event.SelectedAt.AsTime(),
+	})
+	if err != nil {
+		return fmt.Errorf(errorTemplate, err)
+	}
+
+	for _, sent := range notifications {
+		c.baseListener.Logger().Info("Notified winner successfully",
+			slog.String("notification_id", sent.Id.String()),
+			slog.Int64("campaign_id", sent.CampaignId),
+			slog.String("customer_id", sent.CustomerId),
+			slog.String("channel", sent.Channel.String()),
+			slog.Int("attempts", sent.Attempts),
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+package postgres
+
+import (
+	"github.com/uptrace/bun"
+	"time"
+)
+
+type CustomerContact struct {
+	bun.BaseModel `bun:"customer_contacts"`
+	CustomerId    string    `bun:"customer_id,pk"`
+	Channel       string    `bun:"channel,pk"`
+	Address       string    `bun:"address,notnull"`
+	CreatedAt     time.Time `bun:",nullzero,notnull,default:current_timestamp,skipupdate"`
+	UpdatedAt     time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+}
@@ -0,0 +1,38 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/uptrace/bun"
+	domain "specommerce/notificationservice/internal/core/domain/notification"
+	"specommerce/notificationservice/internal/core/ports/secondary"
+	"specommerce/notificationservice/pkg/database"
+)
+
+type contactPersistenceRepository struct {
+	getDbFunc database.GetDbFunc
+}
+
+func NewContactPersistenceRepository(dbFunc database.GetDbFunc) secondary.ContactDirectory {
+	return &contactPersistenceRepository{
+		getDbFunc: dbFunc,
+	}
+}
+
+func (r *contactPersistenceRepository) Lookup(ctx context.Context, customerId string, channel domain.Channel) (string, error) {
+	errTemplate := "contactPersistenceRepository.Lookup: %w"
+	record, err := database.NewPostgresCrudDatabaseOperation[CustomerContact](r.getDbFunc).Get(ctx,
+		func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Where("customer_id = ?", customerId).
+				Where("channel = ?", channel)
+		},
+	)
+	if errors.Is(err, database.ErrRecordNotFound) || (err == nil && record.Address == "") {
+		return "", fmt.Errorf(errTemplate, domain.ErrContactNotFound)
+	}
+	if err != nil {
+		return "", fmt.Errorf(errTemplate, err)
+	}
+	return record.Address, nil
+}
@@ -0,0 +1,70 @@
+package postgres
+
+import (
+	"github.com/rs/xid"
+	"github.com/uptrace/bun"
+	domain "specommerce/notificationservice/internal/core/domain/notification"
+	"time"
+)
+
+type Notification struct {
+	bun.BaseModel `bun:"notifications"`
+	Id            xid.ID     `bun:",skipupdate,pk"`
+	CampaignId    int64      `bun:"campaign_id,notnull,skipupdate"`
+	CampaignName  string     `bun:"campaign_name,notnull,skipupdate"`
+	CustomerId    string     `bun:"customer_id,notnull,skipupdate"`
+	Rank          int        `bun:"rank,notnull,skipupdate"`
+	OrderId       string     `bun:"order_id,nullzero,skipupdate"`
+	Channel       string     `bun:"channel,notnull,skipupdate"`
+	Recipient     string     `bun:"recipient,notnull"`
+	Subject       string     `bun:"subject,notnull"`
+	Body          string     `bun:"body,notnull"`
+	Status        string     `bun:"status,notnull,default:'PENDING'"`
+	Attempts      int        `bun:"attempts,notnull"`
+	LastError     string     `bun:"last_error,notnull"`
+	SentAt        *time.Time `bun:"sent_at"`
+	CreatedAt     time.Time  `bun:",nullzero,notnull,default:current_timestamp,skipupdate"`
+	UpdatedAt     time.Time  `bun:",nullzero,notnull,default:current_timestamp"`
+}
+
+func (n Notification) ToDomainModel() domain.Notification {
+	return domain.Notification{
+		Id:           n.Id,
+		CampaignId:   n.CampaignId,
+		CampaignName: n.CampaignName,
+		CustomerId:   n.CustomerId,
+		Rank:         n.Rank,
+		OrderId:      n.OrderId,
+		Channel:      domain.Channel(n.Channel),
+		Recipient:    n.Recipient,
+		Subject:      n.Subject,
+		Body:         n.Body,
+		Status:       domain.Status(n.Status),
+		Attempts:     n.Attempts,
+		LastError:    n.LastError,
+		SentAt:       n.SentAt,
+		CreatedAt:    n.CreatedAt,
+		UpdatedAt:    n.UpdatedAt,
+	}
+}
+
+func FromDomainModel(dm domain.Notification) Notification {
+	return Notification{
+		Id:           dm.Id,
+		CampaignId:   dm.CampaignId,
+		CampaignName: dm.CampaignName,
+		CustomerId:   dm.CustomerId,
+		Rank:         dm.Rank,
+		OrderId:      dm.OrderId,
+		Channel:      string(dm.Channel),
+		Recipient:    dm.Recipient,
+		Subject:      dm.Subject,
+		Body:         dm.Body,
+		Status:       string(dm.Status),
+		Attempts:     dm.Attempts,
+		LastError:    dm.LastError,
+		SentAt:       dm.SentAt,
+		CreatedAt:    dm.CreatedAt,
+		UpdatedAt:    dm.UpdatedAt,
+	}
+}
@@ -0,0 +1,89 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"github.com/uptrace/bun"
+	domain "specommerce/notificationservice/internal/core/domain/notification"
+	"specommerce/notificationservice/internal/core/ports/secondary"
+	"specommerce/notificationservice/pkg/database"
+	"specommerce/notificationservice/pkg/pagination"
+)
+
+type notificationPersistenceRepository struct {
+	getDbFunc database.GetDbFunc
+}
+
+func NewNotificationPersistenceRepository(dbFunc database.GetDbFunc) secondary.NotificationRepository {
+	return &notificationPersistenceRepository{
+		getDbFunc: dbFunc,
+	}
+}
+
+func (r *notificationPersistenceRepository) GetOrCreate(ctx context.Context, notification domain.Notification) (domain.Notification, error) {
+	errTemplate := "notificationPersistenceRepository.GetOrCreate: %w"
+	model := FromDomainModel(notification)
+	_, err := r.getDbFunc(ctx).NewInsert().Model(&model).
+		On("conflict (campaign_id, customer_id, channel) do nothing").
+		Exec(ctx)
+	if err != nil {
+		return domain.Notification{}, fmt.Errorf(errTemplate, err)
+	}
+	record, err := database.NewPostgresCrudDatabaseOperation[Notification](r.getDbFunc).Get(ctx,
+		func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Where("campaign_id = ?", notification.CampaignId).
+				Where("customer_id = ?", notification.CustomerId).
+				Where("channel = ?", notification.Channel)
+		},
+	)
+	if err != nil {
+		return domain.Notification{}, fmt.Errorf(errTemplate, err)
+	}
+	return record.ToDomainModel(), nil
+}
+
+func (r *notificationPersistenceRepository) Update(ctx context.Context, notification domain.Notification) (domain.Notification, error) {
+	updated, err := database.NewPostgresCrudDatabaseOperation[Notification](r.getDbFunc).Update(ctx, FromDomainModel(notification))
+	if err != nil {
+		return domain.Notification{}, fmt.Errorf("notificationPersistenceRepository.Update: %w", err)
+	}
+	return updated.ToDomainModel(), nil
+}
+
+func (r *notificationPersistenceRepository) SearchNotifications(ctx context.Context, filter secondary.SearchNotificationsFilter) (pagination.Page[domain.Notification], error) {
+	errTemplate := "notificationPersistenceRepository.SearchNotifications: %w"
+
+	records := make([]Notification, 0)
+	query := r.getDbFunc(ctx).NewSelect().Model(&records).
+		Limit(filter.Limit()).Offset(filter.Offset()).
+		Order(filter.Sort.Strings()...)
+	if filter.CampaignId != 0 {
+		query = query.Where("campaign_id = ?", filter.CampaignId)
+	}
+	if filter.CustomerId != "" {
+		query = query.Where("customer_id = ?", filter.CustomerId)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	count, err := query.ScanAndCount(ctx)
+	if err != nil {
+		return pagination.Page[domain.Notification]{}, fmt.Errorf(errTemplate, err)
+	}
+
+	notifications := make([]domain.Notification, 0, len(records))
+	for _, record := range records {
+		notifications = append(notifications, record.ToDomainModel())
+	}
+
+	return pagination.Page[domain.Notification]{
+		Data: notifications,
+		Metadata: pagination.MetaData{
+			Total:      count,
+			PageSize:   filter.Size,
+			PageNumber: filter.Number,
+			TotalPages: filter.TotalPages(count),
+		},
+	}, nil
+}
@@ -0,0 +1,62 @@
+package file
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	domain "specommerce/notificationservice/internal/core/domain/notification"
+	"specommerce/notificationservice/internal/core/ports/secondary"
+	"sync"
+	"time"
+)
+
+// fileSender stands in for the email and SMS providers during local development:
+// it appends every notification to a file as one JSON object per line, so the delivered messages can be inspected
+type fileSender struct {
+	path string
+	mu   sync.Mutex
+}
+
+type record struct {
+	Id        string    `json:"id"`
+	Channel   string    `json:"channel"`
+	Recipient string    `json:"recipient"`
+	Subject   string    `json:"subject,omitempty"`
+	Body      string    `json:"body"`
+	SentAt    time.Time `json:"sent_at"`
+}
+
+func NewFileSender(path string) secondary.Sender {
+	return &fileSender{path: path}
+}
+
+func (s *fileSender) Send(_ context.Context, notification domain.Notification) error {
+	errTemplate := "fileSender.Send: %w"
+	line, err := json.Marshal(record{
+		Id:        notification.Id.String(),
+		Channel:   notification.Channel.String(),
+		Recipient: notification.Recipient,
+		Subject:   notification.Subject,
+		Body:      notification.Body,
+		SentAt:    time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf(errTemplate, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf(errTemplate, err)
+	}
+	if _, err = f.Write(append(line, '\n')); err != nil {
+		_ = f.Close()
+		return fmt.Errorf(errTemplate, err)
+	}
+	if err = f.Close(); err != nil {
+		return fmt.Errorf(errTemplate, err)
+	}
+	return nil
+}
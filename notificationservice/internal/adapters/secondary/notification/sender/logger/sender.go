@@ -0,0 +1,28 @@
+package logger
+
+import (
+	"context"
+	"log/slog"
+	domain "specommerce/notificationservice/internal/core/domain/notification"
+	"specommerce/notificationservice/internal/core/ports/secondary"
+)
+
+// logSender stands in for the email and SMS providers during local development: it only logs the notifications
+type logSender struct {
+	logger *slog.Logger
+}
+
+func NewLogSender(logger *slog.Logger) secondary.Sender {
+	return &logSender{logger: logger}
+}
+
+func (s *logSender) Send(_ context.Context, notification domain.Notification) error {
+	s.logger.Info("Sent notification",
+		slog.String("notification_id", notification.Id.String()),
+		slog.String("channel", notification.Channel.String()),
+		slog.String("recipient", notification.Recipient),
+		slog.String("subject", notification.Subject),
+		slog.String("body", notification.Body),
+	)
+	return nil
+}
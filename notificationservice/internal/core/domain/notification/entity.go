@@ -0,0 +1,70 @@
+package notification
+
+import (
+	"errors"
+	"github.com/rs/xid"
+	"time"
+)
+
+// ErrContactNotFound is returned when the customer has no address on a channel
+var ErrContactNotFound = errors.New("customer has no contact on the channel")
+
+type Channel string
+
+const (
+	ChannelEmail Channel = "EMAIL"
+	ChannelSms   Channel = "SMS"
+)
+
+func (c Channel) String() string {
+	return string(c)
+}
+
+type Status string
+
+const (
+	StatusPending Status = "PENDING"
+	StatusSent    Status = "SENT"
+	StatusFailed  Status = "FAILED"
+)
+
+func (s Status) String() string {
+	return string(s)
+}
+
+// WinnerSelected is the event of the campaign service announcing that a customer won a campaign
+type WinnerSelected struct {
+	CampaignId   int64
+	CampaignName string
+	CampaignType string
+	CustomerId   string
+	Rank         int
+	OrderId      string // Empty when the winning entry is not tied to an order
+	SelectedAt   time.Time
+}
+
+// Message is a rendered notification, Subject is empty on channels without one
+type Message struct {
+	Subject string
+	Body    string
+}
+
+// Notification is one message sent to a winner on one channel, with the outcome of its delivery
+type Notification struct {
+	Id           xid.ID
+	CampaignId   int64
+	CampaignName string
+	CustomerId   string
+	Rank         int
+	OrderId      string
+	Channel      Channel
+	Recipient    string // Address of the customer on the channel
+	Subject      string
+	Body         string
+	Status       Status
+	Attempts     int
+	LastError    string // Error of the last failed attempt
+	SentAt       *time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
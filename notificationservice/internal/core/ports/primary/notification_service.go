@@ -0,0 +1,15 @@
+package primary
+
+import (
+	"context"
+	domain "specommerce/notificationservice/internal/core/domain/notification"
+	"specommerce/notificationservice/internal/core/ports/secondary"
+	"specommerce/notificationservice/pkg/pagination"
+)
+
+// NotificationService defines the primary port for notifying customers
+type NotificationService interface {
+	// NotifyWinner sends the winner notification on every configured channel, it is safe to call again for the same event
+	NotifyWinner(ctx context.Context, event domain.WinnerSelected) ([]domain.Notification, error)
+	SearchNotifications(ctx context.Context, filter secondary.SearchNotificationsFilter) (pagination.Page[domain.Notification], error)
+}
@@ -0,0 +1,12 @@
+package secondary
+
+import (
+	"context"
+	domain "specommerce/notificationservice/internal/core/domain/notification"
+)
+
+// ContactDirectory defines the secondary port resolving the address of a customer on a channel
+type ContactDirectory interface {
+	// Lookup returns the address of the customer on the channel, or domain.ErrContactNotFound when there is none
+	Lookup(ctx context.Context, customerId string, channel domain.Channel) (string, error)
+}
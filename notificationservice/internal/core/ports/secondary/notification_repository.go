@@ -0,0 +1,24 @@
+package secondary
+
+import (
+	"context"
+	domain "specommerce/notificationservice/internal/core/domain/notification"
+	"specommerce/notificationservice/pkg/pagination"
+)
+
+// SearchNotificationsFilter represents the filter for searching notifications, zero fields match everything
+type SearchNotificationsFilter struct {
+	pagination.Paging
+	CampaignId int64
+	CustomerId string
+	Status     domain.Status
+}
+
+// NotificationRepository defines the secondary port for notification persistence
+type NotificationRepository interface {
+	// GetOrCreate stores the notification unless the customer already has one for the campaign and channel,
+	// and returns the stored notification
+	GetOrCreate(ctx context.Context, notification domain.Notification) (domain.Notification, error)
+	Update(ctx context.Context, notification domain.Notification) (domain.Notification, error)
+	SearchNotifications(ctx context.Context, filter SearchNotificationsFilter) (pagination.Page[domain.Notification], error)
+}
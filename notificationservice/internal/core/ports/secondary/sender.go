@@ -0,0 +1,11 @@
+package secondary
+
+import (
+	"context"
+	domain "specommerce/notificationservice/internal/core/domain/notification"
+)
+
+// Sender defines the secondary port delivering notifications to customers, such as an email or SMS provider
+type Sender interface {
+	Send(ctx context.Context, notification domain.Notification) error
+}
@@ -0,0 +1,137 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/rs/xid"
+	"log/slog"
+	"specommerce/notificationservice/internal/core/domain/notification"
+	"specommerce/notificationservice/internal/core/ports/primary"
+	"specommerce/notificationservice/internal/core/ports/secondary"
+	"specommerce/notificationservice/pkg/pagination"
+	"time"
+)
+
+type notificationService struct {
+	notificationRepository secondary.NotificationRepository
+	contactDirectory       secondary.ContactDirectory
+	sender                 secondary.Sender
+	templates              *Templates
+	channels               []notification.Channel
+	logger                 *slog.Logger
+}
+
+func NewNotificationService(
+	notificationRepository secondary.NotificationRepository,
+	contactDirectory secondary.ContactDirectory,
+	sender secondary.Sender,
+	templates *Templates,
+	channels []notification.Channel,
+	logger *slog.Logger,
+) primary.NotificationService {
+	return &notificationService{
+		notificationRepository: notificationRepository,
+		contactDirectory:       contactDirectory,
+		sender:                 sender,
+		templates:              templates,
+		channels:               channels,
+		logger:                 logger,
+	}
+}
+
+// NotifyWinner renders and sends the winner notification on every channel.
+// Each notification is recorded before it is sent, so a redelivered event skips the channels already SENT
+// and retries the ones still PENDING or FAILED. A channel without a contact for the customer is recorded FAILED
+// with the reason, and looked up again when the event is redelivered. An error is returned when any channel fails,
+// letting the consumer retry the event or move it to the dead letter topic.
+func (s *notificationService) NotifyWinner(ctx context.Context, event notification.WinnerSelected) ([]notification.Notification, error) {
+	errTemplate := "notificationService NotifyWinner %w"
+	notifications := make([]notification.Notification, 0, len(s.channels))
+	var errs []error
+	for _, channel := range s.channels {
+		sent, err := s.notify(ctx, event, channel)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", channel, err))
+		}
+		if sent.Id != xid.NilID() {
+			notifications = append(notifications, sent)
+		}
+	}
+	if len(errs) > 0 {
+		return notifications, fmt.Errorf(errTemplate, errors.Join(errs...))
+	}
+	return notifications, nil
+}
+
+func (s *notificationService) notify(ctx context.Context, event notification.WinnerSelected, channel notification.Channel) (notification.Notification, error) {
+	message, err := s.templates.Render(channel, event)
+	if err != nil {
+		return notification.Notification{}, err
+	}
+	recipient, contactErr := s.contactDirectory.Lookup(ctx, event.CustomerId, channel)
+	if contactErr != nil && !errors.Is(contactErr, notification.ErrContactNotFound) {
+		return notification.Notification{}, contactErr
+	}
+	record, err := s.notificationRepository.GetOrCreate(ctx, notification.Notification{
+		Id:           xid.New(),
+		CampaignId:   event.CampaignId,
+		CampaignName: event.CampaignName,
+		CustomerId:   event.CustomerId,
+		Rank:         event.Rank,
+		OrderId:      event.OrderId,
+		Channel:      channel,
+		Recipient:    recipient,
+		Subject:      message.Subject,
+		Body:         message.Body,
+		Status:       notification.StatusPending,
+	})
+	if err != nil {
+		return notification.Notification{}, err
+	}
+	if record.Status == notification.StatusSent {
+		return record, nil
+	}
+	record.Recipient = recipient
+	if contactErr != nil {
+		record.Status = notification.StatusFailed
+		record.LastError = contactErr.Error()
+		s.logger.Warn("no contact for notification",
+			slog.String("notification_id", record.Id.String()),
+			slog.String("customer_id", record.CustomerId),
+			slog.String("channel", channel.String()),
+		)
+		updated, err := s.notificationRepository.Update(ctx, record)
+		if err != nil {
+			return record, errors.Join(contactErr, err)
+		}
+		return updated, contactErr
+	}
+
+	sendErr := s.sender.Send(ctx, record)
+	record.Attempts++
+	if sendErr != nil {
+		record.Status = notification.StatusFailed
+		record.LastError = sendErr.Error()
+		s.logger.Warn("failed to send notification",
+			slog.String("notification_id", record.Id.String()),
+			slog.String("channel", channel.String()),
+			slog.Int("attempts", record.Attempts),
+			slog.String("error", sendErr.Error()),
+		)
+	} else {
+		sentAt := time.Now()
+		record.Status = notification.StatusSent
+		record.LastError = ""
+		record.SentAt = &sentAt
+	}
+	updated, err := s.notificationRepository.Update(ctx, record)
+	if err != nil {
+		return record, errors.Join(sendErr, err)
+	}
+	return updated, sendErr
+}
+
+func (s *notificationService) SearchNotifications(ctx context.Context, filter secondary.SearchNotificationsFilter) (pagination.Page[notification.Notification], error) {
+	return s.notificationRepository.SearchNotifications(ctx, filter)
+}
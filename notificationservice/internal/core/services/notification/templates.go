@@ -0,0 +1,62 @@
+package notification
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"specommerce/notificationservice/internal/core/domain/notification"
+	"strings"
+	"text/template"
+)
+
+const winnerSelectedTemplate = "templates/winner_selected.%s.tmpl"
+
+// Templates renders the winner notification of each channel.
+// The template of a channel defines a "body" and, on channels that have one, a "subject".
+type Templates struct {
+	byChannel map[notification.Channel]*template.Template
+}
+
+func NewTemplates(files fs.FS, channels []notification.Channel) (*Templates, error) {
+	errTemplate := "NewTemplates: %w"
+	byChannel := make(map[notification.Channel]*template.Template, len(channels))
+	for _, channel := range channels {
+		path := fmt.Sprintf(winnerSelectedTemplate, strings.ToLower(channel.String()))
+		tmpl, err := template.ParseFS(files, path)
+		if err != nil {
+			return nil, fmt.Errorf(errTemplate, err)
+		}
+		if tmpl.Lookup("body") == nil {
+			return nil, fmt.Errorf(errTemplate, fmt.Errorf("%s does not define a body", path))
+		}
+		byChannel[channel] = tmpl
+	}
+	return &Templates{byChannel: byChannel}, nil
+}
+
+func (t *Templates) Render(channel notification.Channel, event notification.WinnerSelected) (notification.Message, error) {
+	errTemplate := "Templates.Render: %w"
+	tmpl, ok := t.byChannel[channel]
+	if !ok {
+		return notification.Message{}, fmt.Errorf(errTemplate, fmt.Errorf("no template for channel %s", channel))
+	}
+	body, err := execute(tmpl, "body", event)
+	if err != nil {
+		return notification.Message{}, fmt.Errorf(errTemplate, err)
+	}
+	var subject string
+	if tmpl.Lookup("subject") != nil {
+		if subject, err = execute(tmpl, "subject", event); err != nil {
+			return notification.Message{}, fmt.Errorf(errTemplate, err)
+		}
+	}
+	return notification.Message{Subject: subject, Body: body}, nil
+}
+
+func execute(tmpl *template.Template, name string, data any) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&buf, name, data); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(buf.String()), nil
+}
@@ -0,0 +1,183 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: model/model.proto
+
+package model
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// WinnerSelected is published by the campaign service when a customer wins a campaign
+type WinnerSelected struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CampaignId    int64                  `protobuf:"varint,1,opt,name=campaign_id,json=campaignId,proto3" json:"campaign_id,omitempty"`
+	CampaignName  string                 `protobuf:"bytes,2,opt,name=campaign_name,json=campaignName,proto3" json:"campaign_name,omitempty"`
+	CampaignType  string                 `protobuf:"bytes,3,opt,name=campaign_type,json=campaignType,proto3" json:"campaign_type,omitempty"`
+	CustomerId    string                 `protobuf:"bytes,4,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Rank          int32                  `protobuf:"varint,5,opt,name=rank,proto3" json:"rank,omitempty"`
+	OrderId       string                 `protobuf:"bytes,6,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	SelectedAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=selected_at,json=selectedAt,proto3" json:"selected_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WinnerSelected) Reset() {
+	*x = WinnerSelected{}
+	mi := &file_model_model_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WinnerSelected) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WinnerSelected) ProtoMessage() {}
+
+func (x *WinnerSelected) ProtoReflect() protoreflect.Message {
+	mi := &file_model_model_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WinnerSelected.ProtoReflect.Descriptor instead.
+func (*WinnerSelected) Descriptor() ([]byte, []int) {
+	return file_model_model_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *WinnerSelected) GetCampaignId() int64 {
+	if x != nil {
+		return x.CampaignId
+	}
+	return 0
+}
+
+func (x *WinnerSelected) GetCampaignName() string {
+	if x != nil {
+		return x.CampaignName
+	}
+	return ""
+}
+
+func (x *WinnerSelected) GetCampaignType() string {
+	if x != nil {
+		return x.CampaignType
+	}
+	return ""
+}
+
+func (x *WinnerSelected) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *WinnerSelected) GetRank() int32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *WinnerSelected) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *WinnerSelected) GetSelectedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SelectedAt
+	}
+	return nil
+}
+
+var File_model_model_proto protoreflect.FileDescriptor
+
+const file_model_model_proto_rawDesc = "" +
+	"\n" +
+	"\x11model/model.proto\x12\x05kafka\x1a\x1fgoogle/protobuf/timestamp.proto\"\x88\x02\n" +
+	"\x0eWinnerSelected\x12\x1f\n" +
+	"\vcampaign_id\x18\x01 \x01(\x03R\n" +
+	"campaignId\x12#\n" +
+	"\rcampaign_name\x18\x02 \x01(\tR\fcampaignName\x12#\n" +
+	"\rcampaign_type\x18\x03 \x01(\tR\fcampaignType\x12\x1f\n" +
+	"\vcustomer_id\x18\x04 \x01(\tR\n" +
+	"customerId\x12\x12\n" +
+	"\x04rank\x18\x05 \x01(\x05R\x04rank\x12\x19\n" +
+	"\border_id\x18\x06 \x01(\tR\aorderId\x12;\n" +
+	"\vselected_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"selectedAtB'Z%specommerce/notificationservice/modelb\x06proto3"
+
+var (
+	file_model_model_proto_rawDescOnce sync.Once
+	file_model_model_proto_rawDescData []byte
+)
+
+func file_model_model_proto_rawDescGZIP() []byte {
+	file_model_model_proto_rawDescOnce.Do(func() {
+		file_model_model_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_model_model_proto_rawDesc), len(file_model_model_proto_rawDesc)))
+	})
+	return file_model_model_proto_rawDescData
+}
+
+var file_model_model_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_model_model_proto_goTypes = []any{
+	(*WinnerSelected)(nil),        // 0: kafka.WinnerSelected
+	(*timestamppb.Timestamp)(nil), // 1: google.protobuf.Timestamp
+}
+var file_model_model_proto_depIdxs = []int32{
+	1, // 0: kafka.WinnerSelected.selected_at:type_name -> google.protobuf.Timestamp
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_model_model_proto_init() }
+func file_model_model_proto_init() {
+	if File_model_model_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_model_model_proto_rawDesc), len(file_model_model_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_model_model_proto_goTypes,
+		DependencyIndexes: file_model_model_proto_depIdxs,
+		MessageInfos:      file_model_model_proto_msgTypes,
+	}.Build()
+	File_model_model_proto = out.File
+	file_model_model_proto_goTypes = nil
+	file_model_model_proto_depIdxs = nil
+}
@@ -0,0 +1,330 @@
+package messagequeue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"specommerce/notificationservice/pkg/service_config"
+)
+
+// Headers recorded on messages forwarded to retry and dead-letter topics
+const (
+	HeaderOriginalTopic     = "x-original-topic"
+	HeaderOriginalPartition = "x-original-partition"
+	HeaderOriginalOffset    = "x-original-offset"
+	HeaderConsumerGroup     = "x-consumer-group"
+	HeaderAttempts          = "x-attempts"
+	HeaderError             = "x-error"
+	HeaderFailedAt          = "x-failed-at"
+)
+
+const (
+	deadLetterReadTimeout  = 5 * time.Second
+	deadLetterMaxBatchSize = 10 << 20
+)
+
+var (
+	ErrUnknownDeadLetterTopic = errors.New("topic is not consumed by this service")
+	ErrDeadLetterNotFound     = errors.New("dead letter not found")
+)
+
+func RetryTopic(topic string, stage int) string {
+	return fmt.Sprintf("%s.retry.%d", topic, stage)
+}
+
+func DeadLetterTopic(topic string) string {
+	return topic + ".dlq"
+}
+
+// failureHeaders keeps the application headers of a failed message and records where it originally came from,
+// which consumer group failed it, how many attempts were made in total and the last error
+func failureHeaders(msg kafka.Message, consumerGroup string, attempts int, err error) []kafka.Header {
+	originalTopic := headerValue(msg, HeaderOriginalTopic)
+	originalPartition := headerValue(msg, HeaderOriginalPartition)
+	originalOffset := headerValue(msg, HeaderOriginalOffset)
+	if originalTopic == "" {
+		originalTopic = msg.Topic
+		originalPartition = strconv.Itoa(msg.Partition)
+		originalOffset = strconv.FormatInt(msg.Offset, 10)
+	}
+	previousAttempts, _ := strconv.Atoi(headerValue(msg, HeaderAttempts))
+
+	headers := applicationHeaders(msg)
+	return append(headers,
+		kafka.Header{Key: HeaderOriginalTopic, Value: []byte(originalTopic)},
+		kafka.Header{Key: HeaderOriginalPartition, Value: []byte(originalPartition)},
+		kafka.Header{Key: HeaderOriginalOffset, Value: []byte(originalOffset)},
+		kafka.Header{Key: HeaderConsumerGroup, Value: []byte(consumerGroup)},
+		kafka.Header{Key: HeaderAttempts, Value: []byte(strconv.Itoa(previousAttempts + attempts))},
+		kafka.Header{Key: HeaderError, Value: []byte(err.Error())},
+		kafka.Header{Key: HeaderFailedAt, Value: []byte(time.Now().UTC().Format(time.RFC3339Nano))},
+	)
+}
+
+// applicationHeaders returns the message headers without the ones recorded by failureHeaders
+func applicationHeaders(msg kafka.Message) []kafka.Header {
+	headers := make([]kafka.Header, 0, len(msg.Headers))
+	for _, header := range msg.Headers {
+		if !strings.HasPrefix(header.Key, "x-") {
+			headers = append(headers, header)
+		}
+	}
+	return headers
+}
+
+func headerValue(msg kafka.Message, key string) string {
+	for _, header := range msg.Headers {
+		if header.Key == key {
+			return string(header.Value)
+		}
+	}
+	return ""
+}
+
+// DeadLetter is a message parked in a dead-letter topic
+type DeadLetter struct {
+	Topic             string            `json:"topic"`
+	Partition         int               `json:"partition"`
+	Offset            int64             `json:"offset"`
+	Key               string            `json:"key"`
+	Value             []byte            `json:"value"`
+	Headers           map[string]string `json:"headers"`
+	OriginalTopic     string            `json:"original_topic"`
+	OriginalPartition int               `json:"original_partition"`
+	OriginalOffset    int64             `json:"original_offset"`
+	ConsumerGroup     string            `json:"consumer_group"`
+	Attempts          int               `json:"attempts"`
+	Error             string            `json:"error"`
+	FailedAt          time.Time         `json:"failed_at"`
+}
+
+// DeadLetterPartition reports the offset range of a dead-letter topic partition
+type DeadLetterPartition struct {
+	Partition   int   `json:"partition"`
+	FirstOffset int64 `json:"first_offset"`
+	LastOffset  int64 `json:"last_offset"`
+}
+
+// DeadLetterTopicInfo describes the dead-letter topic of a consumed topic
+type DeadLetterTopicInfo struct {
+	Topic           string                `json:"topic"`
+	DeadLetterTopic string                `json:"dead_letter_topic"`
+	ConsumerGroups  []string              `json:"consumer_groups"`
+	Partitions      []DeadLetterPartition `json:"partitions"`
+}
+
+// DeadLetterQueue inspects the dead-letter topics of the topics consumed by the service and re-drives their messages.
+// Topics are addressed by the consumed topic name, not by the name of the dead-letter topic.
+type DeadLetterQueue interface {
+	Topics(ctx context.Context) ([]DeadLetterTopicInfo, error)
+	List(ctx context.Context, topic string, partition int, offset int64, limit int) ([]DeadLetter, error)
+	Get(ctx context.Context, topic string, partition int, offset int64) (DeadLetter, error)
+	// Redrive publishes the message back to its original topic, addressed to the consumer group that failed it
+	Redrive(ctx context.Context, topic string, partition int, offset int64) (DeadLetter, error)
+}
+
+type kafkaDeadLetterQueue struct {
+	host      string
+	publisher Publisher
+	groups    map[string][]string
+}
+
+func NewDeadLetterQueue(host string, publisher Publisher, consumers ...service_config.KafkaConfig) DeadLetterQueue {
+	groups := make(map[string][]string)
+	for _, consumer := range consumers {
+		if !slices.Contains(groups[consumer.Topic], consumer.ConsumerGroup) {
+			groups[consumer.Topic] = append(groups[consumer.Topic], consumer.ConsumerGroup)
+		}
+	}
+	return &kafkaDeadLetterQueue{
+		host:      host,
+		publisher: publisher,
+		groups:    groups,
+	}
+}
+
+func (q *kafkaDeadLetterQueue) Topics(ctx context.Context) ([]DeadLetterTopicInfo, error) {
+	errTemplate := "deadLetterQueue Topics %w"
+	topics := make([]string, 0, len(q.groups))
+	for topic := range q.groups {
+		topics = append(topics, topic)
+	}
+	slices.Sort(topics)
+
+	conn, err := kafka.DialContext(ctx, "tcp", q.host)
+	if err != nil {
+		return nil, fmt.Errorf(errTemplate, err)
+	}
+	defer conn.Close()
+
+	result := make([]DeadLetterTopicInfo, 0, len(topics))
+	for _, topic := range topics {
+		info := DeadLetterTopicInfo{
+			Topic:           topic,
+			DeadLetterTopic: DeadLetterTopic(topic),
+			ConsumerGroups:  q.groups[topic],
+			Partitions:      make([]DeadLetterPartition, 0),
+		}
+		partitions, err := conn.ReadPartitions(info.DeadLetterTopic)
+		if errors.Is(err, kafka.UnknownTopicOrPartition) {
+			result = append(result, info)
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf(errTemplate, err)
+		}
+		for _, partition := range partitions {
+			first, last, err := q.readOffsets(ctx, info.DeadLetterTopic, partition.ID)
+			if err != nil {
+				return nil, fmt.Errorf(errTemplate, err)
+			}
+			info.Partitions = append(info.Partitions, DeadLetterPartition{
+				Partition:   partition.ID,
+				FirstOffset: first,
+				LastOffset:  last,
+			})
+		}
+		result = append(result, info)
+	}
+	return result, nil
+}
+
+func (q *kafkaDeadLetterQueue) List(ctx context.Context, topic string, partition int, offset int64, limit int) ([]DeadLetter, error) {
+	errTemplate := "deadLetterQueue List %w"
+	if _, ok := q.groups[topic]; !ok {
+		return nil, fmt.Errorf(errTemplate, ErrUnknownDeadLetterTopic)
+	}
+	messages, err := q.read(ctx, DeadLetterTopic(topic), partition, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf(errTemplate, err)
+	}
+	result := make([]DeadLetter, 0, len(messages))
+	for _, message := range messages {
+		result = append(result, toDeadLetter(message))
+	}
+	return result, nil
+}
+
+func (q *kafkaDeadLetterQueue) Get(ctx context.Context, topic string, partition int, offset int64) (DeadLetter, error) {
+	errTemplate := "deadLetterQueue Get %w"
+	if _, ok := q.groups[topic]; !ok {
+		return DeadLetter{}, fmt.Errorf(errTemplate, ErrUnknownDeadLetterTopic)
+	}
+	messages, err := q.read(ctx, DeadLetterTopic(topic), partition, offset, 1)
+	if err != nil {
+		return DeadLetter{}, fmt.Errorf(errTemplate, err)
+	}
+	if len(messages) == 0 || messages[0].Offset != offset {
+		return DeadLetter{}, fmt.Errorf(errTemplate, ErrDeadLetterNotFound)
+	}
+	return toDeadLetter(messages[0]), nil
+}
+
+func (q *kafkaDeadLetterQueue) Redrive(ctx context.Context, topic string, partition int, offset int64) (DeadLetter, error) {
+	errTemplate := "deadLetterQueue Redrive %w"
+	deadLetter, err := q.Get(ctx, topic, partition, offset)
+	if err != nil {
+		return DeadLetter{}, fmt.Errorf(errTemplate, err)
+	}
+	headers := make([]kafka.Header, 0, len(deadLetter.Headers)+1)
+	for key, value := range deadLetter.Headers {
+		if !strings.HasPrefix(key, "x-") {
+			headers = append(headers, kafka.Header{Key: key, Value: []byte(value)})
+		}
+	}
+	headers = append(headers, kafka.Header{Key: HeaderConsumerGroup, Value: []byte(deadLetter.ConsumerGroup)})
+	err = q.publisher.Publish(kafka.Message{
+		Topic:   deadLetter.OriginalTopic,
+		Key:     []byte(deadLetter.Key),
+		Value:   deadLetter.Value,
+		Headers: headers,
+	})
+	if err != nil {
+		return DeadLetter{}, fmt.Errorf(errTemplate, err)
+	}
+	return deadLetter, nil
+}
+
+func (q *kafkaDeadLetterQueue) readOffsets(ctx context.Context, topic string, partition int) (int64, int64, error) {
+	conn, err := kafka.DialLeader(ctx, "tcp", q.host, topic, partition)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer conn.Close()
+	return conn.ReadOffsets()
+}
+
+// read returns up to limit messages of a partition starting at offset, without joining a consumer group
+func (q *kafkaDeadLetterQueue) read(ctx context.Context, topic string, partition int, offset int64, limit int) ([]kafka.Message, error) {
+	conn, err := kafka.DialLeader(ctx, "tcp", q.host, topic, partition)
+	if errors.Is(err, kafka.UnknownTopicOrPartition) {
+		return []kafka.Message{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	first, last, err := conn.ReadOffsets()
+	if err != nil {
+		return nil, err
+	}
+	offset = max(offset, first)
+	if offset >= last {
+		return []kafka.Message{}, nil
+	}
+	if _, err = conn.Seek(offset, kafka.SeekAbsolute); err != nil {
+		return nil, err
+	}
+	if err = conn.SetReadDeadline(time.Now().Add(deadLetterReadTimeout)); err != nil {
+		return nil, err
+	}
+
+	batch := conn.ReadBatch(1, deadLetterMaxBatchSize)
+	defer batch.Close()
+	messages := make([]kafka.Message, 0, limit)
+	for len(messages) < limit {
+		message, err := batch.ReadMessage()
+		if err != nil {
+			break
+		}
+		message.Topic, message.Partition = topic, partition
+		messages = append(messages, message)
+		if message.Offset >= last-1 {
+			break
+		}
+	}
+	return messages, nil
+}
+
+func toDeadLetter(message kafka.Message) DeadLetter {
+	headers := make(map[string]string, len(message.Headers))
+	for _, header := range message.Headers {
+		headers[header.Key] = string(header.Value)
+	}
+	originalPartition, _ := strconv.Atoi(headers[HeaderOriginalPartition])
+	originalOffset, _ := strconv.ParseInt(headers[HeaderOriginalOffset], 10, 64)
+	attempts, _ := strconv.Atoi(headers[HeaderAttempts])
+	failedAt, _ := time.Parse(time.RFC3339Nano, headers[HeaderFailedAt])
+	return DeadLetter{
+		Topic:             message.Topic,
+		Partition:         message.Partition,
+		Offset:            message.Offset,
+		Key:               string(message.Key),
+		Value:             message.Value,
+		Headers:           headers,
+		OriginalTopic:     headers[HeaderOriginalTopic],
+		OriginalPartition: originalPartition,
+		OriginalOffset:    originalOffset,
+		ConsumerGroup:     headers[HeaderConsumerGroup],
+		Attempts:          attempts,
+		Error:             headers[HeaderError],
+		FailedAt:          failedAt,
+	}
+}
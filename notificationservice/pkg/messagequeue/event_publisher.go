@@ -0,0 +1,44 @@
+package messagequeue
+
+import (
+	"context"
+
+	"github.com/segmentio/kafka-go"
+
+	"specommerce/notificationservice/config"
+	"specommerce/notificationservice/pkg/shutdown"
+)
+
+type Publisher interface {
+	Publish(messages ...kafka.Message) error
+	// PublishContext publishes the messages until ctx is done
+	PublishContext(ctx context.Context, messages ...kafka.Message) error
+}
+
+type publisher struct {
+	kafkaWriter *kafka.Writer
+}
+
+func NewPublisher(cfg config.AppConfig, tasks *shutdown.Tasks) Publisher {
+	writer := &kafka.Writer{
+		Addr:                   kafka.TCP(cfg.Kafka.Host),
+		Balancer:               &kafka.Hash{}, // Same key, same partition: keeps per-key ordering for consumers
+		RequiredAcks:           1,
+		AllowAutoTopicCreation: cfg.Kafka.AutoCreateTopic,
+		MaxAttempts:            cfg.Kafka.Retry,
+	}
+	tasks.AddShutdownTask(
+		func(ctx context.Context) error {
+			return writer.Close()
+		},
+	)
+	return &publisher{kafkaWriter: writer}
+}
+
+func (publisher *publisher) Publish(messages ...kafka.Message) error {
+	return publisher.kafkaWriter.WriteMessages(context.Background(), messages...)
+}
+
+func (publisher *publisher) PublishContext(ctx context.Context, messages ...kafka.Message) error {
+	return publisher.kafkaWriter.WriteMessages(ctx, messages...)
+}
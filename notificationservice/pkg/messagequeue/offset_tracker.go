@@ -0,0 +1,56 @@
+package messagequeue
+
+import "sync"
+
+// offsetTracker keeps the fetched offsets of every partition in fetch order, so that an offset
+// is only reported as committable once its message and every lower fetched message are handled
+type offsetTracker struct {
+	mu         sync.Mutex
+	partitions map[int]*partitionOffsets
+}
+
+type partitionOffsets struct {
+	pending []int64
+	done    map[int64]struct{}
+}
+
+func newOffsetTracker() *offsetTracker {
+	return &offsetTracker{
+		partitions: make(map[int]*partitionOffsets),
+	}
+}
+
+// track registers a fetched offset. An offset that is not greater than the last tracked one means
+// the partition was rewound (e.g. after a rebalance), so the state of the partition is reset.
+func (t *offsetTracker) track(partition int, offset int64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	p, ok := t.partitions[partition]
+	if !ok || (len(p.pending) > 0 && offset <= p.pending[len(p.pending)-1]) {
+		p = &partitionOffsets{done: make(map[int64]struct{})}
+		t.partitions[partition] = p
+	}
+	p.pending = append(p.pending, offset)
+}
+
+// markDone marks an offset as handled and returns the highest offset that can be committed,
+// ok is false when a lower offset of the partition is still in flight
+func (t *offsetTracker) markDone(partition int, offset int64) (commit int64, ok bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	p, exists := t.partitions[partition]
+	if !exists {
+		return 0, false
+	}
+	p.done[offset] = struct{}{}
+	for len(p.pending) > 0 {
+		head := p.pending[0]
+		if _, handled := p.done[head]; !handled {
+			break
+		}
+		delete(p.done, head)
+		p.pending = p.pending[1:]
+		commit, ok = head, true
+	}
+	return commit, ok
+}
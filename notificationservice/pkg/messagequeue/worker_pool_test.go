@@ -0,0 +1,59 @@
+package messagequeue
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyedWorkerPool_PreservesOrderPerKey(t *testing.T) {
+	var mu sync.Mutex
+	handled := make(map[string][]int64)
+	pool := newKeyedWorkerPool(4, 2, func(message kafka.Message) {
+		mu.Lock()
+		defer mu.Unlock()
+		handled[string(message.Key)] = append(handled[string(message.Key)], message.Offset)
+	})
+
+	keys := []string{"a", "b", "c", "d", "e"}
+	for offset := int64(0); offset < 100; offset++ {
+		key := keys[offset%int64(len(keys))]
+		assert.True(t, pool.submit(context.Background(), kafka.Message{Key: []byte(key), Offset: offset}))
+	}
+	pool.close()
+
+	for i, key := range keys {
+		offsets := handled[key]
+		assert.Len(t, offsets, 20, fmt.Sprintf("key %s", key))
+		for j, offset := range offsets {
+			assert.Equal(t, int64(i+j*len(keys)), offset)
+		}
+	}
+}
+
+func TestKeyedWorkerPool_SameKeySameWorker(t *testing.T) {
+	pool := newKeyedWorkerPool(16, 1, func(kafka.Message) {})
+	defer pool.close()
+	assert.Equal(t, pool.worker([]byte("customer-1")), pool.worker([]byte("customer-1")))
+}
+
+func TestKeyedWorkerPool_SubmitBlocksWhenQueueIsFull(t *testing.T) {
+	release := make(chan struct{})
+	pool := newKeyedWorkerPool(1, 1, func(kafka.Message) {
+		<-release
+	})
+
+	assert.True(t, pool.submit(context.Background(), kafka.Message{Offset: 0})) // picked up by the worker
+	assert.True(t, pool.submit(context.Background(), kafka.Message{Offset: 1})) // fills the queue
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.False(t, pool.submit(ctx, kafka.Message{Offset: 2}))
+
+	close(release)
+	pool.close()
+}
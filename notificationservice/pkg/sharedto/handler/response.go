@@ -0,0 +1,10 @@
+package handler
+
+type BaseResponse[T any] struct {
+	Data T `json:"data"`
+}
+
+type ErrorResponse struct {
+	Error string `json:"error"`
+	Code  int    `json:"code"`
+}
@@ -0,0 +1,82 @@
+// Code generated by mockery v2.43.2. DO NOT EDIT.
+
+package shutdown
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockTask is an autogenerated mock type for the Task type
+type MockTask struct {
+	mock.Mock
+}
+
+type MockTask_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockTask) EXPECT() *MockTask_Expecter {
+	return &MockTask_Expecter{mock: &_m.Mock}
+}
+
+// Execute provides a mock function with given fields: ctx
+func (_m *MockTask) Execute(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Execute")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockTask_Execute_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Execute'
+type MockTask_Execute_Call struct {
+	*mock.Call
+}
+
+// Execute is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *MockTask_Expecter) Execute(ctx interface{}) *MockTask_Execute_Call {
+	return &MockTask_Execute_Call{Call: _e.mock.On("Execute", ctx)}
+}
+
+func (_c *MockTask_Execute_Call) Run(run func(ctx context.Context)) *MockTask_Execute_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *MockTask_Execute_Call) Return(_a0 error) *MockTask_Execute_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockTask_Execute_Call) RunAndReturn(run func(context.Context) error) *MockTask_Execute_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockTask creates a new instance of MockTask. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockTask(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockTask {
+	mock := &MockTask{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,22 @@
+package server
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/samber/do/v2"
+	deadLetterHandler "specommerce/notificationservice/internal/adapters/primary/deadletter/handler"
+	notificationHandler "specommerce/notificationservice/internal/adapters/primary/notification/handler"
+)
+
+func adminRoutes(routerGroup *gin.RouterGroup, injector do.Injector) {
+	notification := do.MustInvoke[notificationHandler.NotificationHandler](injector)
+	deadLetter := do.MustInvoke[deadLetterHandler.DeadLetterHandler](injector)
+
+	v1NotificationGroup := routerGroup.Group("/v1/notifications")
+	v1NotificationGroup.GET("", notification.SearchNotifications)
+
+	v1DeadLetterGroup := routerGroup.Group("/v1/dead-letters")
+	v1DeadLetterGroup.GET("", deadLetter.GetTopics)
+	v1DeadLetterGroup.GET("/:topic", deadLetter.ListDeadLetters)
+	v1DeadLetterGroup.GET("/:topic/:partition/:offset", deadLetter.GetDeadLetter)
+	v1DeadLetterGroup.POST("/:topic/:partition/:offset/redrive", deadLetter.RedriveDeadLetter)
+}
@@ -0,0 +1,10 @@
+package server
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/samber/do/v2"
+)
+
+func consumerRoutes(routerGroup *gin.RouterGroup, injector do.Injector) {
+
+}
@@ -0,0 +1,59 @@
+package server
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/samber/do/v2"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+	"net/http"
+	"specommerce/notificationservice/config"
+	docs "specommerce/notificationservice/docs/openapi/api/notificationservice"
+	"specommerce/notificationservice/pkg/environment"
+)
+
+// NewRoutes godoc
+//
+//	@securityDefinitions.apikey	BearerAuth
+//	@in							header
+//	@name						Authorization
+func routes(injector do.Injector) http.Handler {
+	appConfig := do.MustInvoke[config.AppConfig](injector)
+	if appConfig.Env == string(environment.Production) {
+		gin.SetMode(gin.ReleaseMode)
+	}
+	docs.SwaggerInfo.Title = "SP Ecommerce - Notification Service API"
+	docs.SwaggerInfo.Description = "Notification Service API"
+	docs.SwaggerInfo.Version = "1.0"
+	docs.SwaggerInfo.BasePath = "/api"
+	docs.SwaggerInfo.Schemes = []string{"http", "https"}
+	docs.SwaggerInfo.Host = "localhost:8083"
+	r := gin.New()
+
+	r.Use(func(c *gin.Context) {
+		c.Header("Access-Control-Allow-Origin", "*")
+		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
+		c.Header("Access-Control-Allow-Headers", "Origin, Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization")
+		
+		if c.Request.Method == "OPTIONS" {
+			c.AbortWithStatus(204)
+			return
+		}
+		
+		c.Next()
+	})
+
+	r.NoRoute(notFound)
+	r.NoMethod(methodNotAllowed)
+	r.GET("/docs/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	r.GET(
+		"/status", func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{"status": "ok"})
+		},
+	)
+	apiUserGroup := r.Group("/api")
+	consumerRoutes(apiUserGroup, injector)
+
+	apiAdminGroup := r.Group("/api/admin")
+	adminRoutes(apiAdminGroup, injector)
+	return r
+}
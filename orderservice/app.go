@@ -8,9 +8,14 @@ import (
 	"specommerce/orderservice/config"
 	"specommerce/orderservice/di"
 	paymentConsumer "specommerce/orderservice/internal/adapters/primary/payment/event/kafka"
+	idempotencyService "specommerce/orderservice/internal/core/services/idempotency"
+	inventoryService "specommerce/orderservice/internal/core/services/inventory"
+	orderService "specommerce/orderservice/internal/core/services/order"
+	sagaService "specommerce/orderservice/internal/core/services/saga"
 	"specommerce/orderservice/pkg/atomicity"
 	"specommerce/orderservice/pkg/database"
 	"specommerce/orderservice/pkg/environment"
+	"specommerce/orderservice/pkg/outbox"
 	"specommerce/orderservice/pkg/service_config"
 	"specommerce/orderservice/pkg/shutdown"
 	"specommerce/orderservice/server"
@@ -44,6 +49,10 @@ func Run(logger *slog.Logger, tasks *shutdown.Tasks) error {
 		func() error {
 			return server.ServeHTTP(injector)
 		})
+	eg.Go(
+		func() error {
+			return server.ServeAdminHTTP(injector)
+		})
 
 	processPaymentResponseConsumer := do.MustInvoke[*paymentConsumer.ProcessPaymentResponseConsumer](injector)
 
@@ -51,5 +60,35 @@ func Run(logger *slog.Logger, tasks *shutdown.Tasks) error {
 		return processPaymentResponseConsumer.Start()
 	})
 
+	outboxRelay := do.MustInvoke[*outbox.Relay](injector)
+	eg.Go(func() error {
+		return outboxRelay.Start()
+	})
+
+	sagaResumer := do.MustInvoke[*sagaService.Resumer](injector)
+	eg.Go(func() error {
+		return sagaResumer.Start()
+	})
+
+	idempotencyCleaner := do.MustInvoke[*idempotencyService.Cleaner](injector)
+	eg.Go(func() error {
+		return idempotencyCleaner.Start()
+	})
+
+	inventorySweeper := do.MustInvoke[*inventoryService.Sweeper](injector)
+	eg.Go(func() error {
+		return inventorySweeper.Start()
+	})
+
+	inventoryReconciler := do.MustInvoke[*inventoryService.Reconciler](injector)
+	eg.Go(func() error {
+		return inventoryReconciler.Start()
+	})
+
+	orderReaper := do.MustInvoke[*orderService.Reaper](injector)
+	eg.Go(func() error {
+		return orderReaper.Start()
+	})
+
 	return eg.Wait()
 }
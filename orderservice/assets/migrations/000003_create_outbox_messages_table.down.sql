@@ -0,0 +1,2 @@
+drop table outbox_messages;
+drop type outbox_status;
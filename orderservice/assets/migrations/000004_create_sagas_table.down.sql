@@ -0,0 +1,4 @@
+drop table saga_steps;
+drop type saga_step_action;
+drop table sagas;
+drop type saga_status;
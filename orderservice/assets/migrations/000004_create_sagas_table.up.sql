@@ -0,0 +1,40 @@
+create type saga_status as enum (
+    'RUNNING',
+    'WAITING',
+    'COMPLETED',
+    'COMPENSATING',
+    'COMPENSATED'
+);
+
+create table sagas (
+    id varchar(20) primary key not null,
+    type varchar(50) not null,
+    order_id varchar(20) not null,
+    status saga_status not null default 'RUNNING',
+    current_step int not null default 0,
+    payload jsonb not null default '{}',
+    last_error text not null default '',
+    created_at timestamp with time zone not null default now(),
+    updated_at timestamp with time zone not null default now()
+);
+
+select create_updated_at_trigger('sagas');
+
+create unique index sagas_type_order_id on sagas(type, order_id);
+create index sagas_status_updated_at on sagas(status, updated_at);
+
+create type saga_step_action as enum (
+    'EXECUTE',
+    'COMPENSATE'
+);
+
+create table saga_steps (
+    id bigserial primary key,
+    saga_id varchar(20) not null references sagas(id),
+    step varchar(50) not null,
+    action saga_step_action not null,
+    error text not null default '',
+    created_at timestamp with time zone not null default now()
+);
+
+create index saga_steps_saga_id on saga_steps(saga_id);
@@ -0,0 +1,10 @@
+create table idempotency_keys (
+    key varchar(255) primary key not null,
+    request_hash varchar(64) not null,
+    status_code int not null default 0,
+    response jsonb,
+    created_at timestamp with time zone not null default now(),
+    expires_at timestamp with time zone not null
+);
+
+create index idempotency_keys_expires_at on idempotency_keys(expires_at);
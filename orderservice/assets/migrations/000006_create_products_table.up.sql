@@ -0,0 +1,13 @@
+create table products (
+    id varchar(20) primary key not null,
+    sku varchar(64) not null,
+    name varchar(255) not null,
+    price decimal(10, 2) not null check (price >= 0),
+    active boolean not null default true,
+    created_at timestamp with time zone not null default now(),
+    updated_at timestamp with time zone not null default now()
+);
+
+select create_updated_at_trigger('products');
+
+create unique index products_sku on products(sku);
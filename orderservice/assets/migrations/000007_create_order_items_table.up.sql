@@ -0,0 +1,10 @@
+-- order_items keeps the name and price of the product at the time of the order
+create table order_items (
+    order_id varchar(20) not null references orders(id) on delete cascade,
+    sku varchar(64) not null,
+    name varchar(255) not null,
+    unit_price decimal(10, 2) not null,
+    quantity int not null check (quantity > 0),
+    created_at timestamp with time zone not null default now(),
+    primary key (order_id, sku)
+);
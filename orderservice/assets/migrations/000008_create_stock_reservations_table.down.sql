@@ -0,0 +1,3 @@
+drop table if exists stock_reservations;
+drop type if exists stock_reservation_status;
+alter table products drop column if exists stock;
@@ -0,0 +1,25 @@
+alter table products add column stock int not null default 0 check (stock >= 0);
+
+create type stock_reservation_status as enum (
+    'RESERVED',
+    'CONFIRMED',
+    'RELEASED'
+);
+
+-- stock_reservations mirrors the reservations held in Redis, the available stock of a product
+-- is its stock minus the quantities still RESERVED. Confirmed reservations are deducted from products.stock
+create table stock_reservations (
+    order_id varchar(20) not null,
+    sku varchar(64) not null,
+    quantity int not null check (quantity > 0),
+    status stock_reservation_status not null default 'RESERVED',
+    expires_at timestamp with time zone not null,
+    created_at timestamp with time zone not null default now(),
+    updated_at timestamp with time zone not null default now(),
+    primary key (order_id, sku)
+);
+
+select create_updated_at_trigger('stock_reservations');
+
+create index stock_reservations_reserved_expires_at on stock_reservations(expires_at) where status = 'RESERVED';
+create index stock_reservations_reserved_sku on stock_reservations(sku) where status = 'RESERVED';
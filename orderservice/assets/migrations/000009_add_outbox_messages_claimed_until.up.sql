@@ -0,0 +1,3 @@
+-- claimed_until is set while a relay publishes the message outside of a transaction,
+-- no other relay claims a batch before it is cleared or expires
+alter table outbox_messages add column claimed_until timestamp with time zone;
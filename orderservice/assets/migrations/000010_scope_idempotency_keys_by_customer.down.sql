@@ -0,0 +1,7 @@
+delete from idempotency_keys where (key, customer_id) not in (
+    select distinct on (key) key, customer_id from idempotency_keys order by key, created_at desc
+);
+alter table idempotency_keys drop constraint idempotency_keys_pkey;
+alter table idempotency_keys add primary key (key);
+alter table idempotency_keys drop column if exists token;
+alter table idempotency_keys drop column if exists customer_id;
@@ -0,0 +1,5 @@
+-- A key is scoped by the customer that sent it, and leased to the request that reserved it through its token
+alter table idempotency_keys add column customer_id varchar(255) not null default '';
+alter table idempotency_keys add column token varchar(20) not null default '';
+alter table idempotency_keys drop constraint idempotency_keys_pkey;
+alter table idempotency_keys add primary key (customer_id, key);
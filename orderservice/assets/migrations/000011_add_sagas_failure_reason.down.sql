@@ -0,0 +1 @@
+alter table sagas drop column if exists failure_reason;
@@ -0,0 +1 @@
+alter table sagas add column failure_reason varchar(64) not null default '';
@@ -3,11 +3,19 @@ package config
 import "specommerce/orderservice/pkg/service_config"
 
 type AppConfig struct {
-	Server                 service_config.RestServiceConfig `koanf:"server"`
-	Env                    string                           `koanf:"env"`
-	Database               service_config.DbConfig          `koanf:"db"`
-	Kafka                  service_config.KafkaConfig       `koanf:"messagequeue"`
-	ProcessPaymentRequest  service_config.KafkaConfig       `koanf:"processPaymentRequest"`
-	ProcessPaymentResponse service_config.KafkaConfig       `koanf:"processPaymentResponse"`
-	OrderEvents            service_config.KafkaConfig       `koanf:"orderEvents"`
+	Server                 service_config.RestServiceConfig    `koanf:"server"`
+	Admin                  service_config.AdminServerConfig    `koanf:"adminServer"`
+	Env                    string                              `koanf:"env"`
+	Database               service_config.DbConfig             `koanf:"db"`
+	Kafka                  service_config.KafkaConfig          `koanf:"messagequeue"`
+	ProcessPaymentRequest  service_config.KafkaConfig          `koanf:"processPaymentRequest"`
+	ProcessPaymentResponse service_config.KafkaConfig          `koanf:"processPaymentResponse"`
+	OrderEvents            service_config.KafkaConfig          `koanf:"orderEvents"`
+	Outbox                 service_config.OutboxConfig         `koanf:"outbox"`
+	Saga                   service_config.SagaConfig           `koanf:"saga"`
+	Idempotency            service_config.IdempotencyConfig    `koanf:"idempotency"`
+	Redis                  service_config.RedisConfig          `koanf:"redis"`
+	Inventory              service_config.InventoryConfig      `koanf:"inventory"`
+	Reaper                 service_config.ReaperConfig         `koanf:"reaper"`
+	PaymentService         service_config.PaymentServiceConfig `koanf:"paymentService"`
 }
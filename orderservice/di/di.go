@@ -4,17 +4,32 @@ import (
 	"github.com/samber/do/v2"
 	"log/slog"
 	"specommerce/orderservice/config"
+	deadLetterHandler "specommerce/orderservice/internal/adapters/primary/deadletter/handler"
 	orderHandler "specommerce/orderservice/internal/adapters/primary/order/handler"
+	outboxHandler "specommerce/orderservice/internal/adapters/primary/outbox/handler"
 	paymentConsumer "specommerce/orderservice/internal/adapters/primary/payment/event/kafka"
+	productHandler "specommerce/orderservice/internal/adapters/primary/product/handler"
+	sagaHandler "specommerce/orderservice/internal/adapters/primary/saga/handler"
 	campaignKafka "specommerce/orderservice/internal/adapters/secondary/campaign/event/kafka"
+	idempotencyPostgres "specommerce/orderservice/internal/adapters/secondary/idempotency/persistence/postgres"
+	inventoryPostgres "specommerce/orderservice/internal/adapters/secondary/inventory/persistence/postgres"
 	orderPostgres "specommerce/orderservice/internal/adapters/secondary/order/persistence/postgres"
 	paymentKafka "specommerce/orderservice/internal/adapters/secondary/payment/event/kafka"
+	paymentRest "specommerce/orderservice/internal/adapters/secondary/payment/rest"
+	productPostgres "specommerce/orderservice/internal/adapters/secondary/product/persistence/postgres"
+	sagaPostgres "specommerce/orderservice/internal/adapters/secondary/saga/persistence/postgres"
 	"specommerce/orderservice/internal/core/ports/primary"
 	"specommerce/orderservice/internal/core/ports/secondary"
+	idempotencyService "specommerce/orderservice/internal/core/services/idempotency"
+	inventoryService "specommerce/orderservice/internal/core/services/inventory"
 	orderService "specommerce/orderservice/internal/core/services/order"
+	productService "specommerce/orderservice/internal/core/services/product"
+	sagaService "specommerce/orderservice/internal/core/services/saga"
 	"specommerce/orderservice/pkg/atomicity"
+	"specommerce/orderservice/pkg/cache"
 	"specommerce/orderservice/pkg/database"
 	"specommerce/orderservice/pkg/messagequeue"
+	"specommerce/orderservice/pkg/outbox"
 	"specommerce/orderservice/pkg/shutdown"
 )
 
@@ -23,13 +38,42 @@ func NewInjector() do.Injector {
 	do.Provide(injector, NewOrderRepository)
 	do.Provide(injector, NewOrderService)
 	do.Provide(injector, NewOrderHandler)
+	do.Provide(injector, NewOrderReaper)
+
+	do.Provide(injector, NewProductRepository)
+	do.Provide(injector, NewProductService)
+	do.Provide(injector, NewProductHandler)
+
+	do.Provide(injector, NewRedisClient)
+	do.Provide(injector, NewInventoryRepository)
+	do.Provide(injector, NewInventoryService)
+	do.Provide(injector, NewInventorySweeper)
+	do.Provide(injector, NewInventoryReconciler)
+
+	do.Provide(injector, NewIdempotencyRepository)
+	do.Provide(injector, NewIdempotencyService)
+	do.Provide(injector, NewIdempotencyCleaner)
+
+	do.Provide(injector, NewSagaRepository)
+	do.Provide(injector, NewSagaOrchestrator)
+	do.Provide(injector, NewSagaResumer)
+	do.Provide(injector, NewSagaService)
+	do.Provide(injector, NewSagaHandler)
 
 	do.Provide(injector, NewCampaignPublisher)
 	do.Provide(injector, NewPaymentPublisher)
+	do.Provide(injector, NewPaymentClient)
 	do.Provide(injector, NewPublisher)
 	do.Provide(injector, NewProcessPaymentResponseConsumer)
 
 	do.Provide(injector, NewBaseEventListener)
+	do.Provide(injector, NewDeadLetterQueue)
+	do.Provide(injector, NewDeadLetterHandler)
+
+	do.Provide(injector, NewOutboxWriter)
+	do.Provide(injector, NewOutboxRelay)
+	do.Provide(injector, NewFailedOutboxMessages)
+	do.Provide(injector, NewOutboxHandler)
 
 	return injector
 }
@@ -41,28 +85,171 @@ func NewOrderRepository(injector do.Injector) (secondary.OrderRepository, error)
 
 func NewOrderService(injector do.Injector) (primary.OrderService, error) {
 	orderRepository := do.MustInvoke[secondary.OrderRepository](injector)
-	paymentPublisher := do.MustInvoke[secondary.PaymentRepository](injector)
+	productRepository := do.MustInvoke[secondary.ProductRepository](injector)
+	sagaRepository := do.MustInvoke[secondary.SagaRepository](injector)
+	paymentClient := do.MustInvoke[secondary.PaymentStatusRepository](injector)
+	orchestrator := do.MustInvoke[*sagaService.Orchestrator](injector)
+	logger := do.MustInvoke[*slog.Logger](injector)
+	return orderService.NewOrderService(
+		orderRepository,
+		productRepository,
+		sagaRepository,
+		paymentClient,
+		orchestrator,
+		logger,
+	), nil
+}
+
+func NewOrderReaper(injector do.Injector) (*orderService.Reaper, error) {
+	cfg := do.MustInvoke[config.AppConfig](injector)
+	orderRepository := do.MustInvoke[secondary.OrderRepository](injector)
+	sagaRepository := do.MustInvoke[secondary.SagaRepository](injector)
+	paymentClient := do.MustInvoke[secondary.PaymentStatusRepository](injector)
 	campaignPublisher := do.MustInvoke[secondary.CampaignRepository](injector)
+	inventory := do.MustInvoke[primary.InventoryService](injector)
+	service := do.MustInvoke[primary.OrderService](injector)
+	orchestrator := do.MustInvoke[*sagaService.Orchestrator](injector)
 	atomicExecutor := do.MustInvoke[atomicity.AtomicExecutor](injector)
+	tasks := do.MustInvoke[*shutdown.Tasks](injector)
 	logger := do.MustInvoke[*slog.Logger](injector)
-	return orderService.NewOrderService(
+	return orderService.NewReaper(
 		orderRepository,
-		paymentPublisher,
-		atomicExecutor,
+		sagaRepository,
+		paymentClient,
 		campaignPublisher,
+		inventory,
+		service,
+		orchestrator,
+		atomicExecutor,
+		cfg.Reaper,
+		tasks,
 		logger,
 	), nil
 }
 
 func NewOrderHandler(injector do.Injector) (orderHandler.OrderHandler, error) {
 	service := do.MustInvoke[primary.OrderService](injector)
-	return orderHandler.NewOrderHandler(service), nil
+	idempotency := do.MustInvoke[primary.IdempotencyService](injector)
+	logger := do.MustInvoke[*slog.Logger](injector)
+	return orderHandler.NewOrderHandler(service, idempotency, logger), nil
+}
+
+func NewProductRepository(injector do.Injector) (secondary.ProductRepository, error) {
+	getDbFunc := do.MustInvoke[database.GetDbFunc](injector)
+	return productPostgres.NewProductPersistenceRepository(getDbFunc), nil
+}
+
+func NewProductService(injector do.Injector) (primary.ProductService, error) {
+	productRepository := do.MustInvoke[secondary.ProductRepository](injector)
+	inventory := do.MustInvoke[primary.InventoryService](injector)
+	return productService.NewProductService(productRepository, inventory), nil
+}
+
+func NewProductHandler(injector do.Injector) (productHandler.ProductHandler, error) {
+	service := do.MustInvoke[primary.ProductService](injector)
+	return productHandler.NewProductHandler(service), nil
+}
+
+func NewRedisClient(injector do.Injector) (cache.Cache, error) {
+	cfg := do.MustInvoke[config.AppConfig](injector)
+	tasks := do.MustInvoke[*shutdown.Tasks](injector)
+	return cache.NewRedisClient(cfg.Redis, tasks), nil
+}
+
+func NewInventoryRepository(injector do.Injector) (secondary.InventoryRepository, error) {
+	getDbFunc := do.MustInvoke[database.GetDbFunc](injector)
+	return inventoryPostgres.NewInventoryPersistenceRepository(getDbFunc), nil
+}
+
+func NewInventoryService(injector do.Injector) (primary.InventoryService, error) {
+	cfg := do.MustInvoke[config.AppConfig](injector)
+	inventoryRepository := do.MustInvoke[secondary.InventoryRepository](injector)
+	cacheClient := do.MustInvoke[cache.Cache](injector)
+	return inventoryService.NewInventoryService(inventoryRepository, cacheClient, cfg.Inventory), nil
+}
+
+func NewInventorySweeper(injector do.Injector) (*inventoryService.Sweeper, error) {
+	cfg := do.MustInvoke[config.AppConfig](injector)
+	inventoryRepository := do.MustInvoke[secondary.InventoryRepository](injector)
+	orderService := do.MustInvoke[primary.OrderService](injector)
+	tasks := do.MustInvoke[*shutdown.Tasks](injector)
+	logger := do.MustInvoke[*slog.Logger](injector)
+	return inventoryService.NewSweeper(inventoryRepository, orderService, cfg.Inventory, tasks, logger), nil
+}
+
+func NewInventoryReconciler(injector do.Injector) (*inventoryService.Reconciler, error) {
+	cfg := do.MustInvoke[config.AppConfig](injector)
+	inventoryRepository := do.MustInvoke[secondary.InventoryRepository](injector)
+	cacheClient := do.MustInvoke[cache.Cache](injector)
+	tasks := do.MustInvoke[*shutdown.Tasks](injector)
+	logger := do.MustInvoke[*slog.Logger](injector)
+	return inventoryService.NewReconciler(inventoryRepository, cacheClient, cfg.Inventory, tasks, logger), nil
+}
+
+func NewIdempotencyRepository(injector do.Injector) (secondary.IdempotencyRepository, error) {
+	getDbFunc := do.MustInvoke[database.GetDbFunc](injector)
+	return idempotencyPostgres.NewIdempotencyPersistenceRepository(getDbFunc), nil
+}
+
+func NewIdempotencyService(injector do.Injector) (primary.IdempotencyService, error) {
+	cfg := do.MustInvoke[config.AppConfig](injector)
+	idempotencyRepository := do.MustInvoke[secondary.IdempotencyRepository](injector)
+	logger := do.MustInvoke[*slog.Logger](injector)
+	return idempotencyService.NewIdempotencyService(idempotencyRepository, cfg.Idempotency, logger), nil
+}
+
+func NewIdempotencyCleaner(injector do.Injector) (*idempotencyService.Cleaner, error) {
+	cfg := do.MustInvoke[config.AppConfig](injector)
+	idempotencyRepository := do.MustInvoke[secondary.IdempotencyRepository](injector)
+	tasks := do.MustInvoke[*shutdown.Tasks](injector)
+	logger := do.MustInvoke[*slog.Logger](injector)
+	return idempotencyService.NewCleaner(idempotencyRepository, cfg.Idempotency, tasks, logger), nil
+}
+
+func NewSagaRepository(injector do.Injector) (secondary.SagaRepository, error) {
+	getDbFunc := do.MustInvoke[database.GetDbFunc](injector)
+	return sagaPostgres.NewSagaPersistenceRepository(getDbFunc), nil
+}
+
+func NewSagaOrchestrator(injector do.Injector) (*sagaService.Orchestrator, error) {
+	sagaRepository := do.MustInvoke[secondary.SagaRepository](injector)
+	inventory := do.MustInvoke[primary.InventoryService](injector)
+	orderRepository := do.MustInvoke[secondary.OrderRepository](injector)
+	paymentPublisher := do.MustInvoke[secondary.PaymentRepository](injector)
+	campaignPublisher := do.MustInvoke[secondary.CampaignRepository](injector)
+	atomicExecutor := do.MustInvoke[atomicity.AtomicExecutor](injector)
+	logger := do.MustInvoke[*slog.Logger](injector)
+	return sagaService.NewOrchestrator(
+		sagaRepository,
+		atomicExecutor,
+		logger,
+		orderService.NewPlacementSaga(inventory, orderRepository, paymentPublisher, campaignPublisher),
+	), nil
+}
+
+func NewSagaResumer(injector do.Injector) (*sagaService.Resumer, error) {
+	cfg := do.MustInvoke[config.AppConfig](injector)
+	orchestrator := do.MustInvoke[*sagaService.Orchestrator](injector)
+	sagaRepository := do.MustInvoke[secondary.SagaRepository](injector)
+	tasks := do.MustInvoke[*shutdown.Tasks](injector)
+	logger := do.MustInvoke[*slog.Logger](injector)
+	return sagaService.NewResumer(orchestrator, sagaRepository, cfg.Saga, tasks, logger), nil
+}
+
+func NewSagaService(injector do.Injector) (primary.SagaService, error) {
+	sagaRepository := do.MustInvoke[secondary.SagaRepository](injector)
+	return sagaService.NewSagaService(sagaRepository), nil
+}
+
+func NewSagaHandler(injector do.Injector) (sagaHandler.SagaHandler, error) {
+	service := do.MustInvoke[primary.SagaService](injector)
+	return sagaHandler.NewSagaHandler(service), nil
 }
 
 func NewCampaignPublisher(injector do.Injector) (secondary.CampaignRepository, error) {
 	cfg := do.MustInvoke[config.AppConfig](injector)
-	publisher := do.MustInvoke[messagequeue.Publisher](injector)
-	return campaignKafka.NewCampaignPublisher(cfg, publisher), nil
+	outboxWriter := do.MustInvoke[outbox.Writer](injector)
+	return campaignKafka.NewCampaignPublisher(cfg, outboxWriter), nil
 }
 
 func NewPublisher(injector do.Injector) (messagequeue.Publisher, error) {
@@ -73,14 +260,31 @@ func NewPublisher(injector do.Injector) (messagequeue.Publisher, error) {
 
 func NewPaymentPublisher(injector do.Injector) (secondary.PaymentRepository, error) {
 	cfg := do.MustInvoke[config.AppConfig](injector)
-	publisher := do.MustInvoke[messagequeue.Publisher](injector)
-	return paymentKafka.NewPaymentPublisher(cfg, publisher), nil
+	outboxWriter := do.MustInvoke[outbox.Writer](injector)
+	return paymentKafka.NewPaymentPublisher(cfg, outboxWriter), nil
+}
+
+func NewPaymentClient(injector do.Injector) (secondary.PaymentStatusRepository, error) {
+	cfg := do.MustInvoke[config.AppConfig](injector)
+	return paymentRest.NewPaymentClient(cfg.PaymentService), nil
 }
 
 func NewBaseEventListener(injector do.Injector) (*messagequeue.BaseEventListener, error) {
+	publisher := do.MustInvoke[messagequeue.Publisher](injector)
 	tasks := do.MustInvoke[*shutdown.Tasks](injector)
 	logger := do.MustInvoke[*slog.Logger](injector)
-	return messagequeue.NewBaseEventListener(tasks, logger), nil
+	return messagequeue.NewBaseEventListener(publisher, tasks, logger), nil
+}
+
+func NewDeadLetterQueue(injector do.Injector) (messagequeue.DeadLetterQueue, error) {
+	cfg := do.MustInvoke[config.AppConfig](injector)
+	publisher := do.MustInvoke[messagequeue.Publisher](injector)
+	return messagequeue.NewDeadLetterQueue(cfg.Kafka.Host, publisher, cfg.ProcessPaymentResponse), nil
+}
+
+func NewDeadLetterHandler(injector do.Injector) (deadLetterHandler.DeadLetterHandler, error) {
+	deadLetterQueue := do.MustInvoke[messagequeue.DeadLetterQueue](injector)
+	return deadLetterHandler.NewDeadLetterHandler(deadLetterQueue), nil
 }
 
 func NewProcessPaymentResponseConsumer(injector do.Injector) (*paymentConsumer.ProcessPaymentResponseConsumer, error) {
@@ -89,3 +293,28 @@ func NewProcessPaymentResponseConsumer(injector do.Injector) (*paymentConsumer.P
 	baseEventListener := do.MustInvoke[*messagequeue.BaseEventListener](injector)
 	return paymentConsumer.NewProcessPaymentResponseConsumer(baseEventListener, cfg.ProcessPaymentResponse, orderService), nil
 }
+
+func NewOutboxWriter(injector do.Injector) (outbox.Writer, error) {
+	getDbFunc := do.MustInvoke[database.GetDbFunc](injector)
+	return outbox.NewWriter(getDbFunc), nil
+}
+
+func NewOutboxRelay(injector do.Injector) (*outbox.Relay, error) {
+	cfg := do.MustInvoke[config.AppConfig](injector)
+	getDbFunc := do.MustInvoke[database.GetDbFunc](injector)
+	atomicExecutor := do.MustInvoke[atomicity.AtomicExecutor](injector)
+	publisher := do.MustInvoke[messagequeue.Publisher](injector)
+	tasks := do.MustInvoke[*shutdown.Tasks](injector)
+	logger := do.MustInvoke[*slog.Logger](injector)
+	return outbox.NewRelay(getDbFunc, atomicExecutor, publisher, cfg.Outbox, tasks, logger), nil
+}
+
+func NewFailedOutboxMessages(injector do.Injector) (outbox.FailedMessages, error) {
+	getDbFunc := do.MustInvoke[database.GetDbFunc](injector)
+	return outbox.NewFailedMessages(getDbFunc), nil
+}
+
+func NewOutboxHandler(injector do.Injector) (outboxHandler.OutboxHandler, error) {
+	failedMessages := do.MustInvoke[outbox.FailedMessages](injector)
+	return outboxHandler.NewOutboxHandler(failedMessages), nil
+}
@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"specommerce/orderservice/pkg/messagequeue"
+	"specommerce/orderservice/pkg/sharedto/handler"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultListSize = 20
+	maxListSize     = 200
+)
+
+type DeadLetterHandler interface {
+	GetTopics(ctx *gin.Context)
+	ListDeadLetters(ctx *gin.Context)
+	GetDeadLetter(ctx *gin.Context)
+	RedriveDeadLetter(ctx *gin.Context)
+}
+type deadLetterHandler struct {
+	deadLetterQueue messagequeue.DeadLetterQueue
+}
+
+func NewDeadLetterHandler(deadLetterQueue messagequeue.DeadLetterQueue) DeadLetterHandler {
+	return &deadLetterHandler{
+		deadLetterQueue: deadLetterQueue,
+	}
+}
+
+// GetTopics godoc
+// @Summary List dead-letter topics
+// @Description List the dead-letter topic of every consumed topic with the offset range of each partition
+// @Tags dead-letters
+// @Produce json
+// @Success 200 {array} messagequeue.DeadLetterTopicInfo "Dead-letter topics"
+// @Failure 500 {object} handler.ErrorResponse "Internal server error"
+// @Router /admin/v1/dead-letters [get]
+func (h *deadLetterHandler) GetTopics(ctx *gin.Context) {
+	topics, err := h.deadLetterQueue.Topics(ctx)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, handler.BaseResponse[[]messagequeue.DeadLetterTopicInfo]{
+		Data: topics,
+	})
+}
+
+// ListDeadLetters godoc
+// @Summary List dead letters
+// @Description List the messages parked in the dead-letter topic of a consumed topic, starting at an offset
+// @Tags dead-letters
+// @Produce json
+// @Param topic path string true "Consumed topic"
+// @Param partition query int false "Partition" default(0)
+// @Param offset query int false "First offset" default(0)
+// @Param size query int false "Maximum number of messages" minimum(1) maximum(200) default(20)
+// @Success 200 {array} messagequeue.DeadLetter "Dead letters"
+// @Failure 400 {object} handler.ErrorResponse "Bad request"
+// @Failure 404 {object} handler.ErrorResponse "Unknown topic"
+// @Failure 500 {object} handler.ErrorResponse "Internal server error"
+// @Router /admin/v1/dead-letters/{topic} [get]
+func (h *deadLetterHandler) ListDeadLetters(ctx *gin.Context) {
+	partition, err := strconv.Atoi(ctx.DefaultQuery("partition", "0"))
+	if err != nil || partition < 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid partition"})
+		return
+	}
+	offset, err := strconv.ParseInt(ctx.DefaultQuery("offset", "0"), 10, 64)
+	if err != nil || offset < 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid offset"})
+		return
+	}
+	size, err := strconv.Atoi(ctx.DefaultQuery("size", strconv.Itoa(defaultListSize)))
+	if err != nil || size <= 0 || size > maxListSize {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid size"})
+		return
+	}
+
+	deadLetters, err := h.deadLetterQueue.List(ctx, ctx.Param("topic"), partition, offset, size)
+	if err != nil {
+		h.error(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, handler.BaseResponse[[]messagequeue.DeadLetter]{
+		Data: deadLetters,
+	})
+}
+
+// GetDeadLetter godoc
+// @Summary Inspect a dead letter
+// @Description Get a message of the dead-letter topic of a consumed topic with its failure details
+// @Tags dead-letters
+// @Produce json
+// @Param topic path string true "Consumed topic"
+// @Param partition path int true "Dead-letter topic partition"
+// @Param offset path int true "Dead-letter topic offset"
+// @Success 200 {object} messagequeue.DeadLetter "Dead letter"
+// @Failure 400 {object} handler.ErrorResponse "Bad request"
+// @Failure 404 {object} handler.ErrorResponse "Dead letter not found"
+// @Failure 500 {object} handler.ErrorResponse "Internal server error"
+// @Router /admin/v1/dead-letters/{topic}/{partition}/{offset} [get]
+func (h *deadLetterHandler) GetDeadLetter(ctx *gin.Context) {
+	partition, offset, ok := parsePosition(ctx)
+	if !ok {
+		return
+	}
+	deadLetter, err := h.deadLetterQueue.Get(ctx, ctx.Param("topic"), partition, offset)
+	if err != nil {
+		h.error(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, handler.BaseResponse[messagequeue.DeadLetter]{
+		Data: deadLetter,
+	})
+}
+
+// RedriveDeadLetter godoc
+// @Summary Re-drive a dead letter
+// @Description Publish a dead letter back to its original topic, to be handled again by the consumer group that failed it
+// @Tags dead-letters
+// @Produce json
+// @Param topic path string true "Consumed topic"
+// @Param partition path int true "Dead-letter topic partition"
+// @Param offset path int true "Dead-letter topic offset"
+// @Success 200 {object} messagequeue.DeadLetter "Re-driven dead letter"
+// @Failure 400 {object} handler.ErrorResponse "Bad request"
+// @Failure 404 {object} handler.ErrorResponse "Dead letter not found"
+// @Failure 500 {object} handler.ErrorResponse "Internal server error"
+// @Router /admin/v1/dead-letters/{topic}/{partition}/{offset}/redrive [post]
+func (h *deadLetterHandler) RedriveDeadLetter(ctx *gin.Context) {
+	partition, offset, ok := parsePosition(ctx)
+	if !ok {
+		return
+	}
+	deadLetter, err := h.deadLetterQueue.Redrive(ctx, ctx.Param("topic"), partition, offset)
+	if err != nil {
+		h.error(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, handler.BaseResponse[messagequeue.DeadLetter]{
+		Data: deadLetter,
+	})
+}
+
+func (h *deadLetterHandler) error(ctx *gin.Context, err error) {
+	if errors.Is(err, messagequeue.ErrUnknownDeadLetterTopic) || errors.Is(err, messagequeue.ErrDeadLetterNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
+
+func parsePosition(ctx *gin.Context) (int, int64, bool) {
+	partition, err := strconv.Atoi(ctx.Param("partition"))
+	if err != nil || partition < 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid partition"})
+		return 0, 0, false
+	}
+	offset, err := strconv.ParseInt(ctx.Param("offset"), 10, 64)
+	if err != nil || offset < 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid offset"})
+		return 0, 0, false
+	}
+	return partition, offset, true
+}
@@ -10,10 +10,16 @@ import (
 
 // CreateOrderRequest represents the request for creating an order
 type CreateOrderRequest struct {
-	CustomerId   string  `json:"customer_id" binding:"required"`
-	CustomerName string  `json:"customer_name" binding:"required"`
-	TotalAmount  float64 `json:"total_amount" binding:"required"`
-	TimeProcess  int64   `json:"time_process" binding:"min=0" default:"2"`
+	CustomerId   string                   `json:"customer_id" binding:"required"`
+	CustomerName string                   `json:"customer_name" binding:"required"`
+	Items        []CreateOrderItemRequest `json:"items" binding:"required,min=1,dive"`
+	TimeProcess  int64                    `json:"time_process" binding:"min=0" default:"2"`
+}
+
+// CreateOrderItemRequest represents a line of the order, the price is taken from the product catalog
+type CreateOrderItemRequest struct {
+	Sku      string `json:"sku" binding:"required" example:"IPHONE-16-128"`
+	Quantity int    `json:"quantity" binding:"required,min=1,max=10000" example:"1"`
 }
 
 // ToOrder converts CreateOrderRequest to domain Order
@@ -24,7 +30,7 @@ func (r *CreateOrderRequest) ToDomain() domain.CreateOrderRequest {
 			CustomerId:   r.CustomerId,
 			CustomerName: r.CustomerName,
 			Status:       domain.OrderStatusPending,
-			TotalAmount:  r.TotalAmount,
+			Items:        r.toDomainItems(),
 			CreatedAt:    time.Now(),
 			UpdatedAt:    time.Now(),
 		},
@@ -32,6 +38,17 @@ func (r *CreateOrderRequest) ToDomain() domain.CreateOrderRequest {
 	}
 }
 
+func (r *CreateOrderRequest) toDomainItems() []domain.OrderItem {
+	items := make([]domain.OrderItem, 0, len(r.Items))
+	for _, item := range r.Items {
+		items = append(items, domain.OrderItem{
+			Sku:      item.Sku,
+			Quantity: item.Quantity,
+		})
+	}
+	return items
+}
+
 func ToCreateOrderResponse(d domain.Order) OrderResponse {
 	return OrderResponse{
 		ID:           d.Id.String(),
@@ -39,6 +56,7 @@ func ToCreateOrderResponse(d domain.Order) OrderResponse {
 		CustomerName: d.CustomerName,
 		Status:       d.Status.String(),
 		TotalAmount:  d.TotalAmount,
+		Items:        ToOrderItemResponses(d.Items),
 		CreatedAt:    d.CreatedAt,
 		UpdatedAt:    d.UpdatedAt,
 	}
@@ -53,6 +71,7 @@ func ToGetAllOrderResponse(entities []domain.Order) []OrderResponse {
 			CustomerName: entity.CustomerName,
 			Status:       entity.Status.String(),
 			TotalAmount:  entity.TotalAmount,
+			Items:        ToOrderItemResponses(entity.Items),
 			CreatedAt:    entity.CreatedAt,
 			UpdatedAt:    entity.UpdatedAt,
 		})
@@ -63,13 +82,37 @@ func ToGetAllOrderResponse(entities []domain.Order) []OrderResponse {
 
 // OrderResponse represents order response for Swagger
 type OrderResponse struct {
-	ID           string    `json:"id" example:"abc123"`
-	CustomerId   string    `json:"customer_id" example:"customer123"`
-	CustomerName string    `json:"customer_name" example:"John Doe"`
-	TotalAmount  float64   `json:"total_amount" example:"99.99"`
-	Status       string    `json:"status" example:"PENDING"`
-	CreatedAt    time.Time `json:"created_at" example:"2023-01-01T00:00:00Z"`
-	UpdatedAt    time.Time `json:"updated_at" example:"2023-01-01T00:00:00Z"`
+	ID           string              `json:"id" example:"abc123"`
+	CustomerId   string              `json:"customer_id" example:"customer123"`
+	CustomerName string              `json:"customer_name" example:"John Doe"`
+	TotalAmount  float64             `json:"total_amount" example:"99.99"`
+	Items        []OrderItemResponse `json:"items"`
+	Status       string              `json:"status" example:"PENDING"`
+	CreatedAt    time.Time           `json:"created_at" example:"2023-01-01T00:00:00Z"`
+	UpdatedAt    time.Time           `json:"updated_at" example:"2023-01-01T00:00:00Z"`
+}
+
+// OrderItemResponse represents an order line for Swagger
+type OrderItemResponse struct {
+	Sku       string  `json:"sku" example:"IPHONE-16-128"`
+	Name      string  `json:"name" example:"iPhone 16 128GB"`
+	UnitPrice float64 `json:"unit_price" example:"1299.00"`
+	Quantity  int     `json:"quantity" example:"1"`
+	Subtotal  float64 `json:"subtotal" example:"1299.00"`
+}
+
+func ToOrderItemResponses(items []domain.OrderItem) []OrderItemResponse {
+	response := make([]OrderItemResponse, 0, len(items))
+	for _, item := range items {
+		response = append(response, OrderItemResponse{
+			Sku:       item.Sku,
+			Name:      item.Name,
+			UnitPrice: item.UnitPrice,
+			Quantity:  item.Quantity,
+			Subtotal:  item.Subtotal(),
+		})
+	}
+	return response
 }
 
 // SearchOrdersRequest represents the request for searching orders with pagination
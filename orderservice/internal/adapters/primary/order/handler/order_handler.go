@@ -1,7 +1,16 @@
 package handler
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log/slog"
 	"net/http"
+	"specommerce/orderservice/internal/core/domain/idempotency"
+	"specommerce/orderservice/internal/core/domain/inventory"
+	"specommerce/orderservice/internal/core/domain/order"
+	"specommerce/orderservice/internal/core/domain/product"
 	"specommerce/orderservice/internal/core/ports/primary"
 	"specommerce/orderservice/pkg/sharedto/handler"
 
@@ -13,25 +22,38 @@ type OrderHandler interface {
 	GetAllOrders(ctx *gin.Context)
 	SearchOrders(ctx *gin.Context)
 }
+
+const (
+	idempotencyKeyHeader    = "Idempotency-Key"
+	maxIdempotencyKeyLength = 255
+)
+
 type orderHandler struct {
-	orderService primary.OrderService
+	orderService       primary.OrderService
+	idempotencyService primary.IdempotencyService
+	logger             *slog.Logger
 }
 
-func NewOrderHandler(orderService primary.OrderService) OrderHandler {
+func NewOrderHandler(orderService primary.OrderService, idempotencyService primary.IdempotencyService, logger *slog.Logger) OrderHandler {
 	return &orderHandler{
-		orderService: orderService,
+		orderService:       orderService,
+		idempotencyService: idempotencyService,
+		logger:             logger,
 	}
 }
 
 // CreateOrder godoc
 // @Summary Create a new order
-// @Description Create a new order with the provided details
+// @Description Create a new order for the given SKUs and quantities, the total amount is computed from the product catalog.
+// @Description Requests sent again with the same Idempotency-Key header replay the first response instead of creating another order.
 // @Tags orders
 // @Accept json
 // @Produce json
+// @Param Idempotency-Key header string false "Unique key of the request for the customer, at most 255 characters"
 // @Param order body CreateOrderRequest true "Order information"
 // @Success 200 {object} OrderResponse "Order created successfully"
-// @Failure 400 {object} handler.ErrorResponse "Bad request"
+// @Failure 400 {object} handler.ErrorResponse "Bad request, e.g. unknown or inactive SKU, quantity or total amount too large"
+// @Failure 409 {object} handler.ErrorResponse "Out of stock, or idempotency key reused with a different request or still in progress"
 // @Failure 500 {object} handler.ErrorResponse "Internal server error"
 // @Router /v1/orders [post]
 func (h *orderHandler) CreateOrder(ctx *gin.Context) {
@@ -41,17 +63,117 @@ func (h *orderHandler) CreateOrder(ctx *gin.Context) {
 		return
 	}
 
+	key := ctx.GetHeader(idempotencyKeyHeader)
+	if len(key) > maxIdempotencyKeyLength {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Idempotency-Key must be at most 255 characters"})
+		return
+	}
+	if key == "" {
+		h.createOrder(ctx, req)
+		return
+	}
+
+	requestHash, err := hashRequest(req)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	record, replay, err := h.idempotencyService.Begin(ctx, req.CustomerId, key, requestHash)
+	switch {
+	case errors.Is(err, idempotency.ErrKeyReused), errors.Is(err, idempotency.ErrRequestInProgress):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	case replay:
+		ctx.Data(record.StatusCode, "application/json; charset=utf-8", record.Response)
+		return
+	}
+
+	stopKeepAlive := h.idempotencyService.KeepAlive(ctx, record)
+	status := http.StatusOK
+	var body []byte
 	createdOrder, err := h.orderService.CreateOrder(ctx, req.ToDomain())
+	stopKeepAlive()
+	switch {
+	case err != nil && leftNothingBehind(err):
+		// the key is released so the client can retry the same request
+		if releaseErr := h.idempotencyService.Release(ctx, record); releaseErr != nil {
+			h.logger.Error("Failed to release idempotency key",
+				slog.String("key", key),
+				slog.String("error", releaseErr.Error()),
+			)
+		}
+		ctx.JSON(createOrderErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		// the order may exist, so the failure is replayed instead of placing the order again
+		status = createOrderErrorStatus(err)
+		body, err = json.Marshal(gin.H{"error": err.Error()})
+	default:
+		body, err = json.Marshal(handler.BaseResponse[OrderResponse]{
+			Data: ToCreateOrderResponse(createdOrder),
+		})
+	}
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	if err := h.idempotencyService.Complete(ctx, record, status, body); err != nil {
+		// the response is still sent, the lease frees the key for a retry
+		h.logger.Error("Failed to record idempotent response",
+			slog.String("key", key),
+			slog.String("error", err.Error()),
+		)
+	}
+	ctx.Data(status, "application/json; charset=utf-8", body)
+}
+
+func (h *orderHandler) createOrder(ctx *gin.Context, req CreateOrderRequest) {
+	createdOrder, err := h.orderService.CreateOrder(ctx, req.ToDomain())
+	if err != nil {
+		ctx.JSON(createOrderErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
 
 	ctx.JSON(http.StatusOK, handler.BaseResponse[OrderResponse]{
 		Data: ToCreateOrderResponse(createdOrder),
 	})
 }
 
+// createOrderErrorStatus reports items that cannot be sold as a bad request and sold out items as a conflict
+func createOrderErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, product.ErrUnknownSku), errors.Is(err, product.ErrProductInactive),
+		errors.Is(err, order.ErrQuantityTooLarge), errors.Is(err, order.ErrTotalAmountTooLarge):
+		return http.StatusBadRequest
+	case errors.Is(err, inventory.ErrOutOfStock):
+		return http.StatusConflict
+	}
+	return http.StatusInternalServerError
+}
+
+// leftNothingBehind reports the errors of a request that was rejected before placing the order, or whose
+// placement was fully compensated, so retrying it cannot place the order twice
+func leftNothingBehind(err error) bool {
+	return errors.Is(err, product.ErrUnknownSku) ||
+		errors.Is(err, product.ErrProductInactive) ||
+		errors.Is(err, order.ErrQuantityTooLarge) ||
+		errors.Is(err, order.ErrTotalAmountTooLarge) ||
+		errors.Is(err, order.ErrPlacementCompensated)
+}
+
+// hashRequest fingerprints the request body so that a reused key can be told apart from a retry
+func hashRequest(req CreateOrderRequest) (string, error) {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
 // GetAllOrders godoc
 // @Summary Get all orders
 // @Description Retrieve all orders from the system
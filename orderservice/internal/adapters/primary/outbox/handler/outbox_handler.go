@@ -0,0 +1,99 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"specommerce/orderservice/pkg/outbox"
+	"specommerce/orderservice/pkg/sharedto/handler"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	defaultListSize = 20
+	maxListSize     = 200
+)
+
+type OutboxHandler interface {
+	ListFailedMessages(ctx *gin.Context)
+	RedriveFailedMessage(ctx *gin.Context)
+}
+type outboxHandler struct {
+	failedMessages outbox.FailedMessages
+}
+
+func NewOutboxHandler(failedMessages outbox.FailedMessages) OutboxHandler {
+	return &outboxHandler{
+		failedMessages: failedMessages,
+	}
+}
+
+// ListFailedMessages godoc
+// @Summary List failed outbox messages
+// @Description List the outbox messages marked FAILED after the maximum publish attempts, in insertion order
+// @Tags outbox
+// @Produce json
+// @Param after_id query int false "List the messages after this id" default(0)
+// @Param size query int false "Maximum number of messages" minimum(1) maximum(200) default(20)
+// @Success 200 {array} OutboxMessageResponse "Failed outbox messages"
+// @Failure 400 {object} handler.ErrorResponse "Bad request"
+// @Failure 500 {object} handler.ErrorResponse "Internal server error"
+// @Router /admin/v1/outbox-messages/failed [get]
+func (h *outboxHandler) ListFailedMessages(ctx *gin.Context) {
+	afterId, err := strconv.ParseInt(ctx.DefaultQuery("after_id", "0"), 10, 64)
+	if err != nil || afterId < 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid after_id"})
+		return
+	}
+	size, err := strconv.Atoi(ctx.DefaultQuery("size", strconv.Itoa(defaultListSize)))
+	if err != nil || size <= 0 || size > maxListSize {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid size"})
+		return
+	}
+
+	messages, err := h.failedMessages.List(ctx, afterId, size)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	data := make([]OutboxMessageResponse, 0, len(messages))
+	for _, message := range messages {
+		data = append(data, ToOutboxMessageResponse(message))
+	}
+	ctx.JSON(http.StatusOK, handler.BaseResponse[[]OutboxMessageResponse]{
+		Data: data,
+	})
+}
+
+// RedriveFailedMessage godoc
+// @Summary Re-drive a failed outbox message
+// @Description Put a failed outbox message back in the pending queue with its attempts reset, to be published again by the relay
+// @Tags outbox
+// @Produce json
+// @Param id path int true "Outbox message ID"
+// @Success 200 {object} OutboxMessageResponse "Re-driven outbox message"
+// @Failure 400 {object} handler.ErrorResponse "Bad request"
+// @Failure 404 {object} handler.ErrorResponse "Failed outbox message not found"
+// @Failure 500 {object} handler.ErrorResponse "Internal server error"
+// @Router /admin/v1/outbox-messages/{id}/redrive [post]
+func (h *outboxHandler) RedriveFailedMessage(ctx *gin.Context) {
+	id, err := strconv.ParseInt(ctx.Param("id"), 10, 64)
+	if err != nil || id <= 0 {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	message, err := h.failedMessages.Redrive(ctx, id)
+	if errors.Is(err, outbox.ErrFailedMessageNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, handler.BaseResponse[OutboxMessageResponse]{
+		Data: ToOutboxMessageResponse(message),
+	})
+}
@@ -56,6 +56,7 @@ func (c *ProcessPaymentResponseConsumer) handleEvent(message kafka.Message) erro
 	successPayment, err := c.service.ProcessPaymentResponse(ctx, payment.ProcessPaymentResponse{
 		OrderId:       orderId,
 		PaymentStatus: ToDomainPaymentStatus(request.PaymentStatus),
+		DeclineReason: request.DeclineReason,
 	})
 
 	if err != nil {
@@ -68,6 +69,7 @@ func (c *ProcessPaymentResponseConsumer) handleEvent(message kafka.Message) erro
 		slog.Float64("total_amount", successPayment.TotalAmount),
 		slog.String("customer_id", successPayment.CustomerId),
 		slog.String("status", successPayment.Status.String()),
+		slog.String("decline_reason", request.DeclineReason),
 	)
 
 	return nil
@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"github.com/rs/xid"
+	domain "specommerce/orderservice/internal/core/domain/product"
+	"specommerce/orderservice/internal/core/ports/secondary"
+	"specommerce/orderservice/pkg/pagination"
+	"time"
+)
+
+// CreateProductRequest represents the request for adding a product to the catalog
+type CreateProductRequest struct {
+	Sku    string  `json:"sku" binding:"required,max=64" example:"IPHONE-16-128"`
+	Name   string  `json:"name" binding:"required,max=255" example:"iPhone 16 128GB"`
+	Price  float64 `json:"price" binding:"required,gt=0" example:"1299.00"`
+	Stock  int     `json:"stock" binding:"min=0" example:"100"`
+	Active *bool   `json:"active" example:"true"`
+}
+
+func (r *CreateProductRequest) ToDomain() domain.Product {
+	active := true
+	if r.Active != nil {
+		active = *r.Active
+	}
+	return domain.Product{
+		Id:        xid.New(),
+		Sku:       r.Sku,
+		Name:      r.Name,
+		Price:     r.Price,
+		Stock:     r.Stock,
+		Active:    active,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+}
+
+// UpdateProductRequest represents the request for replacing a product of the catalog
+type UpdateProductRequest struct {
+	Sku    string  `json:"sku" binding:"required,max=64" example:"IPHONE-16-128"`
+	Name   string  `json:"name" binding:"required,max=255" example:"iPhone 16 128GB"`
+	Price  float64 `json:"price" binding:"required,gt=0" example:"1299.00"`
+	Stock  *int    `json:"stock" binding:"required,min=0" example:"100"`
+	Active *bool   `json:"active" binding:"required" example:"true"`
+}
+
+func (r *UpdateProductRequest) ToDomain(id xid.ID) domain.Product {
+	return domain.Product{
+		Id:     id,
+		Sku:    r.Sku,
+		Name:   r.Name,
+		Price:  r.Price,
+		Stock:  *r.Stock,
+		Active: *r.Active,
+	}
+}
+
+// ProductResponse represents product response for Swagger
+type ProductResponse struct {
+	ID        string    `json:"id" example:"d2k8s1c6n88s73b5ktr0"`
+	Sku       string    `json:"sku" example:"IPHONE-16-128"`
+	Name      string    `json:"name" example:"iPhone 16 128GB"`
+	Price     float64   `json:"price" example:"1299.00"`
+	Stock     int       `json:"stock" example:"100"`
+	Active    bool      `json:"active" example:"true"`
+	CreatedAt time.Time `json:"created_at" example:"2023-01-01T00:00:00Z"`
+	UpdatedAt time.Time `json:"updated_at" example:"2023-01-01T00:00:00Z"`
+}
+
+func ToProductResponse(entity domain.Product) ProductResponse {
+	return ProductResponse{
+		ID:        entity.Id.String(),
+		Sku:       entity.Sku,
+		Name:      entity.Name,
+		Price:     entity.Price,
+		Stock:     entity.Stock,
+		Active:    entity.Active,
+		CreatedAt: entity.CreatedAt,
+		UpdatedAt: entity.UpdatedAt,
+	}
+}
+
+func ToProductPageResponse(page pagination.Page[domain.Product]) pagination.Page[ProductResponse] {
+	data := make([]ProductResponse, 0, len(page.Data))
+	for _, entity := range page.Data {
+		data = append(data, ToProductResponse(entity))
+	}
+	return pagination.Page[ProductResponse]{
+		Data:     data,
+		Metadata: page.Metadata,
+	}
+}
+
+// SearchProductsRequest represents the request for searching products with pagination
+type SearchProductsRequest struct {
+	Paging pagination.Paging
+	Sku    string
+	Active *bool
+}
+
+func (req SearchProductsRequest) ToFilter() secondary.SearchProductsFilter {
+	return secondary.SearchProductsFilter{
+		Paging: req.Paging,
+		Sku:    req.Sku,
+		Active: req.Active,
+	}
+}
@@ -0,0 +1,215 @@
+package handler
+
+import (
+	"errors"
+	"github.com/rs/xid"
+	"net/http"
+	"specommerce/orderservice/internal/core/ports/primary"
+	apperrors "specommerce/orderservice/pkg/app_error"
+	"specommerce/orderservice/pkg/database"
+	"specommerce/orderservice/pkg/sharedto/handler"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ProductHandler interface {
+	CreateProduct(ctx *gin.Context)
+	UpdateProduct(ctx *gin.Context)
+	DeleteProduct(ctx *gin.Context)
+	GetProduct(ctx *gin.Context)
+	SearchProducts(ctx *gin.Context)
+}
+type productHandler struct {
+	productService primary.ProductService
+}
+
+func NewProductHandler(productService primary.ProductService) ProductHandler {
+	return &productHandler{
+		productService: productService,
+	}
+}
+
+// CreateProduct godoc
+// @Summary Create a product
+// @Description Add a product to the catalog, orders are priced from the catalog by SKU
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param product body CreateProductRequest true "Product information"
+// @Success 200 {object} ProductResponse "Product created successfully"
+// @Failure 400 {object} handler.ErrorResponse "Bad request"
+// @Failure 409 {object} handler.ErrorResponse "SKU already exists"
+// @Failure 500 {object} handler.ErrorResponse "Internal server error"
+// @Router /admin/v1/products [post]
+func (h *productHandler) CreateProduct(ctx *gin.Context) {
+	var req CreateProductRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	created, err := h.productService.CreateProduct(ctx, req.ToDomain())
+	if apperrors.IsConstraintViolationError(err) {
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, handler.BaseResponse[ProductResponse]{
+		Data: ToProductResponse(created),
+	})
+}
+
+// UpdateProduct godoc
+// @Summary Update a product
+// @Description Replace a product of the catalog. Placed orders keep the price they were placed with
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Param product body UpdateProductRequest true "Product information"
+// @Success 200 {object} ProductResponse "Product updated successfully"
+// @Failure 400 {object} handler.ErrorResponse "Bad request"
+// @Failure 404 {object} handler.ErrorResponse "Product not found"
+// @Failure 409 {object} handler.ErrorResponse "SKU already exists"
+// @Failure 500 {object} handler.ErrorResponse "Internal server error"
+// @Router /admin/v1/products/{id} [put]
+func (h *productHandler) UpdateProduct(ctx *gin.Context) {
+	id, err := xid.FromString(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	var req UpdateProductRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updated, err := h.productService.UpdateProduct(ctx, req.ToDomain(id))
+	switch {
+	case errors.Is(err, database.ErrRecordNotFound):
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	case apperrors.IsConstraintViolationError(err):
+		ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	case err != nil:
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, handler.BaseResponse[ProductResponse]{
+		Data: ToProductResponse(updated),
+	})
+}
+
+// DeleteProduct godoc
+// @Summary Delete a product
+// @Description Remove a product from the catalog. Set active to false instead to keep it listed but stop selling it
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Success 204 "Product deleted"
+// @Failure 400 {object} handler.ErrorResponse "Bad request"
+// @Failure 404 {object} handler.ErrorResponse "Product not found"
+// @Failure 500 {object} handler.ErrorResponse "Internal server error"
+// @Router /admin/v1/products/{id} [delete]
+func (h *productHandler) DeleteProduct(ctx *gin.Context) {
+	id, err := xid.FromString(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err = h.productService.DeleteProduct(ctx, id)
+	if errors.Is(err, database.ErrRecordNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// GetProduct godoc
+// @Summary Get a product
+// @Description Retrieve a product of the catalog
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param id path string true "Product ID"
+// @Success 200 {object} ProductResponse "Product details"
+// @Failure 400 {object} handler.ErrorResponse "Bad request"
+// @Failure 404 {object} handler.ErrorResponse "Product not found"
+// @Failure 500 {object} handler.ErrorResponse "Internal server error"
+// @Router /admin/v1/products/{id} [get]
+func (h *productHandler) GetProduct(ctx *gin.Context) {
+	id, err := xid.FromString(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := h.productService.GetProduct(ctx, id)
+	if errors.Is(err, database.ErrRecordNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, handler.BaseResponse[ProductResponse]{
+		Data: ToProductResponse(result),
+	})
+}
+
+// SearchProducts godoc
+// @Summary Search products with pagination and sorting
+// @Description Search the product catalog by SKU or availability
+// @Tags products
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number" minimum(1) default(1)
+// @Param size query int false "Page size" minimum(1) default(10)
+// @Param sort query string false "Comma separated sort fields, prefix with - for descending" example(sku)
+// @Param sku query string false "Filter by SKU"
+// @Param active query bool false "Filter by availability"
+// @Success 200 {array} ProductResponse "Paginated products"
+// @Failure 400 {object} handler.ErrorResponse "Bad request"
+// @Failure 500 {object} handler.ErrorResponse "Internal server error"
+// @Router /admin/v1/products/search [get]
+func (h *productHandler) SearchProducts(ctx *gin.Context) {
+	var req SearchProductsRequest
+	if err := handler.ParsePagination(ctx, &req.Paging); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.Sku = ctx.Query("sku")
+	if activeStr := ctx.Query("active"); activeStr != "" {
+		active, err := strconv.ParseBool(activeStr)
+		if err != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		req.Active = &active
+	}
+
+	result, err := h.productService.SearchProducts(ctx, req.ToFilter())
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ToProductPageResponse(result))
+}
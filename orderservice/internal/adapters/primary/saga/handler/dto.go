@@ -0,0 +1,94 @@
+package handler
+
+import (
+	"encoding/json"
+	domain "specommerce/orderservice/internal/core/domain/saga"
+	"specommerce/orderservice/internal/core/ports/secondary"
+	"specommerce/orderservice/pkg/pagination"
+	"time"
+)
+
+// SagaResponse represents saga response for Swagger
+type SagaResponse struct {
+	ID            string          `json:"id" example:"d2k8s1c6n88s73b5ktr0"`
+	Type          string          `json:"type" example:"ORDER_PLACEMENT"`
+	OrderId       string          `json:"order_id" example:"d2k8s1c6n88s73b5ktqg"`
+	Status        string          `json:"status" example:"WAITING"`
+	CurrentStep   int             `json:"current_step" example:"3"`
+	Payload       json.RawMessage `json:"payload" swaggertype:"object"`
+	LastError     string          `json:"last_error" example:""`
+	FailureReason string          `json:"failure_reason" example:""`
+	CreatedAt     time.Time       `json:"created_at" example:"2023-01-01T00:00:00Z"`
+	UpdatedAt     time.Time       `json:"updated_at" example:"2023-01-01T00:00:00Z"`
+}
+
+// SagaStepResponse represents a step executed or compensated by a saga
+type SagaStepResponse struct {
+	Step      string    `json:"step" example:"request_payment"`
+	Action    string    `json:"action" example:"EXECUTE"`
+	Error     string    `json:"error" example:""`
+	CreatedAt time.Time `json:"created_at" example:"2023-01-01T00:00:00Z"`
+}
+
+// SagaDetailResponse represents a saga with its step history
+type SagaDetailResponse struct {
+	SagaResponse
+	Steps []SagaStepResponse `json:"steps"`
+}
+
+func ToSagaResponse(entity domain.Saga) SagaResponse {
+	return SagaResponse{
+		ID:            entity.Id.String(),
+		Type:          string(entity.Type),
+		OrderId:       entity.OrderId.String(),
+		Status:        entity.Status.String(),
+		CurrentStep:   entity.CurrentStep,
+		Payload:       entity.Payload,
+		LastError:     entity.LastError,
+		FailureReason: entity.FailureReason,
+		CreatedAt:     entity.CreatedAt,
+		UpdatedAt:     entity.UpdatedAt,
+	}
+}
+
+func ToSagaDetailResponse(entity domain.Saga, steps []domain.StepLog) SagaDetailResponse {
+	response := SagaDetailResponse{
+		SagaResponse: ToSagaResponse(entity),
+		Steps:        make([]SagaStepResponse, 0, len(steps)),
+	}
+	for _, step := range steps {
+		response.Steps = append(response.Steps, SagaStepResponse{
+			Step:      step.Step,
+			Action:    string(step.Action),
+			Error:     step.Error,
+			CreatedAt: step.CreatedAt,
+		})
+	}
+	return response
+}
+
+func ToSagaPageResponse(page pagination.Page[domain.Saga]) pagination.Page[SagaResponse] {
+	data := make([]SagaResponse, 0, len(page.Data))
+	for _, entity := range page.Data {
+		data = append(data, ToSagaResponse(entity))
+	}
+	return pagination.Page[SagaResponse]{
+		Data:     data,
+		Metadata: page.Metadata,
+	}
+}
+
+// SearchSagasRequest represents the request for searching sagas with pagination
+type SearchSagasRequest struct {
+	Paging  pagination.Paging
+	Status  string
+	OrderId string
+}
+
+func (req SearchSagasRequest) ToFilter() secondary.SearchSagasFilter {
+	return secondary.SearchSagasFilter{
+		Paging:  req.Paging,
+		Status:  domain.SagaStatus(req.Status),
+		OrderId: req.OrderId,
+	}
+}
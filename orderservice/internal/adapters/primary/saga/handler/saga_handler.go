@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"errors"
+	"github.com/rs/xid"
+	"net/http"
+	"specommerce/orderservice/internal/core/ports/primary"
+	"specommerce/orderservice/pkg/database"
+	"specommerce/orderservice/pkg/sharedto/handler"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SagaHandler interface {
+	GetSaga(ctx *gin.Context)
+	SearchSagas(ctx *gin.Context)
+}
+type sagaHandler struct {
+	sagaService primary.SagaService
+}
+
+func NewSagaHandler(sagaService primary.SagaService) SagaHandler {
+	return &sagaHandler{
+		sagaService: sagaService,
+	}
+}
+
+// GetSaga godoc
+// @Summary Get a saga
+// @Description Retrieve a saga with its current state and step history
+// @Tags sagas
+// @Accept json
+// @Produce json
+// @Param id path string true "Saga ID"
+// @Success 200 {object} SagaDetailResponse "Saga details"
+// @Failure 400 {object} handler.ErrorResponse "Bad request"
+// @Failure 404 {object} handler.ErrorResponse "Saga not found"
+// @Failure 500 {object} handler.ErrorResponse "Internal server error"
+// @Router /admin/v1/sagas/{id} [get]
+func (h *sagaHandler) GetSaga(ctx *gin.Context) {
+	id, err := xid.FromString(ctx.Param("id"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, steps, err := h.sagaService.GetSaga(ctx, id)
+	if errors.Is(err, database.ErrRecordNotFound) {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, handler.BaseResponse[SagaDetailResponse]{
+		Data: ToSagaDetailResponse(result, steps),
+	})
+}
+
+// SearchSagas godoc
+// @Summary Search sagas with pagination and sorting
+// @Description Search sagas by status or order id, e.g. to find sagas stuck in compensation
+// @Tags sagas
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number" minimum(1) default(1)
+// @Param size query int false "Page size" minimum(1) default(10)
+// @Param sort query string false "Comma separated sort fields, prefix with - for descending" example(-updated_at)
+// @Param status query string false "Filter by saga status" Enums(RUNNING, WAITING, COMPLETED, COMPENSATING, COMPENSATED)
+// @Param order_id query string false "Filter by order id"
+// @Success 200 {array} SagaResponse "Paginated sagas"
+// @Failure 400 {object} handler.ErrorResponse "Bad request"
+// @Failure 500 {object} handler.ErrorResponse "Internal server error"
+// @Router /admin/v1/sagas/search [get]
+func (h *sagaHandler) SearchSagas(ctx *gin.Context) {
+	var req SearchSagasRequest
+	if err := handler.ParsePagination(ctx, &req.Paging); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.Status = ctx.Query("status")
+	req.OrderId = ctx.Query("order_id")
+
+	result, err := h.sagaService.SearchSagas(ctx, req.ToFilter())
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, ToSagaPageResponse(result))
+}
@@ -10,12 +10,12 @@ import (
 	"specommerce/orderservice/internal/core/domain/order"
 	"specommerce/orderservice/internal/core/ports/secondary"
 	"specommerce/orderservice/model"
-	"specommerce/orderservice/pkg/messagequeue"
+	"specommerce/orderservice/pkg/outbox"
 )
 
 type campaignPublisher struct {
-	config    config.AppConfig
-	publisher messagequeue.Publisher
+	config config.AppConfig
+	outbox outbox.Writer
 }
 
 func (p *campaignPublisher) SendOrderEvent(ctx context.Context, input order.Order) error {
@@ -29,22 +29,36 @@ func (p *campaignPublisher) SendOrderEvent(ctx context.Context, input order.Orde
 		Status:       input.Status.String(),
 		CreatedAt:    timestamppb.New(input.CreatedAt),
 		UpdatedAt:    timestamppb.New(input.UpdatedAt),
+		Items:        toOrderItems(input.Items),
 	})
 
 	if err != nil {
 		return fmt.Errorf(errTemplate, err)
 	}
 
-	return p.publisher.Publish(kafkaGo.Message{
+	return p.outbox.Write(ctx, kafkaGo.Message{
 		Topic: p.config.OrderEvents.Topic,
 		Value: payload,
 		Key:   []byte(input.CustomerId),
 	})
 }
 
-func NewCampaignPublisher(config config.AppConfig, publisher messagequeue.Publisher) secondary.CampaignRepository {
+func NewCampaignPublisher(config config.AppConfig, outboxWriter outbox.Writer) secondary.CampaignRepository {
 	return &campaignPublisher{
-		config:    config,
-		publisher: publisher,
+		config: config,
+		outbox: outboxWriter,
 	}
 }
+
+func toOrderItems(items []order.OrderItem) []*model.OrderItem {
+	result := make([]*model.OrderItem, 0, len(items))
+	for _, item := range items {
+		result = append(result, &model.OrderItem{
+			Sku:       item.Sku,
+			Name:      item.Name,
+			UnitPrice: item.UnitPrice,
+			Quantity:  int32(item.Quantity),
+		})
+	}
+	return result
+}
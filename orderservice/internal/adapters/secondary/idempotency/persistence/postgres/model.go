@@ -0,0 +1,46 @@
+package postgres
+
+import (
+	"encoding/json"
+	"github.com/uptrace/bun"
+	domain "specommerce/orderservice/internal/core/domain/idempotency"
+	"time"
+)
+
+type IdempotencyKey struct {
+	bun.BaseModel `bun:"idempotency_keys"`
+	CustomerId    string          `bun:"customer_id,pk"`
+	Key           string          `bun:",pk"`
+	Token         string          `bun:"token,notnull"`
+	RequestHash   string          `bun:"request_hash,notnull"`
+	StatusCode    int             `bun:"status_code,notnull"`
+	Response      json.RawMessage `bun:"response,type:jsonb,nullzero"`
+	CreatedAt     time.Time       `bun:",nullzero,notnull,default:current_timestamp"`
+	ExpiresAt     time.Time       `bun:"expires_at,notnull"`
+}
+
+func (k IdempotencyKey) ToDomainModel() domain.Record {
+	return domain.Record{
+		CustomerId:  k.CustomerId,
+		Key:         k.Key,
+		Token:       k.Token,
+		RequestHash: k.RequestHash,
+		StatusCode:  k.StatusCode,
+		Response:    k.Response,
+		CreatedAt:   k.CreatedAt,
+		ExpiresAt:   k.ExpiresAt,
+	}
+}
+
+func FromDomainModel(dm domain.Record) IdempotencyKey {
+	return IdempotencyKey{
+		CustomerId:  dm.CustomerId,
+		Key:         dm.Key,
+		Token:       dm.Token,
+		RequestHash: dm.RequestHash,
+		StatusCode:  dm.StatusCode,
+		Response:    dm.Response,
+		CreatedAt:   dm.CreatedAt,
+		ExpiresAt:   dm.ExpiresAt,
+	}
+}
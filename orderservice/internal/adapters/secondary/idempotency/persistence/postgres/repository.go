@@ -0,0 +1,126 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/uptrace/bun"
+	domain "specommerce/orderservice/internal/core/domain/idempotency"
+	"specommerce/orderservice/internal/core/ports/secondary"
+	"specommerce/orderservice/pkg/database"
+	"time"
+)
+
+type idempotencyPersistenceRepository struct {
+	getDbFunc database.GetDbFunc
+}
+
+func NewIdempotencyPersistenceRepository(dbFunc database.GetDbFunc) secondary.IdempotencyRepository {
+	return &idempotencyPersistenceRepository{
+		getDbFunc: dbFunc,
+	}
+}
+
+// Reserve inserts the key, or takes over an expired one, in a single statement so that concurrent
+// requests with the same key cannot both reserve it
+func (r *idempotencyPersistenceRepository) Reserve(ctx context.Context, record domain.Record) (domain.Record, bool, error) {
+	errTemplate := "idempotencyPersistenceRepository.Reserve: %w"
+	row := FromDomainModel(record)
+	res, err := r.getDbFunc(ctx).NewInsert().Model(&row).
+		On("CONFLICT (customer_id, key) DO UPDATE").
+		Set("token = EXCLUDED.token").
+		Set("request_hash = EXCLUDED.request_hash").
+		Set("status_code = 0").
+		Set("response = NULL").
+		Set("created_at = EXCLUDED.created_at").
+		Set("expires_at = EXCLUDED.expires_at").
+		Where("idempotency_keys.expires_at < ?", record.CreatedAt).
+		Returning("*").Exec(ctx)
+	if err != nil {
+		return domain.Record{}, false, fmt.Errorf(errTemplate, err)
+	}
+	if affected, err := res.RowsAffected(); err != nil {
+		return domain.Record{}, false, fmt.Errorf(errTemplate, err)
+	} else if affected == 1 {
+		return row.ToDomainModel(), true, nil
+	}
+
+	var existing IdempotencyKey
+	err = r.getDbFunc(ctx).NewSelect().Model(&existing).
+		Where("customer_id = ?", record.CustomerId).
+		Where("key = ?", record.Key).
+		Scan(ctx)
+	if err != nil {
+		return domain.Record{}, false, fmt.Errorf(errTemplate, err)
+	}
+	return existing.ToDomainModel(), false, nil
+}
+
+// Extend moves the end of the lease of a request still in progress, as long as it holds the key
+func (r *idempotencyPersistenceRepository) Extend(ctx context.Context, record domain.Record, expiresAt time.Time) error {
+	res, err := r.leased(r.getDbFunc(ctx).NewUpdate().Model((*IdempotencyKey)(nil)), record).
+		Set("expires_at = ?", expiresAt).
+		Where("status_code = 0").
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("idempotencyPersistenceRepository.Extend: %w", err)
+	}
+	return r.checkLease(res, "idempotencyPersistenceRepository.Extend: %w")
+}
+
+func (r *idempotencyPersistenceRepository) Complete(ctx context.Context, record domain.Record, statusCode int, response []byte, expiresAt time.Time) error {
+	res, err := r.leased(r.getDbFunc(ctx).NewUpdate().Model((*IdempotencyKey)(nil)), record).
+		Set("status_code = ?", statusCode).
+		Set("response = ?", string(response)).
+		Set("expires_at = ?", expiresAt).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("idempotencyPersistenceRepository.Complete: %w", err)
+	}
+	return r.checkLease(res, "idempotencyPersistenceRepository.Complete: %w")
+}
+
+func (r *idempotencyPersistenceRepository) Delete(ctx context.Context, record domain.Record) error {
+	res, err := r.getDbFunc(ctx).NewDelete().Model((*IdempotencyKey)(nil)).
+		Where("customer_id = ?", record.CustomerId).
+		Where("key = ?", record.Key).
+		Where("token = ?", record.Token).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("idempotencyPersistenceRepository.Delete: %w", err)
+	}
+	return r.checkLease(res, "idempotencyPersistenceRepository.Delete: %w")
+}
+
+// leased matches the key only while it is held by the request of the record
+func (r *idempotencyPersistenceRepository) leased(query *bun.UpdateQuery, record domain.Record) *bun.UpdateQuery {
+	return query.
+		Where("customer_id = ?", record.CustomerId).
+		Where("key = ?", record.Key).
+		Where("token = ?", record.Token)
+}
+
+func (r *idempotencyPersistenceRepository) checkLease(res sql.Result, errTemplate string) error {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf(errTemplate, err)
+	}
+	if affected == 0 {
+		return fmt.Errorf(errTemplate, domain.ErrLeaseLost)
+	}
+	return nil
+}
+
+func (r *idempotencyPersistenceRepository) DeleteExpired(ctx context.Context, before time.Time) (int, error) {
+	res, err := r.getDbFunc(ctx).NewDelete().Model((*IdempotencyKey)(nil)).
+		Where("expires_at < ?", before).
+		Exec(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("idempotencyPersistenceRepository.DeleteExpired: %w", err)
+	}
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("idempotencyPersistenceRepository.DeleteExpired: %w", err)
+	}
+	return int(deleted), nil
+}
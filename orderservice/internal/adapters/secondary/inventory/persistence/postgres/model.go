@@ -0,0 +1,57 @@
+package postgres
+
+import (
+	"github.com/rs/xid"
+	"github.com/uptrace/bun"
+	domain "specommerce/orderservice/internal/core/domain/inventory"
+	"time"
+)
+
+type StockReservation struct {
+	bun.BaseModel `bun:"stock_reservations"`
+	OrderId       xid.ID    `bun:",pk"`
+	Sku           string    `bun:",pk"`
+	Quantity      int       `bun:"quantity,notnull"`
+	Status        string    `bun:"status,notnull,default:'RESERVED'"`
+	ExpiresAt     time.Time `bun:"expires_at,notnull"`
+	CreatedAt     time.Time `bun:",nullzero,notnull,default:current_timestamp,skipupdate"`
+	UpdatedAt     time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+}
+
+type StockLevel struct {
+	Sku      string `bun:"sku"`
+	Stock    int    `bun:"stock"`
+	Reserved int    `bun:"reserved"`
+}
+
+func (r StockReservation) ToDomainModel() domain.Reservation {
+	return domain.Reservation{
+		OrderId:   r.OrderId,
+		Sku:       r.Sku,
+		Quantity:  r.Quantity,
+		Status:    domain.ReservationStatus(r.Status),
+		ExpiresAt: r.ExpiresAt,
+		CreatedAt: r.CreatedAt,
+		UpdatedAt: r.UpdatedAt,
+	}
+}
+
+func FromDomainModel(dm domain.Reservation) StockReservation {
+	return StockReservation{
+		OrderId:   dm.OrderId,
+		Sku:       dm.Sku,
+		Quantity:  dm.Quantity,
+		Status:    string(dm.Status),
+		ExpiresAt: dm.ExpiresAt,
+		CreatedAt: dm.CreatedAt,
+		UpdatedAt: dm.UpdatedAt,
+	}
+}
+
+func (l StockLevel) ToDomainModel() domain.StockLevel {
+	return domain.StockLevel{
+		Sku:      l.Sku,
+		Stock:    l.Stock,
+		Reserved: l.Reserved,
+	}
+}
@@ -0,0 +1,119 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"github.com/rs/xid"
+	"github.com/uptrace/bun"
+	domain "specommerce/orderservice/internal/core/domain/inventory"
+	"specommerce/orderservice/internal/core/ports/secondary"
+	"specommerce/orderservice/pkg/database"
+	"time"
+)
+
+type inventoryPersistenceRepository struct {
+	getDbFunc database.GetDbFunc
+}
+
+func NewInventoryPersistenceRepository(dbFunc database.GetDbFunc) secondary.InventoryRepository {
+	return &inventoryPersistenceRepository{
+		getDbFunc: dbFunc,
+	}
+}
+
+func (r *inventoryPersistenceRepository) CreateReservations(ctx context.Context, reservations []domain.Reservation) error {
+	records := make([]StockReservation, 0, len(reservations))
+	for _, reservation := range reservations {
+		records = append(records, FromDomainModel(reservation))
+	}
+	_, err := r.getDbFunc(ctx).NewInsert().Model(&records).
+		On("CONFLICT (order_id, sku) DO NOTHING").
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("inventoryPersistenceRepository.CreateReservations: %w", err)
+	}
+	return nil
+}
+
+func (r *inventoryPersistenceRepository) ConfirmReservations(ctx context.Context, orderId xid.ID) ([]domain.Reservation, error) {
+	errTemplate := "inventoryPersistenceRepository.ConfirmReservations: %w"
+	records, err := r.updateReservedStatus(ctx, orderId, domain.ReservationStatusConfirmed)
+	if err != nil {
+		return nil, fmt.Errorf(errTemplate, err)
+	}
+	for _, record := range records {
+		// the stock may have been lowered by an admin below what was already reserved
+		_, err = r.getDbFunc(ctx).NewUpdate().Table("products").
+			Set("stock = greatest(stock - ?, 0)", record.Quantity).
+			Where("sku = ?", record.Sku).
+			Exec(ctx)
+		if err != nil {
+			return nil, fmt.Errorf(errTemplate, err)
+		}
+	}
+	return toDomainReservations(records), nil
+}
+
+func (r *inventoryPersistenceRepository) ReleaseReservations(ctx context.Context, orderId xid.ID) ([]domain.Reservation, error) {
+	records, err := r.updateReservedStatus(ctx, orderId, domain.ReservationStatusReleased)
+	if err != nil {
+		return nil, fmt.Errorf("inventoryPersistenceRepository.ReleaseReservations: %w", err)
+	}
+	return toDomainReservations(records), nil
+}
+
+// updateReservedStatus moves the reservations of the order that are still reserved to the given status,
+// so confirming or releasing twice changes nothing the second time
+func (r *inventoryPersistenceRepository) updateReservedStatus(ctx context.Context, orderId xid.ID, status domain.ReservationStatus) ([]StockReservation, error) {
+	records := make([]StockReservation, 0)
+	_, err := r.getDbFunc(ctx).NewUpdate().Model((*StockReservation)(nil)).
+		Set("status = ?", status).
+		Where("order_id = ?", orderId).
+		Where("status = ?", domain.ReservationStatusReserved).
+		Returning("*").
+		Exec(ctx, &records)
+	return records, err
+}
+
+func (r *inventoryPersistenceRepository) FindExpiredReservations(ctx context.Context, before time.Time, limit int) ([]domain.Reservation, error) {
+	records, err := database.NewPostgresCrudDatabaseOperation[StockReservation](r.getDbFunc).FindAll(ctx,
+		func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Where("status = ?", domain.ReservationStatusReserved).
+				Where("expires_at < ?", before).
+				Order("expires_at ASC").
+				Limit(limit)
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("inventoryPersistenceRepository.FindExpiredReservations: %w", err)
+	}
+	return toDomainReservations(records), nil
+}
+
+func (r *inventoryPersistenceRepository) GetStockLevels(ctx context.Context, skus []string) ([]domain.StockLevel, error) {
+	records := make([]StockLevel, 0)
+	query := r.getDbFunc(ctx).NewSelect().
+		TableExpr("products AS p").
+		ColumnExpr("p.sku, p.stock, coalesce(sum(r.quantity), 0) AS reserved").
+		Join("LEFT JOIN stock_reservations AS r ON r.sku = p.sku AND r.status = ?", domain.ReservationStatusReserved).
+		Group("p.sku", "p.stock")
+	if len(skus) > 0 {
+		query = query.Where("p.sku IN (?)", bun.In(skus))
+	}
+	if err := query.Scan(ctx, &records); err != nil {
+		return nil, fmt.Errorf("inventoryPersistenceRepository.GetStockLevels: %w", err)
+	}
+	levels := make([]domain.StockLevel, 0, len(records))
+	for _, record := range records {
+		levels = append(levels, record.ToDomainModel())
+	}
+	return levels, nil
+}
+
+func toDomainReservations(records []StockReservation) []domain.Reservation {
+	reservations := make([]domain.Reservation, 0, len(records))
+	for _, record := range records {
+		reservations = append(reservations, record.ToDomainModel())
+	}
+	return reservations
+}
@@ -9,13 +9,24 @@ import (
 
 type Order struct {
 	bun.BaseModel `bun:"orders"`
-	Id            xid.ID    `bun:",skipupdate,pk"`
-	TotalAmount   float64   `bun:"total_amount,notnull"`
-	CustomerId    string    `bun:"customer_id,notnull"`
-	CustomerName  string    `bun:"customer_name,notnull"`            // Added field for customer name
-	Status        string    `bun:"status,notnull,default:'PENDING'"` //
-	CreatedAt     time.Time `bun:",nullzero,notnull,default:current_timestamp,skipupdate"`
-	UpdatedAt     time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+	Id            xid.ID      `bun:",skipupdate,pk"`
+	TotalAmount   float64     `bun:"total_amount,notnull"`
+	CustomerId    string      `bun:"customer_id,notnull"`
+	CustomerName  string      `bun:"customer_name,notnull"`            // Added field for customer name
+	Status        string      `bun:"status,notnull,default:'PENDING'"` //
+	CreatedAt     time.Time   `bun:",nullzero,notnull,default:current_timestamp,skipupdate"`
+	UpdatedAt     time.Time   `bun:",nullzero,notnull,default:current_timestamp"`
+	Items         []OrderItem `bun:"rel:has-many,join:id=order_id"`
+}
+
+type OrderItem struct {
+	bun.BaseModel `bun:"order_items"`
+	OrderId       xid.ID    `bun:",pk"`
+	Sku           string    `bun:",pk"`
+	Name          string    `bun:"name,notnull"`
+	UnitPrice     float64   `bun:"unit_price,notnull"`
+	Quantity      int       `bun:"quantity,notnull"`
+	CreatedAt     time.Time `bun:",nullzero,notnull,default:current_timestamp"`
 }
 
 func (o Order) ToDomainModel() domain.Order {
@@ -24,6 +35,7 @@ func (o Order) ToDomainModel() domain.Order {
 		CustomerId:   o.CustomerId,
 		CustomerName: o.CustomerName,
 		TotalAmount:  o.TotalAmount,
+		Items:        toDomainItems(o.Items),
 		Status:       domain.OrderStatus(o.Status),
 		CreatedAt:    o.CreatedAt,
 		UpdatedAt:    o.UpdatedAt,
@@ -36,8 +48,36 @@ func FromDomainModel(dm domain.Order) Order {
 		CustomerId:   dm.CustomerId,
 		CustomerName: dm.CustomerName,
 		TotalAmount:  dm.TotalAmount,
+		Items:        fromDomainItems(dm.Id, dm.Items),
 		Status:       string(dm.Status),
 		CreatedAt:    dm.CreatedAt,
 		UpdatedAt:    dm.UpdatedAt,
 	}
 }
+
+func toDomainItems(items []OrderItem) []domain.OrderItem {
+	result := make([]domain.OrderItem, 0, len(items))
+	for _, item := range items {
+		result = append(result, domain.OrderItem{
+			Sku:       item.Sku,
+			Name:      item.Name,
+			UnitPrice: item.UnitPrice,
+			Quantity:  item.Quantity,
+		})
+	}
+	return result
+}
+
+func fromDomainItems(orderId xid.ID, items []domain.OrderItem) []OrderItem {
+	result := make([]OrderItem, 0, len(items))
+	for _, item := range items {
+		result = append(result, OrderItem{
+			OrderId:   orderId,
+			Sku:       item.Sku,
+			Name:      item.Name,
+			UnitPrice: item.UnitPrice,
+			Quantity:  item.Quantity,
+		})
+	}
+	return result
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"github.com/rs/xid"
+	"github.com/uptrace/bun"
 	domain "specommerce/orderservice/internal/core/domain/order"
 	"specommerce/orderservice/internal/core/ports/secondary"
 	"specommerce/orderservice/pkg/database"
 	"specommerce/orderservice/pkg/pagination"
+	"time"
 )
 
 type orderPersistenceRepository struct {
@@ -21,7 +23,7 @@ func NewOrderPersistenceRepository(dbFunc database.GetDbFunc) secondary.OrderRep
 }
 
 func (r *orderPersistenceRepository) GetAll(ctx context.Context) ([]domain.Order, error) {
-	orders, err := database.NewPostgresCrudDatabaseOperation[Order](r.getDbFunc).FindAll(ctx)
+	orders, err := database.NewPostgresCrudDatabaseOperation[Order](r.getDbFunc).FindAll(ctx, withItems)
 	entities := make([]domain.Order, 0, len(orders))
 	if err != nil {
 		return []domain.Order{}, fmt.Errorf("orderPersistenceRepository GetAllOrders %w", err)
@@ -32,10 +34,23 @@ func (r *orderPersistenceRepository) GetAll(ctx context.Context) ([]domain.Order
 	return entities, nil
 }
 
+func withItems(q *bun.SelectQuery) *bun.SelectQuery {
+	return q.Relation("Items")
+}
+
+// Create inserts the order together with its items, it must be called inside a transaction
 func (r *orderPersistenceRepository) Create(ctx context.Context, order domain.Order) (domain.Order, error) {
-	created, err := database.NewPostgresCrudDatabaseOperation[Order](r.getDbFunc).Create(ctx, FromDomainModel(order))
+	errTemplate := "orderPersistenceRepository CreateOrder %w"
+	record := FromDomainModel(order)
+	created, err := database.NewPostgresCrudDatabaseOperation[Order](r.getDbFunc).Create(ctx, record)
 	if err != nil {
-		return domain.Order{}, fmt.Errorf("orderPersistenceRepository CreateOrder %w", err)
+		return domain.Order{}, fmt.Errorf(errTemplate, err)
+	}
+	if len(record.Items) > 0 {
+		created.Items, err = database.NewPostgresCrudDatabaseOperation[OrderItem](r.getDbFunc).CreateAll(ctx, record.Items)
+		if err != nil {
+			return domain.Order{}, fmt.Errorf(errTemplate, err)
+		}
 	}
 	return created.ToDomainModel(), nil
 }
@@ -50,14 +65,43 @@ func (r *orderPersistenceRepository) UpdateStatusById(ctx context.Context, id xi
 	if err != nil {
 		return domain.Order{}, fmt.Errorf(errTemplate, err)
 	}
+	record.Items, err = database.NewPostgresCrudDatabaseOperation[OrderItem](r.getDbFunc).FindAll(ctx,
+		func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Where("order_id = ?", id)
+		},
+	)
+	if err != nil {
+		return domain.Order{}, fmt.Errorf(errTemplate, err)
+	}
 	return record.ToDomainModel(), nil
 }
 
+func (r *orderPersistenceRepository) FindStuck(ctx context.Context, statuses []domain.OrderStatus, createdBefore time.Time, limit int) ([]domain.Order, error) {
+	records, err := database.NewPostgresCrudDatabaseOperation[Order](r.getDbFunc).FindAll(ctx,
+		func(q *bun.SelectQuery) *bun.SelectQuery {
+			return withItems(q).
+				Where("?TableAlias.status IN (?)", bun.In(statuses)).
+				Where("?TableAlias.created_at < ?", createdBefore).
+				Order("created_at ASC").
+				Limit(limit)
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("orderPersistenceRepository.FindStuck: %w", err)
+	}
+	orders := make([]domain.Order, 0, len(records))
+	for _, record := range records {
+		orders = append(orders, record.ToDomainModel())
+	}
+	return orders, nil
+}
+
 func (r *orderPersistenceRepository) SearchOrders(ctx context.Context, filter secondary.SearchOrdersFilter) (pagination.Page[domain.Order], error) {
 	errTemplate := "orderPersistenceRepository.SearchOrders: %w"
 
 	records := make([]Order, 0)
 	query := r.getDbFunc(ctx).NewSelect().Model(&records).
+		Relation("Items").
 		Limit(filter.Limit()).Offset(filter.Offset()).
 		Order(filter.Sort.Strings()...)
 
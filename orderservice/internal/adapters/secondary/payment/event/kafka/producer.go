@@ -6,15 +6,16 @@ import (
 	"github.com/golang/protobuf/proto"
 	kafkaGo "github.com/segmentio/kafka-go"
 	"specommerce/orderservice/config"
+	"specommerce/orderservice/internal/core/domain/order"
 	domain "specommerce/orderservice/internal/core/domain/payment"
 	"specommerce/orderservice/internal/core/ports/secondary"
 	"specommerce/orderservice/model"
-	"specommerce/orderservice/pkg/messagequeue"
+	"specommerce/orderservice/pkg/outbox"
 )
 
 type paymentPublisher struct {
-	config    config.AppConfig
-	publisher messagequeue.Publisher
+	config config.AppConfig
+	outbox outbox.Writer
 }
 
 func (p *paymentPublisher) SendPaymentRequest(ctx context.Context, input domain.ProcessPaymentRequest) error {
@@ -25,22 +26,36 @@ func (p *paymentPublisher) SendPaymentRequest(ctx context.Context, input domain.
 		TotalAmount: input.TotalAmount,
 		CustomerId:  input.CustomerId,
 		TimeProcess: input.TimeProcess,
+		Items:       toOrderItems(input.Items),
 	})
 
 	if err != nil {
 		return fmt.Errorf(errTemplate, err)
 	}
 
-	return p.publisher.Publish(kafkaGo.Message{
+	return p.outbox.Write(ctx, kafkaGo.Message{
 		Topic: p.config.ProcessPaymentRequest.Topic,
 		Value: payload,
 		Key:   []byte(input.CustomerId),
 	})
 }
 
-func NewPaymentPublisher(config config.AppConfig, publisher messagequeue.Publisher) secondary.PaymentRepository {
+func NewPaymentPublisher(config config.AppConfig, outboxWriter outbox.Writer) secondary.PaymentRepository {
 	return &paymentPublisher{
-		config:    config,
-		publisher: publisher,
+		config: config,
+		outbox: outboxWriter,
 	}
 }
+
+func toOrderItems(items []order.OrderItem) []*model.OrderItem {
+	result := make([]*model.OrderItem, 0, len(items))
+	for _, item := range items {
+		result = append(result, &model.OrderItem{
+			Sku:       item.Sku,
+			Name:      item.Name,
+			UnitPrice: item.UnitPrice,
+			Quantity:  int32(item.Quantity),
+		})
+	}
+	return result
+}
@@ -0,0 +1,80 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/rs/xid"
+	"net/http"
+	"net/url"
+	domain "specommerce/orderservice/internal/core/domain/payment"
+	"specommerce/orderservice/internal/core/ports/secondary"
+	"specommerce/orderservice/pkg/service_config"
+	"specommerce/orderservice/pkg/sharedto/handler"
+)
+
+type paymentClient struct {
+	config service_config.PaymentServiceConfig
+	client *http.Client
+}
+
+func NewPaymentClient(cfg service_config.PaymentServiceConfig) secondary.PaymentStatusRepository {
+	return &paymentClient{
+		config: cfg,
+		client: &http.Client{Timeout: cfg.Timeout},
+	}
+}
+
+// GetByOrderId calls GET /admin/v1/payments?order_id= of the payment service
+func (c *paymentClient) GetByOrderId(ctx context.Context, orderId xid.ID) ([]domain.Payment, error) {
+	errTemplate := "paymentClient GetByOrderId %w"
+	endpoint := c.config.BaseUrl + "/admin/v1/payments?" + url.Values{"order_id": {orderId.String()}}.Encode()
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf(errTemplate, err)
+	}
+	response, err := c.client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf(errTemplate, err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf(errTemplate, fmt.Errorf("unexpected status %s", response.Status))
+	}
+	var body handler.BaseResponse[[]domain.Payment]
+	if err = json.NewDecoder(response.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf(errTemplate, err)
+	}
+	return body.Data, nil
+}
+
+// Cancel calls POST /admin/v1/payments/cancellations of the payment service
+func (c *paymentClient) Cancel(ctx context.Context, orderId xid.ID) (domain.ClaimStatus, error) {
+	errTemplate := "paymentClient Cancel %w"
+	payload, err := json.Marshal(map[string]string{"order_id": orderId.String()})
+	if err != nil {
+		return "", fmt.Errorf(errTemplate, err)
+	}
+	endpoint := c.config.BaseUrl + "/admin/v1/payments/cancellations"
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return "", fmt.Errorf(errTemplate, err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+	response, err := c.client.Do(request)
+	if err != nil {
+		return "", fmt.Errorf(errTemplate, err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return "", fmt.Errorf(errTemplate, fmt.Errorf("unexpected status %s", response.Status))
+	}
+	var body handler.BaseResponse[struct {
+		Status domain.ClaimStatus `json:"status"`
+	}]
+	if err = json.NewDecoder(response.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf(errTemplate, err)
+	}
+	return body.Data.Status, nil
+}
@@ -0,0 +1,46 @@
+package postgres
+
+import (
+	"github.com/rs/xid"
+	"github.com/uptrace/bun"
+	domain "specommerce/orderservice/internal/core/domain/product"
+	"time"
+)
+
+type Product struct {
+	bun.BaseModel `bun:"products"`
+	Id            xid.ID    `bun:",skipupdate,pk"`
+	Sku           string    `bun:"sku,notnull"`
+	Name          string    `bun:"name,notnull"`
+	Price         float64   `bun:"price,notnull"`
+	Stock         int       `bun:"stock,notnull"`
+	Active        bool      `bun:"active,notnull"`
+	CreatedAt     time.Time `bun:",nullzero,notnull,default:current_timestamp,skipupdate"`
+	UpdatedAt     time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+}
+
+func (p Product) ToDomainModel() domain.Product {
+	return domain.Product{
+		Id:        p.Id,
+		Sku:       p.Sku,
+		Name:      p.Name,
+		Price:     p.Price,
+		Stock:     p.Stock,
+		Active:    p.Active,
+		CreatedAt: p.CreatedAt,
+		UpdatedAt: p.UpdatedAt,
+	}
+}
+
+func FromDomainModel(dm domain.Product) Product {
+	return Product{
+		Id:        dm.Id,
+		Sku:       dm.Sku,
+		Name:      dm.Name,
+		Price:     dm.Price,
+		Stock:     dm.Stock,
+		Active:    dm.Active,
+		CreatedAt: dm.CreatedAt,
+		UpdatedAt: dm.UpdatedAt,
+	}
+}
@@ -0,0 +1,127 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"github.com/rs/xid"
+	"github.com/uptrace/bun"
+	domain "specommerce/orderservice/internal/core/domain/product"
+	"specommerce/orderservice/internal/core/ports/secondary"
+	"specommerce/orderservice/pkg/database"
+	"specommerce/orderservice/pkg/pagination"
+)
+
+type productPersistenceRepository struct {
+	getDbFunc database.GetDbFunc
+}
+
+func NewProductPersistenceRepository(dbFunc database.GetDbFunc) secondary.ProductRepository {
+	return &productPersistenceRepository{
+		getDbFunc: dbFunc,
+	}
+}
+
+func (r *productPersistenceRepository) Create(ctx context.Context, product domain.Product) (domain.Product, error) {
+	created, err := database.NewPostgresCrudDatabaseOperation[Product](r.getDbFunc).Create(ctx, FromDomainModel(product))
+	if err != nil {
+		return domain.Product{}, fmt.Errorf("productPersistenceRepository.Create: %w", err)
+	}
+	return created.ToDomainModel(), nil
+}
+
+func (r *productPersistenceRepository) Update(ctx context.Context, product domain.Product) (domain.Product, error) {
+	errTemplate := "productPersistenceRepository.Update: %w"
+	record := FromDomainModel(product)
+	res, err := r.getDbFunc(ctx).NewUpdate().Model(&record).
+		Column("sku", "name", "price", "stock", "active").
+		WherePK().
+		Returning("*").Exec(ctx)
+	if err != nil {
+		return domain.Product{}, fmt.Errorf(errTemplate, err)
+	}
+	updated, err := res.RowsAffected()
+	if err != nil {
+		return domain.Product{}, fmt.Errorf(errTemplate, err)
+	}
+	if updated == 0 {
+		return domain.Product{}, fmt.Errorf(errTemplate, database.ErrRecordNotFound)
+	}
+	return record.ToDomainModel(), nil
+}
+
+func (r *productPersistenceRepository) DeleteById(ctx context.Context, id xid.ID) error {
+	errTemplate := "productPersistenceRepository.DeleteById: %w"
+	res, err := r.getDbFunc(ctx).NewDelete().Model((*Product)(nil)).
+		Where("id = ?", id).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf(errTemplate, err)
+	}
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf(errTemplate, err)
+	}
+	if deleted == 0 {
+		return fmt.Errorf(errTemplate, database.ErrRecordNotFound)
+	}
+	return nil
+}
+
+func (r *productPersistenceRepository) GetById(ctx context.Context, id xid.ID) (domain.Product, error) {
+	record, err := database.NewPostgresCrudDatabaseOperation[Product](r.getDbFunc).FindById(ctx, id)
+	if err != nil {
+		return domain.Product{}, fmt.Errorf("productPersistenceRepository.GetById: %w", err)
+	}
+	return record.ToDomainModel(), nil
+}
+
+func (r *productPersistenceRepository) GetBySkus(ctx context.Context, skus []string) ([]domain.Product, error) {
+	records, err := database.NewPostgresCrudDatabaseOperation[Product](r.getDbFunc).FindAll(ctx,
+		func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Where("sku IN (?)", bun.In(skus))
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("productPersistenceRepository.GetBySkus: %w", err)
+	}
+	products := make([]domain.Product, 0, len(records))
+	for _, record := range records {
+		products = append(products, record.ToDomainModel())
+	}
+	return products, nil
+}
+
+func (r *productPersistenceRepository) SearchProducts(ctx context.Context, filter secondary.SearchProductsFilter) (pagination.Page[domain.Product], error) {
+	errTemplate := "productPersistenceRepository.SearchProducts: %w"
+
+	records := make([]Product, 0)
+	query := r.getDbFunc(ctx).NewSelect().Model(&records).
+		Limit(filter.Limit()).Offset(filter.Offset()).
+		Order(filter.Sort.Strings()...)
+	if filter.Sku != "" {
+		query = query.Where("sku = ?", filter.Sku)
+	}
+	if filter.Active != nil {
+		query = query.Where("active = ?", *filter.Active)
+	}
+
+	count, err := query.ScanAndCount(ctx)
+	if err != nil {
+		return pagination.Page[domain.Product]{}, fmt.Errorf(errTemplate, err)
+	}
+
+	products := make([]domain.Product, 0, len(records))
+	for _, record := range records {
+		products = append(products, record.ToDomainModel())
+	}
+
+	return pagination.Page[domain.Product]{
+		Data: products,
+		Metadata: pagination.MetaData{
+			Total:      count,
+			PageSize:   filter.Size,
+			PageNumber: filter.Number,
+			TotalPages: filter.TotalPages(count),
+		},
+	}, nil
+}
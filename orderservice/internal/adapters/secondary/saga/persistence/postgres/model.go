@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"encoding/json"
+	"github.com/rs/xid"
+	"github.com/uptrace/bun"
+	domain "specommerce/orderservice/internal/core/domain/saga"
+	"time"
+)
+
+type Saga struct {
+	bun.BaseModel `bun:"sagas"`
+	Id            xid.ID          `bun:",skipupdate,pk"`
+	Type          string          `bun:"type,notnull,skipupdate"`
+	OrderId       xid.ID          `bun:"order_id,notnull,skipupdate"`
+	Status        string          `bun:"status,notnull"`
+	CurrentStep   int             `bun:"current_step,notnull"`
+	Payload       json.RawMessage `bun:"payload,type:jsonb,notnull"`
+	LastError     string          `bun:"last_error"`
+	FailureReason string          `bun:"failure_reason,notnull"`
+	CreatedAt     time.Time       `bun:",nullzero,notnull,default:current_timestamp,skipupdate"`
+	UpdatedAt     time.Time       `bun:",nullzero,notnull,default:current_timestamp"`
+}
+
+func (s Saga) ToDomainModel() domain.Saga {
+	return domain.Saga{
+		Id:            s.Id,
+		Type:          domain.SagaType(s.Type),
+		OrderId:       s.OrderId,
+		Status:        domain.SagaStatus(s.Status),
+		CurrentStep:   s.CurrentStep,
+		Payload:       s.Payload,
+		LastError:     s.LastError,
+		FailureReason: s.FailureReason,
+		CreatedAt:     s.CreatedAt,
+		UpdatedAt:     s.UpdatedAt,
+	}
+}
+
+func FromDomainModel(dm domain.Saga) Saga {
+	return Saga{
+		Id:            dm.Id,
+		Type:          string(dm.Type),
+		OrderId:       dm.OrderId,
+		Status:        string(dm.Status),
+		CurrentStep:   dm.CurrentStep,
+		Payload:       dm.Payload,
+		LastError:     dm.LastError,
+		FailureReason: dm.FailureReason,
+		CreatedAt:     dm.CreatedAt,
+		UpdatedAt:     dm.UpdatedAt,
+	}
+}
+
+type SagaStep struct {
+	bun.BaseModel `bun:"saga_steps"`
+	Id            int64     `bun:",pk,autoincrement"`
+	SagaId        xid.ID    `bun:"saga_id,notnull"`
+	Step          string    `bun:"step,notnull"`
+	Action        string    `bun:"action,notnull"`
+	Error         string    `bun:"error"`
+	CreatedAt     time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+}
+
+func (s SagaStep) ToDomainModel() domain.StepLog {
+	return domain.StepLog{
+		Id:        s.Id,
+		SagaId:    s.SagaId,
+		Step:      s.Step,
+		Action:    domain.StepAction(s.Action),
+		Error:     s.Error,
+		CreatedAt: s.CreatedAt,
+	}
+}
+
+func FromStepLog(dm domain.StepLog) SagaStep {
+	return SagaStep{
+		Id:        dm.Id,
+		SagaId:    dm.SagaId,
+		Step:      dm.Step,
+		Action:    string(dm.Action),
+		Error:     dm.Error,
+		CreatedAt: dm.CreatedAt,
+	}
+}
@@ -0,0 +1,154 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"github.com/rs/xid"
+	"github.com/uptrace/bun"
+	domain "specommerce/orderservice/internal/core/domain/saga"
+	"specommerce/orderservice/internal/core/ports/secondary"
+	"specommerce/orderservice/pkg/database"
+	"specommerce/orderservice/pkg/pagination"
+	"time"
+)
+
+type sagaPersistenceRepository struct {
+	getDbFunc database.GetDbFunc
+}
+
+func NewSagaPersistenceRepository(dbFunc database.GetDbFunc) secondary.SagaRepository {
+	return &sagaPersistenceRepository{
+		getDbFunc: dbFunc,
+	}
+}
+
+func (r *sagaPersistenceRepository) Create(ctx context.Context, saga domain.Saga) (domain.Saga, error) {
+	created, err := database.NewPostgresCrudDatabaseOperation[Saga](r.getDbFunc).Create(ctx, FromDomainModel(saga))
+	if err != nil {
+		return domain.Saga{}, fmt.Errorf("sagaPersistenceRepository Create %w", err)
+	}
+	return created.ToDomainModel(), nil
+}
+
+func (r *sagaPersistenceRepository) Update(ctx context.Context, saga domain.Saga) (domain.Saga, error) {
+	errTemplate := "sagaPersistenceRepository.Update: %w"
+	record := FromDomainModel(saga)
+	_, err := r.getDbFunc(ctx).NewUpdate().Model(&record).
+		Column("status", "current_step", "payload", "last_error").
+		WherePK().
+		Returning("*").Exec(ctx)
+	if err != nil {
+		return domain.Saga{}, fmt.Errorf(errTemplate, err)
+	}
+	return record.ToDomainModel(), nil
+}
+
+func (r *sagaPersistenceRepository) GetById(ctx context.Context, id xid.ID) (domain.Saga, error) {
+	record, err := database.NewPostgresCrudDatabaseOperation[Saga](r.getDbFunc).FindById(ctx, id)
+	if err != nil {
+		return domain.Saga{}, fmt.Errorf("sagaPersistenceRepository.GetById: %w", err)
+	}
+	return record.ToDomainModel(), nil
+}
+
+func (r *sagaPersistenceRepository) LockById(ctx context.Context, id xid.ID) (domain.Saga, error) {
+	record, err := database.NewPostgresCrudDatabaseOperation[Saga](r.getDbFunc).FindById(ctx, id,
+		func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.For("UPDATE")
+		},
+	)
+	if err != nil {
+		return domain.Saga{}, fmt.Errorf("sagaPersistenceRepository.LockById: %w", err)
+	}
+	return record.ToDomainModel(), nil
+}
+
+func (r *sagaPersistenceRepository) GetByOrderId(ctx context.Context, sagaType domain.SagaType, orderId xid.ID) (domain.Saga, error) {
+	record, err := database.NewPostgresCrudDatabaseOperation[Saga](r.getDbFunc).Get(ctx,
+		func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Where("type = ?", sagaType).Where("order_id = ?", orderId)
+		},
+	)
+	if err != nil {
+		return domain.Saga{}, fmt.Errorf("sagaPersistenceRepository.GetByOrderId: %w", err)
+	}
+	return record.ToDomainModel(), nil
+}
+
+func (r *sagaPersistenceRepository) FindStale(ctx context.Context, statuses []domain.SagaStatus, updatedBefore time.Time, limit int) ([]domain.Saga, error) {
+	records, err := database.NewPostgresCrudDatabaseOperation[Saga](r.getDbFunc).FindAll(ctx,
+		func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Where("status IN (?)", bun.In(statuses)).
+				Where("updated_at < ?", updatedBefore).
+				Order("updated_at ASC").
+				Limit(limit)
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("sagaPersistenceRepository.FindStale: %w", err)
+	}
+	sagas := make([]domain.Saga, 0, len(records))
+	for _, record := range records {
+		sagas = append(sagas, record.ToDomainModel())
+	}
+	return sagas, nil
+}
+
+func (r *sagaPersistenceRepository) SearchSagas(ctx context.Context, filter secondary.SearchSagasFilter) (pagination.Page[domain.Saga], error) {
+	errTemplate := "sagaPersistenceRepository.SearchSagas: %w"
+
+	records := make([]Saga, 0)
+	query := r.getDbFunc(ctx).NewSelect().Model(&records).
+		Limit(filter.Limit()).Offset(filter.Offset()).
+		Order(filter.Sort.Strings()...)
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.OrderId != "" {
+		query = query.Where("order_id = ?", filter.OrderId)
+	}
+
+	count, err := query.ScanAndCount(ctx)
+	if err != nil {
+		return pagination.Page[domain.Saga]{}, fmt.Errorf(errTemplate, err)
+	}
+
+	sagas := make([]domain.Saga, 0, len(records))
+	for _, record := range records {
+		sagas = append(sagas, record.ToDomainModel())
+	}
+
+	return pagination.Page[domain.Saga]{
+		Data: sagas,
+		Metadata: pagination.MetaData{
+			Total:      count,
+			PageSize:   filter.Size,
+			PageNumber: filter.Number,
+			TotalPages: filter.TotalPages(count),
+		},
+	}, nil
+}
+
+func (r *sagaPersistenceRepository) AddStepLog(ctx context.Context, log domain.StepLog) error {
+	_, err := database.NewPostgresCrudDatabaseOperation[SagaStep](r.getDbFunc).Create(ctx, FromStepLog(log))
+	if err != nil {
+		return fmt.Errorf("sagaPersistenceRepository.AddStepLog: %w", err)
+	}
+	return nil
+}
+
+func (r *sagaPersistenceRepository) GetStepLogs(ctx context.Context, sagaId xid.ID) ([]domain.StepLog, error) {
+	records, err := database.NewPostgresCrudDatabaseOperation[SagaStep](r.getDbFunc).FindAll(ctx,
+		func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Where("saga_id = ?", sagaId).Order("id ASC")
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("sagaPersistenceRepository.GetStepLogs: %w", err)
+	}
+	logs := make([]domain.StepLog, 0, len(records))
+	for _, record := range records {
+		logs = append(logs, record.ToDomainModel())
+	}
+	return logs, nil
+}
@@ -0,0 +1,32 @@
+package idempotency
+
+import (
+	"encoding/json"
+	"errors"
+	"time"
+)
+
+var (
+	ErrKeyReused         = errors.New("idempotency key was already used with a different request")
+	ErrRequestInProgress = errors.New("a request with the same idempotency key is still in progress")
+	ErrLeaseLost         = errors.New("idempotency key lease expired and was taken over by another request")
+)
+
+// Record remembers the response of a request sent with an Idempotency-Key header.
+// A key is scoped by the customer that sent it. StatusCode is 0 while the first request is still in progress,
+// ExpiresAt is then the end of its lease, and the end of the retention of the response once the request completed.
+// Token identifies the request holding the lease, only that request may complete or release the key.
+type Record struct {
+	CustomerId  string          `json:"customer_id"`
+	Key         string          `json:"key"`
+	Token       string          `json:"token"`
+	RequestHash string          `json:"request_hash"`
+	StatusCode  int             `json:"status_code"`
+	Response    json.RawMessage `json:"response"`
+	CreatedAt   time.Time       `json:"created_at"`
+	ExpiresAt   time.Time       `json:"expires_at"`
+}
+
+func (r Record) Completed() bool {
+	return r.StatusCode != 0
+}
@@ -0,0 +1,46 @@
+package inventory
+
+import (
+	"errors"
+	"time"
+
+	"github.com/rs/xid"
+)
+
+var ErrOutOfStock = errors.New("insufficient stock")
+
+type ReservationStatus string
+
+const (
+	ReservationStatusReserved  ReservationStatus = "RESERVED"
+	ReservationStatusConfirmed ReservationStatus = "CONFIRMED"
+	ReservationStatusReleased  ReservationStatus = "RELEASED"
+)
+
+// Reservation holds stock of a product for an order until it is paid.
+// A confirmed reservation is deducted from the product stock, a released one is returned to the available stock.
+type Reservation struct {
+	OrderId   xid.ID            `json:"order_id"`
+	Sku       string            `json:"sku"`
+	Quantity  int               `json:"quantity"`
+	Status    ReservationStatus `json:"status"`
+	ExpiresAt time.Time         `json:"expires_at"`
+	CreatedAt time.Time         `json:"created_at"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// StockLevel is the stock of a product in Postgres together with the quantity still reserved
+type StockLevel struct {
+	Sku      string `json:"sku"`
+	Stock    int    `json:"stock"`
+	Reserved int    `json:"reserved"`
+}
+
+// Available is the stock that can still be reserved, it is what the Redis counter of the product should hold
+func (l StockLevel) Available() int {
+	return l.Stock - l.Reserved
+}
+
+func (s ReservationStatus) String() string {
+	return string(s)
+}
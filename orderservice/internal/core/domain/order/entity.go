@@ -1,11 +1,30 @@
 package order
 
 import (
+	"errors"
+	"math"
 	"time"
 
 	"github.com/rs/xid"
 )
 
+// ErrPlacementCompensated is returned for an order whose placement saga failed and was fully compensated,
+// nothing of the order is left behind
+var ErrPlacementCompensated = errors.New("order placement was compensated")
+
+var (
+	ErrQuantityTooLarge    = errors.New("item quantity exceeds the maximum")
+	ErrTotalAmountTooLarge = errors.New("order total amount exceeds the maximum")
+)
+
+const (
+	// MaxItemQuantity bounds the quantity of a SKU in an order, duplicate lines included,
+	// well within the int32 quantity of the order events
+	MaxItemQuantity = 10_000
+	// MaxTotalAmount is the largest total stored by the decimal(10, 2) total_amount column
+	MaxTotalAmount = 99_999_999.99
+)
+
 type OrderStatus string
 
 const (
@@ -24,11 +43,34 @@ type Order struct {
 	CustomerId   string      `json:"customer_id" bun:"customer_id"`
 	CustomerName string      `json:"customer_name" bun:"customer_name,notnull"` // Added field for customer name
 	TotalAmount  float64     `json:"total_amount" bun:"total_amount"`
+	Items        []OrderItem `json:"items" bun:"-"`
 	Status       OrderStatus `json:"status" bun:"status"`
 	CreatedAt    time.Time   `json:"created_at" bun:",nullzero,notnull,default:current_timestamp,skipupdate"`
 	UpdatedAt    time.Time   `json:"updated_at" bun:",nullzero,notnull,default:current_timestamp"`
 }
 
+// OrderItem is a line of an order, Name and UnitPrice are copied from the product catalog when the order is placed
+type OrderItem struct {
+	Sku       string  `json:"sku"`
+	Name      string  `json:"name"`
+	UnitPrice float64 `json:"unit_price"`
+	Quantity  int     `json:"quantity"`
+}
+
+// Subtotal returns the amount of the line rounded to cents
+func (i OrderItem) Subtotal() float64 {
+	return math.Round(i.UnitPrice*100) * float64(i.Quantity) / 100
+}
+
+// CalculateTotal sums up the subtotals of the items
+func CalculateTotal(items []OrderItem) float64 {
+	var cents float64
+	for _, item := range items {
+		cents += math.Round(item.UnitPrice*100) * float64(item.Quantity)
+	}
+	return cents / 100
+}
+
 func (s OrderStatus) String() string {
 	return string(s)
 }
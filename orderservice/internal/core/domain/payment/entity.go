@@ -1,6 +1,11 @@
 package payment
 
-import "github.com/rs/xid"
+import (
+	"specommerce/orderservice/internal/core/domain/order"
+	"time"
+
+	"github.com/rs/xid"
+)
 
 type PaymentStatus string
 
@@ -9,14 +14,41 @@ const (
 	PaymentStatusFailed  PaymentStatus = "FAILED"
 )
 
+// DeclineReasonReservationExpired fails an order whose stock reservation expired before the payment response arrived
+const DeclineReasonReservationExpired = "RESERVATION_EXPIRED"
+
+// DeclineReasonTimeout fails a stuck order that the payment service never processed
+const DeclineReasonTimeout = "TIMEOUT"
+
 type ProcessPaymentRequest struct {
-	OrderId     xid.ID  `json:"order_id" validate:"required"`
-	CustomerId  string  `json:"customer_id" validate:"required"`
-	TotalAmount float64 `json:"total_amount" validate:"required,gt=0"`
-	TimeProcess int64   `json:"time_process" validate:"required"`
+	OrderId     xid.ID            `json:"order_id" validate:"required"`
+	CustomerId  string            `json:"customer_id" validate:"required"`
+	TotalAmount float64           `json:"total_amount" validate:"required,gt=0"`
+	TimeProcess int64             `json:"time_process" validate:"required"`
+	Items       []order.OrderItem `json:"items"`
+}
+
+// Payment is a payment attempt of an order, as known by the payment service
+type Payment struct {
+	Id            xid.ID        `json:"id"`
+	OrderId       xid.ID        `json:"order_id"`
+	Status        PaymentStatus `json:"status"`
+	DeclineReason string        `json:"decline_reason"`
+	CreatedAt     time.Time     `json:"created_at"`
 }
 
+// ClaimStatus is the state of the claim an order holds on the payment service. A CANCELLED claim rejects the
+// payment request of the order, PROCESSING and COMPLETED mean a payment request claimed the order first
+type ClaimStatus string
+
+const (
+	ClaimStatusProcessing ClaimStatus = "PROCESSING"
+	ClaimStatusCompleted  ClaimStatus = "COMPLETED"
+	ClaimStatusCancelled  ClaimStatus = "CANCELLED"
+)
+
 type ProcessPaymentResponse struct {
 	OrderId       xid.ID        `json:"order_id" validate:"required"`
 	PaymentStatus PaymentStatus `json:"payment_status" validate:"required"`
+	DeclineReason string        `json:"decline_reason"`
 }
@@ -0,0 +1,24 @@
+package product
+
+import (
+	"errors"
+	"time"
+
+	"github.com/rs/xid"
+)
+
+var (
+	ErrUnknownSku      = errors.New("unknown product sku")
+	ErrProductInactive = errors.New("product is not available for sale")
+)
+
+type Product struct {
+	Id        xid.ID    `json:"id"`
+	Sku       string    `json:"sku"`
+	Name      string    `json:"name"`
+	Price     float64   `json:"price"`
+	Stock     int       `json:"stock"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
@@ -0,0 +1,84 @@
+package saga
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/rs/xid"
+)
+
+type SagaStatus string
+
+const (
+	SagaStatusRunning      SagaStatus = "RUNNING"
+	SagaStatusWaiting      SagaStatus = "WAITING" // Parked until an external event (e.g. the payment response) resumes it
+	SagaStatusCompleted    SagaStatus = "COMPLETED"
+	SagaStatusCompensating SagaStatus = "COMPENSATING"
+	SagaStatusCompensated  SagaStatus = "COMPENSATED"
+)
+
+type SagaType string
+
+const (
+	SagaTypeOrderPlacement SagaType = "ORDER_PLACEMENT"
+)
+
+type StepAction string
+
+const (
+	StepActionExecute    StepAction = "EXECUTE"
+	StepActionCompensate StepAction = "COMPENSATE"
+)
+
+// Saga is the persisted state of a long-running workflow.
+// CurrentStep is the index of the next step to execute, or while compensating, one past the next step to undo.
+// FailureReason is the code of the business failure of the step that failed the saga, empty for other errors.
+type Saga struct {
+	Id            xid.ID          `json:"id"`
+	Type          SagaType        `json:"type"`
+	OrderId       xid.ID          `json:"order_id"`
+	Status        SagaStatus      `json:"status"`
+	CurrentStep   int             `json:"current_step"`
+	Payload       json.RawMessage `json:"payload"`
+	LastError     string          `json:"last_error"`
+	FailureReason string          `json:"failure_reason"`
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+}
+
+// StepLog records every step executed or compensated by a saga
+type StepLog struct {
+	Id        int64      `json:"id"`
+	SagaId    xid.ID     `json:"saga_id"`
+	Step      string     `json:"step"`
+	Action    StepAction `json:"action"`
+	Error     string     `json:"error"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func New(sagaType SagaType, orderId xid.ID, payload any) (Saga, error) {
+	s := Saga{
+		Id:      xid.New(),
+		Type:    sagaType,
+		OrderId: orderId,
+		Status:  SagaStatusRunning,
+	}
+	return s, s.EncodePayload(payload)
+}
+
+func (s *Saga) DecodePayload(payload any) error {
+	return json.Unmarshal(s.Payload, payload)
+}
+
+func (s *Saga) EncodePayload(payload any) error {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	s.Payload = raw
+	return nil
+}
+
+func (s SagaStatus) String() string {
+	return string(s)
+}
@@ -0,0 +1,22 @@
+package primary
+
+import (
+	"context"
+	"specommerce/orderservice/internal/core/domain/idempotency"
+)
+
+// IdempotencyService defines the primary port for replaying requests sent with an Idempotency-Key header
+type IdempotencyService interface {
+	// Begin reserves the key of the customer for a request. It returns the completed record to replay when the
+	// key was already used with the same request, ErrKeyReused for a different request and ErrRequestInProgress
+	// while the first request has not completed yet. The key is leased to the request: a request that
+	// neither completes nor releases it, e.g. on a crash, frees it once the lease expires.
+	Begin(ctx context.Context, customerId string, key string, requestHash string) (idempotency.Record, bool, error)
+	// KeepAlive extends the lease of the reserved record until the returned stop function is called,
+	// so a request lasting longer than the lease keeps its key
+	KeepAlive(ctx context.Context, record idempotency.Record) (stop func())
+	// Complete records the response replayed to the retries of the request until the TTL expires
+	Complete(ctx context.Context, record idempotency.Record, statusCode int, response []byte) error
+	// Release frees the key of a request that changed nothing so that the client can retry it
+	Release(ctx context.Context, record idempotency.Record) error
+}
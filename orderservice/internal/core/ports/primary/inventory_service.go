@@ -0,0 +1,19 @@
+package primary
+
+import (
+	"context"
+	"github.com/rs/xid"
+	"specommerce/orderservice/internal/core/domain/order"
+)
+
+// InventoryService defines the primary port for reserving stock of orders
+type InventoryService interface {
+	// Reserve holds the stock of all items of the order or none of them, it returns inventory.ErrOutOfStock
+	Reserve(ctx context.Context, orderId xid.ID, items []order.OrderItem) error
+	Confirm(ctx context.Context, orderId xid.ID) error
+	Release(ctx context.Context, orderId xid.ID) error
+	// AdjustStock applies a change of the product stock made by an admin to the available stock
+	AdjustStock(ctx context.Context, sku string, delta int) error
+	// ResetStock drops the available stock of the product, it is loaded again from Postgres on the next reservation
+	ResetStock(ctx context.Context, sku string) error
+}
@@ -2,6 +2,7 @@ package primary
 
 import (
 	"context"
+	"github.com/rs/xid"
 	"specommerce/orderservice/internal/core/domain/order"
 	"specommerce/orderservice/internal/core/domain/payment"
 	"specommerce/orderservice/internal/core/ports/secondary"
@@ -12,6 +13,9 @@ import (
 type OrderService interface {
 	CreateOrder(ctx context.Context, order order.CreateOrderRequest) (order.Order, error)
 	ProcessPaymentResponse(ctx context.Context, request payment.ProcessPaymentResponse) (order.Order, error)
+	// ExpirePayment resolves an order whose saga waits for a payment response that did not arrive in time,
+	// it returns false when the order is left waiting
+	ExpirePayment(ctx context.Context, orderId xid.ID, reason string) (payment.ProcessPaymentResponse, bool, error)
 	GetAllOrders(ctx context.Context) ([]order.Order, error)
 	SearchOrders(ctx context.Context, filter secondary.SearchOrdersFilter) (pagination.Page[order.Order], error)
 }
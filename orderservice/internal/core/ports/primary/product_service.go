@@ -0,0 +1,18 @@
+package primary
+
+import (
+	"context"
+	"github.com/rs/xid"
+	"specommerce/orderservice/internal/core/domain/product"
+	"specommerce/orderservice/internal/core/ports/secondary"
+	"specommerce/orderservice/pkg/pagination"
+)
+
+// ProductService defines the primary port for managing the product catalog
+type ProductService interface {
+	CreateProduct(ctx context.Context, product product.Product) (product.Product, error)
+	UpdateProduct(ctx context.Context, product product.Product) (product.Product, error)
+	DeleteProduct(ctx context.Context, id xid.ID) error
+	GetProduct(ctx context.Context, id xid.ID) (product.Product, error)
+	SearchProducts(ctx context.Context, filter secondary.SearchProductsFilter) (pagination.Page[product.Product], error)
+}
@@ -0,0 +1,15 @@
+package primary
+
+import (
+	"context"
+	"github.com/rs/xid"
+	"specommerce/orderservice/internal/core/domain/saga"
+	"specommerce/orderservice/internal/core/ports/secondary"
+	"specommerce/orderservice/pkg/pagination"
+)
+
+// SagaService defines the primary port for inspecting saga state
+type SagaService interface {
+	GetSaga(ctx context.Context, id xid.ID) (saga.Saga, []saga.StepLog, error)
+	SearchSagas(ctx context.Context, filter secondary.SearchSagasFilter) (pagination.Page[saga.Saga], error)
+}
@@ -0,0 +1,21 @@
+package secondary
+
+import (
+	"context"
+	"specommerce/orderservice/internal/core/domain/idempotency"
+	"time"
+)
+
+// IdempotencyRepository defines the secondary port for idempotency key persistence
+type IdempotencyRepository interface {
+	// Reserve stores the record unless an unexpired record with the same customer and key exists.
+	// It returns the stored record and whether this call reserved the key.
+	Reserve(ctx context.Context, record idempotency.Record) (idempotency.Record, bool, error)
+	// Extend moves the end of the lease of the request of the record, still in progress, to expiresAt.
+	// Extend, Complete and Delete return ErrLeaseLost once another request took the key over.
+	Extend(ctx context.Context, record idempotency.Record, expiresAt time.Time) error
+	// Complete records the response of the request and keeps it until expiresAt
+	Complete(ctx context.Context, record idempotency.Record, statusCode int, response []byte, expiresAt time.Time) error
+	Delete(ctx context.Context, record idempotency.Record) error
+	DeleteExpired(ctx context.Context, before time.Time) (int, error)
+}
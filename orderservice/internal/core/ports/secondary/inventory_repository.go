@@ -0,0 +1,20 @@
+package secondary
+
+import (
+	"context"
+	"github.com/rs/xid"
+	"specommerce/orderservice/internal/core/domain/inventory"
+	"time"
+)
+
+// InventoryRepository defines the secondary port for stock reservation persistence
+type InventoryRepository interface {
+	CreateReservations(ctx context.Context, reservations []inventory.Reservation) error
+	// ConfirmReservations marks the reserved stock of the order confirmed and deducts it from the product stock
+	ConfirmReservations(ctx context.Context, orderId xid.ID) ([]inventory.Reservation, error)
+	// ReleaseReservations marks the reserved stock of the order released
+	ReleaseReservations(ctx context.Context, orderId xid.ID) ([]inventory.Reservation, error)
+	FindExpiredReservations(ctx context.Context, before time.Time, limit int) ([]inventory.Reservation, error)
+	// GetStockLevels returns the stock levels of the given SKUs, or of all products when skus is empty
+	GetStockLevels(ctx context.Context, skus []string) ([]inventory.StockLevel, error)
+}
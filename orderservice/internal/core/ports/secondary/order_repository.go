@@ -5,6 +5,7 @@ import (
 	"github.com/rs/xid"
 	"specommerce/orderservice/internal/core/domain/order"
 	"specommerce/orderservice/pkg/pagination"
+	"time"
 )
 
 // SearchOrdersFilter represents the filter for searching orders
@@ -18,4 +19,6 @@ type OrderRepository interface {
 	GetAll(ctx context.Context) ([]order.Order, error)
 	UpdateStatusById(ctx context.Context, id xid.ID, status order.OrderStatus) (order.Order, error)
 	SearchOrders(ctx context.Context, filter SearchOrdersFilter) (pagination.Page[order.Order], error)
+	// FindStuck returns the orders in one of statuses created before createdBefore, oldest first
+	FindStuck(ctx context.Context, statuses []order.OrderStatus, createdBefore time.Time, limit int) ([]order.Order, error)
 }
@@ -0,0 +1,15 @@
+package secondary
+
+import (
+	"context"
+	"github.com/rs/xid"
+	domain "specommerce/orderservice/internal/core/domain/payment"
+)
+
+// PaymentStatusRepository reads the payments known by the payment service
+type PaymentStatusRepository interface {
+	// GetByOrderId returns the payment attempts of an order, oldest first, none when the payment service never processed it
+	GetByOrderId(ctx context.Context, orderId xid.ID) ([]domain.Payment, error)
+	// Cancel cancels the payment of an order unless a payment request claimed it first, and returns the claim status
+	Cancel(ctx context.Context, orderId xid.ID) (domain.ClaimStatus, error)
+}
@@ -0,0 +1,25 @@
+package secondary
+
+import (
+	"context"
+	"github.com/rs/xid"
+	"specommerce/orderservice/internal/core/domain/product"
+	"specommerce/orderservice/pkg/pagination"
+)
+
+// SearchProductsFilter represents the filter for searching products
+type SearchProductsFilter struct {
+	pagination.Paging
+	Sku    string
+	Active *bool
+}
+
+// ProductRepository defines the secondary port for product catalog persistence
+type ProductRepository interface {
+	Create(ctx context.Context, product product.Product) (product.Product, error)
+	Update(ctx context.Context, product product.Product) (product.Product, error)
+	DeleteById(ctx context.Context, id xid.ID) error
+	GetById(ctx context.Context, id xid.ID) (product.Product, error)
+	GetBySkus(ctx context.Context, skus []string) ([]product.Product, error)
+	SearchProducts(ctx context.Context, filter SearchProductsFilter) (pagination.Page[product.Product], error)
+}
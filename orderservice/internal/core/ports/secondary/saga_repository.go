@@ -0,0 +1,30 @@
+package secondary
+
+import (
+	"context"
+	"github.com/rs/xid"
+	"specommerce/orderservice/internal/core/domain/saga"
+	"specommerce/orderservice/pkg/pagination"
+	"time"
+)
+
+// SearchSagasFilter represents the filter for searching sagas
+type SearchSagasFilter struct {
+	pagination.Paging
+	Status  saga.SagaStatus
+	OrderId string
+}
+
+// SagaRepository defines the secondary port for saga persistence
+type SagaRepository interface {
+	Create(ctx context.Context, saga saga.Saga) (saga.Saga, error)
+	Update(ctx context.Context, saga saga.Saga) (saga.Saga, error)
+	GetById(ctx context.Context, id xid.ID) (saga.Saga, error)
+	// LockById loads the saga with a row lock, it must be called inside a transaction
+	LockById(ctx context.Context, id xid.ID) (saga.Saga, error)
+	GetByOrderId(ctx context.Context, sagaType saga.SagaType, orderId xid.ID) (saga.Saga, error)
+	FindStale(ctx context.Context, statuses []saga.SagaStatus, updatedBefore time.Time, limit int) ([]saga.Saga, error)
+	SearchSagas(ctx context.Context, filter SearchSagasFilter) (pagination.Page[saga.Saga], error)
+	AddStepLog(ctx context.Context, log saga.StepLog) error
+	GetStepLogs(ctx context.Context, sagaId xid.ID) ([]saga.StepLog, error)
+}
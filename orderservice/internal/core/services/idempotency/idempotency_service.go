@@ -0,0 +1,156 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/rs/xid"
+	"log/slog"
+	"specommerce/orderservice/internal/core/domain/idempotency"
+	"specommerce/orderservice/internal/core/ports/primary"
+	"specommerce/orderservice/internal/core/ports/secondary"
+	"specommerce/orderservice/pkg/service_config"
+	"specommerce/orderservice/pkg/shutdown"
+	"time"
+)
+
+type idempotencyService struct {
+	idempotencyRepo secondary.IdempotencyRepository
+	config          service_config.IdempotencyConfig
+	logger          *slog.Logger
+}
+
+func NewIdempotencyService(idempotencyRepo secondary.IdempotencyRepository, cfg service_config.IdempotencyConfig, logger *slog.Logger) primary.IdempotencyService {
+	return &idempotencyService{
+		idempotencyRepo: idempotencyRepo,
+		config:          cfg,
+		logger:          logger,
+	}
+}
+
+func (s *idempotencyService) Begin(ctx context.Context, customerId string, key string, requestHash string) (idempotency.Record, bool, error) {
+	errTemplate := "idempotencyService Begin %w"
+	now := time.Now()
+	record, reserved, err := s.idempotencyRepo.Reserve(ctx, idempotency.Record{
+		CustomerId:  customerId,
+		Key:         key,
+		Token:       xid.New().String(),
+		RequestHash: requestHash,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(s.config.Lease),
+	})
+	switch {
+	case err != nil:
+		return idempotency.Record{}, false, fmt.Errorf(errTemplate, err)
+	case reserved:
+		return record, false, nil
+	case record.RequestHash != requestHash:
+		return idempotency.Record{}, false, fmt.Errorf(errTemplate, idempotency.ErrKeyReused)
+	case !record.Completed():
+		return idempotency.Record{}, false, fmt.Errorf(errTemplate, idempotency.ErrRequestInProgress)
+	}
+	return record, true, nil
+}
+
+// KeepAlive extends the lease by Lease every third of it, the lease of a crashed request still expires
+func (s *idempotencyService) KeepAlive(ctx context.Context, record idempotency.Record) func() {
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(s.config.Lease / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				err := s.idempotencyRepo.Extend(ctx, record, time.Now().Add(s.config.Lease))
+				if errors.Is(err, idempotency.ErrLeaseLost) {
+					return
+				}
+				if err != nil && ctx.Err() == nil {
+					s.logger.Error("Failed to extend idempotency key lease",
+						slog.String("key", record.Key),
+						slog.String("error", err.Error()),
+					)
+				}
+			}
+		}
+	}()
+	return func() {
+		cancel()
+		<-stopped
+	}
+}
+
+func (s *idempotencyService) Complete(ctx context.Context, record idempotency.Record, statusCode int, response []byte) error {
+	if err := s.idempotencyRepo.Complete(ctx, record, statusCode, response, time.Now().Add(s.config.TTL)); err != nil {
+		return fmt.Errorf("idempotencyService Complete %w", err)
+	}
+	return nil
+}
+
+func (s *idempotencyService) Release(ctx context.Context, record idempotency.Record) error {
+	if err := s.idempotencyRepo.Delete(ctx, record); err != nil {
+		return fmt.Errorf("idempotencyService Release %w", err)
+	}
+	return nil
+}
+
+// Cleaner periodically deletes expired idempotency keys
+type Cleaner struct {
+	idempotencyRepo secondary.IdempotencyRepository
+	config          service_config.IdempotencyConfig
+	shutdownTask    *shutdown.Tasks
+	logger          *slog.Logger
+}
+
+func NewCleaner(
+	idempotencyRepo secondary.IdempotencyRepository,
+	cfg service_config.IdempotencyConfig,
+	shutdownTask *shutdown.Tasks,
+	logger *slog.Logger,
+) *Cleaner {
+	return &Cleaner{
+		idempotencyRepo: idempotencyRepo,
+		config:          cfg,
+		shutdownTask:    shutdownTask,
+		logger:          logger,
+	}
+}
+
+func (c *Cleaner) Start() error {
+	c.logger.Info("Starting idempotency key cleaner",
+		slog.Duration("ttl", c.config.TTL),
+		slog.Duration("interval", c.config.CleanupInterval),
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	c.shutdownTask.AddShutdownTask(
+		func(_ context.Context) error {
+			cancel()
+			<-stopped
+			return nil
+		},
+	)
+	defer close(stopped)
+
+	ticker := time.NewTicker(c.config.CleanupInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			deleted, err := c.idempotencyRepo.DeleteExpired(ctx, time.Now())
+			if err != nil {
+				c.logger.Error("Failed to delete expired idempotency keys", slog.String("error", err.Error()))
+				continue
+			}
+			if deleted > 0 {
+				c.logger.Info("Deleted expired idempotency keys", slog.Int("count", deleted))
+			}
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package idempotency
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"specommerce/orderservice/internal/core/domain/idempotency"
+	"specommerce/orderservice/pkg/service_config"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeIdempotencyRepository reserves every key and records the lease extensions
+type fakeIdempotencyRepository struct {
+	mu       sync.Mutex
+	reserved []idempotency.Record
+	extended int
+}
+
+func (r *fakeIdempotencyRepository) Reserve(_ context.Context, record idempotency.Record) (idempotency.Record, bool, error) {
+	r.reserved = append(r.reserved, record)
+	return record, true, nil
+}
+
+func (r *fakeIdempotencyRepository) Extend(_ context.Context, _ idempotency.Record, _ time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.extended++
+	return nil
+}
+
+func (r *fakeIdempotencyRepository) Complete(_ context.Context, _ idempotency.Record, _ int, _ []byte, _ time.Time) error {
+	return nil
+}
+
+func (r *fakeIdempotencyRepository) Delete(_ context.Context, _ idempotency.Record) error {
+	return nil
+}
+
+func (r *fakeIdempotencyRepository) DeleteExpired(_ context.Context, _ time.Time) (int, error) {
+	return 0, nil
+}
+
+func (r *fakeIdempotencyRepository) extensions() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.extended
+}
+
+func TestBegin_ReservesTheKeyOfTheCustomerWithItsOwnToken(t *testing.T) {
+	repo := &fakeIdempotencyRepository{}
+	service := NewIdempotencyService(repo, service_config.IdempotencyConfig{Lease: time.Minute}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	first, replay, err := service.Begin(context.Background(), "alice", "key", "hash")
+	require.NoError(t, err)
+	assert.False(t, replay)
+	second, _, err := service.Begin(context.Background(), "bob", "key", "hash")
+	require.NoError(t, err)
+
+	assert.Equal(t, "alice", first.CustomerId)
+	assert.Equal(t, "bob", second.CustomerId)
+	assert.NotEmpty(t, first.Token)
+	assert.NotEqual(t, first.Token, second.Token)
+}
+
+func TestKeepAlive_ExtendsTheLeaseUntilStopped(t *testing.T) {
+	repo := &fakeIdempotencyRepository{}
+	service := NewIdempotencyService(repo, service_config.IdempotencyConfig{Lease: 30 * time.Millisecond}, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	stop := service.KeepAlive(context.Background(), idempotency.Record{CustomerId: "alice", Key: "key", Token: "token"})
+	assert.Eventually(t, func() bool { return repo.extensions() >= 2 }, time.Second, 5*time.Millisecond)
+	stop()
+
+	extended := repo.extensions()
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, extended, repo.extensions())
+}
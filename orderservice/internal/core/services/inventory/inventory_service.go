@@ -0,0 +1,281 @@
+// Package inventory implements stock reservation for flash sales.
+//
+// The available stock of every product is a Redis counter (inventory:{sku}:available) so that
+// reservations do not contend on Postgres rows under hot-sale traffic. Postgres stays the source of truth:
+// products.stock is the stock on hand and stock_reservations mirrors the reservations held in Redis,
+// so the counter of a product should always equal its stock minus the quantities still RESERVED.
+//
+// Reservation Flow:
+// Reserve - An atomic Lua script checks and decrements the counters of all items of the order at once
+// Confirm - The payment succeeded, the reservation is deducted from products.stock
+// Release - The payment failed or timed out, the reserved quantities are added back to the counters
+//
+// Confirm and Release run in the transaction of a saga step, so neither deletes Redis state that a
+// rollback could not restore: the reservation hash expires on its own, and a release that finds it expired
+// adds back the quantities of the reservations it released in Postgres instead.
+//
+// Counters missing from Redis are loaded from Postgres on demand, and the Reconciler corrects counters
+// that drifted from Postgres, e.g. after a reservation whose transaction failed to commit.
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"github.com/rs/xid"
+	"specommerce/orderservice/internal/core/domain/inventory"
+	"specommerce/orderservice/internal/core/domain/order"
+	"specommerce/orderservice/internal/core/ports/primary"
+	"specommerce/orderservice/internal/core/ports/secondary"
+	"specommerce/orderservice/pkg/cache"
+	"specommerce/orderservice/pkg/service_config"
+	"time"
+)
+
+const (
+	reserveStatusReserved   = "RESERVED"
+	reserveStatusOutOfStock = "OUT_OF_STOCK"
+	reserveStatusMissing    = "MISSING"
+)
+
+type inventoryService struct {
+	inventoryRepo secondary.InventoryRepository
+	cacheClient   cache.Cache
+	config        service_config.InventoryConfig
+}
+
+func NewInventoryService(inventoryRepo secondary.InventoryRepository, cacheClient cache.Cache, cfg service_config.InventoryConfig) primary.InventoryService {
+	return &inventoryService{
+		inventoryRepo: inventoryRepo,
+		cacheClient:   cacheClient,
+		config:        cfg,
+	}
+}
+
+func availableKey(sku string) string {
+	return fmt.Sprintf("inventory:%s:available", sku)
+}
+
+func reservationKey(orderId xid.ID) string {
+	return fmt.Sprintf("inventory:reservation:%s", orderId.String())
+}
+
+// Reserve holds the stock of the order items in Redis and records the reservation in Postgres.
+// Redis is updated first so that the stock is never oversold, the reservation is kept in Redis
+// under inventory:reservation:{order_id} for twice the ReservationTTL, which makes reserving the same
+// order again a no-op.
+// When the Postgres write fails, the Redis reservation is released right away.
+func (s *inventoryService) Reserve(ctx context.Context, orderId xid.ID, items []order.OrderItem) error {
+	errTemplate := "inventoryService Reserve %w"
+	if len(items) == 0 {
+		return nil
+	}
+	status, index, err := s.reserve(ctx, orderId, items)
+	if err == nil && status == reserveStatusMissing {
+		if err = s.loadCounters(ctx, items); err == nil {
+			status, index, err = s.reserve(ctx, orderId, items)
+		}
+	}
+	switch {
+	case err != nil:
+		return fmt.Errorf(errTemplate, err)
+	case status == reserveStatusOutOfStock:
+		return fmt.Errorf(errTemplate, fmt.Errorf("%w: %s", inventory.ErrOutOfStock, items[index].Sku))
+	case status != reserveStatusReserved:
+		return fmt.Errorf(errTemplate, fmt.Errorf("stock of %s is not loaded", items[index].Sku))
+	}
+
+	now := time.Now()
+	reservations := make([]inventory.Reservation, 0, len(items))
+	for _, item := range items {
+		reservations = append(reservations, inventory.Reservation{
+			OrderId:   orderId,
+			Sku:       item.Sku,
+			Quantity:  item.Quantity,
+			Status:    inventory.ReservationStatusReserved,
+			ExpiresAt: now.Add(s.config.ReservationTTL),
+			CreatedAt: now,
+			UpdatedAt: now,
+		})
+	}
+	if err = s.inventoryRepo.CreateReservations(ctx, reservations); err != nil {
+		if _, releaseErr := s.release(ctx, orderId); releaseErr != nil {
+			err = fmt.Errorf("%w, release %w", err, releaseErr)
+		}
+		return fmt.Errorf(errTemplate, err)
+	}
+	return nil
+}
+
+// reserve executes an atomic Lua script that:
+// 1. Returns early if the order already holds a reservation
+// 2. Checks that every counter is loaded and holds at least the ordered quantity
+// 3. Decrements all counters and records the reserved quantity per counter in the reservation hash,
+// which expires after the last argument in milliseconds
+//
+// Returns the status and the index of the item that could not be reserved
+func (s *inventoryService) reserve(ctx context.Context, orderId xid.ID, items []order.OrderItem) (string, int, error) {
+	luaScript := `
+		local reservation_key = KEYS[1]
+		if redis.call('EXISTS', reservation_key) == 1 then
+			return {'RESERVED', 0}
+		end
+
+		for i = 2, #KEYS do
+			local available = redis.call('GET', KEYS[i])
+			if not available then
+				return {'MISSING', i - 2}
+			end
+			if tonumber(available) < tonumber(ARGV[i - 1]) then
+				return {'OUT_OF_STOCK', i - 2}
+			end
+		end
+
+		for i = 2, #KEYS do
+			redis.call('DECRBY', KEYS[i], ARGV[i - 1])
+			redis.call('HSET', reservation_key, KEYS[i], ARGV[i - 1])
+		end
+		redis.call('PEXPIRE', reservation_key, ARGV[#ARGV])
+		return {'RESERVED', 0}
+	`
+	keys := make([]string, 0, len(items)+1)
+	args := make([]interface{}, 0, len(items)+1)
+	keys = append(keys, reservationKey(orderId))
+	for _, item := range items {
+		keys = append(keys, availableKey(item.Sku))
+		args = append(args, item.Quantity)
+	}
+	args = append(args, (2 * s.config.ReservationTTL).Milliseconds())
+	result, err := s.cacheClient.Eval(ctx, luaScript, keys, args...)
+	if err != nil {
+		return "", 0, err
+	}
+	resultArray, ok := result.([]interface{})
+	if !ok || len(resultArray) != 2 {
+		return "", 0, fmt.Errorf("unexpected reserve result %v", result)
+	}
+	status, _ := resultArray[0].(string)
+	index, _ := resultArray[1].(int64)
+	return status, int(index), nil
+}
+
+// loadCounters initializes the counters missing from Redis with the available stock in Postgres.
+// Counters that were loaded meanwhile are left untouched.
+func (s *inventoryService) loadCounters(ctx context.Context, items []order.OrderItem) error {
+	skus := make([]string, 0, len(items))
+	for _, item := range items {
+		skus = append(skus, item.Sku)
+	}
+	levels, err := s.inventoryRepo.GetStockLevels(ctx, skus)
+	if err != nil {
+		return err
+	}
+	return s.setCounters(ctx, levels)
+}
+
+func (s *inventoryService) setCounters(ctx context.Context, levels []inventory.StockLevel) error {
+	if len(levels) == 0 {
+		return nil
+	}
+	luaScript := `
+		for i = 1, #KEYS do
+			redis.call('SET', KEYS[i], ARGV[i], 'NX')
+		end
+		return #KEYS
+	`
+	keys := make([]string, 0, len(levels))
+	args := make([]interface{}, 0, len(levels))
+	for _, level := range levels {
+		keys = append(keys, availableKey(level.Sku))
+		args = append(args, level.Available())
+	}
+	_, err := s.cacheClient.Eval(ctx, luaScript, keys, args...)
+	return err
+}
+
+// Confirm deducts the reservation of a paid order from the product stock in Postgres.
+// The counters already exclude the reserved quantities, and the reservation hash is left to expire:
+// deleting it before the transaction commits would lose the reservation if the transaction rolled back.
+// A confirmed reservation is no longer released, so the hash cannot be released afterwards.
+func (s *inventoryService) Confirm(ctx context.Context, orderId xid.ID) error {
+	if _, err := s.inventoryRepo.ConfirmReservations(ctx, orderId); err != nil {
+		return fmt.Errorf("inventoryService Confirm %w", err)
+	}
+	return nil
+}
+
+// Release returns the reserved stock of an unpaid order, releasing an order twice, or releasing a
+// confirmed order, is a no-op because only the reservations still RESERVED in Postgres are released
+func (s *inventoryService) Release(ctx context.Context, orderId xid.ID) error {
+	errTemplate := "inventoryService Release %w"
+	released, err := s.inventoryRepo.ReleaseReservations(ctx, orderId)
+	if err != nil {
+		return fmt.Errorf(errTemplate, err)
+	}
+	if len(released) == 0 {
+		return nil
+	}
+	if _, err = s.release(ctx, orderId, released...); err != nil {
+		return fmt.Errorf(errTemplate, err)
+	}
+	return nil
+}
+
+// release executes an atomic Lua script that adds the quantities of the reservation hash back
+// to their counters and deletes the hash. When the hash expired, the quantities of the released
+// reservations are added back instead, to the counters that are loaded. Returns the number of released counters.
+func (s *inventoryService) release(ctx context.Context, orderId xid.ID, released ...inventory.Reservation) (int64, error) {
+	luaScript := `
+		local reservation_key = KEYS[1]
+		local reserved = redis.call('HGETALL', reservation_key)
+		if #reserved > 0 then
+			for i = 1, #reserved, 2 do
+				redis.call('INCRBY', reserved[i], reserved[i + 1])
+			end
+			redis.call('DEL', reservation_key)
+			return #reserved / 2
+		end
+
+		local count = 0
+		for i = 2, #KEYS do
+			if redis.call('EXISTS', KEYS[i]) == 1 then
+				redis.call('INCRBY', KEYS[i], ARGV[i - 1])
+				count = count + 1
+			end
+		end
+		return count
+	`
+	keys := make([]string, 0, len(released)+1)
+	args := make([]interface{}, 0, len(released))
+	keys = append(keys, reservationKey(orderId))
+	for _, reservation := range released {
+		keys = append(keys, availableKey(reservation.Sku))
+		args = append(args, reservation.Quantity)
+	}
+	result, err := s.cacheClient.Eval(ctx, luaScript, keys, args...)
+	if err != nil {
+		return 0, err
+	}
+	count, _ := result.(int64)
+	return count, nil
+}
+
+// AdjustStock adds delta to the counter of the product if it is loaded, otherwise it is loaded on the next reservation
+func (s *inventoryService) AdjustStock(ctx context.Context, sku string, delta int) error {
+	luaScript := `
+		if redis.call('EXISTS', KEYS[1]) == 1 then
+			return redis.call('INCRBY', KEYS[1], ARGV[1])
+		end
+		return 0
+	`
+	if _, err := s.cacheClient.Eval(ctx, luaScript, []string{availableKey(sku)}, delta); err != nil {
+		return fmt.Errorf("inventoryService AdjustStock %w", err)
+	}
+	return nil
+}
+
+func (s *inventoryService) ResetStock(ctx context.Context, sku string) error {
+	if err := s.cacheClient.Del(ctx, availableKey(sku)); err != nil {
+		return fmt.Errorf("inventoryService ResetStock %w", err)
+	}
+	return nil
+}
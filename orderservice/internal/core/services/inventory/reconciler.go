@@ -0,0 +1,172 @@
+package inventory
+
+import (
+	"context"
+	"expvar"
+	"fmt"
+	"log/slog"
+	"specommerce/orderservice/internal/core/domain/inventory"
+	"specommerce/orderservice/internal/core/ports/secondary"
+	"specommerce/orderservice/pkg/cache"
+	"specommerce/orderservice/pkg/service_config"
+	"specommerce/orderservice/pkg/shutdown"
+	"strconv"
+	"time"
+)
+
+// reconcileCorrections counts Redis counters corrected from Postgres, exposed on /debug/vars
+var reconcileCorrections = expvar.NewInt("inventory_reconcile_corrections")
+
+// Reconciler periodically compares the Redis counters with the available stock in Postgres.
+// A reservation is written to Redis before its transaction commits, so a counter can briefly
+// be lower than Postgres says. Only a drift seen with the same value on two runs in a row is corrected,
+// and only if the counter did not change in between.
+type Reconciler struct {
+	inventoryRepo secondary.InventoryRepository
+	cacheClient   cache.Cache
+	config        service_config.InventoryConfig
+	shutdownTask  *shutdown.Tasks
+	logger        *slog.Logger
+	drifts        map[string]int
+}
+
+func NewReconciler(
+	inventoryRepo secondary.InventoryRepository,
+	cacheClient cache.Cache,
+	cfg service_config.InventoryConfig,
+	shutdownTask *shutdown.Tasks,
+	logger *slog.Logger,
+) *Reconciler {
+	return &Reconciler{
+		inventoryRepo: inventoryRepo,
+		cacheClient:   cacheClient,
+		config:        cfg,
+		shutdownTask:  shutdownTask,
+		logger:        logger,
+		drifts:        make(map[string]int),
+	}
+}
+
+func (r *Reconciler) Start() error {
+	r.logger.Info("Starting stock reconciler", slog.Duration("interval", r.config.ReconcileInterval))
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	r.shutdownTask.AddShutdownTask(
+		func(_ context.Context) error {
+			cancel()
+			<-stopped
+			return nil
+		},
+	)
+	defer close(stopped)
+
+	ticker := time.NewTicker(r.config.ReconcileInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := r.reconcile(ctx); err != nil {
+				r.logger.Error("Failed to reconcile stock", slog.String("error", err.Error()))
+			}
+		}
+	}
+}
+
+func (r *Reconciler) reconcile(ctx context.Context) error {
+	levels, err := r.inventoryRepo.GetStockLevels(ctx, nil)
+	if err != nil {
+		return err
+	}
+	counters, err := r.getCounters(ctx, levels)
+	if err != nil {
+		return err
+	}
+
+	drifts := make(map[string]int)
+	for i, level := range levels {
+		// counters that are not loaded are loaded from Postgres on the next reservation
+		if counters[i] == nil {
+			continue
+		}
+		drift := level.Available() - *counters[i]
+		if drift == 0 {
+			continue
+		}
+		if previous, ok := r.drifts[level.Sku]; !ok || previous != drift {
+			drifts[level.Sku] = drift
+			continue
+		}
+		corrected, err := r.correct(ctx, level, *counters[i])
+		if err != nil {
+			return err
+		}
+		if corrected {
+			reconcileCorrections.Add(1)
+			r.logger.Warn("Corrected stock counter drift",
+				slog.String("sku", level.Sku),
+				slog.Int("counter", *counters[i]),
+				slog.Int("available", level.Available()),
+			)
+		}
+	}
+	r.drifts = drifts
+	return nil
+}
+
+// getCounters reads the counters of all products at once, a counter that is not loaded is nil
+func (r *Reconciler) getCounters(ctx context.Context, levels []inventory.StockLevel) ([]*int, error) {
+	counters := make([]*int, len(levels))
+	if len(levels) == 0 {
+		return counters, nil
+	}
+	luaScript := `
+		local counters = {}
+		for i = 1, #KEYS do
+			counters[i] = redis.call('GET', KEYS[i])
+		end
+		return counters
+	`
+	keys := make([]string, 0, len(levels))
+	for _, level := range levels {
+		keys = append(keys, availableKey(level.Sku))
+	}
+	result, err := r.cacheClient.Eval(ctx, luaScript, keys)
+	if err != nil {
+		return nil, err
+	}
+	values, ok := result.([]interface{})
+	if !ok || len(values) != len(levels) {
+		return nil, fmt.Errorf("unexpected counters result %v", result)
+	}
+	for i, value := range values {
+		str, ok := value.(string)
+		if !ok {
+			continue
+		}
+		counter, err := strconv.Atoi(str)
+		if err != nil {
+			return nil, err
+		}
+		counters[i] = &counter
+	}
+	return counters, nil
+}
+
+// correct sets the counter to the available stock in Postgres if it still holds the observed value
+func (r *Reconciler) correct(ctx context.Context, level inventory.StockLevel, observed int) (bool, error) {
+	luaScript := `
+		if tonumber(redis.call('GET', KEYS[1])) ~= tonumber(ARGV[1]) then
+			return 0
+		end
+		redis.call('SET', KEYS[1], ARGV[2])
+		return 1
+	`
+	result, err := r.cacheClient.Eval(ctx, luaScript, []string{availableKey(level.Sku)}, observed, level.Available())
+	if err != nil {
+		return false, err
+	}
+	corrected, _ := result.(int64)
+	return corrected == 1, nil
+}
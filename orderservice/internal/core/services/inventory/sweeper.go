@@ -0,0 +1,111 @@
+package inventory
+
+import (
+	"context"
+	"expvar"
+	"github.com/rs/xid"
+	"log/slog"
+	"specommerce/orderservice/internal/core/domain/payment"
+	"specommerce/orderservice/internal/core/ports/primary"
+	"specommerce/orderservice/internal/core/ports/secondary"
+	"specommerce/orderservice/pkg/service_config"
+	"specommerce/orderservice/pkg/shutdown"
+	"time"
+)
+
+// expiredReservations counts orders failed because their stock reservation expired, exposed on /debug/vars
+var expiredReservations = expvar.NewInt("inventory_reservations_expired")
+
+// Sweeper periodically fails the orders whose stock reservation expired before the payment response arrived.
+// Only an order whose placement saga waits for its payment response is swept, through OrderService.ExpirePayment:
+// its payment is cancelled on the payment service first, so the customer is never charged for released stock,
+// and the saga is resumed as a failed payment whose compensation releases the stock. An order whose payment
+// is in progress keeps its stock until the next sweep, and an order paid meanwhile is completed with its payment.
+type Sweeper struct {
+	inventoryRepo secondary.InventoryRepository
+	orderService  primary.OrderService
+	config        service_config.InventoryConfig
+	shutdownTask  *shutdown.Tasks
+	logger        *slog.Logger
+}
+
+func NewSweeper(
+	inventoryRepo secondary.InventoryRepository,
+	orderService primary.OrderService,
+	cfg service_config.InventoryConfig,
+	shutdownTask *shutdown.Tasks,
+	logger *slog.Logger,
+) *Sweeper {
+	return &Sweeper{
+		inventoryRepo: inventoryRepo,
+		orderService:  orderService,
+		config:        cfg,
+		shutdownTask:  shutdownTask,
+		logger:        logger,
+	}
+}
+
+func (s *Sweeper) Start() error {
+	s.logger.Info("Starting stock reservation sweeper",
+		slog.Duration("interval", s.config.SweepInterval),
+		slog.Duration("reservation_ttl", s.config.ReservationTTL),
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	s.shutdownTask.AddShutdownTask(
+		func(_ context.Context) error {
+			cancel()
+			<-stopped
+			return nil
+		},
+	)
+	defer close(stopped)
+
+	ticker := time.NewTicker(s.config.SweepInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			s.sweepExpired(ctx)
+		}
+	}
+}
+
+func (s *Sweeper) sweepExpired(ctx context.Context) {
+	expired, err := s.inventoryRepo.FindExpiredReservations(ctx, time.Now(), s.config.BatchSize)
+	if err != nil {
+		s.logger.Error("Failed to find expired stock reservations", slog.String("error", err.Error()))
+		return
+	}
+	swept := make(map[xid.ID]struct{}, len(expired))
+	for _, reservation := range expired {
+		if _, ok := swept[reservation.OrderId]; ok {
+			continue
+		}
+		swept[reservation.OrderId] = struct{}{}
+		response, resolved, err := s.orderService.ExpirePayment(ctx, reservation.OrderId, payment.DeclineReasonReservationExpired)
+		if err != nil {
+			s.logger.Error("Failed to expire stock reservation",
+				slog.String("order_id", reservation.OrderId.String()),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		if !resolved {
+			continue
+		}
+		if response.DeclineReason != payment.DeclineReasonReservationExpired {
+			s.logger.Info("Resolved order with expired stock reservation from its payment",
+				slog.String("order_id", reservation.OrderId.String()),
+				slog.String("payment_status", string(response.PaymentStatus)),
+			)
+			continue
+		}
+		expiredReservations.Add(1)
+		s.logger.Info("Expired stock reservation",
+			slog.String("order_id", reservation.OrderId.String()),
+		)
+	}
+}
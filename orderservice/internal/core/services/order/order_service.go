@@ -2,120 +2,173 @@ package order
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"github.com/rs/xid"
 	"log/slog"
+	"specommerce/orderservice/internal/core/domain/inventory"
 	"specommerce/orderservice/internal/core/domain/order"
 	"specommerce/orderservice/internal/core/domain/payment"
+	"specommerce/orderservice/internal/core/domain/product"
+	"specommerce/orderservice/internal/core/domain/saga"
 	"specommerce/orderservice/internal/core/ports/primary"
 	"specommerce/orderservice/internal/core/ports/secondary"
-	"specommerce/orderservice/pkg/atomicity"
+	sagaService "specommerce/orderservice/internal/core/services/saga"
 	"specommerce/orderservice/pkg/pagination"
 )
 
 // OrderService implements the order business logic
 type service struct {
 	orderRepo         secondary.OrderRepository
-	paymentPublisher  secondary.PaymentRepository
-	campaignPublisher secondary.CampaignRepository
-	atomicExecutor    atomicity.AtomicExecutor
+	productRepo       secondary.ProductRepository
+	sagaRepo          secondary.SagaRepository
+	paymentStatusRepo secondary.PaymentStatusRepository
+	orchestrator      *sagaService.Orchestrator
 	logger            *slog.Logger
 }
 
-func NewOrderService(orderRepo secondary.OrderRepository, paymentPublisher secondary.PaymentRepository, atomicExecutor atomicity.AtomicExecutor,
-	campaignPublisher secondary.CampaignRepository, logger *slog.Logger) primary.OrderService {
+func NewOrderService(
+	orderRepo secondary.OrderRepository,
+	productRepo secondary.ProductRepository,
+	sagaRepo secondary.SagaRepository,
+	paymentStatusRepo secondary.PaymentStatusRepository,
+	orchestrator *sagaService.Orchestrator,
+	logger *slog.Logger,
+) primary.OrderService {
 	return &service{
 		orderRepo:         orderRepo,
-		campaignPublisher: campaignPublisher,
-		paymentPublisher:  paymentPublisher,
-		atomicExecutor:    atomicExecutor,
+		productRepo:       productRepo,
+		sagaRepo:          sagaRepo,
+		paymentStatusRepo: paymentStatusRepo,
+		orchestrator:      orchestrator,
 		logger:            logger,
 	}
 }
 
-// CreateOrder creates a new order and initiates payment processing
-// Step 1: Create the order in the database with status Pending
-// Step 2: Send a payment request to the payment service
-// Step 3: If sending the payment request is successful, update the order status to Processing
-// TODO: Put all the steps in a workflow or saga pattern
+// CreateOrder creates a new order and initiates payment processing by starting the order placement saga.
+// The items are priced from the product catalog and the total amount is computed from them,
+// the client only chooses the SKUs and quantities. It returns inventory.ErrOutOfStock when the stock
+// of an item cannot be reserved.
+// The saga runs until it waits for the payment response, see NewPlacementSaga for the steps.
+// Every step commits together with the saga state and the events it stages in the outbox,
+// so a crash between steps is resumed instead of leaving the order stranded in Pending.
 func (s *service) CreateOrder(ctx context.Context, input order.CreateOrderRequest) (order.Order, error) {
 	errTemplate := "orderService CreateOrder %w"
-	var orderId xid.ID
+	items, err := s.priceItems(ctx, input.Order.Items)
+	if err != nil {
+		return order.Order{}, fmt.Errorf(errTemplate, err)
+	}
+	input.Order.Items = items
+	input.Order.TotalAmount = order.CalculateTotal(items)
 
-	txErr := s.atomicExecutor.Execute(
-		ctx, func(tc context.Context) error {
-			pendingOrder, err := s.orderRepo.Create(ctx, input.Order)
-			if err != nil {
-				return err
-			}
+	placement, err := saga.New(saga.SagaTypeOrderPlacement, input.Order.Id, placementPayload{
+		Order:       input.Order,
+		TimeProcess: input.TimeProcess,
+	})
+	if err != nil {
+		return order.Order{}, fmt.Errorf(errTemplate, err)
+	}
 
-			err = s.paymentPublisher.SendPaymentRequest(ctx, payment.ProcessPaymentRequest{
-				OrderId:     pendingOrder.Id,
-				CustomerId:  pendingOrder.CustomerId,
-				TotalAmount: pendingOrder.TotalAmount,
-				TimeProcess: input.TimeProcess,
-			})
-			if err != nil {
-				return err
-			}
-			orderId = pendingOrder.Id
-			return nil
-		},
-	)
-	err := s.campaignPublisher.SendOrderEvent(ctx, input.Order)
+	placement, err = s.orchestrator.Start(ctx, placement)
 	if err != nil {
-		s.logger.Error(
-			"failed to send order event to campaign service",
-			slog.String("order_id", input.Order.Id.String()),
-			slog.String("error", err.Error()),
-		)
+		return order.Order{}, fmt.Errorf(errTemplate, err)
 	}
-	if txErr != nil {
-		return order.Order{}, fmt.Errorf(errTemplate, txErr)
+	if placement.Status == saga.SagaStatusCompensated {
+		return order.Order{}, fmt.Errorf(errTemplate, placementError(placement))
 	}
-	processingOrder, err := s.orderRepo.UpdateStatusById(ctx, orderId, order.OrderStatusProcessing)
-	if err != nil {
+
+	var payload placementPayload
+	if err = placement.DecodePayload(&payload); err != nil {
 		return order.Order{}, fmt.Errorf(errTemplate, err)
 	}
-	return processingOrder, nil
+	return payload.Order, nil
+}
 
+// placementError restores the business error of a compensated placement saga from its failure reason
+func placementError(placement saga.Saga) error {
+	if placement.FailureReason == failureReasonOutOfStock {
+		return fmt.Errorf("%w: %w: %s", order.ErrPlacementCompensated, inventory.ErrOutOfStock, placement.LastError)
+	}
+	return fmt.Errorf("%w: %s", order.ErrPlacementCompensated, placement.LastError)
+}
+
+// priceItems merges the requested items by SKU and copies the name and price of the product from the catalog.
+// The merged quantity of a SKU is bounded by MaxItemQuantity and the total of the order by MaxTotalAmount.
+func (s *service) priceItems(ctx context.Context, requested []order.OrderItem) ([]order.OrderItem, error) {
+	items := make([]order.OrderItem, 0, len(requested))
+	indexBySku := make(map[string]int, len(requested))
+	skus := make([]string, 0, len(requested))
+	for _, item := range requested {
+		if i, ok := indexBySku[item.Sku]; ok {
+			items[i].Quantity += item.Quantity
+			continue
+		}
+		indexBySku[item.Sku] = len(items)
+		items = append(items, order.OrderItem{Sku: item.Sku, Quantity: item.Quantity})
+		skus = append(skus, item.Sku)
+	}
+	for _, item := range items {
+		if item.Quantity > order.MaxItemQuantity {
+			return nil, fmt.Errorf("%w: %s has %d, at most %d", order.ErrQuantityTooLarge, item.Sku, item.Quantity, order.MaxItemQuantity)
+		}
+	}
+
+	products, err := s.productRepo.GetBySkus(ctx, skus)
+	if err != nil {
+		return nil, err
+	}
+	productBySku := make(map[string]product.Product, len(products))
+	for _, p := range products {
+		productBySku[p.Sku] = p
+	}
+	for i := range items {
+		p, ok := productBySku[items[i].Sku]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", product.ErrUnknownSku, items[i].Sku)
+		}
+		if !p.Active {
+			return nil, fmt.Errorf("%w: %s", product.ErrProductInactive, items[i].Sku)
+		}
+		items[i].Name = p.Name
+		items[i].UnitPrice = p.Price
+	}
+	if total := order.CalculateTotal(items); total > order.MaxTotalAmount {
+		return nil, fmt.Errorf("%w: %.2f, at most %.2f", order.ErrTotalAmountTooLarge, total, order.MaxTotalAmount)
+	}
+	return items, nil
 }
 
 // ProcessPaymentResponse processes the payment response from the payment service
-// Step 1: Check the payment status
-// Step 2: If the payment is successful, update the order status to Success. If the payment failed, update the order status to Failed
-// Step 3: If the order status is Success, send an order success event to the campaign service
+// by resuming the order placement saga that is waiting for it.
+// A successful payment completes the order and notifies the campaign service,
+// a failed payment compensates the saga which fails the order and notifies the campaign service.
+// A redelivered response finds the saga already resumed and returns the current order.
 // TODO: Use CDC to decouple the campaign service logic from the order service
 func (s *service) ProcessPaymentResponse(ctx context.Context, input payment.ProcessPaymentResponse) (order.Order, error) {
 	errTemplate := "paymentService ProcessPaymentResponse %w"
-	orderResponse := order.Order{}
-	txErr := s.atomicExecutor.Execute(
-		ctx, func(tc context.Context) error {
-			newStatus := order.OrderStatusSuccess
-			if input.PaymentStatus == payment.PaymentStatusFailed {
-				newStatus = order.OrderStatusFailed
-			}
-			updatedOrder, err := s.orderRepo.UpdateStatusById(ctx, input.OrderId, newStatus)
-			if err != nil {
-				return err
-			}
-			orderResponse = updatedOrder
-			return nil
-		},
-	)
-	if txErr != nil {
-		return order.Order{}, fmt.Errorf(errTemplate, txErr)
-	}
-	err := s.campaignPublisher.SendOrderEvent(ctx, orderResponse)
-	if err != nil {
-		s.logger.Error(
-			"failed to send order event to campaign service",
-			slog.String("order_id", orderResponse.Id.String()),
-			slog.String("error", err.Error()),
+	placement, err := s.orchestrator.Resume(ctx, saga.SagaTypeOrderPlacement, input.OrderId, func(current *saga.Saga) error {
+		var payload placementPayload
+		if err := current.DecodePayload(&payload); err != nil {
+			return err
+		}
+		payload.PaymentStatus = input.PaymentStatus
+		payload.DeclineReason = input.DeclineReason
+		return current.EncodePayload(payload)
+	})
+	switch {
+	case errors.Is(err, sagaService.ErrSagaNotWaiting):
+		s.logger.Info("Ignored payment response for saga that is not waiting",
+			slog.String("order_id", input.OrderId.String()),
+			slog.String("saga_status", placement.Status.String()),
 		)
+	case err != nil:
+		return order.Order{}, fmt.Errorf(errTemplate, err)
 	}
 
-	return orderResponse, nil
+	var payload placementPayload
+	if err = placement.DecodePayload(&payload); err != nil {
+		return order.Order{}, fmt.Errorf(errTemplate, err)
+	}
+	return payload.Order, nil
 }
 
 func (s *service) GetAllOrders(ctx context.Context) ([]order.Order, error) {
@@ -0,0 +1,64 @@
+package order
+
+import (
+	"context"
+	"specommerce/orderservice/internal/core/domain/order"
+	"specommerce/orderservice/internal/core/domain/product"
+	"specommerce/orderservice/internal/core/ports/secondary"
+	"testing"
+
+	"github.com/rs/xid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeProductRepository serves a fixed catalog, the other methods are not used by priceItems
+type fakeProductRepository struct {
+	secondary.ProductRepository
+	products []product.Product
+}
+
+func (r *fakeProductRepository) GetBySkus(_ context.Context, _ []string) ([]product.Product, error) {
+	return r.products, nil
+}
+
+func TestPriceItems(t *testing.T) {
+	s := &service{productRepo: &fakeProductRepository{products: []product.Product{
+		{Id: xid.New(), Sku: "CHEAP", Name: "Cheap", Price: 1.5, Active: true},
+		{Id: xid.New(), Sku: "EXPENSIVE", Name: "Expensive", Price: 50_000, Active: true},
+	}}}
+
+	tests := []struct {
+		name      string
+		requested []order.OrderItem
+		wantErr   error
+		wantItems []order.OrderItem
+	}{
+		{
+			name:      "duplicate SKUs are merged",
+			requested: []order.OrderItem{{Sku: "CHEAP", Quantity: 2}, {Sku: "CHEAP", Quantity: 3}},
+			wantItems: []order.OrderItem{{Sku: "CHEAP", Name: "Cheap", UnitPrice: 1.5, Quantity: 5}},
+		},
+		{
+			name:      "merged quantity above the maximum",
+			requested: []order.OrderItem{{Sku: "CHEAP", Quantity: order.MaxItemQuantity}, {Sku: "CHEAP", Quantity: 1}},
+			wantErr:   order.ErrQuantityTooLarge,
+		},
+		{
+			name:      "total above the maximum",
+			requested: []order.OrderItem{{Sku: "EXPENSIVE", Quantity: 2_000}},
+			wantErr:   order.ErrTotalAmountTooLarge,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items, err := s.priceItems(context.Background(), tt.requested)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantItems, items)
+		})
+	}
+}
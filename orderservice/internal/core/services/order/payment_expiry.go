@@ -0,0 +1,89 @@
+package order
+
+import (
+	"context"
+	"fmt"
+	"github.com/rs/xid"
+	"log/slog"
+	"specommerce/orderservice/internal/core/domain/payment"
+	"specommerce/orderservice/internal/core/domain/saga"
+	"specommerce/orderservice/internal/core/ports/secondary"
+)
+
+// ExpirePayment resumes the placement saga of an order still waiting for its payment response with the payment
+// known by the payment service, see resolvePayment. It leaves the order waiting, and returns false, when the saga
+// is not waiting or the payment service is still processing the payment.
+func (s *service) ExpirePayment(ctx context.Context, orderId xid.ID, reason string) (payment.ProcessPaymentResponse, bool, error) {
+	errTemplate := "orderService ExpirePayment %w"
+	placement, err := s.sagaRepo.GetByOrderId(ctx, saga.SagaTypeOrderPlacement, orderId)
+	if err != nil {
+		return payment.ProcessPaymentResponse{}, false, fmt.Errorf(errTemplate, err)
+	}
+	if placement.Status != saga.SagaStatusWaiting {
+		return payment.ProcessPaymentResponse{}, false, nil
+	}
+
+	response, resolved, err := resolvePayment(ctx, s.paymentStatusRepo, orderId, reason)
+	if err != nil {
+		return payment.ProcessPaymentResponse{}, false, fmt.Errorf(errTemplate, err)
+	}
+	if !resolved {
+		s.logger.Info("Left order waiting for the payment in progress",
+			slog.String("order_id", orderId.String()),
+		)
+		return payment.ProcessPaymentResponse{}, false, nil
+	}
+	if _, err = s.ProcessPaymentResponse(ctx, response); err != nil {
+		return payment.ProcessPaymentResponse{}, false, fmt.Errorf(errTemplate, err)
+	}
+	return response, true, nil
+}
+
+// resolvePayment returns the payment response of an order that gave up waiting for it: the captured payment,
+// or else the last failed attempt. When the payment service has no attempt, the payment of the order is
+// cancelled first, so a payment request still in flight is rejected instead of charging the customer for a
+// failed order, and the order fails with reason. It returns false while the payment service processes the order.
+func resolvePayment(
+	ctx context.Context,
+	paymentStatusRepo secondary.PaymentStatusRepository,
+	orderId xid.ID,
+	reason string,
+) (payment.ProcessPaymentResponse, bool, error) {
+	attempts, err := paymentStatusRepo.GetByOrderId(ctx, orderId)
+	if err != nil {
+		return payment.ProcessPaymentResponse{}, false, err
+	}
+	if len(attempts) == 0 {
+		status, err := paymentStatusRepo.Cancel(ctx, orderId)
+		if err != nil {
+			return payment.ProcessPaymentResponse{}, false, err
+		}
+		switch status {
+		case payment.ClaimStatusCancelled:
+			return payment.ProcessPaymentResponse{
+				OrderId:       orderId,
+				PaymentStatus: payment.PaymentStatusFailed,
+				DeclineReason: reason,
+			}, true, nil
+		case payment.ClaimStatusProcessing:
+			return payment.ProcessPaymentResponse{}, false, nil
+		}
+		// the payment completed since the first read
+		if attempts, err = paymentStatusRepo.GetByOrderId(ctx, orderId); err != nil {
+			return payment.ProcessPaymentResponse{}, false, err
+		}
+		if len(attempts) == 0 {
+			return payment.ProcessPaymentResponse{}, false, nil
+		}
+	}
+
+	response := payment.ProcessPaymentResponse{OrderId: orderId}
+	for _, attempt := range attempts {
+		if attempt.Status == payment.PaymentStatusSuccess {
+			return payment.ProcessPaymentResponse{OrderId: orderId, PaymentStatus: payment.PaymentStatusSuccess}, true, nil
+		}
+		response.PaymentStatus = attempt.Status
+		response.DeclineReason = attempt.DeclineReason
+	}
+	return response, true, nil
+}
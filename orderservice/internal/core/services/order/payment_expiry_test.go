@@ -0,0 +1,88 @@
+package order
+
+import (
+	"context"
+	"specommerce/orderservice/internal/core/domain/payment"
+	"testing"
+
+	"github.com/rs/xid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePaymentStatusRepository answers like the payment service: the attempts of the order, and the claim
+// status a cancellation finds, which may record the payment that completed meanwhile
+type fakePaymentStatusRepository struct {
+	attempts  []payment.Payment
+	claim     payment.ClaimStatus
+	completed []payment.Payment
+	cancelled int
+}
+
+func (r *fakePaymentStatusRepository) GetByOrderId(_ context.Context, _ xid.ID) ([]payment.Payment, error) {
+	return r.attempts, nil
+}
+
+func (r *fakePaymentStatusRepository) Cancel(_ context.Context, _ xid.ID) (payment.ClaimStatus, error) {
+	r.cancelled++
+	r.attempts = r.completed
+	return r.claim, nil
+}
+
+func TestResolvePayment(t *testing.T) {
+	orderId := xid.New()
+	success := payment.Payment{OrderId: orderId, Status: payment.PaymentStatusSuccess}
+	declined := payment.Payment{OrderId: orderId, Status: payment.PaymentStatusFailed, DeclineReason: "CARD_DECLINED"}
+
+	tests := []struct {
+		name          string
+		repo          *fakePaymentStatusRepository
+		wantResolved  bool
+		wantResponse  payment.ProcessPaymentResponse
+		wantCancelled int
+	}{
+		{
+			name:          "captured payment wins",
+			repo:          &fakePaymentStatusRepository{attempts: []payment.Payment{declined, success}},
+			wantResolved:  true,
+			wantResponse:  payment.ProcessPaymentResponse{OrderId: orderId, PaymentStatus: payment.PaymentStatusSuccess},
+			wantCancelled: 0,
+		},
+		{
+			name:          "last declined attempt",
+			repo:          &fakePaymentStatusRepository{attempts: []payment.Payment{declined}},
+			wantResolved:  true,
+			wantResponse:  payment.ProcessPaymentResponse{OrderId: orderId, PaymentStatus: payment.PaymentStatusFailed, DeclineReason: "CARD_DECLINED"},
+			wantCancelled: 0,
+		},
+		{
+			name:          "payment never processed is cancelled before failing",
+			repo:          &fakePaymentStatusRepository{claim: payment.ClaimStatusCancelled},
+			wantResolved:  true,
+			wantResponse:  payment.ProcessPaymentResponse{OrderId: orderId, PaymentStatus: payment.PaymentStatusFailed, DeclineReason: payment.DeclineReasonTimeout},
+			wantCancelled: 1,
+		},
+		{
+			name:          "payment in progress leaves the order waiting",
+			repo:          &fakePaymentStatusRepository{claim: payment.ClaimStatusProcessing},
+			wantResolved:  false,
+			wantCancelled: 1,
+		},
+		{
+			name:          "late success completed before the cancellation",
+			repo:          &fakePaymentStatusRepository{claim: payment.ClaimStatusCompleted, completed: []payment.Payment{success}},
+			wantResolved:  true,
+			wantResponse:  payment.ProcessPaymentResponse{OrderId: orderId, PaymentStatus: payment.PaymentStatusSuccess},
+			wantCancelled: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, resolved, err := resolvePayment(context.Background(), tt.repo, orderId, payment.DeclineReasonTimeout)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantResolved, resolved)
+			assert.Equal(t, tt.wantResponse, response)
+			assert.Equal(t, tt.wantCancelled, tt.repo.cancelled)
+		})
+	}
+}
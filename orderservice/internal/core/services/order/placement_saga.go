@@ -0,0 +1,172 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"specommerce/orderservice/internal/core/domain/inventory"
+	"specommerce/orderservice/internal/core/domain/order"
+	"specommerce/orderservice/internal/core/domain/payment"
+	"specommerce/orderservice/internal/core/domain/saga"
+	"specommerce/orderservice/internal/core/ports/primary"
+	"specommerce/orderservice/internal/core/ports/secondary"
+	sagaService "specommerce/orderservice/internal/core/services/saga"
+)
+
+const (
+	stepReserveStock          = "reserve_stock"
+	stepCreateOrder           = "create_order"
+	stepNotifyCampaignPending = "notify_campaign_pending"
+	stepRequestPayment        = "request_payment"
+	stepCompleteOrder         = "complete_order"
+	stepConfirmStock          = "confirm_stock"
+	stepNotifyCampaignResult  = "notify_campaign_result"
+)
+
+// Failure reasons recorded on a placement saga for the business failures of its steps
+const (
+	failureReasonOutOfStock    = "OUT_OF_STOCK"
+	failureReasonPaymentFailed = "PAYMENT_FAILED"
+)
+
+var errPaymentFailed = errors.New("payment failed")
+
+// placementPayload is the state carried by the order placement saga
+type placementPayload struct {
+	Order         order.Order           `json:"order"`
+	TimeProcess   int64                 `json:"time_process"`
+	PaymentStatus payment.PaymentStatus `json:"payment_status,omitempty"`
+	DeclineReason string                `json:"decline_reason,omitempty"`
+}
+
+type placementSaga struct {
+	inventoryService  primary.InventoryService
+	orderRepo         secondary.OrderRepository
+	paymentPublisher  secondary.PaymentRepository
+	campaignPublisher secondary.CampaignRepository
+}
+
+// NewPlacementSaga defines the order placement saga
+// Step 1: Reserve the stock of the order items. Compensation: release the reserved stock
+// Step 2: Create the order with status Pending. Compensation: mark the order Failed
+// Step 3: Send the pending order event to the campaign service. Compensation: send the failed order event
+// Step 4: Send the payment request and mark the order Processing, then wait for the payment response
+// Steps 5-7 are never compensated by an error other than a failed payment, they are retried forward
+// Step 5: Mark the order Success. A failed payment fails this step, which compensates steps 1-4
+// Step 6: Deduct the reserved stock from the product stock
+// Step 7: Send the successful order event to the campaign service
+func NewPlacementSaga(
+	inventoryService primary.InventoryService,
+	orderRepo secondary.OrderRepository,
+	paymentPublisher secondary.PaymentRepository,
+	campaignPublisher secondary.CampaignRepository,
+) sagaService.Definition {
+	p := &placementSaga{
+		inventoryService:  inventoryService,
+		orderRepo:         orderRepo,
+		paymentPublisher:  paymentPublisher,
+		campaignPublisher: campaignPublisher,
+	}
+	return sagaService.Definition{
+		Type: saga.SagaTypeOrderPlacement,
+		Steps: []sagaService.Step{
+			{Name: stepReserveStock, Execute: withPayload(p.reserveStock), Compensate: withPayload(p.releaseStock)},
+			{Name: stepCreateOrder, Execute: withPayload(p.createOrder), Compensate: withPayload(p.failOrder)},
+			{Name: stepNotifyCampaignPending, Execute: withPayload(p.notifyCampaign), Compensate: withPayload(p.notifyCampaignFailed)},
+			{Name: stepRequestPayment, Execute: withPayload(p.requestPayment), AwaitEvent: true, Pivot: true},
+			{Name: stepCompleteOrder, Execute: withPayload(p.completeOrder)},
+			{Name: stepConfirmStock, Execute: withPayload(p.confirmStock)},
+			{Name: stepNotifyCampaignResult, Execute: withPayload(p.notifyCampaign)},
+		},
+	}
+}
+
+// withPayload decodes the saga payload before the step and stores the updated payload after it
+func withPayload(fn func(ctx context.Context, payload *placementPayload) error) func(ctx context.Context, s *saga.Saga) error {
+	return func(ctx context.Context, s *saga.Saga) error {
+		var payload placementPayload
+		if err := s.DecodePayload(&payload); err != nil {
+			return err
+		}
+		if err := fn(ctx, &payload); err != nil {
+			return err
+		}
+		return s.EncodePayload(payload)
+	}
+}
+
+func (p *placementSaga) reserveStock(ctx context.Context, payload *placementPayload) error {
+	err := p.inventoryService.Reserve(ctx, payload.Order.Id, payload.Order.Items)
+	if errors.Is(err, inventory.ErrOutOfStock) {
+		return sagaService.WithReason(failureReasonOutOfStock, err)
+	}
+	return err
+}
+
+func (p *placementSaga) releaseStock(ctx context.Context, payload *placementPayload) error {
+	return p.inventoryService.Release(ctx, payload.Order.Id)
+}
+
+func (p *placementSaga) confirmStock(ctx context.Context, payload *placementPayload) error {
+	return p.inventoryService.Confirm(ctx, payload.Order.Id)
+}
+
+func (p *placementSaga) createOrder(ctx context.Context, payload *placementPayload) error {
+	created, err := p.orderRepo.Create(ctx, payload.Order)
+	if err != nil {
+		return err
+	}
+	payload.Order = created
+	return nil
+}
+
+func (p *placementSaga) failOrder(ctx context.Context, payload *placementPayload) error {
+	failed, err := p.orderRepo.UpdateStatusById(ctx, payload.Order.Id, order.OrderStatusFailed)
+	if err != nil {
+		return err
+	}
+	payload.Order = failed
+	return nil
+}
+
+func (p *placementSaga) notifyCampaign(ctx context.Context, payload *placementPayload) error {
+	return p.campaignPublisher.SendOrderEvent(ctx, payload.Order)
+}
+
+func (p *placementSaga) notifyCampaignFailed(ctx context.Context, payload *placementPayload) error {
+	failed := payload.Order
+	failed.Status = order.OrderStatusFailed
+	return p.campaignPublisher.SendOrderEvent(ctx, failed)
+}
+
+func (p *placementSaga) requestPayment(ctx context.Context, payload *placementPayload) error {
+	err := p.paymentPublisher.SendPaymentRequest(ctx, payment.ProcessPaymentRequest{
+		OrderId:     payload.Order.Id,
+		CustomerId:  payload.Order.CustomerId,
+		TotalAmount: payload.Order.TotalAmount,
+		TimeProcess: payload.TimeProcess,
+		Items:       payload.Order.Items,
+	})
+	if err != nil {
+		return err
+	}
+	processing, err := p.orderRepo.UpdateStatusById(ctx, payload.Order.Id, order.OrderStatusProcessing)
+	if err != nil {
+		return err
+	}
+	payload.Order = processing
+	return nil
+}
+
+func (p *placementSaga) completeOrder(ctx context.Context, payload *placementPayload) error {
+	if payload.PaymentStatus != payment.PaymentStatusSuccess {
+		err := fmt.Errorf("%w: %s", errPaymentFailed, payload.DeclineReason)
+		return sagaService.Compensate(sagaService.WithReason(failureReasonPaymentFailed, err))
+	}
+	succeeded, err := p.orderRepo.UpdateStatusById(ctx, payload.Order.Id, order.OrderStatusSuccess)
+	if err != nil {
+		return err
+	}
+	payload.Order = succeeded
+	return nil
+}
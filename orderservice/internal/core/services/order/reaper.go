@@ -0,0 +1,203 @@
+package order
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"log/slog"
+	"specommerce/orderservice/internal/core/domain/order"
+	"specommerce/orderservice/internal/core/domain/payment"
+	"specommerce/orderservice/internal/core/domain/saga"
+	"specommerce/orderservice/internal/core/ports/primary"
+	"specommerce/orderservice/internal/core/ports/secondary"
+	sagaService "specommerce/orderservice/internal/core/services/saga"
+	"specommerce/orderservice/pkg/atomicity"
+	"specommerce/orderservice/pkg/database"
+	"specommerce/orderservice/pkg/service_config"
+	"specommerce/orderservice/pkg/shutdown"
+	"time"
+)
+
+// reapedOrders counts the stuck orders resolved by the reaper by outcome (SUCCESS, FAILED or TIMEOUT), exposed on /debug/vars
+var reapedOrders = expvar.NewMap("orders_reaped")
+
+var errOrderTimeout = errors.New("order timed out: " + payment.DeclineReasonTimeout)
+
+// Reaper periodically resolves the orders left PENDING or PROCESSING past reaper.stuckAfter.
+// An order whose saga waits for a lost payment response is resumed with the payment known by the
+// payment service, or failed with the TIMEOUT reason once its payment is cancelled on the payment service,
+// see resolvePayment. An order whose payment is still being processed is left for the next round.
+// A PENDING order whose saga never reached the payment request and was not updated for reaper.stuckAfter
+// either is compensated with the TIMEOUT reason.
+// An order without saga is settled directly. Every resolved order emits its order event to the campaigns.
+type Reaper struct {
+	orderRepo         secondary.OrderRepository
+	sagaRepo          secondary.SagaRepository
+	paymentStatusRepo secondary.PaymentStatusRepository
+	campaignPublisher secondary.CampaignRepository
+	inventoryService  primary.InventoryService
+	orderService      primary.OrderService
+	orchestrator      *sagaService.Orchestrator
+	atomicExecutor    atomicity.AtomicExecutor
+	config            service_config.ReaperConfig
+	shutdownTask      *shutdown.Tasks
+	logger            *slog.Logger
+}
+
+func NewReaper(
+	orderRepo secondary.OrderRepository,
+	sagaRepo secondary.SagaRepository,
+	paymentStatusRepo secondary.PaymentStatusRepository,
+	campaignPublisher secondary.CampaignRepository,
+	inventoryService primary.InventoryService,
+	orderService primary.OrderService,
+	orchestrator *sagaService.Orchestrator,
+	atomicExecutor atomicity.AtomicExecutor,
+	cfg service_config.ReaperConfig,
+	shutdownTask *shutdown.Tasks,
+	logger *slog.Logger,
+) *Reaper {
+	return &Reaper{
+		orderRepo:         orderRepo,
+		sagaRepo:          sagaRepo,
+		paymentStatusRepo: paymentStatusRepo,
+		campaignPublisher: campaignPublisher,
+		inventoryService:  inventoryService,
+		orderService:      orderService,
+		orchestrator:      orchestrator,
+		atomicExecutor:    atomicExecutor,
+		config:            cfg,
+		shutdownTask:      shutdownTask,
+		logger:            logger,
+	}
+}
+
+func (r *Reaper) Start() error {
+	r.logger.Info("Starting stuck order reaper",
+		slog.Duration("interval", r.config.Interval),
+		slog.Duration("stuck_after", r.config.StuckAfter),
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	r.shutdownTask.AddShutdownTask(
+		func(_ context.Context) error {
+			cancel()
+			<-stopped
+			return nil
+		},
+	)
+	defer close(stopped)
+
+	ticker := time.NewTicker(r.config.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			r.reapStuck(ctx)
+		}
+	}
+}
+
+func (r *Reaper) reapStuck(ctx context.Context) {
+	stuck, err := r.orderRepo.FindStuck(ctx,
+		[]order.OrderStatus{order.OrderStatusPending, order.OrderStatusProcessing},
+		time.Now().Add(-r.config.StuckAfter),
+		r.config.BatchSize,
+	)
+	if err != nil {
+		r.logger.Error("Failed to find stuck orders", slog.String("error", err.Error()))
+		return
+	}
+	for _, input := range stuck {
+		outcome, err := r.reap(ctx, input)
+		if err != nil {
+			r.logger.Error("Failed to reap stuck order",
+				slog.String("order_id", input.Id.String()),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		if outcome == "" {
+			continue
+		}
+		reapedOrders.Add(outcome, 1)
+		r.logger.Info("Reaped stuck order",
+			slog.String("order_id", input.Id.String()),
+			slog.String("order_status", input.Status.String()),
+			slog.String("outcome", outcome),
+		)
+	}
+}
+
+// reap resolves a stuck order and returns the outcome, or an empty outcome when the order is left
+// to the saga resumer or to a payment in progress
+func (r *Reaper) reap(ctx context.Context, input order.Order) (string, error) {
+	placement, err := r.sagaRepo.GetByOrderId(ctx, saga.SagaTypeOrderPlacement, input.Id)
+	switch {
+	case errors.Is(err, database.ErrRecordNotFound):
+		return r.settle(ctx, input)
+	case err != nil:
+		return "", err
+	case placement.Status == saga.SagaStatusWaiting:
+		response, resolved, err := r.orderService.ExpirePayment(ctx, input.Id, payment.DeclineReasonTimeout)
+		if err != nil || !resolved {
+			return "", err
+		}
+		return outcome(response), nil
+	case input.Status == order.OrderStatusPending &&
+		(placement.Status == saga.SagaStatusRunning || placement.Status == saga.SagaStatusCompensating):
+		if placement.UpdatedAt.After(time.Now().Add(-r.config.StuckAfter)) {
+			// The saga resumer is still retrying the saga, it is compensated once it stops moving
+			return "", nil
+		}
+		// The payment was never requested, so the steps before it can be undone
+		if _, err = r.orchestrator.Abort(ctx, placement.Id, errOrderTimeout); err != nil {
+			return "", err
+		}
+		return payment.DeclineReasonTimeout, nil
+	default:
+		// The saga is past the payment response, the saga resumer completes it
+		return "", nil
+	}
+}
+
+// settle resolves an order without saga from its payment in one transaction: the order status,
+// the stock reservation and the order event to the campaigns
+func (r *Reaper) settle(ctx context.Context, input order.Order) (string, error) {
+	response, resolved, err := resolvePayment(ctx, r.paymentStatusRepo, input.Id, payment.DeclineReasonTimeout)
+	if err != nil || !resolved {
+		return "", err
+	}
+	status := order.OrderStatusFailed
+	if response.PaymentStatus == payment.PaymentStatusSuccess {
+		status = order.OrderStatusSuccess
+	}
+	err = r.atomicExecutor.Execute(ctx, func(tc context.Context) error {
+		settled, err := r.orderRepo.UpdateStatusById(tc, input.Id, status)
+		if err != nil {
+			return err
+		}
+		if status == order.OrderStatusSuccess {
+			err = r.inventoryService.Confirm(tc, input.Id)
+		} else {
+			err = r.inventoryService.Release(tc, input.Id)
+		}
+		if err != nil {
+			return err
+		}
+		return r.campaignPublisher.SendOrderEvent(tc, settled)
+	})
+	if err != nil {
+		return "", err
+	}
+	return outcome(response), nil
+}
+
+func outcome(response payment.ProcessPaymentResponse) string {
+	if response.DeclineReason == payment.DeclineReasonTimeout {
+		return payment.DeclineReasonTimeout
+	}
+	return string(response.PaymentStatus)
+}
@@ -0,0 +1,47 @@
+package order
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"specommerce/orderservice/internal/core/domain/order"
+	"specommerce/orderservice/internal/core/domain/saga"
+	"specommerce/orderservice/internal/core/ports/secondary"
+	"specommerce/orderservice/pkg/service_config"
+	"testing"
+	"time"
+
+	"github.com/rs/xid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePlacementSagaRepository returns the same placement saga for every order
+type fakePlacementSagaRepository struct {
+	secondary.SagaRepository
+	placement saga.Saga
+}
+
+func (r *fakePlacementSagaRepository) GetByOrderId(_ context.Context, _ saga.SagaType, _ xid.ID) (saga.Saga, error) {
+	return r.placement, nil
+}
+
+func TestReap_LeavesSagaBeingRetried(t *testing.T) {
+	stuckAfter := 5 * time.Minute
+	input := order.Order{Id: xid.New(), Status: order.OrderStatusPending, CreatedAt: time.Now().Add(-time.Hour)}
+	sagaRepo := &fakePlacementSagaRepository{placement: saga.Saga{
+		Id:        xid.New(),
+		Type:      saga.SagaTypeOrderPlacement,
+		OrderId:   input.Id,
+		Status:    saga.SagaStatusRunning,
+		LastError: "database unavailable",
+		UpdatedAt: time.Now().Add(-time.Minute),
+	}}
+	// A nil orchestrator fails the test if the reaper tries to abort the saga
+	reaper := NewReaper(nil, sagaRepo, nil, nil, nil, nil, nil, nil,
+		service_config.ReaperConfig{StuckAfter: stuckAfter}, nil, slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+	outcome, err := reaper.reap(context.Background(), input)
+	require.NoError(t, err)
+	assert.Empty(t, outcome)
+}
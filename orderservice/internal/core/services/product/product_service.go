@@ -0,0 +1,83 @@
+package product
+
+import (
+	"context"
+	"fmt"
+	"github.com/rs/xid"
+	"specommerce/orderservice/internal/core/domain/product"
+	"specommerce/orderservice/internal/core/ports/primary"
+	"specommerce/orderservice/internal/core/ports/secondary"
+	"specommerce/orderservice/pkg/pagination"
+)
+
+type productService struct {
+	productRepo      secondary.ProductRepository
+	inventoryService primary.InventoryService
+}
+
+func NewProductService(productRepo secondary.ProductRepository, inventoryService primary.InventoryService) primary.ProductService {
+	return &productService{
+		productRepo:      productRepo,
+		inventoryService: inventoryService,
+	}
+}
+
+func (s *productService) CreateProduct(ctx context.Context, input product.Product) (product.Product, error) {
+	created, err := s.productRepo.Create(ctx, input)
+	if err != nil {
+		return product.Product{}, fmt.Errorf("productService CreateProduct %w", err)
+	}
+	return created, nil
+}
+
+// UpdateProduct replaces the product and applies the change of its stock to the available stock
+func (s *productService) UpdateProduct(ctx context.Context, input product.Product) (product.Product, error) {
+	errTemplate := "productService UpdateProduct %w"
+	existing, err := s.productRepo.GetById(ctx, input.Id)
+	if err != nil {
+		return product.Product{}, fmt.Errorf(errTemplate, err)
+	}
+	updated, err := s.productRepo.Update(ctx, input)
+	if err != nil {
+		return product.Product{}, fmt.Errorf(errTemplate, err)
+	}
+	switch {
+	case existing.Sku != updated.Sku:
+		err = s.inventoryService.ResetStock(ctx, existing.Sku)
+	case existing.Stock != updated.Stock:
+		err = s.inventoryService.AdjustStock(ctx, updated.Sku, updated.Stock-existing.Stock)
+	}
+	if err != nil {
+		return product.Product{}, fmt.Errorf(errTemplate, err)
+	}
+	return updated, nil
+}
+
+// DeleteProduct removes the product from the catalog.
+// Placed orders are not affected because order items keep a copy of the product name and price.
+func (s *productService) DeleteProduct(ctx context.Context, id xid.ID) error {
+	errTemplate := "productService DeleteProduct %w"
+	existing, err := s.productRepo.GetById(ctx, id)
+	if err != nil {
+		return fmt.Errorf(errTemplate, err)
+	}
+	if err = s.productRepo.DeleteById(ctx, id); err != nil {
+		return fmt.Errorf(errTemplate, err)
+	}
+	if err = s.inventoryService.ResetStock(ctx, existing.Sku); err != nil {
+		return fmt.Errorf(errTemplate, err)
+	}
+	return nil
+}
+
+func (s *productService) GetProduct(ctx context.Context, id xid.ID) (product.Product, error) {
+	result, err := s.productRepo.GetById(ctx, id)
+	if err != nil {
+		return product.Product{}, fmt.Errorf("productService GetProduct %w", err)
+	}
+	return result, nil
+}
+
+func (s *productService) SearchProducts(ctx context.Context, filter secondary.SearchProductsFilter) (pagination.Page[product.Product], error) {
+	return s.productRepo.SearchProducts(ctx, filter)
+}
@@ -0,0 +1,333 @@
+// Package saga implements a persisted, resumable saga orchestrator.
+//
+// A saga is a sequence of steps. Every step runs in its own transaction together with the
+// saga state update, so progress is committed exactly when the step's writes are (events are
+// staged in the outbox within the same transaction). When a step fails, the steps already
+// committed are compensated in reverse order. A saga interrupted by a crash is picked up
+// again by the Resumer from the last committed step.
+//
+// Once its pivot step has executed, a saga can no longer be undone: a failed step is retried
+// forward by the Resumer instead, unless it fails with an error wrapped by Compensate.
+package saga
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/rs/xid"
+	"log/slog"
+	"specommerce/orderservice/internal/core/domain/saga"
+	"specommerce/orderservice/internal/core/ports/secondary"
+	"specommerce/orderservice/pkg/atomicity"
+)
+
+var ErrSagaNotWaiting = errors.New("saga is not waiting for an event")
+
+// compensateError is a step failure past the pivot step that still compensates the saga
+type compensateError struct {
+	err error
+}
+
+func (e compensateError) Error() string {
+	return e.err.Error()
+}
+
+func (e compensateError) Unwrap() error {
+	return e.err
+}
+
+// Compensate marks the failure of a step past the pivot step as a business outcome that
+// compensates the saga, such as a declined payment, rather than an error to retry.
+func Compensate(err error) error {
+	return compensateError{err: err}
+}
+
+// reasonError is a step failure with the code of its business reason
+type reasonError struct {
+	reason string
+	err    error
+}
+
+func (e reasonError) Error() string {
+	return e.err.Error()
+}
+
+func (e reasonError) Unwrap() error {
+	return e.err
+}
+
+// WithReason attaches the code of its business reason to a step failure, it is recorded as the
+// FailureReason of the saga so callers do not have to parse its LastError.
+func WithReason(reason string, err error) error {
+	return reasonError{reason: reason, err: err}
+}
+
+// Step is one unit of work of a saga.
+// Execute and Compensate receive a transactional context and may update the saga payload.
+// A step with AwaitEvent parks the saga in WAITING once it has executed, until Resume is called.
+// A Pivot step commits the saga to completion once it has executed, see Compensate.
+type Step struct {
+	Name       string
+	Execute    func(ctx context.Context, s *saga.Saga) error
+	Compensate func(ctx context.Context, s *saga.Saga) error
+	AwaitEvent bool
+	Pivot      bool
+}
+
+// Definition is the ordered list of steps of a saga type
+type Definition struct {
+	Type  saga.SagaType
+	Steps []Step
+}
+
+type Orchestrator struct {
+	sagaRepo       secondary.SagaRepository
+	atomicExecutor atomicity.AtomicExecutor
+	definitions    map[saga.SagaType]Definition
+	logger         *slog.Logger
+}
+
+func NewOrchestrator(
+	sagaRepo secondary.SagaRepository,
+	atomicExecutor atomicity.AtomicExecutor,
+	logger *slog.Logger,
+	definitions ...Definition,
+) *Orchestrator {
+	registry := make(map[saga.SagaType]Definition, len(definitions))
+	for _, definition := range definitions {
+		registry[definition.Type] = definition
+	}
+	return &Orchestrator{
+		sagaRepo:       sagaRepo,
+		atomicExecutor: atomicExecutor,
+		definitions:    registry,
+		logger:         logger,
+	}
+}
+
+// Start persists a new saga and runs it until it completes, waits for an event or is compensated.
+// The returned error reports infrastructure failures only, the business outcome is the saga status.
+func (o *Orchestrator) Start(ctx context.Context, input saga.Saga) (saga.Saga, error) {
+	errTemplate := "sagaOrchestrator Start %w"
+	if _, ok := o.definitions[input.Type]; !ok {
+		return saga.Saga{}, fmt.Errorf(errTemplate, fmt.Errorf("unknown saga type %s", input.Type))
+	}
+	created, err := o.sagaRepo.Create(ctx, input)
+	if err != nil {
+		return saga.Saga{}, fmt.Errorf(errTemplate, err)
+	}
+	result, err := o.Run(ctx, created.Id)
+	if err != nil {
+		return saga.Saga{}, fmt.Errorf(errTemplate, err)
+	}
+	return result, nil
+}
+
+// Resume delivers an event to a waiting saga through onEvent and continues running it.
+// It returns ErrSagaNotWaiting when the saga already moved on, e.g. for a redelivered event.
+func (o *Orchestrator) Resume(ctx context.Context, sagaType saga.SagaType, orderId xid.ID, onEvent func(s *saga.Saga) error) (saga.Saga, error) {
+	errTemplate := "sagaOrchestrator Resume %w"
+	existing, err := o.sagaRepo.GetByOrderId(ctx, sagaType, orderId)
+	if err != nil {
+		return saga.Saga{}, fmt.Errorf(errTemplate, err)
+	}
+	txErr := o.atomicExecutor.Execute(
+		ctx, func(tc context.Context) error {
+			current, err := o.sagaRepo.LockById(tc, existing.Id)
+			if err != nil {
+				return err
+			}
+			if current.Status != saga.SagaStatusWaiting {
+				existing = current
+				return ErrSagaNotWaiting
+			}
+			if err = onEvent(&current); err != nil {
+				return err
+			}
+			current.Status = saga.SagaStatusRunning
+			_, err = o.sagaRepo.Update(tc, current)
+			return err
+		},
+	)
+	if errors.Is(txErr, ErrSagaNotWaiting) {
+		return existing, ErrSagaNotWaiting
+	}
+	if txErr != nil {
+		return saga.Saga{}, fmt.Errorf(errTemplate, txErr)
+	}
+	result, err := o.Run(ctx, existing.Id)
+	if err != nil {
+		return saga.Saga{}, fmt.Errorf(errTemplate, err)
+	}
+	return result, nil
+}
+
+// Abort gives up a running saga: reason is recorded as the failure of its current step and the
+// steps already committed are compensated. A saga past its pivot step is retried forward instead.
+// A saga already compensating is run again, a saga in any other status is returned as is.
+func (o *Orchestrator) Abort(ctx context.Context, id xid.ID, reason error) (saga.Saga, error) {
+	errTemplate := "sagaOrchestrator Abort %w"
+	existing, err := o.sagaRepo.GetById(ctx, id)
+	if err != nil {
+		return saga.Saga{}, fmt.Errorf(errTemplate, err)
+	}
+	if existing.Status == saga.SagaStatusRunning && !pastPivot(o.definitions[existing.Type].Steps, existing.CurrentStep) {
+		// The saga is locked again, so a saga that moved on in the meantime is left as is
+		if _, _, err = o.fail(ctx, id, reason); err != nil {
+			return saga.Saga{}, fmt.Errorf(errTemplate, err)
+		}
+	}
+	result, err := o.Run(ctx, id)
+	if err != nil {
+		return saga.Saga{}, fmt.Errorf(errTemplate, err)
+	}
+	return result, nil
+}
+
+// Run advances a saga one step at a time until it completes, waits for an event or is compensated.
+// It is safe to call concurrently for the same saga: every step locks the saga row first.
+func (o *Orchestrator) Run(ctx context.Context, id xid.ID) (saga.Saga, error) {
+	for {
+		current, done, err := o.advance(ctx, id)
+		if err != nil || done {
+			return current, err
+		}
+	}
+}
+
+// advance executes or compensates exactly one step in its own transaction
+func (o *Orchestrator) advance(ctx context.Context, id xid.ID) (saga.Saga, bool, error) {
+	var current saga.Saga
+	var stepErr error
+	done := false
+	txErr := o.atomicExecutor.Execute(
+		ctx, func(tc context.Context) error {
+			s, err := o.sagaRepo.LockById(tc, id)
+			if err != nil {
+				return err
+			}
+			steps := o.definitions[s.Type].Steps
+			var log saga.StepLog
+			switch s.Status {
+			case saga.SagaStatusRunning:
+				if s.CurrentStep >= len(steps) {
+					s.Status = saga.SagaStatusCompleted
+					break
+				}
+				step := steps[s.CurrentStep]
+				if stepErr = step.Execute(tc, &s); stepErr != nil {
+					return stepErr
+				}
+				log = saga.StepLog{SagaId: s.Id, Step: step.Name, Action: saga.StepActionExecute}
+				s.CurrentStep++
+				switch {
+				case s.CurrentStep == len(steps):
+					s.Status = saga.SagaStatusCompleted
+				case step.AwaitEvent:
+					s.Status = saga.SagaStatusWaiting
+				}
+			case saga.SagaStatusCompensating:
+				if s.CurrentStep == 0 {
+					s.Status = saga.SagaStatusCompensated
+					break
+				}
+				step := steps[s.CurrentStep-1]
+				if step.Compensate != nil {
+					if stepErr = step.Compensate(tc, &s); stepErr != nil {
+						return stepErr
+					}
+				}
+				log = saga.StepLog{SagaId: s.Id, Step: step.Name, Action: saga.StepActionCompensate}
+				s.CurrentStep--
+				if s.CurrentStep == 0 {
+					s.Status = saga.SagaStatusCompensated
+				}
+			default:
+				current = s
+				done = true
+				return nil
+			}
+			if log.Step != "" {
+				if err = o.sagaRepo.AddStepLog(tc, log); err != nil {
+					return err
+				}
+			}
+			current, err = o.sagaRepo.Update(tc, s)
+			return err
+		},
+	)
+	if stepErr != nil {
+		return o.fail(ctx, id, stepErr)
+	}
+	if txErr != nil {
+		return saga.Saga{}, false, txErr
+	}
+	return current, done, nil
+}
+
+// fail records a step failure. A failed execution switches the saga to compensation, unless
+// the saga is past its pivot step. A failed execution past the pivot step, or a failed
+// compensation, stops the run and is retried later by the Resumer.
+func (o *Orchestrator) fail(ctx context.Context, id xid.ID, stepErr error) (saga.Saga, bool, error) {
+	var current saga.Saga
+	done := false
+	txErr := o.atomicExecutor.Execute(
+		ctx, func(tc context.Context) error {
+			s, err := o.sagaRepo.LockById(tc, id)
+			if err != nil {
+				return err
+			}
+			steps := o.definitions[s.Type].Steps
+			log := saga.StepLog{SagaId: s.Id, Error: stepErr.Error()}
+			switch s.Status {
+			case saga.SagaStatusRunning:
+				log.Step, log.Action = steps[s.CurrentStep].Name, saga.StepActionExecute
+				s.FailureReason = ""
+				if reason := (reasonError{}); errors.As(stepErr, &reason) {
+					s.FailureReason = reason.reason
+				}
+				if pastPivot(steps, s.CurrentStep) && !errors.As(stepErr, &compensateError{}) {
+					done = true
+					break
+				}
+				s.Status = saga.SagaStatusCompensating
+			case saga.SagaStatusCompensating:
+				log.Step, log.Action = steps[s.CurrentStep-1].Name, saga.StepActionCompensate
+				done = true
+			default:
+				current = s
+				done = true
+				return nil
+			}
+			s.LastError = stepErr.Error()
+			if err = o.sagaRepo.AddStepLog(tc, log); err != nil {
+				return err
+			}
+			current, err = o.sagaRepo.Update(tc, s)
+			return err
+		},
+	)
+	if txErr != nil {
+		return saga.Saga{}, false, txErr
+	}
+	o.logger.Error("Saga step failed",
+		slog.String("saga_id", current.Id.String()),
+		slog.String("order_id", current.OrderId.String()),
+		slog.String("status", current.Status.String()),
+		slog.String("error", stepErr.Error()),
+	)
+	if done {
+		return current, true, stepErr
+	}
+	return current, false, nil
+}
+
+// pastPivot reports whether the pivot step is among the steps before the current one
+func pastPivot(steps []Step, currentStep int) bool {
+	for _, step := range steps[:currentStep] {
+		if step.Pivot {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,149 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"specommerce/orderservice/internal/core/domain/saga"
+	"specommerce/orderservice/internal/core/ports/secondary"
+	"specommerce/orderservice/pkg/pagination"
+	"testing"
+	"time"
+
+	"github.com/rs/xid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSagaRepository keeps the sagas and their step logs in memory
+type fakeSagaRepository struct {
+	sagas map[xid.ID]saga.Saga
+	logs  []saga.StepLog
+}
+
+func newFakeSagaRepository() *fakeSagaRepository {
+	return &fakeSagaRepository{sagas: make(map[xid.ID]saga.Saga)}
+}
+
+func (r *fakeSagaRepository) Create(_ context.Context, s saga.Saga) (saga.Saga, error) {
+	r.sagas[s.Id] = s
+	return s, nil
+}
+
+func (r *fakeSagaRepository) Update(_ context.Context, s saga.Saga) (saga.Saga, error) {
+	r.sagas[s.Id] = s
+	return s, nil
+}
+
+func (r *fakeSagaRepository) GetById(_ context.Context, id xid.ID) (saga.Saga, error) {
+	return r.sagas[id], nil
+}
+
+func (r *fakeSagaRepository) LockById(_ context.Context, id xid.ID) (saga.Saga, error) {
+	return r.sagas[id], nil
+}
+
+func (r *fakeSagaRepository) GetByOrderId(_ context.Context, sagaType saga.SagaType, orderId xid.ID) (saga.Saga, error) {
+	for _, s := range r.sagas {
+		if s.Type == sagaType && s.OrderId == orderId {
+			return s, nil
+		}
+	}
+	return saga.Saga{}, errors.New("saga not found")
+}
+
+func (r *fakeSagaRepository) FindStale(_ context.Context, _ []saga.SagaStatus, _ time.Time, _ int) ([]saga.Saga, error) {
+	return nil, nil
+}
+
+func (r *fakeSagaRepository) SearchSagas(_ context.Context, _ secondary.SearchSagasFilter) (pagination.Page[saga.Saga], error) {
+	return pagination.Page[saga.Saga]{}, nil
+}
+
+func (r *fakeSagaRepository) AddStepLog(_ context.Context, log saga.StepLog) error {
+	r.logs = append(r.logs, log)
+	return nil
+}
+
+func (r *fakeSagaRepository) GetStepLogs(_ context.Context, _ xid.ID) ([]saga.StepLog, error) {
+	return r.logs, nil
+}
+
+type fakeAtomicExecutor struct{}
+
+func (fakeAtomicExecutor) Execute(ctx context.Context, executeFunc func(ctx context.Context) error) error {
+	return executeFunc(ctx)
+}
+
+// pivotDefinition runs a compensable step, the pivot step and a last step failing with the given errors
+func pivotDefinition(compensated *[]string, lastStepErrs ...error) Definition {
+	record := func(name string) func(context.Context, *saga.Saga) error {
+		return func(context.Context, *saga.Saga) error {
+			*compensated = append(*compensated, name)
+			return nil
+		}
+	}
+	noop := func(context.Context, *saga.Saga) error { return nil }
+	return Definition{
+		Type: saga.SagaTypeOrderPlacement,
+		Steps: []Step{
+			{Name: "reserve", Execute: noop, Compensate: record("reserve")},
+			{Name: "pay", Execute: noop, Compensate: record("pay"), Pivot: true},
+			{Name: "complete", Execute: func(context.Context, *saga.Saga) error {
+				if len(lastStepErrs) == 0 {
+					return nil
+				}
+				err := lastStepErrs[0]
+				lastStepErrs = lastStepErrs[1:]
+				return err
+			}},
+		},
+	}
+}
+
+func newTestSaga(t *testing.T) saga.Saga {
+	s, err := saga.New(saga.SagaTypeOrderPlacement, xid.New(), struct{}{})
+	require.NoError(t, err)
+	return s
+}
+
+func TestOrchestrator_FailureAfterPivotIsRetriedForward(t *testing.T) {
+	repo := newFakeSagaRepository()
+	var compensated []string
+	stepErr := errors.New("database unavailable")
+	orchestrator := NewOrchestrator(repo, fakeAtomicExecutor{}, slog.New(slog.NewTextHandler(io.Discard, nil)), pivotDefinition(&compensated, stepErr))
+
+	created, err := repo.Create(context.Background(), newTestSaga(t))
+	require.NoError(t, err)
+
+	failed, err := orchestrator.Run(context.Background(), created.Id)
+	assert.ErrorIs(t, err, stepErr)
+	assert.Equal(t, saga.SagaStatusRunning, failed.Status)
+	assert.Equal(t, 2, failed.CurrentStep)
+	assert.Equal(t, stepErr.Error(), failed.LastError)
+	assert.Empty(t, failed.FailureReason)
+	assert.Empty(t, compensated)
+
+	aborted, err := orchestrator.Abort(context.Background(), created.Id, errors.New("order timed out"))
+	require.NoError(t, err)
+	assert.Equal(t, saga.SagaStatusCompleted, aborted.Status)
+	assert.Empty(t, compensated)
+}
+
+func TestOrchestrator_CompensatedFailureAfterPivot(t *testing.T) {
+	repo := newFakeSagaRepository()
+	var compensated []string
+	stepErr := Compensate(WithReason("PAYMENT_FAILED", errors.New("payment failed")))
+	orchestrator := NewOrchestrator(repo, fakeAtomicExecutor{}, slog.New(slog.NewTextHandler(io.Discard, nil)), pivotDefinition(&compensated, stepErr))
+
+	created, err := repo.Create(context.Background(), newTestSaga(t))
+	require.NoError(t, err)
+
+	result, err := orchestrator.Run(context.Background(), created.Id)
+	require.NoError(t, err)
+	assert.Equal(t, saga.SagaStatusCompensated, result.Status)
+	assert.Equal(t, "payment failed", result.LastError)
+	assert.Equal(t, "PAYMENT_FAILED", result.FailureReason)
+	assert.Equal(t, []string{"pay", "reserve"}, compensated)
+}
@@ -0,0 +1,92 @@
+package saga
+
+import (
+	"context"
+	"log/slog"
+	"specommerce/orderservice/internal/core/domain/saga"
+	"specommerce/orderservice/internal/core/ports/secondary"
+	"specommerce/orderservice/pkg/service_config"
+	"specommerce/orderservice/pkg/shutdown"
+	"time"
+)
+
+// Resumer periodically picks up sagas that stopped making progress, either because the process
+// crashed between two steps or because a compensation failed, and runs them again.
+type Resumer struct {
+	orchestrator *Orchestrator
+	sagaRepo     secondary.SagaRepository
+	config       service_config.SagaConfig
+	shutdownTask *shutdown.Tasks
+	logger       *slog.Logger
+}
+
+func NewResumer(
+	orchestrator *Orchestrator,
+	sagaRepo secondary.SagaRepository,
+	cfg service_config.SagaConfig,
+	shutdownTask *shutdown.Tasks,
+	logger *slog.Logger,
+) *Resumer {
+	return &Resumer{
+		orchestrator: orchestrator,
+		sagaRepo:     sagaRepo,
+		config:       cfg,
+		shutdownTask: shutdownTask,
+		logger:       logger,
+	}
+}
+
+func (r *Resumer) Start() error {
+	r.logger.Info("Starting saga resumer",
+		slog.Duration("interval", r.config.ResumeInterval),
+		slog.Duration("stale_after", r.config.StaleAfter),
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	r.shutdownTask.AddShutdownTask(
+		func(_ context.Context) error {
+			cancel()
+			<-stopped
+			return nil
+		},
+	)
+	defer close(stopped)
+
+	ticker := time.NewTicker(r.config.ResumeInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			r.resumeStale(ctx)
+		}
+	}
+}
+
+func (r *Resumer) resumeStale(ctx context.Context) {
+	stale, err := r.sagaRepo.FindStale(ctx,
+		[]saga.SagaStatus{saga.SagaStatusRunning, saga.SagaStatusCompensating},
+		time.Now().Add(-r.config.StaleAfter),
+		r.config.BatchSize,
+	)
+	if err != nil {
+		r.logger.Error("Failed to find stale sagas", slog.String("error", err.Error()))
+		return
+	}
+	for _, s := range stale {
+		result, err := r.orchestrator.Run(ctx, s.Id)
+		if err != nil {
+			r.logger.Error("Failed to resume saga",
+				slog.String("saga_id", s.Id.String()),
+				slog.String("error", err.Error()),
+			)
+			continue
+		}
+		r.logger.Info("Resumed saga",
+			slog.String("saga_id", result.Id.String()),
+			slog.String("order_id", result.OrderId.String()),
+			slog.String("status", result.Status.String()),
+		)
+	}
+}
@@ -0,0 +1,38 @@
+package saga
+
+import (
+	"context"
+	"fmt"
+	"github.com/rs/xid"
+	"specommerce/orderservice/internal/core/domain/saga"
+	"specommerce/orderservice/internal/core/ports/primary"
+	"specommerce/orderservice/internal/core/ports/secondary"
+	"specommerce/orderservice/pkg/pagination"
+)
+
+type sagaService struct {
+	sagaRepo secondary.SagaRepository
+}
+
+func NewSagaService(sagaRepo secondary.SagaRepository) primary.SagaService {
+	return &sagaService{
+		sagaRepo: sagaRepo,
+	}
+}
+
+func (s *sagaService) GetSaga(ctx context.Context, id xid.ID) (saga.Saga, []saga.StepLog, error) {
+	errTemplate := "sagaService GetSaga %w"
+	result, err := s.sagaRepo.GetById(ctx, id)
+	if err != nil {
+		return saga.Saga{}, nil, fmt.Errorf(errTemplate, err)
+	}
+	steps, err := s.sagaRepo.GetStepLogs(ctx, id)
+	if err != nil {
+		return saga.Saga{}, nil, fmt.Errorf(errTemplate, err)
+	}
+	return result, steps, nil
+}
+
+func (s *sagaService) SearchSagas(ctx context.Context, filter secondary.SearchSagasFilter) (pagination.Page[saga.Saga], error) {
+	return s.sagaRepo.SearchSagas(ctx, filter)
+}
@@ -0,0 +1,57 @@
+package outbox
+
+import (
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/uptrace/bun"
+)
+
+type Status string
+
+const (
+	StatusPending Status = "PENDING"
+	StatusSent    Status = "SENT"
+	StatusFailed  Status = "FAILED"
+)
+
+// Message is a Kafka message staged in the outbox_messages table.
+// It is written in the same transaction as the business data and published later by the Relay.
+type Message struct {
+	bun.BaseModel `bun:"outbox_messages"`
+	Id            int64          `bun:"id,pk,autoincrement"`
+	Topic         string         `bun:"topic,notnull"`
+	Key           []byte         `bun:"message_key"`
+	Payload       []byte         `bun:"payload,notnull"`
+	Headers       []kafka.Header `bun:"headers,type:jsonb,notnull"`
+	Status        Status         `bun:"status,notnull,default:'PENDING'"`
+	Attempts      int            `bun:"attempts,notnull"`
+	LastError     string         `bun:"last_error,notnull"`
+	SentAt        bun.NullTime   `bun:"sent_at"`
+	ClaimedUntil  bun.NullTime   `bun:"claimed_until"`
+	CreatedAt     time.Time      `bun:",nullzero,notnull,default:current_timestamp,skipupdate"`
+	UpdatedAt     time.Time      `bun:",nullzero,notnull,default:current_timestamp"`
+}
+
+func FromKafkaMessage(message kafka.Message) Message {
+	headers := message.Headers
+	if headers == nil {
+		headers = []kafka.Header{}
+	}
+	return Message{
+		Topic:   message.Topic,
+		Key:     message.Key,
+		Payload: message.Value,
+		Headers: headers,
+		Status:  StatusPending,
+	}
+}
+
+func (m Message) ToKafkaMessage() kafka.Message {
+	return kafka.Message{
+		Topic:   m.Topic,
+		Key:     m.Key,
+		Value:   m.Payload,
+		Headers: m.Headers,
+	}
+}
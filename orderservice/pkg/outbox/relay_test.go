@@ -0,0 +1,57 @@
+package outbox
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitPublishResult(t *testing.T) {
+	messages := []Message{
+		{Id: 1, Topic: "orders", Key: []byte("a")},
+		{Id: 2, Topic: "orders", Key: []byte("b")},
+		{Id: 3, Topic: "orders", Key: []byte("a")},
+		{Id: 4, Topic: "payments", Key: []byte("a")},
+		{Id: 5, Topic: "orders", Key: []byte("a")},
+		{Id: 6, Topic: "orders"},
+	}
+	writeErr := errors.New("leader not available")
+
+	tests := []struct {
+		name       string
+		publishErr error
+		wantSent   []int64
+		wantFailed []int64
+	}{
+		{
+			name:     "whole batch published",
+			wantSent: []int64{1, 2, 3, 4, 5, 6},
+		},
+		{
+			name:       "whole batch failed keeps the later messages of each key pending",
+			publishErr: writeErr,
+			wantFailed: []int64{1, 2, 4, 6},
+		},
+		{
+			name:       "later messages of a failed key are held even when they reached kafka",
+			publishErr: kafka.WriteErrors{writeErr, nil, nil, nil, writeErr, nil},
+			wantSent:   []int64{2, 4, 6},
+			wantFailed: []int64{1},
+		},
+		{
+			name:       "messages without key are not held",
+			publishErr: kafka.WriteErrors{nil, nil, nil, nil, nil, writeErr},
+			wantSent:   []int64{1, 2, 3, 4, 5},
+			wantFailed: []int64{6},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sentIds, failedIds := splitPublishResult(messages, tt.publishErr)
+			assert.Equal(t, tt.wantSent, sentIds)
+			assert.Equal(t, tt.wantFailed, failedIds)
+		})
+	}
+}
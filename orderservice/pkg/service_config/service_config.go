@@ -1,5 +1,7 @@
 package service_config
 
+import "time"
+
 type DbConfig struct {
 	User            string `koanf:"user"`
 	Password        string `koanf:"password"`
@@ -12,11 +14,68 @@ type DbConfig struct {
 }
 
 type KafkaConfig struct {
-	Host            string `koanf:"host"`
-	Retry           int    `koanf:"retry"`
-	AutoCreateTopic bool   `koanf:"autoCreateTopic"`
-	Topic           string `koanf:"topic"`
-	ConsumerGroup   string `koanf:"consumerGroup"`
+	Host            string        `koanf:"host"`
+	Retry           int           `koanf:"retry"`
+	AutoCreateTopic bool          `koanf:"autoCreateTopic"`
+	Topic           string        `koanf:"topic"`
+	ConsumerGroup   string        `koanf:"consumerGroup"`
+	Workers         int           `koanf:"workers"`      // Consumer workers, messages with the same key go to the same worker
+	QueueSize       int           `koanf:"queueSize"`    // Messages buffered per worker before fetching blocks
+	RetryBackoff    time.Duration `koanf:"retryBackoff"` // Initial handler retry backoff, doubled on every retry
+	RetryTopics     int           `koanf:"retryTopics"`  // Delay topics <topic>.retry.N a failed message goes through before <topic>.dlq
+}
+
+// OutboxConfig defines how the outbox relay drains staged messages to Kafka
+type OutboxConfig struct {
+	PollInterval   time.Duration `koanf:"pollInterval"`
+	BatchSize      int           `koanf:"batchSize"`
+	MaxAttempts    int           `koanf:"maxAttempts"`
+	MaxBackoff     time.Duration `koanf:"maxBackoff"`
+	PublishTimeout time.Duration `koanf:"publishTimeout"`
+}
+
+// SagaConfig defines how often interrupted sagas are resumed
+type SagaConfig struct {
+	ResumeInterval time.Duration `koanf:"resumeInterval"`
+	StaleAfter     time.Duration `koanf:"staleAfter"`
+	BatchSize      int           `koanf:"batchSize"`
+}
+
+type RedisConfig struct {
+	Host     string `koanf:"host"`
+	Port     int    `koanf:"port"`
+	Password string `koanf:"password"`
+	DB       int    `koanf:"db"`
+}
+
+// InventoryConfig defines how long stock stays reserved for an unpaid order
+// and how often reservations are swept and Redis counters are reconciled against Postgres
+type InventoryConfig struct {
+	ReservationTTL    time.Duration `koanf:"reservationTtl"`
+	SweepInterval     time.Duration `koanf:"sweepInterval"`
+	ReconcileInterval time.Duration `koanf:"reconcileInterval"`
+	BatchSize         int           `koanf:"batchSize"`
+}
+
+// ReaperConfig defines how old an order still PENDING or PROCESSING must be to be resolved
+// by the stuck order reaper, and how often and how many orders are reaped at once
+type ReaperConfig struct {
+	StuckAfter time.Duration `koanf:"stuckAfter"`
+	Interval   time.Duration `koanf:"interval"`
+	BatchSize  int           `koanf:"batchSize"`
+}
+
+// PaymentServiceConfig defines how the REST API of the payment service is called
+type PaymentServiceConfig struct {
+	BaseUrl string        `koanf:"baseUrl"`
+	Timeout time.Duration `koanf:"timeout"`
+}
+
+// IdempotencyConfig defines how long idempotency keys are kept
+type IdempotencyConfig struct {
+	TTL             time.Duration `koanf:"ttl"`   // Retention of the recorded response of a completed request
+	Lease           time.Duration `koanf:"lease"` // A request in progress extends its key every Lease/3, a key not extended for Lease is taken over by a retry
+	CleanupInterval time.Duration `koanf:"cleanupInterval"`
 }
 
 // GrpcServiceConfig defines the configuration for gRPC services
@@ -32,3 +91,9 @@ type RestServiceConfig struct {
 	Port int    `koanf:"port" yaml:"port" required:"true"`
 	Name string `koanf:"name" yaml:"name" required:"true"`
 }
+
+// AdminServerConfig configures the internal listener of the operational endpoints, kept off the public router
+type AdminServerConfig struct {
+	Host string `koanf:"host"` // Interface the listener binds to, loopback by default so only the host can reach it
+	Port int    `koanf:"port"`
+}
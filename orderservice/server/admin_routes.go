@@ -3,13 +3,42 @@ package server
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/samber/do/v2"
+	deadLetterHandler "specommerce/orderservice/internal/adapters/primary/deadletter/handler"
 	orderHandler "specommerce/orderservice/internal/adapters/primary/order/handler"
+	outboxHandler "specommerce/orderservice/internal/adapters/primary/outbox/handler"
+	productHandler "specommerce/orderservice/internal/adapters/primary/product/handler"
+	sagaHandler "specommerce/orderservice/internal/adapters/primary/saga/handler"
 )
 
 func adminRoutes(routerGroup *gin.RouterGroup, injector do.Injector) {
 	order := do.MustInvoke[orderHandler.OrderHandler](injector)
+	product := do.MustInvoke[productHandler.ProductHandler](injector)
+	saga := do.MustInvoke[sagaHandler.SagaHandler](injector)
+	deadLetter := do.MustInvoke[deadLetterHandler.DeadLetterHandler](injector)
+	outbox := do.MustInvoke[outboxHandler.OutboxHandler](injector)
 
 	v1OrderGroup := routerGroup.Group("/v1/orders")
 	v1OrderGroup.GET("", order.GetAllOrders)
 	v1OrderGroup.GET("/search", order.SearchOrders)
+
+	v1ProductGroup := routerGroup.Group("/v1/products")
+	v1ProductGroup.POST("", product.CreateProduct)
+	v1ProductGroup.GET("/search", product.SearchProducts)
+	v1ProductGroup.GET("/:id", product.GetProduct)
+	v1ProductGroup.PUT("/:id", product.UpdateProduct)
+	v1ProductGroup.DELETE("/:id", product.DeleteProduct)
+
+	v1SagaGroup := routerGroup.Group("/v1/sagas")
+	v1SagaGroup.GET("/search", saga.SearchSagas)
+	v1SagaGroup.GET("/:id", saga.GetSaga)
+
+	v1DeadLetterGroup := routerGroup.Group("/v1/dead-letters")
+	v1DeadLetterGroup.GET("", deadLetter.GetTopics)
+	v1DeadLetterGroup.GET("/:topic", deadLetter.ListDeadLetters)
+	v1DeadLetterGroup.GET("/:topic/:partition/:offset", deadLetter.GetDeadLetter)
+	v1DeadLetterGroup.POST("/:topic/:partition/:offset/redrive", deadLetter.RedriveDeadLetter)
+
+	v1OutboxGroup := routerGroup.Group("/v1/outbox-messages")
+	v1OutboxGroup.GET("/failed", outbox.ListFailedMessages)
+	v1OutboxGroup.POST("/:id/redrive", outbox.RedriveFailedMessage)
 }
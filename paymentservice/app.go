@@ -11,6 +11,7 @@ import (
 	"specommerce/paymentservice/pkg/atomicity"
 	"specommerce/paymentservice/pkg/database"
 	"specommerce/paymentservice/pkg/environment"
+	"specommerce/paymentservice/pkg/outbox"
 	"specommerce/paymentservice/pkg/service_config"
 	"specommerce/paymentservice/pkg/shutdown"
 	"specommerce/paymentservice/server"
@@ -44,6 +45,10 @@ func Run(logger *slog.Logger, tasks *shutdown.Tasks) error {
 		func() error {
 			return server.ServeHTTP(injector)
 		})
+	eg.Go(
+		func() error {
+			return server.ServeAdminHTTP(injector)
+		})
 
 	processPaymentRequestConsumer := do.MustInvoke[*paymentConsumer.ProcessPaymentRequestConsumer](injector)
 
@@ -51,5 +56,10 @@ func Run(logger *slog.Logger, tasks *shutdown.Tasks) error {
 		return processPaymentRequestConsumer.Start()
 	})
 
+	outboxRelay := do.MustInvoke[*outbox.Relay](injector)
+	eg.Go(func() error {
+		return outboxRelay.Start()
+	})
+
 	return eg.Wait()
 }
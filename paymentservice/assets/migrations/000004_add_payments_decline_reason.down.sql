@@ -0,0 +1,3 @@
+alter table payments drop column if exists decline_reason;
+
+drop type if exists payment_decline_reason;
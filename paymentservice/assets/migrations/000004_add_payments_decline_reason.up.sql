@@ -0,0 +1,9 @@
+create type payment_decline_reason as enum (
+    'INSUFFICIENT_FUNDS',
+    'CARD_DECLINED',
+    'EXPIRED_CARD',
+    'FRAUD_SUSPECTED',
+    'GATEWAY_TIMEOUT'
+);
+
+alter table payments add column decline_reason payment_decline_reason;
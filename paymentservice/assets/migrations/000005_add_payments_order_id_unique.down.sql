@@ -0,0 +1,3 @@
+drop index if exists payments_order_id_captured;
+
+drop index if exists payments_order_id;
@@ -0,0 +1,4 @@
+create index payments_order_id on payments(order_id);
+
+-- an order is captured at most once, declined attempts are kept for auditing
+create unique index payments_order_id_captured on payments(order_id) where status = 'SUCCESS';
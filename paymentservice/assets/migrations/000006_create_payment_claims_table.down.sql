@@ -0,0 +1,2 @@
+drop table if exists payment_claims;
+drop type if exists payment_claim_status;
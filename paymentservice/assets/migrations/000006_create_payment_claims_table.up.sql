@@ -0,0 +1,21 @@
+create type payment_claim_status as enum (
+    'PROCESSING',
+    'COMPLETED'
+);
+
+-- the first delivery of a payment request claims the order before the customer is charged
+create table payment_claims (
+    order_id varchar(20) primary key not null,
+    payment_id varchar(20) not null,
+    status payment_claim_status not null default 'PROCESSING',
+    created_at timestamp with time zone not null default now(),
+    updated_at timestamp with time zone not null default now()
+);
+
+select create_updated_at_trigger('payment_claims');
+
+-- orders processed before claims existed
+insert into payment_claims (order_id, payment_id, status, created_at)
+select distinct on (order_id) order_id, id, 'COMPLETED', created_at
+from payments
+order by order_id, status = 'SUCCESS' desc, created_at desc;
@@ -0,0 +1,11 @@
+delete from payment_claims where status = 'CANCELLED';
+
+alter type payment_claim_status rename to payment_claim_status_old;
+create type payment_claim_status as enum (
+    'PROCESSING',
+    'COMPLETED'
+);
+alter table payment_claims alter column status drop default;
+alter table payment_claims alter column status type payment_claim_status using status::text::payment_claim_status;
+alter table payment_claims alter column status set default 'PROCESSING';
+drop type payment_claim_status_old;
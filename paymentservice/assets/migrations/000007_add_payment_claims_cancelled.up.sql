@@ -0,0 +1,2 @@
+-- the order service cancels the payment of an order it timed out before the payment request arrived
+alter type payment_claim_status add value if not exists 'CANCELLED';
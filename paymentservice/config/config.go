@@ -1,12 +1,29 @@
 package config
 
-import "specommerce/paymentservice/pkg/service_config"
+import (
+	"specommerce/paymentservice/pkg/service_config"
+	"time"
+)
 
 type AppConfig struct {
 	Server               service_config.RestServiceConfig `koanf:"server"`
+	Admin                service_config.AdminServerConfig `koanf:"adminServer"`
 	Env                  string                           `koanf:"env"`
 	Database             service_config.DbConfig          `koanf:"db"`
 	Kafka                service_config.KafkaConfig       `koanf:"messagequeue"`
 	ProcessPaymentRequest  service_config.KafkaConfig       `koanf:"processPaymentRequest"`
 	ProcessPaymentResponse service_config.KafkaConfig       `koanf:"processPaymentResponse"`
+	Outbox                 service_config.OutboxConfig      `koanf:"outbox"`
+	PaymentGateway         PaymentGatewayConfig             `koanf:"paymentGateway"`
+}
+
+// PaymentGatewayConfig configures the simulated payment provider
+type PaymentGatewayConfig struct {
+	ApprovalRate   float64       `koanf:"approvalRate"`   // Share of charges approved, between 0 and 1
+	MinLatency     time.Duration `koanf:"minLatency"`     // Provider latency is uniformly distributed between MinLatency and MaxLatency
+	MaxLatency     time.Duration `koanf:"maxLatency"`
+	TimeoutRate    float64       `koanf:"timeoutRate"`    // Share of charges on which the provider hangs, between 0 and 1
+	Timeout        time.Duration `koanf:"timeout"`        // Charges not answered within Timeout are declined with GATEWAY_TIMEOUT, required when TimeoutRate is set
+	DeclineReasons []string      `koanf:"declineReasons"` // Reasons picked uniformly for declined charges
+	Seed           uint64        `koanf:"seed"`           // Seed of the random source, 0 seeds from the clock
 }
@@ -4,9 +4,12 @@ import (
 	"github.com/samber/do/v2"
 	"log/slog"
 	"specommerce/paymentservice/config"
+	deadLetterHandler "specommerce/paymentservice/internal/adapters/primary/deadletter/handler"
+	outboxHandler "specommerce/paymentservice/internal/adapters/primary/outbox/handler"
 	paymentConsumer "specommerce/paymentservice/internal/adapters/primary/payment/event/kafka"
 	paymentHandler "specommerce/paymentservice/internal/adapters/primary/payment/handler"
 	paymentKafka "specommerce/paymentservice/internal/adapters/secondary/payment/event/kafka"
+	paymentGateway "specommerce/paymentservice/internal/adapters/secondary/payment/gateway/simulated"
 	paymentPostgres "specommerce/paymentservice/internal/adapters/secondary/payment/persistence/postgres"
 	"specommerce/paymentservice/internal/core/ports/primary"
 	"specommerce/paymentservice/internal/core/ports/secondary"
@@ -14,20 +17,30 @@ import (
 	"specommerce/paymentservice/pkg/atomicity"
 	"specommerce/paymentservice/pkg/database"
 	"specommerce/paymentservice/pkg/messagequeue"
+	"specommerce/paymentservice/pkg/outbox"
 	"specommerce/paymentservice/pkg/shutdown"
 )
 
 func NewInjector() do.Injector {
 	injector := do.New()
 	do.Provide(injector, NewPaymentRepository)
+	do.Provide(injector, NewPaymentClaimRepository)
 	do.Provide(injector, NewPaymentService)
 	do.Provide(injector, NewPaymentHandler)
+	do.Provide(injector, NewPaymentGateway)
 
 	do.Provide(injector, NewPaymentPublisher)
 	do.Provide(injector, NewPublisher)
 	do.Provide(injector, NewProcessPaymentRequestConsumer)
 
 	do.Provide(injector, NewBaseEventListener)
+	do.Provide(injector, NewDeadLetterQueue)
+	do.Provide(injector, NewDeadLetterHandler)
+
+	do.Provide(injector, NewOutboxWriter)
+	do.Provide(injector, NewOutboxRelay)
+	do.Provide(injector, NewFailedOutboxMessages)
+	do.Provide(injector, NewOutboxHandler)
 
 	return injector
 }
@@ -37,17 +50,33 @@ func NewPaymentRepository(injector do.Injector) (secondary.PaymentRepository, er
 	return paymentPostgres.NewPaymentPersistenceRepository(getDbFunc), nil
 }
 
+func NewPaymentClaimRepository(injector do.Injector) (secondary.PaymentClaimRepository, error) {
+	getDbFunc := do.MustInvoke[database.GetDbFunc](injector)
+	return paymentPostgres.NewPaymentClaimPersistenceRepository(getDbFunc), nil
+}
+
 func NewPaymentService(injector do.Injector) (primary.PaymentService, error) {
 	paymentRepository := do.MustInvoke[secondary.PaymentRepository](injector)
+	claimRepository := do.MustInvoke[secondary.PaymentClaimRepository](injector)
 	paymentPublisher := do.MustInvoke[secondary.PaymentEventRepository](injector)
+	gateway := do.MustInvoke[secondary.PaymentGateway](injector)
 	atomicExecutor := do.MustInvoke[atomicity.AtomicExecutor](injector)
+	logger := do.MustInvoke[*slog.Logger](injector)
 	return paymentService.NewPaymentService(
 		paymentRepository,
+		claimRepository,
 		paymentPublisher,
+		gateway,
 		atomicExecutor,
+		logger,
 	), nil
 }
 
+func NewPaymentGateway(injector do.Injector) (secondary.PaymentGateway, error) {
+	cfg := do.MustInvoke[config.AppConfig](injector)
+	return paymentGateway.NewSimulatedGateway(cfg.PaymentGateway)
+}
+
 func NewPaymentHandler(injector do.Injector) (paymentHandler.PaymentHandler, error) {
 	service := do.MustInvoke[primary.PaymentService](injector)
 	return paymentHandler.NewPaymentHandler(service), nil
@@ -61,14 +90,26 @@ func NewPublisher(injector do.Injector) (messagequeue.Publisher, error) {
 
 func NewPaymentPublisher(injector do.Injector) (secondary.PaymentEventRepository, error) {
 	cfg := do.MustInvoke[config.AppConfig](injector)
-	publisher := do.MustInvoke[messagequeue.Publisher](injector)
-	return paymentKafka.NewPaymentPublisher(cfg, publisher), nil
+	outboxWriter := do.MustInvoke[outbox.Writer](injector)
+	return paymentKafka.NewPaymentPublisher(cfg, outboxWriter), nil
 }
 
 func NewBaseEventListener(injector do.Injector) (*messagequeue.BaseEventListener, error) {
+	publisher := do.MustInvoke[messagequeue.Publisher](injector)
 	tasks := do.MustInvoke[*shutdown.Tasks](injector)
 	logger := do.MustInvoke[*slog.Logger](injector)
-	return messagequeue.NewBaseEventListener(tasks, logger), nil
+	return messagequeue.NewBaseEventListener(publisher, tasks, logger), nil
+}
+
+func NewDeadLetterQueue(injector do.Injector) (messagequeue.DeadLetterQueue, error) {
+	cfg := do.MustInvoke[config.AppConfig](injector)
+	publisher := do.MustInvoke[messagequeue.Publisher](injector)
+	return messagequeue.NewDeadLetterQueue(cfg.Kafka.Host, publisher, cfg.ProcessPaymentRequest), nil
+}
+
+func NewDeadLetterHandler(injector do.Injector) (deadLetterHandler.DeadLetterHandler, error) {
+	deadLetterQueue := do.MustInvoke[messagequeue.DeadLetterQueue](injector)
+	return deadLetterHandler.NewDeadLetterHandler(deadLetterQueue), nil
 }
 
 func NewProcessPaymentRequestConsumer(injector do.Injector) (*paymentConsumer.ProcessPaymentRequestConsumer, error) {
@@ -77,3 +118,28 @@ func NewProcessPaymentRequestConsumer(injector do.Injector) (*paymentConsumer.Pr
 	service := do.MustInvoke[primary.PaymentService](injector)
 	return paymentConsumer.NewProcessPaymentRequestConsumer(baseEventListener, cfg.ProcessPaymentRequest, service), nil
 }
+
+func NewOutboxWriter(injector do.Injector) (outbox.Writer, error) {
+	getDbFunc := do.MustInvoke[database.GetDbFunc](injector)
+	return outbox.NewWriter(getDbFunc), nil
+}
+
+func NewOutboxRelay(injector do.Injector) (*outbox.Relay, error) {
+	cfg := do.MustInvoke[config.AppConfig](injector)
+	getDbFunc := do.MustInvoke[database.GetDbFunc](injector)
+	atomicExecutor := do.MustInvoke[atomicity.AtomicExecutor](injector)
+	publisher := do.MustInvoke[messagequeue.Publisher](injector)
+	tasks := do.MustInvoke[*shutdown.Tasks](injector)
+	logger := do.MustInvoke[*slog.Logger](injector)
+	return outbox.NewRelay(getDbFunc, atomicExecutor, publisher, cfg.Outbox, tasks, logger), nil
+}
+
+func NewFailedOutboxMessages(injector do.Injector) (outbox.FailedMessages, error) {
+	getDbFunc := do.MustInvoke[database.GetDbFunc](injector)
+	return outbox.NewFailedMessages(getDbFunc), nil
+}
+
+func NewOutboxHandler(injector do.Injector) (outboxHandler.OutboxHandler, error) {
+	failedMessages := do.MustInvoke[outbox.FailedMessages](injector)
+	return outboxHandler.NewOutboxHandler(failedMessages), nil
+}
@@ -2,6 +2,7 @@ package kafka
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/golang/protobuf/proto"
 	"github.com/rs/xid"
@@ -9,7 +10,6 @@ import (
 	"specommerce/paymentservice/internal/core/domain/payment"
 	"specommerce/paymentservice/internal/core/ports/primary"
 	"specommerce/paymentservice/model"
-	"time"
 
 	"github.com/segmentio/kafka-go"
 	"specommerce/paymentservice/pkg/messagequeue"
@@ -50,31 +50,32 @@ func (c *ProcessPaymentRequestConsumer) handleEvent(message kafka.Message) error
 		return fmt.Errorf(errorTemplate, err)
 	}
 	ctx := context.Background()
-	time.Sleep(time.Duration(request.TimeProcess) * time.Millisecond)
 	orderId, err := xid.FromString(request.OrderId)
 	if err != nil {
 		return fmt.Errorf(errorTemplate, fmt.Errorf("invalid order ID: %w", err))
 	}
-	successPayment, err := c.service.ProcessPaymentRequest(ctx, payment.Payment{
-		Id:          xid.New(),
+	processedPayment, err := c.service.ProcessPaymentRequest(ctx, payment.ProcessPaymentRequest{
 		OrderId:     orderId,
-		TotalAmount: request.TotalAmount,
 		CustomerId:  request.CustomerId,
-		Status:      payment.PaymentStatusSuccess,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+		TotalAmount: request.TotalAmount,
+		TimeProcess: request.TimeProcess,
 	})
 
+	if errors.Is(err, payment.ErrOrderCancelled) {
+		// the order was already failed by the order service, there is nobody to answer
+		return nil
+	}
 	if err != nil {
 		return fmt.Errorf(errorTemplate, err)
 	}
 
 	c.baseListener.Logger().Info("Processed payment request successfully",
-		slog.String("payment_id", successPayment.Id.String()),
-		slog.String("order_id", successPayment.OrderId.String()),
-		slog.Float64("total_amount", successPayment.TotalAmount),
-		slog.String("customer_id", successPayment.CustomerId),
-		slog.String("status", successPayment.Status.String()),
+		slog.String("payment_id", processedPayment.Id.String()),
+		slog.String("order_id", processedPayment.OrderId.String()),
+		slog.Float64("total_amount", processedPayment.TotalAmount),
+		slog.String("customer_id", processedPayment.CustomerId),
+		slog.String("status", processedPayment.Status.String()),
+		slog.String("decline_reason", processedPayment.DeclineReason.String()),
 	)
 
 	return nil
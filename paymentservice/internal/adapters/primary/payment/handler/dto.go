@@ -11,13 +11,14 @@ func ToGetAllPaymentResponse(entities []domain.Payment) []PaymentResponse {
 	response := make([]PaymentResponse, 0, len(entities))
 	for _, entity := range entities {
 		response = append(response, PaymentResponse{
-			ID:          entity.Id.String(),
-			OrderID:     entity.OrderId.String(),
-			CustomerID:  entity.CustomerId,
-			TotalAmount: entity.TotalAmount,
-			Status:      entity.Status.String(),
-			CreatedAt:   entity.CreatedAt,
-			UpdatedAt:   entity.UpdatedAt,
+			ID:            entity.Id.String(),
+			OrderID:       entity.OrderId.String(),
+			CustomerID:    entity.CustomerId,
+			TotalAmount:   entity.TotalAmount,
+			Status:        entity.Status.String(),
+			DeclineReason: entity.DeclineReason.String(),
+			CreatedAt:     entity.CreatedAt,
+			UpdatedAt:     entity.UpdatedAt,
 		})
 	}
 	return response
@@ -25,13 +26,14 @@ func ToGetAllPaymentResponse(entities []domain.Payment) []PaymentResponse {
 
 // PaymentResponse represents payment response for Swagger
 type PaymentResponse struct {
-	ID          string    `json:"id" example:"abc123"`
-	OrderID     string    `json:"order_id" example:"order123"`
-	CustomerID  string    `json:"customer_id" example:"customer123"`
-	TotalAmount float64   `json:"total_amount" example:"99.99"`
-	Status      string    `json:"status" example:"SUCCESS"`
-	CreatedAt   time.Time `json:"created_at" example:"2023-01-01T00:00:00Z"`
-	UpdatedAt   time.Time `json:"updated_at" example:"2023-01-01T00:00:00Z"`
+	ID            string    `json:"id" example:"abc123"`
+	OrderID       string    `json:"order_id" example:"order123"`
+	CustomerID    string    `json:"customer_id" example:"customer123"`
+	TotalAmount   float64   `json:"total_amount" example:"99.99"`
+	Status        string    `json:"status" example:"FAILED"`
+	DeclineReason string    `json:"decline_reason,omitempty" example:"INSUFFICIENT_FUNDS"`
+	CreatedAt     time.Time `json:"created_at" example:"2023-01-01T00:00:00Z"`
+	UpdatedAt     time.Time `json:"updated_at" example:"2023-01-01T00:00:00Z"`
 }
 
 // SearchPaymentsRequest represents the request for searching payments with pagination
@@ -44,3 +46,23 @@ func (req SearchPaymentsRequest) ToFilter() secondary.SearchPaymentsFilter {
 		Paging: req.Paging,
 	}
 }
+
+// CancelPaymentRequest represents the request for cancelling the payment of an order
+type CancelPaymentRequest struct {
+	OrderID string `json:"order_id" binding:"required" example:"order123"`
+}
+
+// PaymentClaimResponse represents the claim an order holds on the payment service for Swagger
+type PaymentClaimResponse struct {
+	OrderID   string `json:"order_id" example:"order123"`
+	PaymentID string `json:"payment_id" example:"abc123"`
+	Status    string `json:"status" example:"CANCELLED"`
+}
+
+func ToPaymentClaimResponse(claim domain.Claim) PaymentClaimResponse {
+	return PaymentClaimResponse{
+		OrderID:   claim.OrderId.String(),
+		PaymentID: claim.PaymentId.String(),
+		Status:    claim.Status.String(),
+	}
+}
@@ -1,7 +1,9 @@
 package handler
 
 import (
+	"github.com/rs/xid"
 	"net/http"
+	domain "specommerce/paymentservice/internal/core/domain/payment"
 	"specommerce/paymentservice/internal/core/ports/primary"
 	"specommerce/paymentservice/pkg/sharedto/handler"
 
@@ -11,6 +13,7 @@ import (
 type PaymentHandler interface {
 	GetAllPayments(ctx *gin.Context)
 	SearchPayments(ctx *gin.Context)
+	CancelPayment(ctx *gin.Context)
 }
 type paymentHandler struct {
 	paymentService primary.PaymentService
@@ -24,15 +27,29 @@ func NewPaymentHandler(paymentService primary.PaymentService) PaymentHandler {
 
 // GetAllPayments godoc
 // @Summary Get all payments
-// @Description Retrieve all payments from the system
+// @Description Retrieve all payments from the system, or the payment attempts of an order, oldest first, when order_id is set.
+// @Description An order without payment attempts was never processed by the payment service
 // @Tags payments
 // @Accept json
 // @Produce json
+// @Param order_id query string false "Order ID"
 // @Success 200 {array} PaymentResponse "List of payments"
+// @Failure 400 {object} handler.ErrorResponse "Bad request"
 // @Failure 500 {object} handler.ErrorResponse "Internal server error"
 // @Router /admin/v1/payments [get]
 func (h *paymentHandler) GetAllPayments(ctx *gin.Context) {
-	payments, err := h.paymentService.GetAllPayments(ctx)
+	var payments []domain.Payment
+	var err error
+	if orderId, ok := ctx.GetQuery("order_id"); ok {
+		id, parseErr := xid.FromString(orderId)
+		if parseErr != nil {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": "order_id is invalid: " + parseErr.Error()})
+			return
+		}
+		payments, err = h.paymentService.GetPaymentsByOrderId(ctx, id)
+	} else {
+		payments, err = h.paymentService.GetAllPayments(ctx)
+	}
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -71,3 +88,38 @@ func (h *paymentHandler) SearchPayments(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, result)
 }
+
+// CancelPayment godoc
+// @Summary Cancel the payment of an order
+// @Description Cancel the payment of an order the payment service has not processed yet, a later payment request of the order is rejected without charging the customer.
+// @Description The status of the claim held by the order tells whether the cancellation won: CANCELLED, or PROCESSING or COMPLETED when a payment request claimed the order first
+// @Tags payments
+// @Accept json
+// @Produce json
+// @Param request body CancelPaymentRequest true "Order to cancel"
+// @Success 200 {object} PaymentClaimResponse "Claim held by the order"
+// @Failure 400 {object} handler.ErrorResponse "Bad request"
+// @Failure 500 {object} handler.ErrorResponse "Internal server error"
+// @Router /admin/v1/payments/cancellations [post]
+func (h *paymentHandler) CancelPayment(ctx *gin.Context) {
+	var req CancelPaymentRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	orderId, err := xid.FromString(req.OrderID)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "order_id is invalid: " + err.Error()})
+		return
+	}
+
+	claim, err := h.paymentService.CancelPayment(ctx, orderId)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, handler.BaseResponse[PaymentClaimResponse]{
+		Data: ToPaymentClaimResponse(claim),
+	})
+}
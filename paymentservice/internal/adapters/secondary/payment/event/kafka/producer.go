@@ -9,12 +9,12 @@ import (
 	domain "specommerce/paymentservice/internal/core/domain/payment"
 	"specommerce/paymentservice/internal/core/ports/secondary"
 	"specommerce/paymentservice/model"
-	"specommerce/paymentservice/pkg/messagequeue"
+	"specommerce/paymentservice/pkg/outbox"
 )
 
 type paymentPublisher struct {
-	config    config.AppConfig
-	publisher messagequeue.Publisher
+	config config.AppConfig
+	outbox outbox.Writer
 }
 
 func (p *paymentPublisher) SendPaymentResponse(ctx context.Context, input domain.ProcessPaymentResponse) error {
@@ -26,6 +26,7 @@ func (p *paymentPublisher) SendPaymentResponse(ctx context.Context, input domain
 		TotalAmount:   input.TotalAmount,
 		CustomerId:    input.CustomerId,
 		PaymentStatus: input.Status.String(),
+		DeclineReason: input.DeclineReason.String(),
 	}
 	payload, err := proto.Marshal(data)
 
@@ -33,16 +34,16 @@ func (p *paymentPublisher) SendPaymentResponse(ctx context.Context, input domain
 		return fmt.Errorf(errTemplate, err)
 	}
 
-	return p.publisher.Publish(kafkaGo.Message{
+	return p.outbox.Write(ctx, kafkaGo.Message{
 		Topic: p.config.ProcessPaymentResponse.Topic,
 		Value: payload,
 		Key:   []byte(input.CustomerId),
 	})
 }
 
-func NewPaymentPublisher(config config.AppConfig, publisher messagequeue.Publisher) secondary.PaymentEventRepository {
+func NewPaymentPublisher(config config.AppConfig, outboxWriter outbox.Writer) secondary.PaymentEventRepository {
 	return &paymentPublisher{
-		config:    config,
-		publisher: publisher,
+		config: config,
+		outbox: outboxWriter,
 	}
 }
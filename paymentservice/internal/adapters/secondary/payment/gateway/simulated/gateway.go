@@ -0,0 +1,175 @@
+package simulated
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"specommerce/paymentservice/config"
+	domain "specommerce/paymentservice/internal/core/domain/payment"
+	"specommerce/paymentservice/internal/core/ports/secondary"
+	"sync"
+	"time"
+)
+
+// idempotencyKeyRetention is how long a charge is answered again for a repeated idempotency key, as providers do
+const idempotencyKeyRetention = 24 * time.Hour
+
+// simulatedGateway stands in for a real payment provider. It approves a configurable share of charges,
+// answers after a random latency and sometimes hangs until the timeout, so every failure path downstream is exercised.
+// Like a real provider it charges at most once per idempotency key.
+type simulatedGateway struct {
+	config  config.PaymentGatewayConfig
+	mu      sync.Mutex
+	random  *rand.Rand
+	charges map[string]*charge
+	keys    []chargedKey // idempotency keys in the order they were charged, to expire them
+}
+
+type chargedKey struct {
+	key       string
+	chargedAt time.Time
+}
+
+// charge is the outcome of the first charge of an idempotency key, done is closed once it is known
+type charge struct {
+	done      chan struct{}
+	result    domain.ChargeResult
+	err       error
+	chargedAt time.Time
+}
+
+// NewSimulatedGateway returns an error for a configuration the gateway cannot honour, instead of
+// failing charges at runtime
+func NewSimulatedGateway(cfg config.PaymentGatewayConfig) (secondary.PaymentGateway, error) {
+	if err := validate(cfg); err != nil {
+		return nil, fmt.Errorf("NewSimulatedGateway %w", err)
+	}
+	seed := cfg.Seed
+	if seed == 0 {
+		seed = uint64(time.Now().UnixNano())
+	}
+	if len(cfg.DeclineReasons) == 0 {
+		cfg.DeclineReasons = []string{domain.DeclineReasonCardDeclined.String()}
+	}
+	return &simulatedGateway{
+		config:  cfg,
+		random:  rand.New(rand.NewPCG(seed, seed)),
+		charges: make(map[string]*charge),
+	}, nil
+}
+
+func validate(cfg config.PaymentGatewayConfig) error {
+	if cfg.ApprovalRate < 0 || cfg.ApprovalRate > 1 {
+		return fmt.Errorf("approvalRate %v is not between 0 and 1", cfg.ApprovalRate)
+	}
+	if cfg.TimeoutRate < 0 || cfg.TimeoutRate > 1 {
+		return fmt.Errorf("timeoutRate %v is not between 0 and 1", cfg.TimeoutRate)
+	}
+	// a hanging charge is only answered by the timeout
+	if cfg.TimeoutRate > 0 && cfg.Timeout <= 0 {
+		return fmt.Errorf("timeout must be positive when timeoutRate is %v", cfg.TimeoutRate)
+	}
+	if cfg.MinLatency < 0 || cfg.MaxLatency < cfg.MinLatency {
+		return fmt.Errorf("latency range [%v, %v] is invalid", cfg.MinLatency, cfg.MaxLatency)
+	}
+	for _, reason := range cfg.DeclineReasons {
+		if !domain.DeclineReason(reason).IsValid() {
+			return fmt.Errorf("declineReasons contains unknown reason %q", reason)
+		}
+	}
+	return nil
+}
+
+func (g *simulatedGateway) Charge(ctx context.Context, request domain.ChargeRequest) (domain.ChargeResult, error) {
+	key := request.IdempotencyKey
+	if key == "" {
+		return g.charge(ctx, request)
+	}
+
+	g.mu.Lock()
+	g.expireKeys(time.Now())
+	if first, ok := g.charges[key]; ok {
+		g.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			return domain.ChargeResult{}, ctx.Err()
+		case <-first.done:
+			return first.result, first.err
+		}
+	}
+	first := &charge{done: make(chan struct{}), chargedAt: time.Now()}
+	g.charges[key] = first
+	g.keys = append(g.keys, chargedKey{key: key, chargedAt: first.chargedAt})
+	g.mu.Unlock()
+
+	first.result, first.err = g.charge(ctx, request)
+	if first.err != nil {
+		// the customer was not charged, a retry with the same key charges again
+		g.mu.Lock()
+		delete(g.charges, key)
+		g.mu.Unlock()
+	}
+	close(first.done)
+	return first.result, first.err
+}
+
+// expireKeys forgets the charges of the idempotency keys older than the retention, the caller holds the lock
+func (g *simulatedGateway) expireKeys(now time.Time) {
+	for len(g.keys) > 0 && now.Sub(g.keys[0].chargedAt) >= idempotencyKeyRetention {
+		oldest := g.keys[0]
+		// a key charged again after a failed charge belongs to its later entry
+		if first, ok := g.charges[oldest.key]; ok && first.chargedAt.Equal(oldest.chargedAt) {
+			delete(g.charges, oldest.key)
+		}
+		g.keys = g.keys[1:]
+	}
+}
+
+// charge draws the outcome of a charge and waits for the simulated provider to answer
+func (g *simulatedGateway) charge(ctx context.Context, request domain.ChargeRequest) (domain.ChargeResult, error) {
+	hang, approved, latency, reason := g.roll()
+	// The processing time requested by the client is simulated on top of the provider latency and timeout
+	latency += request.ProcessTime
+
+	// A nil channel never fires: a hanging provider only answers through the timeout
+	var answered, timedOut <-chan time.Time
+	if !hang {
+		timer := time.NewTimer(latency)
+		defer timer.Stop()
+		answered = timer.C
+	}
+	if g.config.Timeout > 0 {
+		timer := time.NewTimer(g.config.Timeout + request.ProcessTime)
+		defer timer.Stop()
+		timedOut = timer.C
+	}
+
+	select {
+	case <-ctx.Done():
+		return domain.ChargeResult{}, ctx.Err()
+	case <-timedOut:
+		return domain.ChargeResult{
+			Status:        domain.PaymentStatusFailed,
+			DeclineReason: domain.DeclineReasonGatewayTimeout,
+		}, nil
+	case <-answered:
+	}
+	if !approved {
+		return domain.ChargeResult{Status: domain.PaymentStatusFailed, DeclineReason: reason}, nil
+	}
+	return domain.ChargeResult{Status: domain.PaymentStatusSuccess}, nil
+}
+
+// roll draws the outcome of a charge from the shared random source
+func (g *simulatedGateway) roll() (hang bool, approved bool, latency time.Duration, reason domain.DeclineReason) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	hang = g.random.Float64() < g.config.TimeoutRate
+	approved = g.random.Float64() < g.config.ApprovalRate
+	latency = g.config.MinLatency
+	if spread := g.config.MaxLatency - g.config.MinLatency; spread > 0 {
+		latency += time.Duration(g.random.Int64N(int64(spread)))
+	}
+	reason = domain.DeclineReason(g.config.DeclineReasons[g.random.IntN(len(g.config.DeclineReasons))])
+	return hang, approved, latency, reason
+}
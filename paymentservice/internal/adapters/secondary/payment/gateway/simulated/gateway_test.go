@@ -0,0 +1,69 @@
+package simulated
+
+import (
+	"context"
+	"specommerce/paymentservice/config"
+	domain "specommerce/paymentservice/internal/core/domain/payment"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/xid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCharge_RepeatedIdempotencyKeyAnswersFirstResult(t *testing.T) {
+	gateway, err := NewSimulatedGateway(config.PaymentGatewayConfig{ApprovalRate: 0.5, Seed: 42})
+	assert.NoError(t, err)
+	request := domain.ChargeRequest{IdempotencyKey: xid.New().String(), PaymentId: xid.New(), Amount: 10}
+
+	first, err := gateway.Charge(context.Background(), request)
+	assert.NoError(t, err)
+
+	var wg sync.WaitGroup
+	for range 16 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			again, err := gateway.Charge(context.Background(), request)
+			assert.NoError(t, err)
+			assert.Equal(t, first, again)
+		}()
+	}
+	wg.Wait()
+	assert.Len(t, gateway.(*simulatedGateway).charges, 1)
+}
+
+func TestNewSimulatedGateway_RejectsInvalidConfig(t *testing.T) {
+	valid := config.PaymentGatewayConfig{
+		ApprovalRate:   0.9,
+		MinLatency:     50 * time.Millisecond,
+		MaxLatency:     300 * time.Millisecond,
+		TimeoutRate:    0.02,
+		Timeout:        2 * time.Second,
+		DeclineReasons: []string{"CARD_DECLINED", "INSUFFICIENT_FUNDS"},
+	}
+	tests := []struct {
+		name   string
+		modify func(cfg *config.PaymentGatewayConfig)
+	}{
+		{"approval rate above 1", func(cfg *config.PaymentGatewayConfig) { cfg.ApprovalRate = 1.5 }},
+		{"negative approval rate", func(cfg *config.PaymentGatewayConfig) { cfg.ApprovalRate = -0.1 }},
+		{"timeout rate above 1", func(cfg *config.PaymentGatewayConfig) { cfg.TimeoutRate = 2 }},
+		{"timeout rate without timeout", func(cfg *config.PaymentGatewayConfig) { cfg.Timeout = 0 }},
+		{"max latency below min latency", func(cfg *config.PaymentGatewayConfig) { cfg.MaxLatency = cfg.MinLatency - 1 }},
+		{"unknown decline reason", func(cfg *config.PaymentGatewayConfig) { cfg.DeclineReasons = append(cfg.DeclineReasons, "STOLEN_CARD") }},
+	}
+
+	_, err := NewSimulatedGateway(valid)
+	assert.NoError(t, err)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid
+			cfg.DeclineReasons = append([]string(nil), valid.DeclineReasons...)
+			tt.modify(&cfg)
+			_, err := NewSimulatedGateway(cfg)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"github.com/rs/xid"
+	"github.com/uptrace/bun"
+	domain "specommerce/paymentservice/internal/core/domain/payment"
+	"specommerce/paymentservice/internal/core/ports/secondary"
+	"specommerce/paymentservice/pkg/database"
+)
+
+type paymentClaimPersistenceRepository struct {
+	getDbFunc database.GetDbFunc
+}
+
+func NewPaymentClaimPersistenceRepository(dbFunc database.GetDbFunc) secondary.PaymentClaimRepository {
+	return &paymentClaimPersistenceRepository{
+		getDbFunc: dbFunc,
+	}
+}
+
+// Claim inserts the claim unless the order already holds one, so that of concurrent claims of an order
+// exactly one is stored and all of them read it back
+func (r *paymentClaimPersistenceRepository) Claim(ctx context.Context, claim domain.Claim) (domain.Claim, error) {
+	errTemplate := "paymentClaimPersistenceRepository.Claim: %w"
+	row := FromDomainClaim(claim)
+	_, err := r.getDbFunc(ctx).NewInsert().Model(&row).
+		On("CONFLICT (order_id) DO NOTHING").
+		Exec(ctx)
+	if err != nil {
+		return domain.Claim{}, fmt.Errorf(errTemplate, err)
+	}
+
+	held, err := database.NewPostgresCrudDatabaseOperation[PaymentClaim](r.getDbFunc).Get(ctx,
+		func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Where("order_id = ?", claim.OrderId)
+		},
+	)
+	if err != nil {
+		return domain.Claim{}, fmt.Errorf(errTemplate, err)
+	}
+	return held.ToDomainModel(), nil
+}
+
+func (r *paymentClaimPersistenceRepository) Complete(ctx context.Context, orderId xid.ID) (bool, error) {
+	errTemplate := "paymentClaimPersistenceRepository.Complete: %w"
+	res, err := r.getDbFunc(ctx).NewUpdate().Model((*PaymentClaim)(nil)).
+		Set("status = ?", domain.ClaimStatusCompleted).
+		Where("order_id = ?", orderId).
+		Where("status = ?", domain.ClaimStatusProcessing).
+		Exec(ctx)
+	if err != nil {
+		return false, fmt.Errorf(errTemplate, err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf(errTemplate, err)
+	}
+	return affected == 1, nil
+}
@@ -14,30 +14,62 @@ type Payment struct {
 	TotalAmount   float64   `bun:"total_amount,notnull"`
 	CustomerId    string    `bun:"customer_id,notnull"`
 	Status        string    `bun:"status,notnull,default:'SUCCESS'"` //
+	DeclineReason string    `bun:"decline_reason,nullzero"`
 	CreatedAt     time.Time `bun:",nullzero,notnull,default:current_timestamp,skipupdate"`
 	UpdatedAt     time.Time `bun:",nullzero,notnull,default:current_timestamp"`
 }
 
 func (o Payment) ToDomainModel() domain.Payment {
 	return domain.Payment{
-		Id:          o.Id,
-		OrderId:     o.OrderId,
-		CustomerId:  o.CustomerId,
-		TotalAmount: o.TotalAmount,
-		Status:      domain.PaymentStatus(o.Status),
-		CreatedAt:   o.CreatedAt,
-		UpdatedAt:   o.UpdatedAt,
+		Id:            o.Id,
+		OrderId:       o.OrderId,
+		CustomerId:    o.CustomerId,
+		TotalAmount:   o.TotalAmount,
+		Status:        domain.PaymentStatus(o.Status),
+		DeclineReason: domain.DeclineReason(o.DeclineReason),
+		CreatedAt:     o.CreatedAt,
+		UpdatedAt:     o.UpdatedAt,
 	}
 }
 
 func FromDomainModel(dm domain.Payment) Payment {
 	return Payment{
-		Id:          dm.Id,
-		OrderId:     dm.OrderId,
-		CustomerId:  dm.CustomerId,
-		TotalAmount: dm.TotalAmount,
-		Status:      string(dm.Status),
-		CreatedAt:   dm.CreatedAt,
-		UpdatedAt:   dm.UpdatedAt,
+		Id:            dm.Id,
+		OrderId:       dm.OrderId,
+		CustomerId:    dm.CustomerId,
+		TotalAmount:   dm.TotalAmount,
+		Status:        string(dm.Status),
+		DeclineReason: string(dm.DeclineReason),
+		CreatedAt:     dm.CreatedAt,
+		UpdatedAt:     dm.UpdatedAt,
+	}
+}
+
+type PaymentClaim struct {
+	bun.BaseModel `bun:"payment_claims"`
+	OrderId       xid.ID    `bun:",pk"`
+	PaymentId     xid.ID    `bun:"payment_id,notnull"`
+	Status        string    `bun:"status,notnull,default:'PROCESSING'"`
+	CreatedAt     time.Time `bun:",nullzero,notnull,default:current_timestamp,skipupdate"`
+	UpdatedAt     time.Time `bun:",nullzero,notnull,default:current_timestamp"`
+}
+
+func (c PaymentClaim) ToDomainModel() domain.Claim {
+	return domain.Claim{
+		OrderId:   c.OrderId,
+		PaymentId: c.PaymentId,
+		Status:    domain.ClaimStatus(c.Status),
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}
+}
+
+func FromDomainClaim(dm domain.Claim) PaymentClaim {
+	return PaymentClaim{
+		OrderId:   dm.OrderId,
+		PaymentId: dm.PaymentId,
+		Status:    string(dm.Status),
+		CreatedAt: dm.CreatedAt,
+		UpdatedAt: dm.UpdatedAt,
 	}
 }
@@ -3,6 +3,8 @@ package postgres
 import (
 	"context"
 	"fmt"
+	"github.com/rs/xid"
+	"github.com/uptrace/bun"
 	domain "specommerce/paymentservice/internal/core/domain/payment"
 	"specommerce/paymentservice/internal/core/ports/secondary"
 	"specommerce/paymentservice/pkg/database"
@@ -39,6 +41,36 @@ func (r *paymentPersistenceRepository) Create(ctx context.Context, payment domai
 	return created.ToDomainModel(), nil
 }
 
+func (r *paymentPersistenceRepository) GetByOrderId(ctx context.Context, orderId xid.ID) (domain.Payment, error) {
+	record, err := database.NewPostgresCrudDatabaseOperation[Payment](r.getDbFunc).Get(ctx,
+		func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Where("order_id = ?", orderId).
+				OrderExpr("status = 'SUCCESS' DESC, created_at DESC")
+		},
+	)
+	if err != nil {
+		return domain.Payment{}, fmt.Errorf("paymentPersistenceRepository.GetByOrderId: %w", err)
+	}
+	return record.ToDomainModel(), nil
+}
+
+func (r *paymentPersistenceRepository) GetAllByOrderId(ctx context.Context, orderId xid.ID) ([]domain.Payment, error) {
+	records, err := database.NewPostgresCrudDatabaseOperation[Payment](r.getDbFunc).FindAll(ctx,
+		func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.Where("order_id = ?", orderId).
+				Order("created_at")
+		},
+	)
+	if err != nil {
+		return nil, fmt.Errorf("paymentPersistenceRepository.GetAllByOrderId: %w", err)
+	}
+	payments := make([]domain.Payment, 0, len(records))
+	for _, record := range records {
+		payments = append(payments, record.ToDomainModel())
+	}
+	return payments, nil
+}
+
 func (r *paymentPersistenceRepository) SearchPayments(ctx context.Context, filter secondary.SearchPaymentsFilter) (pagination.Page[domain.Payment], error) {
 	errTemplate := "paymentPersistenceRepository.SearchPayments: %w"
 
@@ -1,22 +1,25 @@
 package payment
 
 import (
+	"errors"
 	"github.com/rs/xid"
 	"time"
 )
 
 type ProcessPaymentResponse struct {
-	PaymentId   xid.ID        `json:"payment_id" validate:"required"`
-	OrderId     xid.ID        `json:"order_id" validate:"required"`
-	Status      PaymentStatus `json:"status" validate:"required"`
-	CustomerId  string        `json:"customer_id" validate:"required"`
-	TotalAmount float64       `json:"total_amount" validate:"required,gt=0"`
+	PaymentId     xid.ID        `json:"payment_id" validate:"required"`
+	OrderId       xid.ID        `json:"order_id" validate:"required"`
+	Status        PaymentStatus `json:"status" validate:"required"`
+	DeclineReason DeclineReason `json:"decline_reason"`
+	CustomerId    string        `json:"customer_id" validate:"required"`
+	TotalAmount   float64       `json:"total_amount" validate:"required,gt=0"`
 }
 
 type ProcessPaymentRequest struct {
 	OrderId     xid.ID  `json:"order_id" validate:"required"`
 	CustomerId  string  `json:"customer_id" validate:"required"`
 	TotalAmount float64 `json:"total_amount" validate:"required,gt=0"`
+	TimeProcess int64   `json:"time_process"` // Extra processing time in milliseconds requested by the client
 }
 type PaymentStatus string
 
@@ -25,16 +28,84 @@ const (
 	PaymentStatusFailed  PaymentStatus = "FAILED"
 )
 
+type DeclineReason string
+
+const (
+	DeclineReasonInsufficientFunds DeclineReason = "INSUFFICIENT_FUNDS"
+	DeclineReasonCardDeclined      DeclineReason = "CARD_DECLINED"
+	DeclineReasonExpiredCard       DeclineReason = "EXPIRED_CARD"
+	DeclineReasonFraudSuspected    DeclineReason = "FRAUD_SUSPECTED"
+	DeclineReasonGatewayTimeout    DeclineReason = "GATEWAY_TIMEOUT"
+)
+
+// ChargeRequest is sent to the payment gateway to charge a customer.
+// The gateway charges a customer at most once per IdempotencyKey and answers a repeated key with the first result
+type ChargeRequest struct {
+	IdempotencyKey string
+	PaymentId      xid.ID
+	OrderId        xid.ID
+	CustomerId     string
+	Amount         float64
+	ProcessTime    time.Duration
+}
+
+// ChargeResult is the decision of the payment gateway, DeclineReason is empty when the charge is approved
+type ChargeResult struct {
+	Status        PaymentStatus
+	DeclineReason DeclineReason
+}
+
+type ClaimStatus string
+
+const (
+	ClaimStatusProcessing ClaimStatus = "PROCESSING"
+	ClaimStatusCompleted  ClaimStatus = "COMPLETED"
+	ClaimStatusCancelled  ClaimStatus = "CANCELLED"
+)
+
+// Claim is held by an order on the payment service. The first payment request of the order fixes the payment id,
+// so a concurrent delivery charges the gateway with the same idempotency key, and an order whose payment was
+// cancelled before its payment request arrived is never charged
+type Claim struct {
+	OrderId   xid.ID
+	PaymentId xid.ID
+	Status    ClaimStatus
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ErrOrderCancelled is returned for a payment request of an order whose payment was cancelled
+var ErrOrderCancelled = errors.New("payment of the order was cancelled")
+
 type Payment struct {
-	Id          xid.ID        `json:"id" bun:"id,pk,skipupdate"`
-	OrderId     xid.ID        `json:"order_id" bun:"order_id,notnull"` // Reference to the order
-	CustomerId  string        `json:"customer_id" bun:"customer_id"`
-	TotalAmount float64       `json:"total_amount" bun:"total_amount"`
-	Status      PaymentStatus `json:"status" bun:"status"`
-	CreatedAt   time.Time     `json:"created_at" bun:",nullzero,notnull,default:current_timestamp,skipupdate"`
-	UpdatedAt   time.Time     `json:"updated_at" bun:",nullzero,notnull,default:current_timestamp"`
+	Id            xid.ID        `json:"id" bun:"id,pk,skipupdate"`
+	OrderId       xid.ID        `json:"order_id" bun:"order_id,notnull"` // Reference to the order
+	CustomerId    string        `json:"customer_id" bun:"customer_id"`
+	TotalAmount   float64       `json:"total_amount" bun:"total_amount"`
+	Status        PaymentStatus `json:"status" bun:"status"`
+	DeclineReason DeclineReason `json:"decline_reason" bun:"decline_reason"`
+	CreatedAt     time.Time     `json:"created_at" bun:",nullzero,notnull,default:current_timestamp,skipupdate"`
+	UpdatedAt     time.Time     `json:"updated_at" bun:",nullzero,notnull,default:current_timestamp"`
 }
 
 func (s PaymentStatus) String() string {
 	return string(s)
 }
+
+func (r DeclineReason) String() string {
+	return string(r)
+}
+
+// IsValid reports whether the reason is one of the decline reasons stored with payments
+func (r DeclineReason) IsValid() bool {
+	switch r {
+	case DeclineReasonInsufficientFunds, DeclineReasonCardDeclined, DeclineReasonExpiredCard,
+		DeclineReasonFraudSuspected, DeclineReasonGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+func (s ClaimStatus) String() string {
+	return string(s)
+}
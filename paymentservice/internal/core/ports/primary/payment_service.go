@@ -2,6 +2,7 @@ package primary
 
 import (
 	"context"
+	"github.com/rs/xid"
 	"specommerce/paymentservice/internal/core/domain/payment"
 	"specommerce/paymentservice/internal/core/ports/secondary"
 	"specommerce/paymentservice/pkg/pagination"
@@ -10,6 +11,10 @@ import (
 // PaymentService defines the primary port for payment operations
 type PaymentService interface {
 	GetAllPayments(ctx context.Context) ([]payment.Payment, error)
-	ProcessPaymentRequest(ctx context.Context, input payment.Payment) (payment.Payment, error)
+	// GetPaymentsByOrderId returns the payment attempts of an order, oldest first
+	GetPaymentsByOrderId(ctx context.Context, orderId xid.ID) ([]payment.Payment, error)
+	ProcessPaymentRequest(ctx context.Context, input payment.ProcessPaymentRequest) (payment.Payment, error)
+	// CancelPayment cancels the payment of an order not processed yet and returns the claim held by the order
+	CancelPayment(ctx context.Context, orderId xid.ID) (payment.Claim, error)
 	SearchPayments(ctx context.Context, filter secondary.SearchPaymentsFilter) (pagination.Page[payment.Payment], error)
 }
@@ -0,0 +1,15 @@
+package secondary
+
+import (
+	"context"
+	"github.com/rs/xid"
+	domain "specommerce/paymentservice/internal/core/domain/payment"
+)
+
+// PaymentClaimRepository defines the secondary port for the claims orders hold on the payment service
+type PaymentClaimRepository interface {
+	// Claim stores the claim unless the order already holds one, and returns the claim held by the order
+	Claim(ctx context.Context, claim domain.Claim) (domain.Claim, error)
+	// Complete marks the processing claim of an order as completed, it returns false when the claim is no longer processing
+	Complete(ctx context.Context, orderId xid.ID) (bool, error)
+}
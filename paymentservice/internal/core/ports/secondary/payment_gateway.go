@@ -0,0 +1,12 @@
+package secondary
+
+import (
+	"context"
+	domain "specommerce/paymentservice/internal/core/domain/payment"
+)
+
+// PaymentGateway defines the secondary port for the payment provider that charges customers.
+// A declined charge is a result, not an error: the error reports that the provider could not be reached.
+type PaymentGateway interface {
+	Charge(ctx context.Context, request domain.ChargeRequest) (domain.ChargeResult, error)
+}
@@ -2,6 +2,7 @@ package secondary
 
 import (
 	"context"
+	"github.com/rs/xid"
 	domain "specommerce/paymentservice/internal/core/domain/payment"
 	"specommerce/paymentservice/pkg/pagination"
 )
@@ -15,5 +16,9 @@ type SearchPaymentsFilter struct {
 type PaymentRepository interface {
 	GetAll(ctx context.Context) ([]domain.Payment, error)
 	Create(ctx context.Context, payment domain.Payment) (domain.Payment, error)
+	// GetByOrderId returns the payment of an order, the captured one if the order has several attempts
+	GetByOrderId(ctx context.Context, orderId xid.ID) (domain.Payment, error)
+	// GetAllByOrderId returns all the payment attempts of an order, oldest first
+	GetAllByOrderId(ctx context.Context, orderId xid.ID) ([]domain.Payment, error)
 	SearchPayments(ctx context.Context, filter SearchPaymentsFilter) (pagination.Page[domain.Payment], error)
 }
@@ -2,29 +2,53 @@ package payment
 
 import (
 	"context"
+	"errors"
+	"expvar"
 	"fmt"
+	"github.com/rs/xid"
+	"log/slog"
 	"specommerce/paymentservice/internal/core/domain/payment"
 	"specommerce/paymentservice/internal/core/ports/primary"
 	"specommerce/paymentservice/internal/core/ports/secondary"
+	apperrors "specommerce/paymentservice/pkg/app_error"
 	"specommerce/paymentservice/pkg/atomicity"
 	"specommerce/paymentservice/pkg/pagination"
+	"time"
 )
 
 type paymentService struct {
 	paymentRepository secondary.PaymentRepository
+	claimRepository   secondary.PaymentClaimRepository
 	paymentPublisher  secondary.PaymentEventRepository
+	paymentGateway    secondary.PaymentGateway
 	atomicExecutor    atomicity.AtomicExecutor
+	logger            *slog.Logger
 }
 
+// suppressedDuplicates counts payment requests answered from an existing payment, exposed on /debug/vars
+var suppressedDuplicates = expvar.NewInt("payment_duplicate_requests_suppressed")
+
+// rejectedCancelled counts payment requests rejected because the payment of the order was cancelled, exposed on /debug/vars
+var rejectedCancelled = expvar.NewInt("payment_cancelled_requests_rejected")
+
+// errClaimSettled aborts storing a payment whose claim was completed by a concurrent delivery
+var errClaimSettled = errors.New("payment claim is no longer processing")
+
 func NewPaymentService(
 	paymentRepository secondary.PaymentRepository,
+	claimRepository secondary.PaymentClaimRepository,
 	paymentPublisher secondary.PaymentEventRepository,
+	paymentGateway secondary.PaymentGateway,
 	atomicExecutor atomicity.AtomicExecutor,
+	logger *slog.Logger,
 ) primary.PaymentService {
 	return &paymentService{
 		paymentRepository: paymentRepository,
+		claimRepository:   claimRepository,
 		paymentPublisher:  paymentPublisher,
+		paymentGateway:    paymentGateway,
 		atomicExecutor:    atomicExecutor,
+		logger:            logger,
 	}
 }
 
@@ -32,35 +56,142 @@ func (s *paymentService) GetAllPayments(ctx context.Context) ([]payment.Payment,
 	return s.paymentRepository.GetAll(ctx)
 }
 
-func (s *paymentService) ProcessPaymentRequest(ctx context.Context, input payment.Payment) (payment.Payment, error) {
+// ProcessPaymentRequest charges the customer through the payment gateway, then stores the approved or declined payment
+// and stages the payment response for the order service in the outbox.
+// Both writes share one transaction, so the response is published if and only if the payment is committed.
+// The request first claims the order: a redelivered request of a completed claim re-emits the response of the payment
+// instead of charging again, and concurrent deliveries of a processing claim charge with the order as idempotency key,
+// so the gateway charges the customer once and only the first of them stores the payment.
+// A request of a cancelled claim is rejected with ErrOrderCancelled without charging the customer
+func (s *paymentService) ProcessPaymentRequest(ctx context.Context, input payment.ProcessPaymentRequest) (payment.Payment, error) {
 	errTemplate := "paymentService ProcessPaymentRequest %w"
+	claim, err := s.claimRepository.Claim(ctx, payment.Claim{
+		OrderId:   input.OrderId,
+		PaymentId: xid.New(),
+		Status:    payment.ClaimStatusProcessing,
+	})
+	if err != nil {
+		return payment.Payment{}, fmt.Errorf(errTemplate, err)
+	}
+	switch claim.Status {
+	case payment.ClaimStatusCancelled:
+		rejectedCancelled.Add(1)
+		s.logger.Warn("Rejected payment request of a cancelled order",
+			slog.String("order_id", input.OrderId.String()),
+			slog.Int64("rejected_total", rejectedCancelled.Value()),
+		)
+		return payment.Payment{}, fmt.Errorf(errTemplate, payment.ErrOrderCancelled)
+	case payment.ClaimStatusCompleted:
+		return s.resendProcessedPayment(ctx, input.OrderId)
+	}
+
+	result, err := s.paymentGateway.Charge(ctx, payment.ChargeRequest{
+		IdempotencyKey: input.OrderId.String(),
+		PaymentId:      claim.PaymentId,
+		OrderId:        input.OrderId,
+		CustomerId:     input.CustomerId,
+		Amount:         input.TotalAmount,
+		ProcessTime:    time.Duration(input.TimeProcess) * time.Millisecond,
+	})
+	if err != nil {
+		return payment.Payment{}, fmt.Errorf(errTemplate, err)
+	}
+
 	paymentResponse := payment.Payment{}
 	txErr := s.atomicExecutor.Execute(
 		ctx, func(tc context.Context) error {
-			pendingOrder, err := s.paymentRepository.Create(ctx, input)
+			// the claim row lock orders concurrent deliveries, the later ones find it completed
+			completed, err := s.claimRepository.Complete(tc, input.OrderId)
+			if err != nil {
+				return err
+			}
+			if !completed {
+				return errClaimSettled
+			}
+
+			processedPayment, err := s.paymentRepository.Create(tc, payment.Payment{
+				Id:            claim.PaymentId,
+				OrderId:       input.OrderId,
+				CustomerId:    input.CustomerId,
+				TotalAmount:   input.TotalAmount,
+				Status:        result.Status,
+				DeclineReason: result.DeclineReason,
+			})
 			if err != nil {
 				return err
 			}
 
-			err = s.paymentPublisher.SendPaymentResponse(ctx, payment.ProcessPaymentResponse{
-				PaymentId:   pendingOrder.Id,
-				OrderId:     pendingOrder.OrderId,
-				CustomerId:  pendingOrder.CustomerId,
-				TotalAmount: pendingOrder.TotalAmount,
-				Status:      pendingOrder.Status,
+			err = s.paymentPublisher.SendPaymentResponse(tc, payment.ProcessPaymentResponse{
+				PaymentId:     processedPayment.Id,
+				OrderId:       processedPayment.OrderId,
+				CustomerId:    processedPayment.CustomerId,
+				TotalAmount:   processedPayment.TotalAmount,
+				Status:        processedPayment.Status,
+				DeclineReason: processedPayment.DeclineReason,
 			})
 			if err != nil {
 				return err
 			}
-			paymentResponse = pendingOrder
+			paymentResponse = processedPayment
 			return nil
 		},
 	)
+	if errors.Is(txErr, errClaimSettled) || apperrors.IsConstraintViolationError(txErr) {
+		return s.resendProcessedPayment(ctx, input.OrderId)
+	}
 	if txErr != nil {
 		return payment.Payment{}, fmt.Errorf(errTemplate, txErr)
 	}
 	return paymentResponse, nil
+}
+
+// CancelPayment claims an order as cancelled unless a payment request claimed it first, so that a payment request
+// arriving after the order service gave up on the order is rejected instead of charging the customer.
+// The returned claim tells the caller which happened: CANCELLED, or PROCESSING or COMPLETED when the payment won
+func (s *paymentService) CancelPayment(ctx context.Context, orderId xid.ID) (payment.Claim, error) {
+	claim, err := s.claimRepository.Claim(ctx, payment.Claim{
+		OrderId:   orderId,
+		PaymentId: xid.New(),
+		Status:    payment.ClaimStatusCancelled,
+	})
+	if err != nil {
+		return payment.Claim{}, fmt.Errorf("paymentService CancelPayment %w", err)
+	}
+	return claim, nil
+}
+
+// GetPaymentsByOrderId returns the payment attempts of an order, oldest first
+func (s *paymentService) GetPaymentsByOrderId(ctx context.Context, orderId xid.ID) ([]payment.Payment, error) {
+	return s.paymentRepository.GetAllByOrderId(ctx, orderId)
+}
 
+// resendProcessedPayment re-emits the response of the already processed payment of an order, so a duplicate request
+// still gets an answer when the original response was lost
+func (s *paymentService) resendProcessedPayment(ctx context.Context, orderId xid.ID) (payment.Payment, error) {
+	errTemplate := "paymentService resendProcessedPayment %w"
+	existing, err := s.paymentRepository.GetByOrderId(ctx, orderId)
+	if err != nil {
+		return payment.Payment{}, fmt.Errorf(errTemplate, err)
+	}
+	err = s.paymentPublisher.SendPaymentResponse(ctx, payment.ProcessPaymentResponse{
+		PaymentId:     existing.Id,
+		OrderId:       existing.OrderId,
+		CustomerId:    existing.CustomerId,
+		TotalAmount:   existing.TotalAmount,
+		Status:        existing.Status,
+		DeclineReason: existing.DeclineReason,
+	})
+	if err != nil {
+		return payment.Payment{}, fmt.Errorf(errTemplate, err)
+	}
+	suppressedDuplicates.Add(1)
+	s.logger.Warn("Suppressed duplicate payment request",
+		slog.String("payment_id", existing.Id.String()),
+		slog.String("order_id", existing.OrderId.String()),
+		slog.String("status", existing.Status.String()),
+		slog.Int64("suppressed_total", suppressedDuplicates.Value()),
+	)
+	return existing, nil
 }
 
 func (s *paymentService) SearchPayments(ctx context.Context, filter secondary.SearchPaymentsFilter) (pagination.Page[payment.Payment], error) {
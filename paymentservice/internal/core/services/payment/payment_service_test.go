@@ -0,0 +1,198 @@
+package payment
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"specommerce/paymentservice/internal/core/domain/payment"
+	"specommerce/paymentservice/internal/core/ports/secondary"
+	"specommerce/paymentservice/pkg/database"
+	"specommerce/paymentservice/pkg/pagination"
+	"sync"
+	"testing"
+
+	"github.com/rs/xid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeStore struct {
+	mu        sync.Mutex
+	claims    map[xid.ID]payment.Claim
+	payments  []payment.Payment
+	responses []payment.ProcessPaymentResponse
+}
+
+func newFakeStore() *fakeStore {
+	return &fakeStore{claims: make(map[xid.ID]payment.Claim)}
+}
+
+func (s *fakeStore) Claim(_ context.Context, claim payment.Claim) (payment.Claim, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if held, ok := s.claims[claim.OrderId]; ok {
+		return held, nil
+	}
+	s.claims[claim.OrderId] = claim
+	return claim, nil
+}
+
+func (s *fakeStore) Complete(_ context.Context, orderId xid.ID) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	claim := s.claims[orderId]
+	if claim.Status != payment.ClaimStatusProcessing {
+		return false, nil
+	}
+	claim.Status = payment.ClaimStatusCompleted
+	s.claims[orderId] = claim
+	return true, nil
+}
+
+func (s *fakeStore) GetAll(_ context.Context) ([]payment.Payment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]payment.Payment(nil), s.payments...), nil
+}
+
+func (s *fakeStore) Create(_ context.Context, p payment.Payment) (payment.Payment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.payments = append(s.payments, p)
+	return p, nil
+}
+
+func (s *fakeStore) GetByOrderId(_ context.Context, orderId xid.ID) (payment.Payment, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, p := range s.payments {
+		if p.OrderId == orderId {
+			return p, nil
+		}
+	}
+	return payment.Payment{}, database.ErrRecordNotFound
+}
+
+func (s *fakeStore) GetAllByOrderId(ctx context.Context, orderId xid.ID) ([]payment.Payment, error) {
+	p, err := s.GetByOrderId(ctx, orderId)
+	if err != nil {
+		return nil, nil
+	}
+	return []payment.Payment{p}, nil
+}
+
+func (s *fakeStore) SearchPayments(_ context.Context, _ secondary.SearchPaymentsFilter) (pagination.Page[payment.Payment], error) {
+	return pagination.Page[payment.Payment]{}, nil
+}
+
+func (s *fakeStore) SendPaymentResponse(_ context.Context, response payment.ProcessPaymentResponse) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.responses = append(s.responses, response)
+	return nil
+}
+
+// Execute runs the function directly, the store serializes the writes
+func (s *fakeStore) Execute(ctx context.Context, executeFunc func(ctx context.Context) error) error {
+	return executeFunc(ctx)
+}
+
+type fakeGateway struct {
+	mu       sync.Mutex
+	requests []payment.ChargeRequest
+	result   payment.ChargeResult
+}
+
+func (g *fakeGateway) Charge(_ context.Context, request payment.ChargeRequest) (payment.ChargeResult, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.requests = append(g.requests, request)
+	return g.result, nil
+}
+
+func newTestService(store *fakeStore, gateway *fakeGateway) *paymentService {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	return NewPaymentService(store, store, store, gateway, store, logger).(*paymentService)
+}
+
+func TestProcessPaymentRequest_ConcurrentDeliveriesStoreOnePayment(t *testing.T) {
+	store := newFakeStore()
+	gateway := &fakeGateway{result: payment.ChargeResult{Status: payment.PaymentStatusSuccess}}
+	service := newTestService(store, gateway)
+	request := payment.ProcessPaymentRequest{OrderId: xid.New(), CustomerId: "customer", TotalAmount: 10}
+
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := service.ProcessPaymentRequest(context.Background(), request)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	require.Len(t, store.payments, 1)
+	assert.Equal(t, payment.ClaimStatusCompleted, store.claims[request.OrderId].Status)
+	assert.Equal(t, store.claims[request.OrderId].PaymentId, store.payments[0].Id)
+	for _, charged := range gateway.requests {
+		assert.Equal(t, request.OrderId.String(), charged.IdempotencyKey)
+		assert.Equal(t, store.payments[0].Id, charged.PaymentId)
+	}
+	for _, response := range store.responses {
+		assert.Equal(t, store.payments[0].Id, response.PaymentId)
+	}
+}
+
+func TestProcessPaymentRequest_RedeliveryResendsResponse(t *testing.T) {
+	store := newFakeStore()
+	gateway := &fakeGateway{result: payment.ChargeResult{Status: payment.PaymentStatusFailed, DeclineReason: payment.DeclineReasonCardDeclined}}
+	service := newTestService(store, gateway)
+	request := payment.ProcessPaymentRequest{OrderId: xid.New(), CustomerId: "customer", TotalAmount: 10}
+
+	first, err := service.ProcessPaymentRequest(context.Background(), request)
+	require.NoError(t, err)
+	again, err := service.ProcessPaymentRequest(context.Background(), request)
+	require.NoError(t, err)
+
+	assert.Equal(t, first.Id, again.Id)
+	assert.Len(t, gateway.requests, 1)
+	require.Len(t, store.responses, 2)
+	assert.Equal(t, store.responses[0], store.responses[1])
+}
+
+func TestProcessPaymentRequest_AfterCancellationDoesNotCharge(t *testing.T) {
+	store := newFakeStore()
+	gateway := &fakeGateway{result: payment.ChargeResult{Status: payment.PaymentStatusSuccess}}
+	service := newTestService(store, gateway)
+	request := payment.ProcessPaymentRequest{OrderId: xid.New(), CustomerId: "customer", TotalAmount: 10}
+
+	claim, err := service.CancelPayment(context.Background(), request.OrderId)
+	require.NoError(t, err)
+	assert.Equal(t, payment.ClaimStatusCancelled, claim.Status)
+
+	// the payment request was delayed past the order timeout and would have succeeded
+	_, err = service.ProcessPaymentRequest(context.Background(), request)
+	assert.ErrorIs(t, err, payment.ErrOrderCancelled)
+	assert.Empty(t, gateway.requests)
+	assert.Empty(t, store.payments)
+	assert.Empty(t, store.responses)
+}
+
+func TestCancelPayment_AfterPaymentKeepsPayment(t *testing.T) {
+	store := newFakeStore()
+	gateway := &fakeGateway{result: payment.ChargeResult{Status: payment.PaymentStatusSuccess}}
+	service := newTestService(store, gateway)
+	request := payment.ProcessPaymentRequest{OrderId: xid.New(), CustomerId: "customer", TotalAmount: 10}
+
+	paid, err := service.ProcessPaymentRequest(context.Background(), request)
+	require.NoError(t, err)
+
+	claim, err := service.CancelPayment(context.Background(), request.OrderId)
+	require.NoError(t, err)
+	assert.Equal(t, payment.ClaimStatusCompleted, claim.Status)
+	assert.Equal(t, paid.Id, claim.PaymentId)
+	payments, err := service.GetPaymentsByOrderId(context.Background(), request.OrderId)
+	require.NoError(t, err)
+	assert.Equal(t, []payment.Payment{paid}, payments)
+}
@@ -0,0 +1,54 @@
+package messagequeue
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFailureHeaders_RecordsOrigin(t *testing.T) {
+	msg := kafka.Message{
+		Topic:     "order_events",
+		Partition: 2,
+		Offset:    41,
+		Headers:   []kafka.Header{{Key: "trace-id", Value: []byte("abc")}},
+	}
+
+	deadLetter := toDeadLetter(kafka.Message{Headers: failureHeaders(msg, "campaign-order-consumer", 3, errors.New("boom"))})
+
+	assert.Equal(t, "order_events", deadLetter.OriginalTopic)
+	assert.Equal(t, 2, deadLetter.OriginalPartition)
+	assert.Equal(t, int64(41), deadLetter.OriginalOffset)
+	assert.Equal(t, "campaign-order-consumer", deadLetter.ConsumerGroup)
+	assert.Equal(t, 3, deadLetter.Attempts)
+	assert.Equal(t, "boom", deadLetter.Error)
+	assert.Equal(t, "abc", deadLetter.Headers["trace-id"])
+	assert.False(t, deadLetter.FailedAt.IsZero())
+}
+
+func TestFailureHeaders_KeepsOriginAcrossRetryTopics(t *testing.T) {
+	first := kafka.Message{Topic: "order_events", Partition: 1, Offset: 7}
+	retried := kafka.Message{
+		Topic:     RetryTopic("order_events", 1),
+		Partition: 0,
+		Offset:    3,
+		Headers:   failureHeaders(first, "campaign-order-consumer", 6, errors.New("first")),
+	}
+
+	headers := failureHeaders(retried, "campaign-order-consumer", 1, errors.New("second"))
+	deadLetter := toDeadLetter(kafka.Message{Headers: headers})
+
+	assert.Equal(t, "order_events", deadLetter.OriginalTopic)
+	assert.Equal(t, 1, deadLetter.OriginalPartition)
+	assert.Equal(t, int64(7), deadLetter.OriginalOffset)
+	assert.Equal(t, 7, deadLetter.Attempts)
+	assert.Equal(t, "second", deadLetter.Error)
+	assert.Len(t, headers, 7)
+}
+
+func TestTopicNames(t *testing.T) {
+	assert.Equal(t, "payment_process_response.retry.2", RetryTopic("payment_process_response", 2))
+	assert.Equal(t, "payment_process_response.dlq", DeadLetterTopic("payment_process_response"))
+}
@@ -10,7 +10,9 @@ import (
 )
 
 type Publisher interface {
-	Publish(message kafka.Message) error
+	Publish(messages ...kafka.Message) error
+	// PublishContext publishes the messages until ctx is done
+	PublishContext(ctx context.Context, messages ...kafka.Message) error
 }
 
 type publisher struct {
@@ -20,7 +22,7 @@ type publisher struct {
 func NewPublisher(cfg config.AppConfig, tasks *shutdown.Tasks) Publisher {
 	writer := &kafka.Writer{
 		Addr:                   kafka.TCP(cfg.Kafka.Host),
-		Balancer:               &kafka.LeastBytes{},
+		Balancer:               &kafka.Hash{}, // Same key, same partition: keeps per-key ordering for consumers
 		RequiredAcks:           1,
 		AllowAutoTopicCreation: cfg.Kafka.AutoCreateTopic,
 		MaxAttempts:            cfg.Kafka.Retry,
@@ -33,6 +35,10 @@ func NewPublisher(cfg config.AppConfig, tasks *shutdown.Tasks) Publisher {
 	return &publisher{kafkaWriter: writer}
 }
 
-func (publisher *publisher) Publish(message kafka.Message) error {
-	return publisher.kafkaWriter.WriteMessages(context.Background(), message)
+func (publisher *publisher) Publish(messages ...kafka.Message) error {
+	return publisher.kafkaWriter.WriteMessages(context.Background(), messages...)
+}
+
+func (publisher *publisher) PublishContext(ctx context.Context, messages ...kafka.Message) error {
+	return publisher.kafkaWriter.WriteMessages(ctx, messages...)
 }
@@ -0,0 +1,57 @@
+package messagequeue
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOffsetTracker_CommitsInOrder(t *testing.T) {
+	tracker := newOffsetTracker()
+	for offset := int64(10); offset < 13; offset++ {
+		tracker.track(0, offset)
+	}
+
+	_, ok := tracker.markDone(0, 12)
+	assert.False(t, ok)
+	_, ok = tracker.markDone(0, 11)
+	assert.False(t, ok)
+
+	commit, ok := tracker.markDone(0, 10)
+	assert.True(t, ok)
+	assert.Equal(t, int64(12), commit)
+}
+
+func TestOffsetTracker_PartitionsAreIndependent(t *testing.T) {
+	tracker := newOffsetTracker()
+	tracker.track(0, 1)
+	tracker.track(1, 5)
+	tracker.track(0, 2)
+
+	commit, ok := tracker.markDone(1, 5)
+	assert.True(t, ok)
+	assert.Equal(t, int64(5), commit)
+
+	_, ok = tracker.markDone(0, 2)
+	assert.False(t, ok)
+	commit, ok = tracker.markDone(0, 1)
+	assert.True(t, ok)
+	assert.Equal(t, int64(2), commit)
+}
+
+func TestOffsetTracker_ResetsOnRewind(t *testing.T) {
+	tracker := newOffsetTracker()
+	tracker.track(0, 7)
+	tracker.track(0, 8)
+
+	tracker.track(0, 7)
+	commit, ok := tracker.markDone(0, 7)
+	assert.True(t, ok)
+	assert.Equal(t, int64(7), commit)
+}
+
+func TestOffsetTracker_UnknownPartition(t *testing.T) {
+	tracker := newOffsetTracker()
+	_, ok := tracker.markDone(3, 1)
+	assert.False(t, ok)
+}
@@ -0,0 +1,71 @@
+package messagequeue
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	defaultWorkers   = 8
+	defaultQueueSize = 64
+)
+
+// keyedWorkerPool dispatches messages to a fixed number of workers by hashing the message key,
+// so messages with the same key are handled one at a time in fetch order while different keys run in parallel
+type keyedWorkerPool struct {
+	queues    []chan kafka.Message
+	waitGroup sync.WaitGroup
+}
+
+func newKeyedWorkerPool(workers int, queueSize int, handle func(message kafka.Message)) *keyedWorkerPool {
+	if workers <= 0 {
+		workers = defaultWorkers
+	}
+	if queueSize <= 0 {
+		queueSize = defaultQueueSize
+	}
+	pool := &keyedWorkerPool{
+		queues: make([]chan kafka.Message, workers),
+	}
+	for i := range pool.queues {
+		queue := make(chan kafka.Message, queueSize)
+		pool.queues[i] = queue
+		pool.waitGroup.Add(1)
+		go func() {
+			defer pool.waitGroup.Done()
+			for message := range queue {
+				handle(message)
+			}
+		}()
+	}
+	return pool
+}
+
+// submit enqueues the message on the worker owning its key. It blocks while that worker's queue is full,
+// which holds back fetching, and returns false if ctx is cancelled first.
+func (p *keyedWorkerPool) submit(ctx context.Context, message kafka.Message) bool {
+	select {
+	case p.queues[p.worker(message.Key)] <- message:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (p *keyedWorkerPool) worker(key []byte) int {
+	hash := fnv.New32a()
+	_, _ = hash.Write(key)
+	return int(hash.Sum32() % uint32(len(p.queues)))
+}
+
+// close stops accepting messages and waits until the workers have drained their queues.
+// It must not be called concurrently with submit.
+func (p *keyedWorkerPool) close() {
+	for _, queue := range p.queues {
+		close(queue)
+	}
+	p.waitGroup.Wait()
+}
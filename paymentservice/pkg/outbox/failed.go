@@ -0,0 +1,64 @@
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"specommerce/paymentservice/pkg/database"
+)
+
+var ErrFailedMessageNotFound = errors.New("failed outbox message not found")
+
+// FailedMessages lists the messages the relay marked FAILED after MaxAttempts and re-drives them.
+// A re-driven message is pending again, so it is published after the messages of its key sent meanwhile.
+type FailedMessages interface {
+	// List returns the failed messages with an id greater than afterId, in insertion order
+	List(ctx context.Context, afterId int64, limit int) ([]Message, error)
+	// Redrive resets the attempts of a failed message and puts it back in the pending queue
+	Redrive(ctx context.Context, id int64) (Message, error)
+}
+
+type postgresFailedMessages struct {
+	getDbFunc database.GetDbFunc
+}
+
+func NewFailedMessages(getDbFunc database.GetDbFunc) FailedMessages {
+	return &postgresFailedMessages{getDbFunc: getDbFunc}
+}
+
+func (f *postgresFailedMessages) List(ctx context.Context, afterId int64, limit int) ([]Message, error) {
+	errTemplate := "outbox List %w"
+	messages := make([]Message, 0, limit)
+	err := f.getDbFunc(ctx).NewSelect().Model(&messages).
+		Where("status = ?", StatusFailed).
+		Where("id > ?", afterId).
+		OrderExpr("id ASC").
+		Limit(limit).
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf(errTemplate, err)
+	}
+	return messages, nil
+}
+
+func (f *postgresFailedMessages) Redrive(ctx context.Context, id int64) (Message, error) {
+	errTemplate := "outbox Redrive %w"
+	var message Message
+	_, err := f.getDbFunc(ctx).NewUpdate().Model(&message).
+		Set("status = ?", StatusPending).
+		Set("attempts = 0").
+		Set("last_error = ''").
+		Where("id = ?", id).
+		Where("status = ?", StatusFailed).
+		Returning("*").
+		Exec(ctx)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Message{}, fmt.Errorf(errTemplate, ErrFailedMessageNotFound)
+	}
+	if err != nil {
+		return Message{}, fmt.Errorf(errTemplate, err)
+	}
+	return message, nil
+}
@@ -1,5 +1,7 @@
 package service_config
 
+import "time"
+
 type DbConfig struct {
 	User            string `koanf:"user"`
 	Password        string `koanf:"password"`
@@ -12,11 +14,24 @@ type DbConfig struct {
 }
 
 type KafkaConfig struct {
-	Host            string `koanf:"host"`
-	Retry           int    `koanf:"retry"`
-	AutoCreateTopic bool   `koanf:"autoCreateTopic"`
-	Topic           string `koanf:"topic"`
-	ConsumerGroup   string `koanf:"consumerGroup"`
+	Host            string        `koanf:"host"`
+	Retry           int           `koanf:"retry"`
+	AutoCreateTopic bool          `koanf:"autoCreateTopic"`
+	Topic           string        `koanf:"topic"`
+	ConsumerGroup   string        `koanf:"consumerGroup"`
+	Workers         int           `koanf:"workers"`      // Consumer workers, messages with the same key go to the same worker
+	QueueSize       int           `koanf:"queueSize"`    // Messages buffered per worker before fetching blocks
+	RetryBackoff    time.Duration `koanf:"retryBackoff"` // Initial handler retry backoff, doubled on every retry
+	RetryTopics     int           `koanf:"retryTopics"`  // Delay topics <topic>.retry.N a failed message goes through before <topic>.dlq
+}
+
+// OutboxConfig defines how the outbox relay drains staged messages to Kafka
+type OutboxConfig struct {
+	PollInterval   time.Duration `koanf:"pollInterval"`
+	BatchSize      int           `koanf:"batchSize"`
+	MaxAttempts    int           `koanf:"maxAttempts"`
+	MaxBackoff     time.Duration `koanf:"maxBackoff"`
+	PublishTimeout time.Duration `koanf:"publishTimeout"`
 }
 
 // GrpcServiceConfig defines the configuration for gRPC services
@@ -32,3 +47,9 @@ type RestServiceConfig struct {
 	Port int    `koanf:"port" yaml:"port" required:"true"`
 	Name string `koanf:"name" yaml:"name" required:"true"`
 }
+
+// AdminServerConfig configures the internal listener of the operational endpoints, kept off the public router
+type AdminServerConfig struct {
+	Host string `koanf:"host"` // Interface the listener binds to, loopback by default so only the host can reach it
+	Port int    `koanf:"port"`
+}
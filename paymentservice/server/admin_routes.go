@@ -3,13 +3,28 @@ package server
 import (
 	"github.com/gin-gonic/gin"
 	"github.com/samber/do/v2"
+	deadLetterHandler "specommerce/paymentservice/internal/adapters/primary/deadletter/handler"
+	outboxHandler "specommerce/paymentservice/internal/adapters/primary/outbox/handler"
 	paymentHandler "specommerce/paymentservice/internal/adapters/primary/payment/handler"
 )
 
 func adminRoutes(routerGroup *gin.RouterGroup, injector do.Injector) {
 	payment := do.MustInvoke[paymentHandler.PaymentHandler](injector)
+	deadLetter := do.MustInvoke[deadLetterHandler.DeadLetterHandler](injector)
+	outbox := do.MustInvoke[outboxHandler.OutboxHandler](injector)
 
 	v1PaymentGroup := routerGroup.Group("/v1/payments")
 	v1PaymentGroup.GET("", payment.GetAllPayments)
 	v1PaymentGroup.GET("/search", payment.SearchPayments)
+	v1PaymentGroup.POST("/cancellations", payment.CancelPayment)
+
+	v1DeadLetterGroup := routerGroup.Group("/v1/dead-letters")
+	v1DeadLetterGroup.GET("", deadLetter.GetTopics)
+	v1DeadLetterGroup.GET("/:topic", deadLetter.ListDeadLetters)
+	v1DeadLetterGroup.GET("/:topic/:partition/:offset", deadLetter.GetDeadLetter)
+	v1DeadLetterGroup.POST("/:topic/:partition/:offset/redrive", deadLetter.RedriveDeadLetter)
+
+	v1OutboxGroup := routerGroup.Group("/v1/outbox-messages")
+	v1OutboxGroup.GET("/failed", outbox.ListFailedMessages)
+	v1OutboxGroup.POST("/:id/redrive", outbox.RedriveFailedMessage)
 }
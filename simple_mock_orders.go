@@ -12,12 +12,52 @@ import (
 )
 
 type CreateOrderRequest struct {
-	CustomerID   string  `json:"customer_id"`
-	CustomerName string  `json:"customer_name"`
-	TotalAmount  float64 `json:"total_amount"`
-	TimeProcess  int64   `json:"time_process"` // Time in seconds to process the order
+	CustomerID   string      `json:"customer_id"`
+	CustomerName string      `json:"customer_name"`
+	Items        []OrderItem `json:"items"`
+	TimeProcess  int64       `json:"time_process"` // Time in seconds to process the order
 }
 
+type OrderItem struct {
+	Sku      string `json:"sku"`
+	Quantity int    `json:"quantity"`
+}
+
+type Product struct {
+	Sku   string  `json:"sku"`
+	Name  string  `json:"name"`
+	Price float64 `json:"price"`
+	Stock int     `json:"stock"`
+}
+
+// Catalog used by the generated orders, the order service computes the total amount from these prices
+var products = []Product{
+	{"ACC-CASE", "Phone Case", 29.90, 1000},
+	{"ACC-CHARGER", "Fast Charger", 49.90, 1000},
+	{"AUDIO-BUDS", "Wireless Earbuds", 179.00, 500},
+	{"WATCH-SE", "Smart Watch SE", 249.00, 100},
+	{"TABLET-MINI", "Tablet Mini", 349.00, 50},
+}
+
+// Baskets below $200 and from $200 to $400
+var (
+	basketsBelow200 = [][]OrderItem{
+		{{"ACC-CASE", 1}},
+		{{"ACC-CASE", 2}},
+		{{"ACC-CHARGER", 1}},
+		{{"ACC-CHARGER", 3}},
+		{{"ACC-CASE", 1}, {"ACC-CHARGER", 1}},
+		{{"AUDIO-BUDS", 1}},
+	}
+	baskets200to400 = [][]OrderItem{
+		{{"WATCH-SE", 1}},
+		{{"TABLET-MINI", 1}},
+		{{"AUDIO-BUDS", 1}, {"ACC-CASE", 1}},
+		{{"AUDIO-BUDS", 1}, {"ACC-CHARGER", 2}},
+		{{"WATCH-SE", 1}, {"ACC-CHARGER", 1}},
+	}
+)
+
 // 200 unique real customer names
 var customers = []struct {
 	ID   string
@@ -77,6 +117,7 @@ var customers = []struct {
 
 const (
 	orderServiceURL = "http://localhost:8080/api/v1/orders"
+	productAdminURL = "http://localhost:8080/api/admin/v1/products"
 	totalOrders     = 500
 	ordersBelow200  = 400 // Orders less than $200
 	orders200to400  = 100 // Orders from $200 to $400
@@ -104,7 +145,29 @@ func createOrder(client *http.Client, order CreateOrderRequest, wg *sync.WaitGro
 		return
 	}
 
-	fmt.Printf("Order #%d - %d %s: $%.2f (TimeProcess: %d μs)\n", orderNum, resp.StatusCode, order.CustomerID, order.TotalAmount, order.TimeProcess)
+	var created struct {
+		Data struct {
+			TotalAmount float64 `json:"total_amount"`
+		} `json:"data"`
+	}
+	_ = json.NewDecoder(resp.Body).Decode(&created)
+	fmt.Printf("Order #%d - %d %s: $%.2f (TimeProcess: %d μs)\n", orderNum, resp.StatusCode, order.CustomerID, created.Data.TotalAmount, order.TimeProcess)
+}
+
+// seedProducts adds the catalog to the order service, products that already exist are kept
+func seedProducts(client *http.Client) error {
+	for _, product := range products {
+		jsonData, _ := json.Marshal(product)
+		resp, err := client.Post(productAdminURL, "application/json", bytes.NewBuffer(jsonData))
+		if err != nil {
+			return err
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusConflict {
+			return fmt.Errorf("create product %s: %s", product.Sku, resp.Status)
+		}
+	}
+	return nil
 }
 
 // Step 1: Create two separate lists of transactions with proper ratios
@@ -125,7 +188,12 @@ func main() {
 
 	// Test connection first
 	fmt.Println("Testing connection to order service...")
-	testReq, _ := http.NewRequest("POST", orderServiceURL, bytes.NewBuffer([]byte(`{"customer_id":"TEST","customer_name":"Test","total_amount":100,"time_process":0}`)))
+	if err := seedProducts(client); err != nil {
+		fmt.Printf("Cannot seed the product catalog: %v\n", err)
+		fmt.Println("Make sure the order service is running on http://localhost:8080")
+		return
+	}
+	testReq, _ := http.NewRequest("POST", orderServiceURL, bytes.NewBuffer([]byte(`{"customer_id":"TEST","customer_name":"Test","items":[{"sku":"ACC-CASE","quantity":1}],"time_process":0}`)))
 	testReq.Header.Set("Content-Type", "application/json")
 	testResp, err := client.Do(testReq)
 	if err != nil {
@@ -143,19 +211,17 @@ func main() {
 	var aboveTransactions []CreateOrderRequest
 
 	for i := 0; i < ordersBelow200; i++ {
-		amount := 50.0 + rand.Float64()*149.0
 		timeProcess := rand.Intn(8000) + 2000 // Random 2-10 seconds in milliseconds
 		belowTransactions = append(belowTransactions, CreateOrderRequest{
-			TotalAmount: float64(int(amount*100)) / 100,
+			Items:       basketsBelow200[rand.Intn(len(basketsBelow200))],
 			TimeProcess: int64(timeProcess),
 		})
 	}
 
 	for i := 0; i < orders200to400; i++ {
-		amount := 200.0 + rand.Float64()*200.0
 		timeProcess := rand.Intn(8000) + 2000 // Random 2-10 seconds in milliseconds
 		aboveTransactions = append(aboveTransactions, CreateOrderRequest{
-			TotalAmount: float64(int(amount*100)) / 100,
+			Items:       baskets200to400[rand.Intn(len(baskets200to400))],
 			TimeProcess: int64(timeProcess),
 		})
 	}